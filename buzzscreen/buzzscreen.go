@@ -11,13 +11,19 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/appsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/clickredirectsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/configsvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentadminsvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentcampaignsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentimpressionsvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentscoresvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentvariantsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/custompreviewsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/eventsvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/identitygraphsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/installedappsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/monitorsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/notiplussvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/policysvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/privacysvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/reportsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/rewardsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/unlocksvc"
@@ -25,14 +31,28 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/ad"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/auth"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentbookmark"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentexploration"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentsnapshot"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contenttrending"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/event"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/impressiondata"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/landing"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/location"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/payload"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/redirect"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/reward"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/session"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/tracker"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/trackingdata"
 	"github.com/go-redis/redis"
 	"github.com/guregu/dynamo"
@@ -55,17 +75,31 @@ type Buzzscreen struct {
 	BuzzScreenAPIURL string
 
 	// Deprecated - DDD 적용 이후 삭제 해야함
-	AdUseCase             ad.UseCase
-	AppUseCase            app.UseCase
-	AuthUseCase           auth.UseCase
-	DeviceUseCase         device.UseCase
-	EventUseCase          event.UseCase
-	ImpressionDataUseCase impressiondata.UseCase
-	LocationUseCase       location.UseCase
-	PayloadUseCase        payload.UseCase
-	RewardUseCase         reward.UseCase
-	SessionUseCase        session.UseCase
-	TrackingDataUseCase   trackingdata.UseCase
+	AdUseCase                 ad.UseCase
+	AppUseCase                app.UseCase
+	AuthUseCase               auth.UseCase
+	ContentBookmarkUseCase    contentbookmark.UseCase
+	ContentCampaignUseCase    contentcampaign.UseCase
+	ContentExplorationUseCase contentexploration.UseCase
+	ContentPreferenceUseCase  contentpreference.UseCase
+	ContentScoreUseCase       contentscore.UseCase
+	ContentSnapshotUseCase    contentsnapshot.UseCase
+	ContentTrendingUseCase    contenttrending.UseCase
+	ContentVariantUseCase     contentvariant.UseCase
+	DeviceUseCase             device.UseCase
+	EventUseCase              event.UseCase
+	IdentityGraphUseCase      identitygraph.UseCase
+	ImpressionDataUseCase     impressiondata.UseCase
+	LandingUseCase            landing.UseCase
+	LocalizationUseCase       localization.UseCase
+	LocationUseCase           location.UseCase
+	PayloadUseCase            payload.UseCase
+	RedirectUseCase           redirect.UseCase
+	RewardUseCase             reward.UseCase
+	SessionUseCase            session.UseCase
+	ShortLinkUseCase          shortlink.UseCase
+	TrackerUseCase            tracker.UseCase
+	TrackingDataUseCase       trackingdata.UseCase
 }
 
 // Service is buzzscreen service instance
@@ -74,8 +108,11 @@ var Service *Buzzscreen
 // Init will do service initialization
 func (bs *Buzzscreen) Init() (err error) {
 	env.LoadServerConfig()
-	if err := bs.waitForConn(); err != nil { // https://buzzvil.atlassian.net/browse/BS-2802
-		return err
+	if !useMemorySearchRepository() {
+		if err := bs.waitForConn(); err != nil { // https://buzzvil.atlassian.net/browse/BS-2802
+			return err
+		}
+		bs.ES = env.GetElasticsearch()
 	}
 
 	bs.DB, err = env.GetDatabase()
@@ -84,7 +121,6 @@ func (bs *Buzzscreen) Init() (err error) {
 	}
 
 	bs.DynamoDB = env.GetDynamoDB()
-	bs.ES = env.GetElasticsearch()
 	bs.Metrics = env.NewMetrics()
 	bs.Redis = env.InitRedis()
 
@@ -109,6 +145,10 @@ func (bs *Buzzscreen) waitForConn() (err error) {
 
 // Clean will do cleaning task before server exits
 func (bs *Buzzscreen) Clean() (err error) {
+	// 종료 전에 queue에 남은 tracker를 모두 전송한다
+	if bs.TrackerUseCase != nil {
+		bs.TrackerUseCase.Wait()
+	}
 	if bs.DB != nil {
 		bs.DB.Close()
 	}
@@ -138,16 +178,31 @@ func (bs *Buzzscreen) RegisterRoute(driver *core.Engine) {
 	appUC := bs.initAppUseCase(redisCache)
 	authUC := bs.initAuthUseCase()
 	configUC := bs.initConfigUseCase()
+	contentAdminUC := bs.initContentAdminUseCase()
 	contentCampaignUC := bs.initContentCampaignUseCase(redisCache)
+	contentExplorationUC := bs.initContentExplorationUseCase()
+	contentPreferenceUC := bs.initContentPreferenceUseCase()
+	contentScoreUC := bs.initContentScoreUseCase()
+	contentSnapshotUC := bs.initContentSnapshotUseCase(redisCache, contentCampaignUC)
+	contentTrendingUC := bs.initContentTrendingUseCase(appUC)
+	contentVariantUC := bs.initContentVariantUseCase(redisCache)
 	deviceUC := bs.initDeviceUseCase()
+	contentBookmarkUC := bs.initContentBookmarkUseCase(deviceUC)
 	eventUC := bs.initEventUseCase(redisCache)
+	identityGraphUC := bs.initIdentityGraphUseCase()
 	impressionDataUC := bs.initImpressionDataUseCase()
+	landingUC := bs.initLandingUseCase()
+	localizationUC := bs.initLocalizationUseCase(redisCache)
 	locationUC := bs.initLocationUseCase()
 	notiplusUC := bs.initNotiPlusUseCase()
 	payloadUC := bs.initPayloadUseCase()
+	privacyUC := bs.initPrivacyUseCase()
+	redirectUC := bs.initRedirectUseCase(redisCache)
 	reportUC := bs.initReportUseCase()
 	rewardUC := bs.initRewardUseCase()
 	sessionUC := bs.initSessionUseCase()
+	shortLinkUC := bs.initShortLinkUseCase(redisCache)
+	trackerUC := bs.initTrackerUseCase()
 	trackingDataUC := bs.initTrackingDataUseCase()
 	userReferralUC := bs.initUserReferralUseCase()
 	customPreviewUC := bs.initCustomPreviewUseCase()
@@ -156,29 +211,49 @@ func (bs *Buzzscreen) RegisterRoute(driver *core.Engine) {
 	activitysvc.NewController(driver, deviceUC, locationUC)
 	notiplussvc.NewController(driver, notiplusUC)
 	appsvc.NewController(driver, appUC)
-	clickredirectsvc.NewController(driver, rewardUC, appUC, contentCampaignUC, payloadUC, trackingDataUC, deviceUC, eventUC, profileRequestUC, bs.BuzzAdURL)
+	clickredirectsvc.NewController(driver, rewardUC, appUC, contentCampaignUC, payloadUC, trackingDataUC, deviceUC, eventUC, profileRequestUC, redirectUC, landingUC, trackerUC, shortLinkUC, contentVariantUC, identityGraphUC, bs.BuzzAdURL)
 	configsvc.NewController(driver, configUC)
-	contentimpressionsvc.NewController(driver, trackingDataUC, impressionDataUC, contentCampaignUC, deviceUC)
+	contentadminsvc.NewController(driver, contentAdminUC)
+	contentcampaignsvc.NewController(driver, contentCampaignUC, appUC, deviceUC, contentScoreUC)
+	contentimpressionsvc.NewController(driver, trackingDataUC, impressionDataUC, contentCampaignUC, deviceUC, contentVariantUC)
+	contentscoresvc.NewController(driver, contentScoreUC)
+	contentvariantsvc.NewController(driver, contentVariantUC)
 	eventsvc.NewController(driver, appUC, authUC, deviceUC, eventUC, contentCampaignUC, adUC, publisher)
+	identitygraphsvc.NewController(driver, identityGraphUC)
 	installedappsvc.NewController(driver, deviceUC, bs.BuzzAdURL)
 	monitorsvc.NewController(driver)
 	policysvc.NewController(driver, appUC, locationUC)
+	privacysvc.NewController(driver, privacyUC)
 	reportsvc.NewController(driver, reportUC, appUC)
-	rewardsvc.NewController(driver, appUC, eventUC, rewardUC)
+	rewardsvc.NewController(driver, appUC, eventUC, rewardUC, trackerUC)
 	unlocksvc.NewController(driver, rewardUC, appUC, payloadUC)
 	userreferralsvc.NewController(driver, userReferralUC, deviceUC, appUC)
-	custompreviewsvc.NewController(driver, customPreviewUC, nil)
+	custompreviewsvc.NewController(driver, customPreviewUC, localizationUC, nil)
 
 	bs.AdUseCase = adUC
 	bs.AppUseCase = appUC
 	bs.AuthUseCase = authUC
+	bs.ContentBookmarkUseCase = contentBookmarkUC
+	bs.ContentCampaignUseCase = contentCampaignUC
+	bs.ContentExplorationUseCase = contentExplorationUC
+	bs.ContentPreferenceUseCase = contentPreferenceUC
+	bs.ContentScoreUseCase = contentScoreUC
+	bs.ContentSnapshotUseCase = contentSnapshotUC
+	bs.ContentTrendingUseCase = contentTrendingUC
+	bs.ContentVariantUseCase = contentVariantUC
 	bs.DeviceUseCase = deviceUC
 	bs.EventUseCase = eventUC
+	bs.IdentityGraphUseCase = identityGraphUC
 	bs.ImpressionDataUseCase = impressionDataUC
+	bs.LandingUseCase = landingUC
+	bs.LocalizationUseCase = localizationUC
 	bs.LocationUseCase = locationUC
 	bs.PayloadUseCase = payloadUC
+	bs.RedirectUseCase = redirectUC
 	bs.RewardUseCase = rewardUC
 	bs.SessionUseCase = sessionUC
+	bs.ShortLinkUseCase = shortLinkUC
+	bs.TrackerUseCase = trackerUC
 	bs.TrackingDataUseCase = trackingDataUC
 }
 
@@ -13,6 +13,8 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/service"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/ad"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/landing"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/trackingdata"
 )
 
@@ -148,7 +150,7 @@ func getAllocationRes(ctx context.Context, allocReq *dto.AllocV1Request, additio
 	cContentCamps := make(chan ContentAllocV1Result)
 	go func(allocReq dto.ContentAllocV1Request) {
 		camps, err := getContent(ctx, &allocReq)
-		//Payload / ClickURL 및 2048 길이 제한등은 안에서 전부 처리해버림
+		//Payload / ClickURL 및 short link 변환등은 안에서 전부 처리해버림
 		cContentCamps <- ContentAllocV1Result{
 			campaigns: camps,
 			err:       err,
@@ -178,6 +180,12 @@ func getAllocationRes(ctx context.Context, allocReq *dto.AllocV1Request, additio
 						Type:            camp.Type,
 						Unit:            allocReq.GetUnit(ctx),
 						UnitDeviceToken: allocReq.UnitDeviceToken,
+						DeepLink:        ad.DeepLink,
+						UniversalLink:   ad.UniversalLink,
+						TargetApp:       ad.TargetApp,
+					}
+					if allocReq.DeviceOs <= 20 {
+						clickReq.MaxURLLength = shortlink.MaxURLLength
 					}
 					if ma := allocReq.GetModelArtifact(ctx); ma != nil {
 						td := &trackingdata.TrackingData{
@@ -191,8 +199,8 @@ func getAllocationRes(ctx context.Context, allocReq *dto.AllocV1Request, additio
 		}
 
 		// url length problems in kitkat (api level 19 - 20)
-		if allocReq.DeviceOs <= 20 && len(camp.ClickURL) > 2048 {
-			// 안드로이드 OS 20 버전 이하에서, url 길이가 길면 잘리는 이슈
+		if allocReq.DeviceOs <= 20 && len(camp.ClickURL) > shortlink.MaxURLLength {
+			// 안드로이드 OS 20 버전 이하에서, url 길이가 길면 잘리는 이슈. short link 생성에 실패한 경우에만 해당
 			filteredAdIDs = append(filteredAdIDs, ad.ID)
 			continue
 		}
@@ -457,6 +465,12 @@ func parseAdToCampaign(ctx context.Context, ad *ad.AdV1, allocReq *dto.AllocV1Re
 		Type:            campaign.Type,
 		Unit:            allocReq.GetUnit(ctx),
 		UnitDeviceToken: allocReq.UnitDeviceToken,
+		DeepLink:        ad.DeepLink,
+		UniversalLink:   ad.UniversalLink,
+		TargetApp:       ad.TargetApp,
+	}
+	if allocReq.DeviceOs <= 20 {
+		clickReq.MaxURLLength = shortlink.MaxURLLength
 	}
 	if ma := allocReq.GetModelArtifact(ctx); ma != nil {
 		td := &trackingdata.TrackingData{
@@ -473,6 +487,7 @@ func parseAdToCampaign(ctx context.Context, ad *ad.AdV1, allocReq *dto.AllocV1Re
 		if len(landedEvent.TrackingURLs) > 0 {
 			// TODO 여러개 tracking url이 생긴다면 문제 될 수 있음
 			clickReq.TrackingURL = &landedEvent.TrackingURLs[0]
+			clickReq.FailTrackers = ad.FailTrackers
 		}
 
 		minimumStayDuration := landedEvent.Reward.MinimumStayDuration()
@@ -509,5 +524,32 @@ func parseAdToCampaign(ctx context.Context, ad *ad.AdV1, allocReq *dto.AllocV1Re
 		campaign.Creative["click_url"] = clickReq.BuildClickRedirectURL()
 	}
 
+	campaign.Landing = resolveAdLanding(ctx, ad, campaign, allocReq)
+
 	return campaign
 }
+
+// resolveAdLanding returns the app landing of the ad. click_url is resolved to the same landing by ClickRedirect
+func resolveAdLanding(ctx context.Context, ad *ad.AdV1, campaign dto.CampaignV1, allocReq *dto.AllocV1Request) *landing.Landing {
+	target := landing.Target{
+		DeepLink:         ad.DeepLink,
+		UniversalLink:    ad.UniversalLink,
+		TargetApp:        ad.TargetApp,
+		WebURL:           campaign.ClickURL,
+		PreferredBrowser: campaign.PreferredBrowser,
+	}
+	if !target.HasAppLink() {
+		return nil
+	}
+
+	var installedPackages *string
+	if profile := allocReq.GetDynamoProfile(); profile != nil {
+		installedPackages = profile.InstalledPackages
+	}
+
+	resolved := buzzscreen.Service.LandingUseCase.ResolveLanding(target, landing.NewDevice(allocReq.GetUnit(ctx).Platform, installedPackages))
+	if !resolved.IsApp() {
+		return nil
+	}
+	return &resolved
+}
@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
 	"github.com/labstack/echo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,3 +27,35 @@ func TestBindHeaders(t *testing.T) {
 	require.Nil(t, err, err)
 	assert.Equal(t, "test-header-value", tm.TestKey)
 }
+
+func TestCopyValue_ContentArticlesRequest(t *testing.T) {
+	v2Req := ContentV2ArticlesRequest{LandingTypes: "1,2", Keyword: "buzz", Sort: dto.ContentSortTrending, TypesString: `{"NATIVE":[]}`}
+	var v3Req dto.ContentArticlesRequest
+
+	err := copyValue(&v2Req, &v3Req)
+	require.Nil(t, err, err)
+	assert.Equal(t, "1,2", v3Req.LandingTypes)
+	assert.Equal(t, "buzz", v3Req.Keyword)
+	assert.Equal(t, dto.ContentSortTrending, v3Req.Sort)
+	assert.Equal(t, `{"NATIVE":[]}`, v3Req.TypesString)
+}
+
+func TestCopyValue_ContentChannelsRequest(t *testing.T) {
+	v2Req := GetContentV2ChannelsRequest{CategoryID: "sports", Followed: true}
+	var v3Req dto.GetContentChannelsRequest
+
+	err := copyValue(&v2Req, &v3Req)
+	require.Nil(t, err, err)
+	assert.Equal(t, "sports", v3Req.CategoryID)
+	assert.True(t, v3Req.Followed)
+}
+
+func TestCopyValue_ContentPreferenceRequest(t *testing.T) {
+	v2Req := ContentV2PreferenceRequest{Languages: "ko,en", CategoryWeights: `{"sports":0.5}`}
+	var v3Req dto.ContentPreferenceRequest
+
+	err := copyValue(&v2Req, &v3Req)
+	require.Nil(t, err, err)
+	assert.Equal(t, "ko,en", v3Req.Languages)
+	assert.Equal(t, `{"sports":0.5}`, v3Req.CategoryWeights)
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/Buzzvil/buzzlib-go/core"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen"
@@ -17,6 +16,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/common/ifa"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
 	"github.com/asaskevich/govalidator"
 )
 
@@ -38,7 +38,7 @@ func GetContentCategories(c core.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
 	}
 
-	categories, err := service.GetCategories(contentReq.GetLanguage())
+	categories, err := service.GetCategories(contentReq.GetLanguages())
 
 	if err != nil {
 		core.Logger.WithError(err).WithField("user", contentReq.Locale).Errorf("GetContentCategories()")
@@ -62,34 +62,11 @@ func GetContentArticles(c core.Context) error {
 		return err
 	}
 
-	if ifa.ShouldReplaceIFAWithIFV(req.AdID, req.IFV) {
-		req.AdID = ifa.GetDeviceIFV(*req.IFV)
-	}
-
-	err := req.UnpackSession()
-	if err != nil {
-		return common.NewSessionError(err)
-	}
-
-	ok, err := buzzscreen.Service.DeviceUseCase.ValidateUnitDeviceToken(req.Session.UserID)
-	if !ok {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
-	}
-
-	unit := req.GetUnit(ctx)
-	if unit == nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "unit can't be nil"})
-	} else if !unit.IsActive {
-		return c.JSON(http.StatusForbidden, map[string]interface{}{"error": "unit is inactive"})
-	}
-
-	profile := req.GetDynamoProfile()
-	if profile != nil {
-		service.UpdateProfileUnitRegisterSeconds(profile, unit.ID, req.Session.AppID, c.Path())
-		service.GiveWelcomeReward(ctx, profile, req.Session.UserID, unit.ID, req.GetCountry(ctx))
+	if ok, err := validateContentArticlesRequest(c, &req); !ok {
+		return err
 	}
 
-	categoryMap, err := service.GetCategoriesMap(req.GetLanguage())
+	categoryMap, err := service.GetCategoriesMap(req.GetLanguages())
 	if err != nil {
 		return err
 	}
@@ -100,7 +77,7 @@ func GetContentArticles(c core.Context) error {
 	} else {
 		var queryKey *dto.ContentQueryKey
 
-		res.ContentArticles, queryKey, err = getContentArticlesFromES(c, &req, categoryMap)
+		res.ContentArticles, queryKey, res.Degraded, err = getContentArticlesFromES(c, &req, categoryMap)
 		if err != nil {
 			switch err.(type) {
 			case contentcampaign.RemoteESError:
@@ -117,7 +94,7 @@ func GetContentArticles(c core.Context) error {
 		}
 	}
 
-	logGetContentArticles(ctx, req, res)
+	logGetContentArticles(ctx, c.Path(), req, res)
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"code":   dto.CodeOk,
@@ -125,13 +102,90 @@ func GetContentArticles(c core.Context) error {
 	})
 }
 
-func logGetContentArticles(ctx context.Context, req dto.ContentArticlesRequest, res dto.ContentArticlesResponse) {
+// SearchContentArticles returns the articles matching the keyword q with the same targeting as GetContentArticles.
+// Articles are ranked by relevance and the matched fragments of title and description are returned in highlights.
+func SearchContentArticles(c core.Context) error {
+	ctx := c.Request().Context()
+	var req dto.ContentArticlesRequest
+	if err := bindRequestSupport(c, &req, &ContentV2ArticlesRequest{}); err != nil {
+		return err
+	}
+
+	req.Keyword = strings.TrimSpace(req.Keyword)
+	if req.Keyword == "" {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "q is required"})
+	}
+
+	if ok, err := validateContentArticlesRequest(c, &req); !ok {
+		return err
+	}
+
+	categoryMap, err := service.GetCategoriesMap(req.GetLanguages())
+	if err != nil {
+		return err
+	}
+
+	var res dto.ContentArticlesResponse
+	var queryKey *dto.ContentQueryKey
+	res.ContentArticles, queryKey, _, err = getContentArticlesFromES(c, &req, categoryMap)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("controller.SearchContentArticles() - err: %s", err)
+		return common.NewInternalServerError(err)
+	}
+	if queryKey != nil {
+		keyStr := queryKey.Marshal()
+		res.QueryKey = &keyStr
+	}
+
+	logGetContentArticles(ctx, c.Path(), req, res)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"code":   dto.CodeOk,
+		"result": getResponseSupport(c, res),
+	})
+}
+
+// validateContentArticlesRequest unpacks the session and checks the unit of the request.
+// false is returned with the error of the response if the request isn't valid.
+func validateContentArticlesRequest(c core.Context, req *dto.ContentArticlesRequest) (bool, error) {
+	ctx := c.Request().Context()
+	if ifa.ShouldReplaceIFAWithIFV(req.AdID, req.IFV) {
+		req.AdID = ifa.GetDeviceIFV(*req.IFV)
+	}
+
+	err := req.UnpackSession()
+	if err != nil {
+		return false, common.NewSessionError(err)
+	}
+
+	ok, err := buzzscreen.Service.DeviceUseCase.ValidateUnitDeviceToken(req.Session.UserID)
+	if !ok {
+		return false, c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	}
+
+	unit := req.GetUnit(ctx)
+	if unit == nil {
+		return false, c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "unit can't be nil"})
+	} else if !unit.IsActive {
+		return false, c.JSON(http.StatusForbidden, map[string]interface{}{"error": "unit is inactive"})
+	}
+
+	profile := req.GetDynamoProfile()
+	if profile != nil {
+		service.UpdateProfileUnitRegisterSeconds(profile, unit.ID, req.Session.AppID, c.Path())
+		service.GiveWelcomeReward(ctx, profile, req.Session.UserID, unit.ID, req.GetCountry(ctx))
+	}
+	return true, nil
+}
+
+func logGetContentArticles(ctx context.Context, path string, req dto.ContentArticlesRequest, res dto.ContentArticlesResponse) {
 	var campaignIDs []int64
 	for _, c := range res.ContentArticles {
 		campaignIDs = append(campaignIDs, c.ID)
 	}
 
-	core.Logger.Infof("/api/v3/content/articles - Request{AppID:%d, UnitID:%d, DeviceID:%d, IFA:%s, UnitDeviceToken:%s, SDKVersion:%d, OSVersion:%d} Response{Contents:%v}",
+	core.Logger.Infof("%s - Request{AppID:%d, UnitID:%d, DeviceID:%d, IFA:%s, UnitDeviceToken:%s, SDKVersion:%d, OSVersion:%d} Response{Contents:%v}",
+		path,
 		req.Session.AppID,
 		req.GetUnit(ctx).ID,
 		req.Session.DeviceID,
@@ -143,23 +197,25 @@ func logGetContentArticles(ctx context.Context, req dto.ContentArticlesRequest,
 	)
 }
 
-func getContentArticlesFromES(c core.Context, contentReq *dto.ContentArticlesRequest, categoryMap map[string]*model.ContentCategory) (dto.ContentArticles, *dto.ContentQueryKey, error) {
+// getContentArticlesFromES returns the articles searched from ElasticSearch and the query key of the next page.
+// If ElasticSearch fails, the articles of the content snapshot are returned as degraded without the query key
+func getContentArticlesFromES(c core.Context, contentReq *dto.ContentArticlesRequest, categoryMap map[string]*model.ContentCategory) (dto.ContentArticles, *dto.ContentQueryKey, bool, error) {
 	ctx := c.Request().Context()
 	contentArticles := make(dto.ContentArticles, 0)
 	var queryKeyFrom *dto.ContentQueryKey
 	var err error
-	currentTime := time.Now().Unix()
+	currentTime := contentReq.GetQueryTime()
 	dp := contentReq.GetDynamoProfile()
 	campaignIDs := make([]int64, 0)
 	if queryKeyFrom, err = contentReq.GetQueryKey(); err != nil {
-		return nil, nil, err
+		return nil, nil, false, err
 	}
 	idMap := make(map[int64]bool)
 
 	if queryKeyFrom != nil {
 		currentTime = queryKeyFrom.CreatedAt
 
-		if dp != nil && dp.ScoredCampaignsKey != nil && *queryKeyFrom.ScoredCampaignsKey != *dp.ScoredCampaignsKey {
+		if dp != nil && dp.ScoredCampaignsKey != nil && queryKeyFrom.ScoredCampaignsKey != nil && *queryKeyFrom.ScoredCampaignsKey != *dp.ScoredCampaignsKey {
 			core.Logger.WithField("http_request", c.Request()).Warnf("ScoredCampaigns Key is changed. deviceID: %v, queryKeyFrom.ScoredCampaignsKey: %v, dp.ScoredCampaignsKey: %v ", contentReq.Session.DeviceID, *queryKeyFrom.ScoredCampaignsKey, *dp.ScoredCampaignsKey)
 		}
 
@@ -167,13 +223,33 @@ func getContentArticlesFromES(c core.Context, contentReq *dto.ContentArticlesReq
 		for _, id := range queryKeyFrom.CampaignIDs {
 			idMap[id] = true
 		}
+		for _, id := range queryKeyFrom.ExploredIDs {
+			idMap[id] = true
+		}
 	}
 
+	degraded := false
 	esContentCamps, totalSize, err := service.GetContentCampaignsFromES(ctx, contentReq)
 	if err != nil {
-		return nil, nil, contentcampaign.RemoteESError{Err: err}
+		if esContentCamps, err = getSnapshotContentCampaigns(ctx, contentReq, err); err != nil {
+			return nil, nil, false, err
+		}
+		degraded, totalSize = true, 0
+	}
+	// 탐색으로 끼워 넣은 캠페인은 다음 페이지의 Index와 커서에서 제외하고, 다음 페이지 검색에서도 제외하도록 커서에 남긴다
+	rankedCamps := make([]*dto.ESContentCampaign, 0, len(esContentCamps))
+	var exploredIDs []int64
+	if queryKeyFrom != nil {
+		exploredIDs = append([]int64{}, queryKeyFrom.ExploredIDs...)
 	}
-	nextItemIndex := len(esContentCamps)
+	for _, esContent := range esContentCamps {
+		if esContent.Exploration == "" {
+			rankedCamps = append(rankedCamps, esContent)
+		} else {
+			exploredIDs = append(exploredIDs, esContent.ID)
+		}
+	}
+	nextItemIndex := len(rankedCamps)
 	if queryKeyFrom != nil {
 		nextItemIndex += queryKeyFrom.Index
 	}
@@ -213,25 +289,52 @@ func getContentArticlesFromES(c core.Context, contentReq *dto.ContentArticlesReq
 			continue
 		}
 
+		article.VariantID = esContent.VariantID
+		article.Exploration = esContent.Exploration
 		article.SetImpClickPayload(ctx, &(esContent.ContentCampaign), contentReq)
+		article.Highlights = esContent.Highlights
 
 		campaignIDs = append(campaignIDs, article.ID)
 		contentArticles = append(contentArticles, article)
 	}
+	localizeArticleChannels(contentArticles, contentReq.GetLanguages())
 
 	var queryKeyTo *dto.ContentQueryKey
 	if nextItemIndex < (totalSize) {
 		queryKeyTo = &dto.ContentQueryKey{
 			CreatedAt:   currentTime,
 			Index:       nextItemIndex,
-			CampaignIDs: campaignIDs,
+			ExploredIDs: exploredIDs,
+		}
+		if len(rankedCamps) > 0 && rankedCamps[len(rankedCamps)-1].Score != nil {
+			last := rankedCamps[len(rankedCamps)-1]
+			queryKeyTo.SearchAfter = &dto.ContentSearchAfter{Score: *last.Score, ID: last.ID}
+		} else {
+			// 정렬값이 없으면 이전 방식대로 Index로 조회하고 중복을 제거한다
+			queryKeyTo.CampaignIDs = campaignIDs
 		}
 		if dp != nil && dp.ScoredCampaignsKey != nil {
 			queryKeyTo.ScoredCampaignsKey = dp.ScoredCampaignsKey
 		}
 	}
 
-	return contentArticles, queryKeyTo, nil
+	return contentArticles, queryKeyTo, degraded, nil
+}
+
+// getSnapshotContentCampaigns returns the campaigns of the content snapshot served when ElasticSearch fails by esErr.
+// RemoteESError of esErr is returned if the snapshot can't be served
+func getSnapshotContentCampaigns(ctx context.Context, contentReq *dto.ContentArticlesRequest, esErr error) ([]*dto.ESContentCampaign, error) {
+	country := service.GetSupportedCountry(contentReq.GetCountry(ctx))
+	esContentCamps, err := service.GetSnapshotContentCampaigns(ctx, contentReq)
+	if err != nil {
+		buzzscreen.Service.Metrics.ContentFallbacks.WithLabelValues(country, "unavailable").Inc()
+		core.Logger.WithError(err).Warnf("getSnapshotContentCampaigns() - failed to serve content snapshot. esErr: %s", esErr)
+		return nil, contentcampaign.RemoteESError{Err: esErr}
+	}
+
+	buzzscreen.Service.Metrics.ContentFallbacks.WithLabelValues(country, "served").Inc()
+	core.Logger.WithError(esErr).Warnf("getSnapshotContentCampaigns() - served content snapshot to device %d", contentReq.Session.DeviceID)
+	return esContentCamps, nil
 }
 
 func getContentArticlesByIDs(ctx context.Context, contentReq *dto.ContentArticlesRequest, categoryMap map[string]*model.ContentCategory) dto.ContentArticles {
@@ -251,9 +354,20 @@ func getContentArticlesByIDs(ctx context.Context, contentReq *dto.ContentArticle
 			continue
 		}
 	}
+	localizeArticleChannels(contentArticles, contentReq.GetLanguages())
 	return contentArticles
 }
 
+func localizeArticleChannels(contentArticles dto.ContentArticles, languages localization.Languages) {
+	channels := make([]*model.ContentChannel, 0, len(contentArticles))
+	for _, article := range contentArticles {
+		if article.Channel != nil {
+			channels = append(channels, article.Channel)
+		}
+	}
+	service.LocalizeChannels(channels, languages)
+}
+
 func parseContentCampaignToContentArticle(cc *model.ContentCampaign, channel *model.ContentChannel, imageURL string, types *dto.CreativeTypes, categoryMap map[string]*model.ContentCategory) *dto.ContentArticle {
 	defer recovery.LimitedLogRecoverWith(*cc, 100)
 	publishedAtUnix := utils.ConvertToUnixTime(cc.PublishedAt)
@@ -322,7 +436,7 @@ func parseContentCampaignToContentArticle(cc *model.ContentCampaign, channel *mo
 func GetContentChannels(c core.Context) error {
 	ctx := c.Request().Context()
 	var contentReq dto.GetContentChannelsRequest
-	if err := bindRequestSupport(c, &contentReq, &ContentV2ArticlesRequest{}); err != nil {
+	if err := bindRequestSupport(c, &contentReq, &GetContentV2ChannelsRequest{}); err != nil {
 		return err
 	}
 
@@ -340,12 +454,23 @@ func GetContentChannels(c core.Context) error {
 
 	channelsResponse := dto.ContentChannelsResponse{}
 	var channelQuery *model.ContentChannelsQuery
-	if contentReq.IDs != "" {
+	if contentReq.Followed {
+		preference, err := buzzscreen.Service.ContentPreferenceUseCase.GetPreference(contentReq.Session.DeviceID)
+		if err != nil {
+			core.Logger.WithError(err).Errorf("GetContentChannels() - failed to get preference of device %d", contentReq.Session.DeviceID)
+			return common.NewInternalServerError(err)
+		} else if len(preference.FollowedChannelIDs) == 0 {
+			channelsResponse.Result.Channels = &model.ContentChannels{}
+			return c.JSON(http.StatusOK, getResponseSupport(c, channelsResponse))
+		}
+		channelQuery = model.NewContentChannelsQuery().WithIDs(joinInt64s(preference.FollowedChannelIDs))
+	} else if contentReq.IDs != "" {
 		channelQuery = model.NewContentChannelsQuery().WithIDs(contentReq.IDs)
 	} else {
 		channelQuery = model.NewContentChannelsQuery().WithCountryAndCategoryID(service.GetSupportedCountry(country), contentReq.CategoryID)
 	}
 	channelsResponse.Result.Channels = service.GetContentChannels(channelQuery)
+	service.LocalizeChannels(*channelsResponse.Result.Channels, contentReq.GetLanguages())
 	return c.JSON(http.StatusOK, getResponseSupport(c, channelsResponse))
 }
 
@@ -383,10 +508,12 @@ type (
 		FilterChannelIDs  string        `form:"filterChannelIDs" query:"filterChannelIDs"`
 		Gender            string        `form:"gender" query:"gender"`
 		IsDebugging       bool          `form:"isDebugging" query:"isDebugging"`
+		Keyword           string        `form:"q" query:"q"`
 		LandingTypes      string        `form:"landingTypes" query:"landingTypes"`
 		Package           string        `form:"package" query:"package"`
 		PlaceType         dto.PlaceType `form:"placeType" query:"placeType"`
 		Size              int           `form:"size" query:"size"`
+		Sort              string        `form:"sort" query:"sort" validate:"omitempty,oneof=trending"`
 		TypesString       string        `form:"types" query:"types" validate:"required"` //eg. {"IMAGE":["INTERSTITIAL"]} or {"NATIVE":[]}
 	}
 	// GetContentV2ChannelsRequest type definition
@@ -394,6 +521,7 @@ type (
 		ContentV2BaseRequest
 		CategoryID string `form:"categoryId" query:"categoryId"`
 		IDs        string `form:"ids" query:"ids"`
+		Followed   bool   `form:"followed" query:"followed"`
 	}
 	// GetDeviceV2ConfigRequest type definition
 	GetDeviceV2ConfigRequest struct {
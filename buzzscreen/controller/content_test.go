@@ -9,9 +9,12 @@ import (
 	"testing"
 
 	"github.com/Buzzvil/buzzlib-go/network"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/model"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/utils"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentexploration"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/trackingdata"
 	"github.com/Buzzvil/buzzscreen-api/tests"
 	"github.com/Buzzvil/go-test/test"
 )
@@ -225,6 +228,110 @@ func TestGetContentArticlesCategories(t *testing.T) {
 	})
 }
 
+func TestSearchContentArticles(t *testing.T) {
+	_, removeFunc := getPatchedHttpClient()
+	defer removeFunc()
+
+	camps := createBaseContentCampaigns(3)
+	camps[0].Title = "Baseball season opens"
+	camps[1].Description = "Highlights of the baseball game"
+	camps[2].Title = "Weather"
+	insertContentCampaignsToESAndDB(t, camps...)
+	defer deleteContentCampaignsFromESAndDB(t, camps...)
+
+	newContentArticleTestCase(t, "TestSearchContentArticles", func(contentCase *ContentTestCase) {
+		contentCase.path = "/api/v3/content/search"
+		contentCase.params.Set("types", `{"NATIVE":[]}`)
+		contentCase.params.Set("unit_id", strconv.FormatInt(tests.HsKrFeedUnitID, 10))
+		contentCase.params.Set("q", "baseball")
+	}).run(func(tc *ContentTestCase, res *TestContentArticlesResponse) bool {
+		tc.t.Logf("%s - res: %v", tc.name, *res)
+		for _, article := range res.Result.ContentArticles {
+			test.AssertEqual(tc.t, len(article.Highlights) > 0, true, tc.name+" - highlights")
+		}
+		return len(res.Result.ContentArticles) == 2 && res.Result.ContentArticles[0].ID == camps[0].ID
+	})
+}
+
+func TestGetContentArticlesExploration(t *testing.T) {
+	_, removeFunc := getPatchedHttpClient()
+	defer removeFunc()
+
+	// config.test.json은 탐색을 끄므로 이 테스트에서만 켠다
+	policy := contentexploration.DefaultPolicy
+	policy.Fraction = 0.5
+	explorationUseCase := buzzscreen.Service.ContentExplorationUseCase
+	buzzscreen.Service.ContentExplorationUseCase = contentexploration.NewUseCase(policy)
+	defer func() { buzzscreen.Service.ContentExplorationUseCase = explorationUseCase }()
+
+	pageSize := 4
+	camps := createBaseContentCampaigns(pageSize * 2)
+	insertContentCampaignsToESAndDB(t, camps...)
+	defer deleteContentCampaignsFromESAndDB(t, camps...)
+
+	newContentArticleTestCase(t, "TestGetContentArticlesExploration", func(contentCase *ContentTestCase) {
+		contentCase.params.Set("types", `{"NATIVE":[]}`)
+		contentCase.params.Set("unit_id", strconv.FormatInt(tests.HsKrFeedUnitID, 10))
+		contentCase.params.Set("size", strconv.Itoa(pageSize))
+	}).run(func(tc *ContentTestCase, res *TestContentArticlesResponse) bool {
+		tc.t.Logf("%s - res: %v", tc.name, *res)
+		articles := res.Result.ContentArticles
+		explored := 0
+		for _, article := range articles {
+			if trackingData := parseArticleTrackingData(tc, article); trackingData != nil {
+				test.AssertEqual(tc.t, trackingData.Exploration, contentexploration.TagUCB, tc.name+" - exploration")
+				explored++
+			}
+		}
+		if len(articles) > 0 {
+			test.AssertEqual(tc.t, parseArticleTrackingData(tc, articles[len(articles)-1]) == nil, true, tc.name+" - last article is ranked")
+		}
+		if res.Result.QueryKey == nil {
+			return false
+		}
+
+		// 첫 페이지에 탐색으로 나온 캠페인도 다음 페이지에 다시 나오지 않아야 한다
+		firstPageIDs := make(map[int64]bool)
+		for _, article := range articles {
+			firstPageIDs[article.ID] = true
+		}
+		newContentArticleTestCase(t, "TestGetContentArticlesExploration - Page 1", func(contentCase *ContentTestCase) {
+			contentCase.params.Set("types", `{"NATIVE":[]}`)
+			contentCase.params.Set("unit_id", strconv.FormatInt(tests.HsKrFeedUnitID, 10))
+			contentCase.params.Set("size", strconv.Itoa(pageSize))
+			contentCase.params.Set("query_key", *res.Result.QueryKey)
+		}).run(func(tc *ContentTestCase, res *TestContentArticlesResponse) bool {
+			tc.t.Logf("%s - res: %v", tc.name, *res)
+			for _, article := range res.Result.ContentArticles {
+				test.AssertEqual(tc.t, firstPageIDs[article.ID], false, tc.name+" - duplicated")
+			}
+			return len(res.Result.ContentArticles) == len(camps)-pageSize
+		})
+
+		return len(articles) == pageSize && explored == policy.Slots(pageSize)
+	})
+}
+
+// parseArticleTrackingData returns the tracking data of the impression tracker. nil is returned if the article is ranked by the model
+func parseArticleTrackingData(tc *ContentTestCase, article *dto.ContentArticle) *trackingdata.TrackingData {
+	if len(article.ImpressionTrackers) == 0 {
+		return nil
+	}
+	impressionURL, err := url.Parse(article.ImpressionTrackers[0])
+	if err != nil {
+		tc.t.Fatalf("%s - invalid impression tracker %s", tc.name, article.ImpressionTrackers[0])
+	}
+	trackingDataString := impressionURL.Query().Get("tracking_data")
+	if trackingDataString == "" {
+		return nil
+	}
+	trackingData, err := buzzscreen.Service.TrackingDataUseCase.ParseTrackingData(trackingDataString)
+	if err != nil {
+		tc.t.Fatalf("%s - invalid tracking data %s", tc.name, trackingDataString)
+	}
+	return trackingData
+}
+
 func compareCampaignWithArticle(tc *ContentTestCase, camp *dto.ESContentCampaign, article *dto.ContentArticle) {
 	test.AssertEqual(tc.t, article.ID, camp.ID, tc.name+" - id")
 	test.AssertEqual(tc.t, article.Creative["click_url"] != nil, true, tc.name+" - click_url")
@@ -266,6 +373,7 @@ type (
 	ContentTestCase struct {
 		t      *testing.T
 		name   string
+		path   string
 		params *url.Values
 	}
 )
@@ -274,6 +382,7 @@ func newContentArticleTestCase(t *testing.T, name string, builder func(*ContentT
 	requestParams, _ := buildV3BaseTestRequest(t)
 	cac := &ContentTestCase{
 		name:   name,
+		path:   "/api/v3/content/articles",
 		params: requestParams,
 		t:      t,
 	}
@@ -299,7 +408,7 @@ func (tc *ContentTestCase) run(equalFunc func(tc *ContentTestCase, res *TestCont
 	statusCode, err := (&network.Request{
 		Method: "GET",
 		Params: tc.params,
-		URL:    ts.URL + "/api/v3/content/articles",
+		URL:    ts.URL + tc.path,
 	}).GetResponse(&articlesResponse)
 
 	if err != nil {
@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/model"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/service"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentbookmark"
+)
+
+// GetContentBookmarks returns the bookmarks of the device from the latest one as articles.
+// Articles are built from the snapshots so that bookmarks of ended campaigns are returned as well.
+func GetContentBookmarks(c core.Context) error {
+	ctx := c.Request().Context()
+	var req dto.ContentBookmarksRequest
+	if err := bindRequestSupport(c, &req, &ContentV2BookmarksRequest{}); err != nil {
+		return err
+	}
+
+	if ok, err := validateContentArticlesRequest(c, &req.ContentArticlesRequest); !ok {
+		return err
+	}
+
+	categoryMap, err := service.GetCategoriesMap(req.GetLanguages())
+	if err != nil {
+		return err
+	}
+
+	page, err := buzzscreen.Service.ContentBookmarkUseCase.GetBookmarks(req.Session.DeviceID, req.Cursor, req.Size)
+	if err != nil {
+		core.Logger.WithError(err).Errorf("GetContentBookmarks() - failed to get bookmarks of device %d", req.Session.DeviceID)
+		return common.NewInternalServerError(err)
+	}
+
+	res := dto.ContentBookmarksResponse{ContentArticles: make(dto.ContentArticles, 0, len(page.Bookmarks))}
+	for _, bookmark := range page.Bookmarks {
+		if article := parseBookmarkToContentArticle(ctx, bookmark, &req.ContentArticlesRequest, categoryMap); article != nil {
+			res.ContentArticles = append(res.ContentArticles, article)
+		}
+	}
+	localizeArticleChannels(res.ContentArticles, req.GetLanguages())
+	if page.NextCursor > 0 {
+		res.NextCursor = &page.NextCursor
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"code":   dto.CodeOk,
+		"result": getResponseSupport(c, res),
+	})
+}
+
+// PostContentBookmark saves the snapshot of the campaign for the device
+func PostContentBookmark(c core.Context) error {
+	var req dto.ContentBookmarkRequest
+	if ok, err := bindContentBookmarkRequest(c, &req); !ok {
+		return err
+	}
+
+	campaign, relevance, err := service.GetContentCampaignWithRelevance(req.CampaignID)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("PostContentBookmark() - failed to get campaign %d", req.CampaignID)
+		return common.NewInternalServerError(err)
+	} else if campaign == nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": "campaign not found"})
+	}
+
+	_, err = buzzscreen.Service.ContentBookmarkUseCase.AddBookmark(req.Session.DeviceID, req.CampaignID, buildBookmarkSnapshot(campaign), *relevance)
+	if err != nil {
+		switch err.(type) {
+		case contentbookmark.TooManyBookmarksError:
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		default:
+			core.Logger.WithError(err).Errorf("PostContentBookmark() - failed to bookmark campaign %d of device %d", req.CampaignID, req.Session.DeviceID)
+			return common.NewInternalServerError(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"code": dto.CodeOk,
+	})
+}
+
+// DeleteContentBookmark removes the bookmark of the campaign. It succeeds even if the campaign isn't bookmarked
+func DeleteContentBookmark(c core.Context) error {
+	var req dto.ContentBookmarkRequest
+	if ok, err := bindContentBookmarkRequest(c, &req); !ok {
+		return err
+	}
+
+	if err := buzzscreen.Service.ContentBookmarkUseCase.RemoveBookmark(req.Session.DeviceID, req.CampaignID); err != nil {
+		core.Logger.WithError(err).Errorf("DeleteContentBookmark() - failed to remove bookmark of campaign %d of device %d", req.CampaignID, req.Session.DeviceID)
+		return common.NewInternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"code": dto.CodeOk,
+	})
+}
+
+// bindContentBookmarkRequest binds the request and unpacks the session.
+// false is returned with the error of the response if the request isn't valid.
+func bindContentBookmarkRequest(c core.Context, req *dto.ContentBookmarkRequest) (bool, error) {
+	if err := bindRequestSupport(c, req, &ContentV2BookmarkRequest{}); err != nil {
+		return false, err
+	}
+
+	if err := req.UnpackSession(); err != nil {
+		return false, common.NewSessionError(err)
+	}
+
+	ok, err := buzzscreen.Service.DeviceUseCase.ValidateUnitDeviceToken(req.Session.UserID)
+	if !ok {
+		return false, c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	}
+	return true, nil
+}
+
+func buildBookmarkSnapshot(campaign *dto.ESContentCampaign) contentbookmark.Snapshot {
+	snapshot := contentbookmark.Snapshot{
+		Name:           campaign.Name,
+		Title:          campaign.Title,
+		Description:    campaign.Description,
+		ImageURL:       campaign.Image,
+		ClickURL:       campaign.ClickURL,
+		CleanLink:      campaign.CleanLink,
+		CleanMode:      campaign.CleanMode,
+		LandingType:    int(campaign.LandingType),
+		Categories:     campaign.Categories,
+		PublishedAt:    campaign.PublishedAt,
+		EndDate:        campaign.EndDate,
+		Timezone:       campaign.Timezone,
+		OrganizationID: campaign.OrganizationID,
+		OwnerID:        campaign.OwnerID,
+	}
+
+	if campaign.Channel != nil {
+		snapshot.Channel = &contentbookmark.Channel{ID: campaign.Channel.ID, Name: campaign.Channel.Name, Logo: campaign.Channel.Logo}
+	} else if campaign.ChannelID != nil {
+		if channel := service.GetChannel(*campaign.ChannelID); channel.ID != 0 {
+			snapshot.Channel = &contentbookmark.Channel{ID: channel.ID, Name: channel.Name, Logo: channel.Logo}
+		}
+	}
+	return snapshot
+}
+
+func parseBookmarkToContentArticle(ctx context.Context, bookmark contentbookmark.Bookmark, req *dto.ContentArticlesRequest, categoryMap map[string]*model.ContentCategory) *dto.ContentArticle {
+	snapshot := bookmark.Snapshot
+	campaign := &model.ContentCampaign{
+		ID:             bookmark.CampaignID,
+		Name:           snapshot.Name,
+		Title:          snapshot.Title,
+		Description:    snapshot.Description,
+		Image:          snapshot.ImageURL,
+		ClickURL:       snapshot.ClickURL,
+		CleanLink:      snapshot.CleanLink,
+		CleanMode:      snapshot.CleanMode,
+		LandingType:    model.LandingType(snapshot.LandingType),
+		Categories:     snapshot.Categories,
+		PublishedAt:    snapshot.PublishedAt,
+		EndDate:        snapshot.EndDate,
+		Timezone:       snapshot.Timezone,
+		OrganizationID: snapshot.OrganizationID,
+		OwnerID:        snapshot.OwnerID,
+		JSON:           "{}",
+	}
+	// 시각이 없는 snapshot은 북마크한 시각으로 대신한다
+	if campaign.PublishedAt == "" {
+		campaign.PublishedAt = bookmark.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if campaign.EndDate == "" {
+		campaign.EndDate = bookmark.CreatedAt.UTC().Format(time.RFC3339)
+	}
+
+	var channel *model.ContentChannel
+	if snapshot.Channel != nil {
+		channel = &model.ContentChannel{ID: snapshot.Channel.ID, Name: snapshot.Channel.Name, Logo: snapshot.Channel.Logo}
+		campaign.ChannelID = &snapshot.Channel.ID
+	}
+
+	imageURL := snapshot.ImageURL
+	if req.OsVersion >= 14 && req.Os != "ios" {
+		imageURL = strings.Replace(imageURL, "jpeg", "webp", -1)
+	}
+
+	article := parseContentCampaignToContentArticle(campaign, channel, imageURL, req.GetTypes(), categoryMap)
+	if article == nil {
+		core.Logger.Warnf("GetContentBookmarks() - article is nil. bookmark: %d", bookmark.ID)
+		return nil
+	}
+	article.SetImpClickPayload(ctx, campaign, req)
+	// 북마크 목록은 피드 노출이 아니므로 impression을 집계하지 않는다
+	article.ImpressionTrackers = nil
+	return article
+}
+
+type (
+	// ContentV2BookmarkRequest type definition
+	ContentV2BookmarkRequest struct {
+		ContentV2BaseRequest
+		CampaignID int64 `form:"campaignId" query:"campaignId" validate:"required"`
+	}
+	// ContentV2BookmarksRequest type definition
+	ContentV2BookmarksRequest struct {
+		ContentV2ArticlesRequest
+		Cursor int64 `form:"cursor" query:"cursor"`
+	}
+)
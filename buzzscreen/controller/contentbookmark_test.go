@@ -0,0 +1,96 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
+	"github.com/Buzzvil/buzzscreen-api/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// TestContentBookmarksResponse type definition
+	TestContentBookmarksResponse struct {
+		Result dto.ContentBookmarksResponse `json:"result"`
+		Code   int                          `json:"code"`
+	}
+)
+
+func TestContentBookmarks(t *testing.T) {
+	camp := createBaseContentCampaignWithID(1)
+	insertContentCampaignsToESAndDB(t, camp)
+	defer deleteContentCampaignsFromESAndDB(t, camp)
+
+	params, _ := buildV3BaseTestRequest(t)
+	params.Set("types", `{"NATIVE":[]}`)
+	params.Set("unit_id", strconv.FormatInt(tests.HsKrFeedUnitID, 10))
+	params.Set("campaign_id", strconv.FormatInt(camp.ID, 10))
+
+	var res TestCodeResponse
+	statusCode := requestContentAPI(t, http.MethodPost, "/api/v3/content/bookmarks", params, &res)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, dto.CodeOk, res.Code)
+
+	var bookmarksRes TestContentBookmarksResponse
+	statusCode = requestContentAPI(t, http.MethodGet, "/api/v3/content/bookmarks", params, &bookmarksRes)
+	assert.Equal(t, http.StatusOK, statusCode)
+	if assert.Len(t, bookmarksRes.Result.ContentArticles, 1) {
+		article := bookmarksRes.Result.ContentArticles[0]
+		assert.Equal(t, camp.ID, article.ID)
+		assert.Equal(t, camp.Title, article.Creative["title"])
+		assert.NotNil(t, article.Creative["click_url"])
+		assert.Empty(t, article.ImpressionTrackers)
+	}
+	assert.Nil(t, bookmarksRes.Result.NextCursor)
+
+	statusCode = requestContentAPI(t, http.MethodDelete, "/api/v3/content/bookmarks", params, &res)
+	assert.Equal(t, http.StatusOK, statusCode)
+
+	bookmarksRes = TestContentBookmarksResponse{}
+	statusCode = requestContentAPI(t, http.MethodGet, "/api/v3/content/bookmarks", params, &bookmarksRes)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Empty(t, bookmarksRes.Result.ContentArticles)
+}
+
+func TestPostContentBookmarkInvalidRequest(t *testing.T) {
+	for name, tc := range map[string]struct {
+		campaignID string
+		session    bool
+		statusCode int
+	}{
+		"without campaign_id": {campaignID: "", session: true, statusCode: http.StatusBadRequest},
+		"invalid campaign_id": {campaignID: "abc", session: true, statusCode: http.StatusBadRequest},
+		"unknown campaign":    {campaignID: "99999", session: true, statusCode: http.StatusNotFound},
+		"without session":     {campaignID: "1", session: false, statusCode: http.StatusUnauthorized},
+	} {
+		params := buildContentBookmarkRequest(t, tc.campaignID)
+		if !tc.session {
+			params.Del("session_key")
+		}
+
+		var res map[string]interface{}
+		statusCode := requestContentAPI(t, http.MethodPost, "/api/v3/content/bookmarks", params, &res)
+		assert.Equal(t, tc.statusCode, statusCode, name)
+	}
+}
+
+func TestDeleteContentBookmarkInvalidRequest(t *testing.T) {
+	var res map[string]interface{}
+	statusCode := requestContentAPI(t, http.MethodDelete, "/api/v3/content/bookmarks", buildContentBookmarkRequest(t, ""), &res)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	// 북마크하지 않은 캠페인도 성공한다
+	statusCode = requestContentAPI(t, http.MethodDelete, "/api/v3/content/bookmarks", buildContentBookmarkRequest(t, "99999"), &res)
+	assert.Equal(t, http.StatusOK, statusCode)
+}
+
+func buildContentBookmarkRequest(t *testing.T, campaignID string) *url.Values {
+	params, _ := buildV3BaseTestRequest(t)
+	if campaignID != "" {
+		params.Set("campaign_id", campaignID)
+	}
+	return params
+}
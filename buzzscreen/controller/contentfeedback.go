@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/service"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
+)
+
+// PostContentFeedback records like, dislike, hide or not_interested_in_channel of the device on the campaign
+// and updates the category and entity scores of the device profile immediately.
+// Hidden campaigns and channels are excluded from the articles of the device afterwards.
+func PostContentFeedback(c core.Context) error {
+	var req dto.ContentFeedbackRequest
+	if err := bindRequestSupport(c, &req, &ContentV2FeedbackRequest{}); err != nil {
+		return err
+	}
+
+	if err := req.UnpackSession(); err != nil {
+		return common.NewSessionError(err)
+	}
+
+	ok, err := buzzscreen.Service.DeviceUseCase.ValidateUnitDeviceToken(req.Session.UserID)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	}
+
+	campaign, relevance, err := service.GetContentCampaignWithRelevance(req.CampaignID)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("PostContentFeedback() - failed to get campaign %d", req.CampaignID)
+		return common.NewInternalServerError(err)
+	} else if campaign == nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": "campaign not found"})
+	}
+
+	feedback := device.ContentFeedback{
+		Type:       device.FeedbackType(req.Type),
+		CampaignID: req.CampaignID,
+		CreatedAt:  time.Now().Unix(),
+	}
+	if campaign.ChannelID != nil {
+		feedback.ChannelID = *campaign.ChannelID
+	}
+
+	if _, err := buzzscreen.Service.DeviceUseCase.ApplyContentFeedback(req.Session.DeviceID, feedback, *relevance); err != nil {
+		core.Logger.WithError(err).Errorf("PostContentFeedback() - failed to apply feedback of device %d", req.Session.DeviceID)
+		return common.NewInternalServerError(err)
+	}
+
+	logObj := map[string]interface{}{
+		"log_type":    "ContentFeedback",
+		"device_id":   req.Session.DeviceID,
+		"campaign_id": feedback.CampaignID,
+		"channel_id":  feedback.ChannelID,
+		"type":        feedback.Type,
+		"message":     "general",
+	}
+	core.Loggers["general"].WithFields(logObj).Info("Log")
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"code": dto.CodeOk,
+	})
+}
+
+type (
+	// ContentV2FeedbackRequest type definition
+	ContentV2FeedbackRequest struct {
+		ContentV2BaseRequest
+		CampaignID int64  `form:"campaignId" query:"campaignId" validate:"required"`
+		Type       string `form:"type" query:"type" validate:"required,oneof=like dislike hide not_interested_in_channel"`
+	}
+)
@@ -0,0 +1,72 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
+	"github.com/Buzzvil/buzzscreen-api/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// TestCodeResponse type definition
+	TestCodeResponse struct {
+		Code int `json:"code"`
+	}
+)
+
+func TestPostContentFeedback(t *testing.T) {
+	camp := createBaseContentCampaignWithID(1)
+	insertContentCampaignsToESAndDB(t, camp)
+	defer deleteContentCampaignsFromESAndDB(t, camp)
+
+	params := buildContentFeedbackRequest(t, camp.ID, "hide")
+	var res TestCodeResponse
+	statusCode := requestContentAPI(t, http.MethodPost, "/api/v3/content/feedback", params, &res)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, dto.CodeOk, res.Code)
+
+	session, err := buzzscreen.Service.SessionUseCase.GetSessionFromKey(params.Get("session_key"))
+	assert.Nil(t, err)
+	profile := tests.GetProfileByID(session.DeviceID)
+	if assert.NotNil(t, profile) && assert.NotNil(t, profile.HiddenCampaignIDs) {
+		assert.Contains(t, *profile.HiddenCampaignIDs, camp.ID)
+	}
+}
+
+func TestPostContentFeedbackInvalidRequest(t *testing.T) {
+	camp := createBaseContentCampaignWithID(1)
+	insertContentCampaignsToESAndDB(t, camp)
+	defer deleteContentCampaignsFromESAndDB(t, camp)
+
+	for name, tc := range map[string]struct {
+		params     *url.Values
+		statusCode int
+	}{
+		"without campaign_id": {params: buildContentFeedbackRequest(t, 0, "like"), statusCode: http.StatusBadRequest},
+		"invalid type":        {params: buildContentFeedbackRequest(t, camp.ID, "love"), statusCode: http.StatusBadRequest},
+		"unknown campaign":    {params: buildContentFeedbackRequest(t, 99999, "like"), statusCode: http.StatusNotFound},
+		"without session": {params: func() *url.Values {
+			params := buildContentFeedbackRequest(t, camp.ID, "like")
+			params.Del("session_key")
+			return params
+		}(), statusCode: http.StatusUnauthorized},
+	} {
+		var res map[string]interface{}
+		statusCode := requestContentAPI(t, http.MethodPost, "/api/v3/content/feedback", tc.params, &res)
+		assert.Equal(t, tc.statusCode, statusCode, name)
+	}
+}
+
+func buildContentFeedbackRequest(t *testing.T, campaignID int64, feedbackType string) *url.Values {
+	params, _ := buildV3BaseTestRequest(t)
+	if campaignID > 0 {
+		params.Set("campaign_id", strconv.FormatInt(campaignID, 10))
+	}
+	params.Set("type", feedbackType)
+	return params
+}
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference"
+)
+
+// GetContentPreference returns the content preference of the device
+func GetContentPreference(c core.Context) error {
+	var req dto.ContentPreferenceRequest
+	if ok, err := bindContentPreferenceRequest(c, &req, &ContentV2PreferenceRequest{}, &req.ContentBaseRequest); !ok {
+		return err
+	}
+
+	preference, err := buzzscreen.Service.ContentPreferenceUseCase.GetPreference(req.Session.DeviceID)
+	if err != nil {
+		core.Logger.WithError(err).Errorf("GetContentPreference() - failed to get preference of device %d", req.Session.DeviceID)
+		return common.NewInternalServerError(err)
+	}
+	return contentPreferenceResponse(c, preference)
+}
+
+// PutContentPreference replaces the preferred languages and the category weights of the device
+func PutContentPreference(c core.Context) error {
+	var req dto.ContentPreferenceRequest
+	if ok, err := bindContentPreferenceRequest(c, &req, &ContentV2PreferenceRequest{}, &req.ContentBaseRequest); !ok {
+		return err
+	}
+
+	categoryWeights := make(map[string]float64)
+	if req.CategoryWeights != "" {
+		if err := json.Unmarshal([]byte(req.CategoryWeights), &categoryWeights); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "invalid category_weights"})
+		}
+	}
+
+	// 빈 언어는 use case에서 제거되므로 languages를 보내지 않으면 선호 언어가 지워진다
+	languages := strings.Split(req.Languages, ",")
+	preference, err := buzzscreen.Service.ContentPreferenceUseCase.UpdateSettings(req.Session.DeviceID, languages, categoryWeights)
+	if err != nil {
+		return contentPreferenceError(c, "PutContentPreference", req.Session.DeviceID, err)
+	}
+	return contentPreferenceResponse(c, preference)
+}
+
+// PostContentChannelFollow follows the channel
+func PostContentChannelFollow(c core.Context) error {
+	return updateContentChannelPreference(c, "PostContentChannelFollow", buzzscreen.Service.ContentPreferenceUseCase.FollowChannel)
+}
+
+// DeleteContentChannelFollow unfollows the channel
+func DeleteContentChannelFollow(c core.Context) error {
+	return updateContentChannelPreference(c, "DeleteContentChannelFollow", buzzscreen.Service.ContentPreferenceUseCase.UnfollowChannel)
+}
+
+// PostContentChannelMute mutes the channel so that its articles aren't shown to the device
+func PostContentChannelMute(c core.Context) error {
+	return updateContentChannelPreference(c, "PostContentChannelMute", buzzscreen.Service.ContentPreferenceUseCase.MuteChannel)
+}
+
+// DeleteContentChannelMute unmutes the channel
+func DeleteContentChannelMute(c core.Context) error {
+	return updateContentChannelPreference(c, "DeleteContentChannelMute", buzzscreen.Service.ContentPreferenceUseCase.UnmuteChannel)
+}
+
+func updateContentChannelPreference(c core.Context, caller string, update func(deviceID int64, channelID int64) (*contentpreference.Preference, error)) error {
+	var req dto.ContentChannelPreferenceRequest
+	if ok, err := bindContentPreferenceRequest(c, &req, &ContentV2ChannelPreferenceRequest{}, &req.ContentBaseRequest); !ok {
+		return err
+	}
+
+	preference, err := update(req.Session.DeviceID, req.ChannelID)
+	if err != nil {
+		return contentPreferenceError(c, caller, req.Session.DeviceID, err)
+	}
+	return contentPreferenceResponse(c, preference)
+}
+
+// bindContentPreferenceRequest binds the request and unpacks the session of the base request.
+// false is returned with the error of the response if the request isn't valid.
+func bindContentPreferenceRequest(c core.Context, req interface{}, v2Req interface{}, baseReq *dto.ContentBaseRequest) (bool, error) {
+	if err := bindRequestSupport(c, req, v2Req); err != nil {
+		return false, err
+	}
+
+	if err := baseReq.UnpackSession(); err != nil {
+		return false, common.NewSessionError(err)
+	}
+
+	ok, err := buzzscreen.Service.DeviceUseCase.ValidateUnitDeviceToken(baseReq.Session.UserID)
+	if !ok {
+		return false, c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	}
+	return true, nil
+}
+
+func contentPreferenceError(c core.Context, caller string, deviceID int64, err error) error {
+	switch err.(type) {
+	case contentpreference.InvalidPreferenceError, contentpreference.TooManyChannelsError:
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+	default:
+		core.Logger.WithError(err).Errorf("%s() - failed to update preference of device %d", caller, deviceID)
+		return common.NewInternalServerError(err)
+	}
+}
+
+func contentPreferenceResponse(c core.Context, preference *contentpreference.Preference) error {
+	res := dto.ContentPreferenceResponse{
+		FollowedChannelIDs: preference.FollowedChannelIDs,
+		MutedChannelIDs:    preference.MutedChannelIDs,
+		Languages:          preference.Languages,
+		CategoryWeights:    preference.CategoryWeights,
+	}
+	if res.FollowedChannelIDs == nil {
+		res.FollowedChannelIDs = []int64{}
+	}
+	if res.MutedChannelIDs == nil {
+		res.MutedChannelIDs = []int64{}
+	}
+	if res.Languages == nil {
+		res.Languages = []string{}
+	}
+	if res.CategoryWeights == nil {
+		res.CategoryWeights = map[string]float64{}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"code":   dto.CodeOk,
+		"result": getResponseSupport(c, res),
+	})
+}
+
+type (
+	// ContentV2PreferenceRequest type definition
+	ContentV2PreferenceRequest struct {
+		ContentV2BaseRequest
+		Languages       string `form:"languages" query:"languages"`
+		CategoryWeights string `form:"categoryWeights" query:"categoryWeights"`
+	}
+	// ContentV2ChannelPreferenceRequest type definition
+	ContentV2ChannelPreferenceRequest struct {
+		ContentV2BaseRequest
+		ChannelID int64 `form:"channelId" query:"channelId" validate:"required"`
+	}
+)
+
+func joinInt64s(ints []int64) string {
+	strs := make([]string, 0, len(ints))
+	for _, i := range ints {
+		strs = append(strs, strconv.FormatInt(i, 10))
+	}
+	return strings.Join(strs, ",")
+}
@@ -0,0 +1,106 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// TestContentPreferenceResponse type definition
+	TestContentPreferenceResponse struct {
+		Result dto.ContentPreferenceResponse `json:"result"`
+		Code   int                           `json:"code"`
+	}
+)
+
+func TestContentPreference(t *testing.T) {
+	params, _ := buildV3BaseTestRequest(t)
+
+	var res TestContentPreferenceResponse
+	statusCode := requestContentAPI(t, http.MethodGet, "/api/v3/content/preferences", params, &res)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, dto.ContentPreferenceResponse{
+		FollowedChannelIDs: []int64{},
+		MutedChannelIDs:    []int64{},
+		Languages:          []string{},
+		CategoryWeights:    map[string]float64{},
+	}, res.Result)
+
+	params.Set("languages", "ko,en")
+	params.Set("category_weights", `{"sports":0.5}`)
+	res = TestContentPreferenceResponse{}
+	statusCode = requestContentAPI(t, http.MethodPut, "/api/v3/content/preferences", params, &res)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, []string{"ko", "en"}, res.Result.Languages)
+	assert.Equal(t, map[string]float64{"sports": 0.5}, res.Result.CategoryWeights)
+
+	params.Set("channel_id", "1")
+	res = TestContentPreferenceResponse{}
+	statusCode = requestContentAPI(t, http.MethodPost, "/api/v3/content/channels/follow", params, &res)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, []int64{1}, res.Result.FollowedChannelIDs)
+
+	res = TestContentPreferenceResponse{}
+	statusCode = requestContentAPI(t, http.MethodGet, "/api/v3/content/preferences", params, &res)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, []int64{1}, res.Result.FollowedChannelIDs)
+	assert.Equal(t, []string{"ko", "en"}, res.Result.Languages)
+
+	res = TestContentPreferenceResponse{}
+	statusCode = requestContentAPI(t, http.MethodDelete, "/api/v3/content/channels/follow", params, &res)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Empty(t, res.Result.FollowedChannelIDs)
+}
+
+func TestPutContentPreferenceInvalidRequest(t *testing.T) {
+	for name, tc := range map[string]struct {
+		categoryWeights string
+		session         bool
+		statusCode      int
+	}{
+		"malformed category_weights":    {categoryWeights: `{"sports":`, session: true, statusCode: http.StatusBadRequest},
+		"out of range category_weights": {categoryWeights: `{"sports":2}`, session: true, statusCode: http.StatusBadRequest},
+		"without session":               {categoryWeights: `{"sports":0.5}`, session: false, statusCode: http.StatusUnauthorized},
+	} {
+		params, _ := buildV3BaseTestRequest(t)
+		params.Set("category_weights", tc.categoryWeights)
+		if !tc.session {
+			params.Del("session_key")
+		}
+
+		var res map[string]interface{}
+		statusCode := requestContentAPI(t, http.MethodPut, "/api/v3/content/preferences", params, &res)
+		assert.Equal(t, tc.statusCode, statusCode, name)
+	}
+}
+
+func TestPostContentChannelFollowInvalidRequest(t *testing.T) {
+	for name, tc := range map[string]struct {
+		params     *url.Values
+		statusCode int
+	}{
+		"without channel_id": {params: buildContentChannelPreferenceRequest(t, 0), statusCode: http.StatusBadRequest},
+		"without session": {params: func() *url.Values {
+			params := buildContentChannelPreferenceRequest(t, 1)
+			params.Del("session_key")
+			return params
+		}(), statusCode: http.StatusUnauthorized},
+	} {
+		var res map[string]interface{}
+		statusCode := requestContentAPI(t, http.MethodPost, "/api/v3/content/channels/follow", tc.params, &res)
+		assert.Equal(t, tc.statusCode, statusCode, name)
+	}
+}
+
+func buildContentChannelPreferenceRequest(t *testing.T, channelID int64) *url.Values {
+	params, _ := buildV3BaseTestRequest(t)
+	if channelID > 0 {
+		params.Set("channel_id", strconv.FormatInt(channelID, 10))
+	}
+	return params
+}
@@ -7,10 +7,13 @@ import (
 	"strconv"
 
 	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
 )
 
 // PostContentScores func definition
+// Deprecated: scores without version are only cached and not used for allocation. Use /api/internal/content/scores/versions/:version/targets
 func PostContentScores(c core.Context) error {
 	authValues := c.Request().Header["Authorization"]
 	if len(authValues) < 1 || authValues[0] != os.Getenv("BASIC_AUTHORIZATION_VALUE") {
@@ -31,11 +34,23 @@ func PostContentScores(c core.Context) error {
 	core.Logger.Debugf("PostContentScores() contentReq: %+v", contentReq)
 
 	if len(contentReq.Scores) > 0 {
-		(&dto.ContentTarget{
-			Age:     int(age),
-			Country: c.QueryParam("country"),
-			Gender:  c.QueryParam("gender"),
-		}).SaveContentScores(&contentReq.Scores)
+		// version이 있으면 버전 관리되는 점수 저장소에 저장한다. 게시는 /api/internal/content/scores/versions/:version/publish
+		if version := c.QueryParam("version"); version != "" {
+			scores := make(map[int64]int, len(contentReq.Scores))
+			for campaignID, score := range contentReq.Scores {
+				scores[int64(campaignID)] = score
+			}
+			target := contentscore.Target{Country: c.QueryParam("country"), Gender: c.QueryParam("gender"), Age: int(age)}
+			if err := buzzscreen.Service.ContentScoreUseCase.SaveScores(version, target, scores); err != nil {
+				return err
+			}
+		} else {
+			(&dto.ContentTarget{
+				Age:     int(age),
+				Country: c.QueryParam("country"),
+				Gender:  c.QueryParam("gender"),
+			}).SaveContentScores(&contentReq.Scores)
+		}
 		return c.JSON(http.StatusOK, map[string]interface{}{
 			"code": dto.CodeOk,
 		})
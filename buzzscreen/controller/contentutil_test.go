@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net/url"
 	"strconv"
 	"testing"
 	"time"
 
+	"github.com/Buzzvil/buzzlib-go/network"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/env"
@@ -157,3 +159,16 @@ func insertContentCampaignsToESAndDB(t *testing.T, contentCampaigns ...*dto.ESCo
 func yearOfBirth(age int) string {
 	return fmt.Sprintf("%d", time.Now().Year()-age-1)
 }
+
+// requestContentAPI requests the content api of the path and returns the status code. The response is parsed into the target
+func requestContentAPI(t *testing.T, method string, path string, params *url.Values, target interface{}) int {
+	statusCode, err := (&network.Request{
+		Method: method,
+		Params: params,
+		URL:    ts.URL + path,
+	}).GetResponse(target)
+	if err != nil && statusCode == 0 {
+		t.Fatalf("requestContentAPI() - %s %s err: %s", method, path, err)
+	}
+	return statusCode
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/utils"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/impressiondata"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/trackingdata"
 )
 
@@ -94,17 +95,14 @@ func getCampaignsFromESContentCampaigns(ctx context.Context, esContentList []*dt
 			Type:            camp.Type,
 			Unit:            allocReq.GetUnit(ctx),
 			UnitDeviceToken: allocReq.UnitDeviceToken,
+			MaxURLLength:    shortlink.MaxURLLength,
 		}
 
-		if ma := allocReq.GetModelArtifact(ctx); ma != nil {
-			td := &trackingdata.TrackingData{
-				ModelArtifact: *ma,
-			}
-			clickReq.TrackingData = td
-		}
+		clickReq.TrackingData = buildV1TrackingData(ctx, esContent, allocReq)
 
 		camp.ClickURL = clickReq.BuildClickRedirectURL()
-		if len(camp.ClickURL) > 2048 {
+		// short link 생성에 실패한 경우에만 해당
+		if len(camp.ClickURL) > shortlink.MaxURLLength {
 			core.Logger.Infof("getCampaignsFromESContentCampaigns() - %v click_url is too long (%v)\n%v", camp.ID, len(camp.ClickURL), camp.ClickURL)
 			continue
 		}
@@ -114,6 +112,19 @@ func getCampaignsFromESContentCampaigns(ctx context.Context, esContentList []*dt
 	return campaigns
 }
 
+// buildV1TrackingData returns the tracking data with the model artifact of the request and the exploration tag of the campaign
+func buildV1TrackingData(ctx context.Context, esContent *dto.ESContentCampaign, allocReq *dto.ContentAllocV1Request) *trackingdata.TrackingData {
+	ma := allocReq.GetModelArtifact(ctx)
+	if ma == nil && esContent.Exploration == "" {
+		return nil
+	}
+	td := &trackingdata.TrackingData{Exploration: esContent.Exploration}
+	if ma != nil {
+		td.ModelArtifact = *ma
+	}
+	return td
+}
+
 //es_campaign_to_response 완료
 func parseToCampaign(ctx context.Context, esContent *dto.ESContentCampaign, allocReq *dto.ContentAllocV1Request) *dto.CampaignV1 {
 	defer recovery.LogRecoverWith(*esContent)
@@ -135,15 +146,11 @@ func parseToCampaign(ctx context.Context, esContent *dto.ESContentCampaign, allo
 			IFA:             allocReq.IFA,
 			UnitDeviceToken: allocReq.UnitDeviceToken,
 			UnitID:          allocReq.GetUnit(ctx).ID,
+			VariantID:       esContent.VariantID,
 		},
 	}
 
-	if ma := allocReq.GetModelArtifact(ctx); ma != nil {
-		td := &trackingdata.TrackingData{
-			ModelArtifact: *ma,
-		}
-		impReq.TrackingData = td
-	}
+	impReq.TrackingData = buildV1TrackingData(ctx, esContent, allocReq)
 
 	if allocReq.Gender != "" {
 		impReq.ImpressionData.Gender = &(allocReq.Gender)
@@ -203,6 +210,7 @@ func parseToCampaign(ctx context.Context, esContent *dto.ESContentCampaign, allo
 		Type:                 model.CampaignTypeCast,
 		UnitPrice:            0,
 		UnlockReward:         0,
+		VariantID:            esContent.VariantID,
 	}
 
 	if len(unitExtraData) > 0 {
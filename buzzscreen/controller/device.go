@@ -145,6 +145,7 @@ func (con DeviceController) PostDevice(c core.Context) error {
 			appVersion = &deviceReq.AppVersion
 		}
 		service.LogDevice(device, country, appVersion, deviceReq.IsInBatteryOpts, deviceReq.IsBackgroundRestricted, deviceReq.HasOverlayPermission)
+		service.LinkDeviceIdentifiers(device, deviceReq.IFV)
 	}
 
 	sessionKey := con.sessionUseCase.GetNewSessionKey(deviceReq.AppID, deviceReq.UserID, device.ID, deviceReq.AndroidID, device.CreatedAt.Unix())
@@ -13,6 +13,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/model"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/service"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/reward"
 )
 
@@ -66,6 +67,7 @@ func GetNotifications(c core.Context) error {
 		minVersionCodeFilter{VersionCode: &req.SdkVersion},
 		maxVersionCodeFilter{VersionCode: &req.SdkVersion},
 		eligibleTimeFilter{Time: time.Now()})
+	localizeSchedules(eligibleSchedules, localization.Negotiate(req.Locale, c.Request().Header.Get("Accept-Language")))
 	notifications, err := buildNotifications(c, req.AdsRequest, eligibleSchedules)
 
 	if err != nil {
@@ -124,6 +126,31 @@ func buildNotification(schedule *model.NotificationSchedule, payload map[string]
 	}
 }
 
+// localizeSchedules replaces the texts of the schedules by the translations in the languages. Untranslated texts are kept
+func localizeSchedules(schedules []model.NotificationSchedule, languages localization.Languages) {
+	localizationUseCase := buzzscreen.Service.LocalizationUseCase
+	if localizationUseCase == nil || len(schedules) == 0 {
+		return
+	}
+
+	scheduleIDs := make([]int64, 0, len(schedules))
+	for _, schedule := range schedules {
+		scheduleIDs = append(scheduleIDs, schedule.ID)
+	}
+	textsMap, err := localizationUseCase.Localize(localization.ResourceTypeNotification, scheduleIDs, languages)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("localizeSchedules() - failed to localize notification schedules %v", scheduleIDs)
+		return
+	}
+
+	for i := range schedules {
+		texts := textsMap[schedules[i].ID]
+		schedules[i].Title = texts.Get(localization.FieldTitle, schedules[i].Title)
+		schedules[i].Description = texts.Get(localization.FieldDescription, schedules[i].Description)
+		schedules[i].InboxSummary = texts.Get(localization.FieldInboxSummary, schedules[i].InboxSummary)
+	}
+}
+
 func fetchTotalReward(c core.Context, adsReq dto.AdsRequest) (int, error) {
 	ctx := c.Request().Context()
 	if adsReq.UserAgent == "" {
@@ -19,6 +19,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/payload"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/reward"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/session"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
 )
 
 // BuzzAdCampaignIDOffset const definition
@@ -249,9 +250,17 @@ func (adsReq *AdsRequest) buildClickURL(ctx context.Context, ad ad.AdV2, clickUR
 		UnitDeviceToken: adsReq.Session.UserID,
 	}
 
+	// https://buzzvil.atlassian.net/browse/PO-613 url length problems in kitkat (api level 19 - 20)
+	if clickReq.Unit != nil && clickReq.Unit.IsAndroid() {
+		if osVersion, _ := strconv.ParseInt(adsReq.OsVersion, 0, 64); osVersion <= 20 {
+			clickReq.MaxURLLength = shortlink.MaxURLLength
+		}
+	}
+
 	if len(trackingURLs) > 0 {
 		clickReq.UseRewardAPI = useRewardAPI
 		clickReq.TrackingURL = &(trackingURLs[0])
+		clickReq.FailTrackers = ad.FailTrackers
 	}
 
 	return clickReq.BuildClickRedirectURL()
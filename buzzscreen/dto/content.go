@@ -18,9 +18,13 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/common/cypher"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/impressiondata"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/payload"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/redirect"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/session"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/trackingdata"
 )
@@ -44,6 +48,9 @@ const (
 	TypeWeb    CreativeType = "WEB"
 )
 
+// ContentSortTrending sorts the articles by the trending scores of contenttrending instead of the model artifact
+const ContentSortTrending = "trending"
+
 type (
 	// ContentBaseRequest type definition
 	ContentBaseRequest struct {
@@ -66,6 +73,7 @@ type (
 		yob       *int // 0: unknown
 		country   string
 		language  string
+		languages localization.Languages
 		localTime *time.Time
 		Session   session.Session
 		unit      *app.Unit
@@ -95,6 +103,9 @@ type (
 		Score         *float64            `json:"score"`
 		ScoreFactors  map[string]float64  `json:"score_factors"`
 		ModelArtifact string              `json:"model_artifact"`
+		Highlights    map[string][]string `json:"-"` // fragments matching the keyword of the search
+		VariantID     int64               `json:"-"` // creative variant allocated to the campaign
+		Exploration   string              `json:"-"` // tag of the exploration policy. empty if the campaign is ranked by the model
 
 		CreativeTypes string `json:"creative_types"`
 
@@ -170,6 +181,9 @@ type (
 		Name          string                 `json:"name"`
 		SourceURL     string                 `json:"source_url"`
 		ScoringParams *[]float64             `json:"scoring_params,omitempty"`
+		Highlights    map[string][]string    `json:"highlights,omitempty"` // fragments matching q of the search
+		VariantID     int64                  `json:"-"`                    // creative variant allocated to the campaign
+		Exploration   string                 `json:"-"`                    // tag of the exploration policy. empty if the article is ranked by the model
 	}
 
 	// ContentImage type definition
@@ -232,6 +246,18 @@ func (contentReq *ContentBaseRequest) GetLanguage() string {
 	return contentReq.language
 }
 
+// GetLanguages returns the languages negotiated from the locale and the Accept-Language header in order of preference
+func (contentReq *ContentBaseRequest) GetLanguages() localization.Languages {
+	if contentReq.languages == nil {
+		acceptLanguage := ""
+		if contentReq.Request != nil {
+			acceptLanguage = contentReq.Request.Header.Get("Accept-Language")
+		}
+		contentReq.languages = localization.Negotiate(contentReq.Locale, acceptLanguage)
+	}
+	return contentReq.languages
+}
+
 // GetCountry func definition
 func (contentReq *ContentBaseRequest) GetCountry(ctx context.Context) string {
 	if contentReq.country != "" {
@@ -319,6 +345,25 @@ func (cc *ESContentCampaign) GetCDNImageURL(creativeType string, sdkVersion int)
 	return imageURL
 }
 
+// ApplyVariant overrides the creative of the campaign by the non empty fields of the variant
+func (cc *ESContentCampaign) ApplyVariant(variant contentvariant.Variant) {
+	cc.VariantID = variant.ID
+	if variant.Title != "" {
+		cc.Title = variant.Title
+	}
+	if variant.Description != "" {
+		cc.Description = variant.Description
+	}
+	if variant.ImageURL != "" {
+		cc.Image = variant.ImageURL
+		creativeLinks := make(map[string][]string, len(cc.CreativeLinks))
+		for creativeType := range cc.CreativeLinks {
+			creativeLinks[creativeType] = []string{variant.ImageURL}
+		}
+		cc.CreativeLinks = creativeLinks
+	}
+}
+
 // GetDocToCreate func definition
 func (cc ESContentCampaign) GetDocToCreate() ESContentCampaign {
 	valueRef := reflect.ValueOf(&cc)
@@ -392,17 +437,21 @@ type (
 		FilterChannelIDs  string `form:"filter_channel_ids" query:"filter_channel_ids"`
 		Gender            string `form:"gender" query:"gender"`
 		IsDebugging       bool   `form:"is_debugging" query:"is_debugging"`
+		Keyword           string `form:"q" query:"q"` // keyword of /content/search
 		LandingTypes      string `form:"landing_types" query:"landing_types"`
 		Package           string `form:"package" query:"package"`
 		//Deprecated
 		PlaceType   PlaceType `form:"place_type" query:"place_type"`
 		Size        int       `form:"size" query:"size"`
+		Sort        string    `form:"sort" query:"sort" validate:"omitempty,oneof=trending"`
 		TypesString string    `form:"types" query:"types" validate:"required"` //eg. {"IMAGE":["INTERSTITIAL"]} or {"NATIVE":[]}
 
 		queryKey       *ContentQueryKey
+		queryTime      int64
 		types          CreativeTypes
 		deviceProfile  *device.Profile
 		deviceActivity *device.Activity
+		preference     *contentpreference.Preference
 		target         *ContentTarget
 		campaignScores *map[int]int
 
@@ -419,14 +468,24 @@ type (
 	ContentArticlesResponse struct {
 		ContentArticles ContentArticles `json:"articles"`
 		QueryKey        *string         `json:"query_key,omitempty"`
+		Degraded        bool            `json:"degraded,omitempty"` // articles are served from the content snapshot since ElasticSearch failed
 	}
 
 	// ContentQueryKey type definition
+	// SearchAfter가 없는 key는 이전 버전의 key로 Index부터 조회한다
 	ContentQueryKey struct {
-		CreatedAt          int64   `json:"c"`
-		Index              int     `json:"i"`
-		ScoredCampaignsKey *int64  `json:"sk,omitempty"`
-		CampaignIDs        []int64 `json:"cid"`
+		CreatedAt          int64               `json:"c"`
+		Index              int                 `json:"i"`
+		ScoredCampaignsKey *int64              `json:"sk,omitempty"`
+		CampaignIDs        []int64             `json:"cid,omitempty"`
+		SearchAfter        *ContentSearchAfter `json:"sa,omitempty"`
+		ExploredIDs        []int64             `json:"eid,omitempty"` // campaigns explored on the first page, excluded from the next pages
+	}
+
+	// ContentSearchAfter is the sort values of the last campaign of the previous page
+	ContentSearchAfter struct {
+		Score float64 `json:"s"`
+		ID    int64   `json:"id"`
 	}
 )
 
@@ -508,6 +567,18 @@ func (car *ContentArticlesRequest) GetTarget(ctx context.Context) *ContentTarget
 	return car.target
 }
 
+// GetQueryTime returns the unix time the first page of the query is requested at
+func (car *ContentArticlesRequest) GetQueryTime() int64 {
+	if car.queryTime == 0 {
+		if queryKey, err := car.GetQueryKey(); err == nil && queryKey != nil {
+			car.queryTime = queryKey.CreatedAt
+		} else {
+			car.queryTime = time.Now().Unix()
+		}
+	}
+	return car.queryTime
+}
+
 // GetQueryKey func definition
 func (car *ContentArticlesRequest) GetQueryKey() (*ContentQueryKey, error) {
 	if car.queryKey == nil && car.EncryptedQueryKey != "" {
@@ -546,6 +617,18 @@ func (car *ContentArticlesRequest) GetDynamoActivity() *device.Activity {
 	return car.deviceActivity
 }
 
+// GetContentPreference returns the content preference of the device. nil is returned if it fails to get
+func (car *ContentArticlesRequest) GetContentPreference() *contentpreference.Preference {
+	if car.preference == nil && buzzscreen.Service.ContentPreferenceUseCase != nil {
+		var err error
+		car.preference, err = buzzscreen.Service.ContentPreferenceUseCase.GetPreference(car.Session.DeviceID)
+		if err != nil {
+			core.Logger.WithError(err).Warnf("ContentArticlesRequest - Device %d GetPreference error", car.Session.DeviceID)
+		}
+	}
+	return car.preference
+}
+
 // GetTypes func definition
 func (car *ContentArticlesRequest) GetTypes() *CreativeTypes {
 	if car.types == nil && car.TypesString != "" {
@@ -574,11 +657,13 @@ func (article *ContentArticle) setImpressionURL(ctx context.Context, contentReq
 			UnitDeviceToken: contentReq.Session.UserID,
 			Country:         contentReq.GetCountry(ctx),
 			YearOfBirth:     contentReq.GetYearOfBirth(),
+			VariantID:       article.VariantID,
 		},
 	}
 	if contentReq.Gender != "" {
 		impReq.ImpressionData.Gender = &(contentReq.Gender)
 	}
+	impReq.TrackingData = article.buildTrackingData(ctx, contentReq)
 
 	article.ImpressionTrackers = []string{
 		impReq.BuildImpressionURL(),
@@ -598,11 +683,23 @@ func (article *ContentArticle) setClickURL(ctx context.Context, contentReq *Cont
 		Type:            model.CampaignTypeCast,
 		Unit:            contentReq.GetUnit(ctx),
 		UnitDeviceToken: contentReq.Session.UserID,
+		TrackingData:    article.buildTrackingData(ctx, contentReq),
 	}
 
 	article.Creative["click_url"] = clickReq.BuildClickRedirectURL()
 }
 
+// buildTrackingData returns the tracking data of the explored article. nil is returned if the article is ranked by the model
+func (article *ContentArticle) buildTrackingData(ctx context.Context, contentReq *ContentArticlesRequest) *trackingdata.TrackingData {
+	if article.Exploration == "" {
+		return nil
+	}
+	return &trackingdata.TrackingData{
+		ModelArtifact: *contentReq.GetModelArtifact(ctx),
+		Exploration:   article.Exploration,
+	}
+}
+
 func (article *ContentArticle) setPayload(ctx context.Context, contentReq *ContentArticlesRequest, content *model.ContentCampaign) {
 	p := &payload.Payload{
 		Country:     contentReq.GetCountry(ctx),
@@ -611,6 +708,7 @@ func (article *ContentArticle) setPayload(ctx context.Context, contentReq *Conte
 		Time:        time.Now().Unix(),
 		Timezone:    content.Timezone,
 		YearOfBirth: contentReq.GetYearOfBirth(),
+		VariantID:   article.VariantID,
 	}
 
 	if contentReq.Gender != "" {
@@ -629,6 +727,7 @@ type (
 		ContentBaseRequest
 		CategoryID string `form:"category_id" query:"category_id"`
 		IDs        string `form:"ids" query:"ids"`
+		Followed   bool   `form:"followed" query:"followed"` // channels the device follows
 	}
 
 	// ContentChannelsResponse type definition
@@ -700,6 +799,15 @@ type ClickRequest struct {
 
 	TrackingURL  *string
 	UseRewardAPI bool
+	// FailTrackers are fired by the server when TrackingURL can't be delivered
+	FailTrackers []string
+
+	DeepLink      string
+	UniversalLink string
+	TargetApp     string
+
+	// MaxURLLength 보다 긴 click url은 short link로 변환한다. 0이면 변환하지 않음
+	MaxURLLength int
 }
 
 // BuildClickRedirectURL func definition
@@ -708,6 +816,20 @@ func (clickReq *ClickRequest) BuildClickRedirectURL() string {
 		return ""
 	}
 
+	redirectTarget := redirect.Target{
+		CampaignID:    clickReq.ID,
+		UnitID:        clickReq.Unit.ID,
+		URL:           strings.Replace(clickReq.ClickURL, "{ifa}", clickReq.IFA, -1),
+		CleanURL:      clickReq.ClickURLClean,
+		DeepLink:      clickReq.DeepLink,
+		UniversalLink: clickReq.UniversalLink,
+		TargetApp:     clickReq.TargetApp,
+	}
+	if clickReq.TrackingURL != nil {
+		redirectTarget.FailTrackers = clickReq.FailTrackers
+	}
+	redirectSignature := buzzscreen.Service.RedirectUseCase.SignTarget(redirectTarget)
+
 	parameters := url.Values{
 		"app_id":                   {strconv.FormatInt(clickReq.Unit.AppID, 10)},
 		"base_reward":              {"__base_reward__"},
@@ -723,8 +845,9 @@ func (clickReq *ClickRequest) BuildClickRedirectURL() string {
 		"unit_id":                  {strconv.FormatInt(clickReq.Unit.ID, 10)},
 		"place":                    {"__place__"},
 		"position":                 {"__position__"},
-		"redirect_url":             {strings.Replace(clickReq.ClickURL, "{ifa}", clickReq.IFA, -1)},
-		"redirect_url_clean":       {clickReq.ClickURLClean},
+		"redirect_url":             {redirectTarget.URL},
+		"redirect_url_clean":       {redirectTarget.CleanURL},
+		"redirect_signature":       {redirectSignature},
 		"reward":                   {"__reward__"},
 		"session_id":               {"__session_id__"},
 		"slot":                     {"__slot__"},
@@ -734,6 +857,12 @@ func (clickReq *ClickRequest) BuildClickRedirectURL() string {
 	if clickReq.TrackingURL != nil {
 		parameters.Add("tracking_url", *clickReq.TrackingURL)
 		parameters.Add("use_reward_api", strconv.FormatBool(clickReq.UseRewardAPI))
+		// 서명되지 않은 fail tracker는 click redirect에서 거절되므로 서명이 있을 때만 전달한다
+		if redirectSignature != "" {
+			for _, failTracker := range redirectTarget.FailTrackers {
+				parameters.Add("fail_tracker", failTracker)
+			}
+		}
 	}
 
 	if clickReq.TrackingData != nil {
@@ -741,6 +870,12 @@ func (clickReq *ClickRequest) BuildClickRedirectURL() string {
 		parameters.Add("tracking_data", trackingDataUseCase.BuildTrackingDataString(clickReq.TrackingData))
 	}
 
+	if redirectTarget.DeepLink != "" || redirectTarget.UniversalLink != "" {
+		parameters.Add("deep_link", redirectTarget.DeepLink)
+		parameters.Add("universal_link", redirectTarget.UniversalLink)
+		parameters.Add("target_app", redirectTarget.TargetApp)
+	}
+
 	if clickReq.OwnerID != 0 {
 		parameters.Add("campaign_owner_id", strconv.FormatInt(clickReq.OwnerID, 10))
 	} else {
@@ -754,7 +889,11 @@ func (clickReq *ClickRequest) BuildClickRedirectURL() string {
 	}
 
 	queryString := parameters.Encode()
-	return fmt.Sprint(buzzscreen.Service.BuzzScreenAPIURL, "/api/click_redirect/?", queryString)
+	clickRedirectURL := fmt.Sprint(buzzscreen.Service.BuzzScreenAPIURL, "/api/click_redirect/?", queryString)
+	if clickReq.MaxURLLength > 0 {
+		return buzzscreen.Service.ShortLinkUseCase.ShortenIfLonger(clickRedirectURL, clickReq.MaxURLLength)
+	}
+	return clickRedirectURL
 }
 
 // SetUseRewardAPI set UseRewardAPI field
@@ -14,6 +14,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/model"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/common/cypher"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
 	"github.com/Buzzvil/buzzscreen-api/tests"
 	"github.com/Buzzvil/go-test/test"
 	uuid "github.com/satori/go.uuid"
@@ -232,3 +233,18 @@ func TestContentCreativeImage(t *testing.T) {
 	test.AssertEqual(t, eccImageNameWithoutSize.GetCDNImageURL("R", 3899), RTypeImage, "TestContentCreativeImage")
 
 }
+
+func TestContentApplyVariant(t *testing.T) {
+	ecc := &dto.ESContentCampaign{
+		ContentCampaign: model.ContentCampaign{Title: "title", Description: "description"},
+		CreativeLinks:   map[string][]string{"A": {"http://abc-A.jpg"}, "R": {"http://abc-R.jpg"}},
+	}
+
+	ecc.ApplyVariant(contentvariant.Variant{ID: 10, Title: "variant title", ImageURL: "http://variant.jpg"})
+
+	test.AssertEqual(t, ecc.VariantID, int64(10), "TestContentApplyVariant")
+	test.AssertEqual(t, ecc.Title, "variant title", "TestContentApplyVariant")
+	test.AssertEqual(t, ecc.Description, "description", "TestContentApplyVariant")
+	test.AssertEqual(t, ecc.GetCDNImageURL("A", 3900), "http://variant.jpg", "TestContentApplyVariant")
+	test.AssertEqual(t, ecc.GetCDNImageURL("R", 3900), "http://variant.jpg", "TestContentApplyVariant")
+}
@@ -0,0 +1,21 @@
+package dto
+
+type (
+	// ContentBookmarkRequest type definition
+	ContentBookmarkRequest struct {
+		ContentBaseRequest
+		CampaignID int64 `form:"campaign_id" query:"campaign_id" validate:"required"`
+	}
+
+	// ContentBookmarksRequest type definition
+	ContentBookmarksRequest struct {
+		ContentArticlesRequest
+		Cursor int64 `form:"cursor" query:"cursor"`
+	}
+
+	// ContentBookmarksResponse type definition
+	ContentBookmarksResponse struct {
+		ContentArticles ContentArticles `json:"articles"`
+		NextCursor      *int64          `json:"next_cursor,omitempty"`
+	}
+)
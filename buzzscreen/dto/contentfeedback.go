@@ -0,0 +1,10 @@
+package dto
+
+type (
+	// ContentFeedbackRequest type definition
+	ContentFeedbackRequest struct {
+		ContentBaseRequest
+		CampaignID int64  `form:"campaign_id" query:"campaign_id" validate:"required"`
+		Type       string `form:"type" query:"type" validate:"required,oneof=like dislike hide not_interested_in_channel"`
+	}
+)
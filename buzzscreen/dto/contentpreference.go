@@ -0,0 +1,24 @@
+package dto
+
+type (
+	// ContentPreferenceRequest type definition
+	ContentPreferenceRequest struct {
+		ContentBaseRequest
+		Languages       string `form:"languages" query:"languages"`               // comma separated e.g. ko,en
+		CategoryWeights string `form:"category_weights" query:"category_weights"` // JSON of weights in [0, 1] e.g. {"sports":0.5,"politics":0}
+	}
+
+	// ContentChannelPreferenceRequest type definition
+	ContentChannelPreferenceRequest struct {
+		ContentBaseRequest
+		ChannelID int64 `form:"channel_id" query:"channel_id" validate:"required"`
+	}
+
+	// ContentPreferenceResponse type definition
+	ContentPreferenceResponse struct {
+		FollowedChannelIDs []int64            `json:"followed_channel_ids"`
+		MutedChannelIDs    []int64            `json:"muted_channel_ids"`
+		Languages          []string           `json:"languages"`
+		CategoryWeights    map[string]float64 `json:"category_weights"`
+	}
+)
@@ -10,6 +10,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/model"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/utils"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
 )
 
@@ -76,6 +77,7 @@ type (
 
 		dynamoProfile  *device.Profile
 		dynamoActivity *device.Activity
+		preference     *contentpreference.Preference
 	}
 
 	// ContentAllocV1Response type definition
@@ -246,6 +248,18 @@ func (car *ContentAllocV1Request) GetDynamoProfile() *device.Profile {
 	return car.dynamoProfile
 }
 
+// GetContentPreference returns the content preference of the device. nil is returned if it fails to get
+func (car *ContentAllocV1Request) GetContentPreference() *contentpreference.Preference {
+	if car.preference == nil && buzzscreen.Service.ContentPreferenceUseCase != nil {
+		var err error
+		car.preference, err = buzzscreen.Service.ContentPreferenceUseCase.GetPreference(car.DeviceID)
+		if err != nil {
+			core.Logger.WithError(err).Warnf("ContentAllocV1Request - Device %d GetPreference error", car.DeviceID)
+		}
+	}
+	return car.preference
+}
+
 // GetDynamoActivity func definition
 func (car *ContentAllocV1Request) GetDynamoActivity() *device.Activity {
 	if car.dynamoActivity == nil {
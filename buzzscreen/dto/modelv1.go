@@ -6,6 +6,7 @@ import (
 
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/ad"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/landing"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/payload"
 )
 
@@ -35,6 +36,7 @@ type (
 		IsAd                  bool                     `json:"is_ad"`
 		IsMedia               bool                     `json:"is_media"`
 		LandingReward         int                      `json:"landing_reward"`
+		Landing               *landing.Landing         `json:"landing,omitempty"`
 		LandingType           string                   `json:"landing_type"`
 		Meta                  map[string]interface{}   `json:"meta"`
 		Name                  string                   `json:"name"`
@@ -59,6 +61,7 @@ type (
 		Creative              map[string]interface{}   `json:"creative"`
 		AdReportData          string                   `json:"ad_report_data"`
 		Creatives             []map[string]interface{} `json:"creatives,omitempty"`
+		VariantID             int64                    `json:"-"` // creative variant allocated to the content campaign
 	}
 
 	// NativeCampaignV1 type definition
@@ -101,11 +104,12 @@ func (s ContentByCtr) Less(x, y int) bool {
 // SetPayloadWith func definition
 func (camp *CampaignV1) SetPayloadWith(ctx context.Context, allocReq *ContentAllocV1Request) {
 	p := &payload.Payload{
-		Country:  allocReq.GetCountry(ctx),
-		EndedAt:  camp.EndedAt,
-		OrgID:    camp.OrganizationID,
-		Time:     time.Now().Unix(),
-		Timezone: camp.Timezone,
+		Country:   allocReq.GetCountry(ctx),
+		EndedAt:   camp.EndedAt,
+		OrgID:     camp.OrganizationID,
+		Time:      time.Now().Unix(),
+		Timezone:  camp.Timezone,
+		VariantID: camp.VariantID,
 	}
 
 	if allocReq.Gender != "" {
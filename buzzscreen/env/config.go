@@ -37,6 +37,8 @@ type (
 		DynamoHost          string
 
 		Loggers map[string]*Logger
+
+		ContentExploration *ContentExplorationConfig
 	}
 
 	// ElasticsearchConfig type definition
@@ -61,6 +63,15 @@ type (
 		DB       int
 	}
 
+	// ContentExplorationConfig overrides the default exploration policy of content allocation. Zero values keep the default
+	ContentExplorationConfig struct {
+		Fraction       *float64 // 0 disables the exploration
+		MaxAgeHours    int
+		MaxImpressions int
+		Strategy       string
+		Epsilon        *float64
+	}
+
 	// Logger type definition
 	Logger struct {
 		File      string
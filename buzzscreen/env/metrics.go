@@ -14,6 +14,10 @@ type Metrics struct {
 	AllocationRequests *prometheus.CounterVec
 	// AllocatedContent is histogram metric representing number of content served per allocation request(sampled).
 	AllocatedContent *prometheus.HistogramVec
+	// ContentFallbacks is counter metric representing number of content requests served by the snapshot when ElasticSearch fails.
+	ContentFallbacks *prometheus.CounterVec
+	// TrackerDeliveries is counter metric representing number of tracker deliveries by result.
+	TrackerDeliveries *prometheus.CounterVec
 }
 
 type numContentCollector struct {
@@ -65,10 +69,28 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"country"},
 		),
+		ContentFallbacks: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "bs",
+				Name:      "content_fallbacks",
+				Help:      "Number of content requests falling back to the snapshot by result.",
+			},
+			[]string{"country", "result"},
+		),
+		TrackerDeliveries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "bs",
+				Name:      "tracker_deliveries",
+				Help:      "Number of tracker deliveries by result.",
+			},
+			[]string{"result"},
+		),
 	}
 	prometheus.MustRegister(m.numContentCollector)
 	prometheus.MustRegister(m.AllocationRequests)
 	prometheus.MustRegister(m.AllocatedContent)
+	prometheus.MustRegister(m.ContentFallbacks)
+	prometheus.MustRegister(m.TrackerDeliveries)
 	return m
 }
 
@@ -44,6 +44,22 @@ func getRedisInstance() *singletonRedis {
 	return instanceRedis
 }
 
+var instanceStatRedis *singletonRedis
+var onceStatRedis sync.Once
+
+// GetStatRedis returns the client of the redis keeping the hourly stats and trending lists of content campaigns
+func GetStatRedis() *redis.Client {
+	onceStatRedis.Do(func() {
+		instanceStatRedis = &singletonRedis{}
+		instanceStatRedis.Client = redis.NewClient(&redis.Options{
+			Addr:     Config.StatRedis.Endpoint,
+			Password: "", // no password set
+			DB:       Config.StatRedis.DB,
+		})
+	})
+	return instanceStatRedis.Client
+}
+
 // SetRedisDeviceDau func definition
 func SetRedisDeviceDau(deviceID int64) {
 	GetRedis().SetBit(fmt.Sprintf("stat:device:dau:%s", datetime.GetDate("2006-01-02", "Asia/Tokyo")), deviceID, 1)
@@ -61,10 +61,21 @@ func registerContentRoutes(router *core.RouterGroup) {
 	{
 		contentRouter.GET("/categories", controller.GetContentCategories)
 		contentRouter.GET("/articles", controller.GetContentArticles)
+		contentRouter.GET("/search", controller.SearchContentArticles)
 		contentRouter.GET("/channels", controller.GetContentChannels)
+		contentRouter.POST("/channels/follow", controller.PostContentChannelFollow)
+		contentRouter.DELETE("/channels/follow", controller.DeleteContentChannelFollow)
+		contentRouter.POST("/channels/mute", controller.PostContentChannelMute)
+		contentRouter.DELETE("/channels/mute", controller.DeleteContentChannelMute)
 		contentRouter.GET("/config/:method", controller.GetDeviceConfig)
 		contentRouter.PUT("/config/:method", controller.PutDeviceConfig)
 		contentRouter.POST("/scores", controller.PostContentScores)
+		contentRouter.POST("/feedback", controller.PostContentFeedback)
+		contentRouter.GET("/bookmarks", controller.GetContentBookmarks)
+		contentRouter.POST("/bookmarks", controller.PostContentBookmark)
+		contentRouter.DELETE("/bookmarks", controller.DeleteContentBookmark)
+		contentRouter.GET("/preferences", controller.GetContentPreference)
+		contentRouter.PUT("/preferences", controller.PutContentPreference)
 	}
 }
 
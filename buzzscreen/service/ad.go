@@ -18,6 +18,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/location"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/reward"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
 )
 
 //AdBuilder should be passed when getting AdV2s from BuzzAd
@@ -364,7 +365,8 @@ func shouldIgnoreAdWithClickURLLength(ad dto.Ad, unit app.Unit, osVersionString
 				return false
 			}
 
-			if len(clickURL.(string)) > 2048 {
+			// click url은 short link로 변환되므로 short link 생성에 실패한 경우에만 해당
+			if len(clickURL.(string)) > shortlink.MaxURLLength {
 				return true
 			}
 		}
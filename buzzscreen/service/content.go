@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
-	"reflect"
 	"strings"
 	"time"
 
@@ -17,15 +16,67 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/env"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/model"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/utils"
-	"gopkg.in/olivere/elastic.v5"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentexploration"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentsnapshot"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
 )
 
 func getCacheKeyCategories(lang string) string {
 	return fmt.Sprintf("CACHE_GO_GET_CATEGORIES_%v", lang)
 }
 
-// GetCategories func definition
-func GetCategories(lang string) (*model.ContentCategories, error) {
+// GetCategories returns the categories in the first of the languages. Names missing in a language are filled by the next ones of the fallback chain. e.g. zh-TW → zh → en
+func GetCategories(languages localization.Languages) (*model.ContentCategories, error) {
+	categories := model.ContentCategories{}
+	var err error
+	for _, lang := range legacyLanguages(languages.WithFallback()) {
+		var translated *model.ContentCategories
+		if translated, err = getCategoriesIn(lang); err != nil {
+			core.Logger.WithError(err).Warnf("GetCategories() - failed to get categories in %v", lang)
+			continue
+		}
+
+		if len(categories) == 0 {
+			categories = *translated
+		} else {
+			fillUntranslatedCategories(categories, *translated)
+		}
+		if len(categories) > 0 && !hasUntranslatedCategory(categories) {
+			break
+		}
+	}
+
+	if len(categories) > 0 {
+		err = nil
+	}
+	return &categories, err
+}
+
+func fillUntranslatedCategories(categories model.ContentCategories, translated model.ContentCategories) {
+	translatedMap := make(map[string]*model.ContentCategory, len(translated))
+	for _, category := range translated {
+		translatedMap[category.ID] = category
+	}
+	for _, category := range categories {
+		if t, ok := translatedMap[category.ID]; ok && category.Name == "" {
+			category.Name = t.Name
+			category.Translation = t.Translation
+		}
+	}
+}
+
+func hasUntranslatedCategory(categories model.ContentCategories) bool {
+	for _, category := range categories {
+		if category.Name == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func getCategoriesIn(lang string) (*model.ContentCategories, error) {
 	var categories model.ContentCategories
 
 	cacheKey := getCacheKeyCategories(lang)
@@ -61,9 +112,9 @@ func GetCategories(lang string) (*model.ContentCategories, error) {
 }
 
 // GetCategoriesMap func definition
-func GetCategoriesMap(lang string) (map[string]*model.ContentCategory, error) {
+func GetCategoriesMap(languages localization.Languages) (map[string]*model.ContentCategory, error) {
 	categoriesMap := make(map[string]*model.ContentCategory)
-	categories, err := GetCategories(lang)
+	categories, err := GetCategories(languages)
 	if err == nil {
 		for _, category := range *categories {
 			categoriesMap[category.ID] = category
@@ -92,6 +143,45 @@ func GetChannel(channelID int64) *model.ContentChannel {
 	return &channel
 }
 
+// LocalizeChannels replaces the names of the channels by the translations in the languages. Channels without translation keep their names
+func LocalizeChannels(channels []*model.ContentChannel, languages localization.Languages) {
+	localizationUseCase := buzzscreen.Service.LocalizationUseCase
+	if localizationUseCase == nil || len(channels) == 0 {
+		return
+	}
+
+	channelIDs := make([]int64, 0, len(channels))
+	for _, channel := range channels {
+		if !int64In(channel.ID, channelIDs) {
+			channelIDs = append(channelIDs, channel.ID)
+		}
+	}
+
+	textsMap, err := localizationUseCase.Localize(localization.ResourceTypeChannel, channelIDs, languages)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("LocalizeChannels() - failed to localize channels %v", channelIDs)
+		return
+	}
+	for _, channel := range channels {
+		channel.Name = textsMap[channel.ID].Get(localization.FieldName, channel.Name)
+	}
+}
+
+// legacyLanguages returns the languages without region in the format of buzzcon and the language targeting of campaigns. e.g. [zh-Hant-TW zh-Hant zh] → [zh_Hant zh]
+func legacyLanguages(languages localization.Languages) []string {
+	var legacy []string
+	for _, language := range languages {
+		subtags := strings.Split(language, "-")
+		if len(subtags) > 1 && len(subtags[len(subtags)-1]) != 4 {
+			continue // region
+		}
+		if lang := strings.Join(subtags, "_"); !stringIn(lang, legacy) {
+			legacy = append(legacy, lang)
+		}
+	}
+	return legacy
+}
+
 // GetContentCampaignsFromDB func definition
 func GetContentCampaignsFromDB(strIDs []string) (camps []*model.ContentCampaign) {
 	articleIDs, err := utils.SliceAtoi(strIDs)
@@ -131,33 +221,34 @@ func GetChannelMap(camps []*model.ContentCampaign) map[int64]*model.ContentChann
 
 // GetContentCampaignByIDs func definition
 func GetContentCampaignByIDs(deviceID int64, campIDs ...int64) ([]*dto.ESContentCampaign, error) {
-	ss := buzzscreen.Service.ES.Search().Index(env.Config.ElasticSearch.CampaignIndexName).Type("content_campaign").TimeoutInMillis(1000).Preference("_local")
-	idInterfaces := make([]interface{}, 0)
-	for _, campID := range campIDs {
-		idInterfaces = append(idInterfaces, campID)
+	searchResult, err := buzzscreen.Service.ContentCampaignUseCase.SearchByIDs(campIDs...)
+	if err != nil {
+		return nil, err
 	}
-	ss = ss.Query(elastic.NewBoolQuery().Filter(elastic.NewBoolQuery().Must(elastic.NewTermsQuery("id", idInterfaces...))))
 
-	if env.IsLocal() {
-		ss.Pretty(true)
-	}
-	sr, err := ss.Do(context.Background())
+	return parseSearchHitsToContentCampaigns(searchResult.Hits), nil
+}
+
+// GetContentCampaignWithRelevance returns the indexed campaign with the relevance of it to watson categories and entities.
+// nil is returned if the campaign isn't indexed
+func GetContentCampaignWithRelevance(campaignID int64) (*dto.ESContentCampaign, *device.ContentRelevance, error) {
+	searchResult, err := buzzscreen.Service.ContentCampaignUseCase.SearchByIDs(campaignID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	contentCampaigns := make([]*dto.ESContentCampaign, 0)
-	if err != nil || sr.Hits == nil {
-		errLog := core.Logger.WithError(err)
-		if sr != nil && sr.Error != nil {
-			errLog.Errorf("getContentCampaignsFromES() - error: %v", sr.Error.CausedBy)
-		} else {
-			errLog.Errorf("getContentCampaignsFromES() - searchResult: %v", sr)
+
+	for _, hit := range searchResult.Hits {
+		candidate, err := contentcampaign.NewCandidate(hit, contentcampaign.SearchRanking{})
+		if err != nil {
+			return nil, nil, err
 		}
-	} else {
-		contentCampaigns = *parseESHitsToContentCampaigns(deviceID, sr)
+		campaigns := parseSearchHitsToContentCampaigns([]contentcampaign.SearchHit{hit})
+		if len(campaigns) == 0 {
+			continue
+		}
+		return campaigns[0], &device.ContentRelevance{CategoryScores: candidate.CategoryScores, EntityScores: candidate.EntityScores}, nil
 	}
-
-	return contentCampaigns, nil
+	return nil, nil, nil
 }
 
 func logDebugScore(did int64, ts string, ccs []*dto.ESContentCampaign) {
@@ -181,12 +272,14 @@ func GetV1ContentCampaignsFromES(ctx context.Context, allocReq *dto.ContentAlloc
 	}
 
 	fetcher := (&V1ContentFetcher{}).buildReqWith(ctx, allocReq)
-	searchResult, err := fetcher.fetch()
+	searchResult, err := fetcher.fetch(ctx)
 
 	var contentCampaigns []*dto.ESContentCampaign
 
-	if err == nil && searchResult.Hits != nil {
-		contentCampaigns = *parseESHitsToContentCampaigns(allocReq.DeviceID, searchResult)
+	if err == nil {
+		contentCampaigns = parseSearchHitsToContentCampaigns(searchResult.Hits)
+		contentCampaigns = exploreColdStartCampaigns(contentCampaigns, fetcher.searchReq, searchResult.Hits, fetcher.explorationSlots)
+		applyContentVariants(contentCampaigns)
 	}
 
 	// If device is required for intermediate logging
@@ -206,41 +299,23 @@ func parseESSourceToContentCampaign(source []byte) (*dto.ESContentCampaign, erro
 	return &esContent, err
 }
 
-func parseESHitsToContentCampaigns(deviceID int64, searchResult *elastic.SearchResult) *[]*dto.ESContentCampaign {
-	contentCampaigns := make([]*dto.ESContentCampaign, 0)
-	ccIDs := make([]int64, 0)
-	if searchResult.Hits.TotalHits > 0 {
-		for _, hit := range searchResult.Hits.Hits {
-			if esContent, err := parseESSourceToContentCampaign(*hit.Source); err != nil {
-				core.Logger.WithError(err).Errorf("parseESHitsToContentCampaigns() - json parse error. _id: %v", hit.Id)
-			} else {
-				// Add score factors & model artifact
-				var esScoreFactor = make(map[string]float64)
-
-				for k, v := range hit.Fields {
-					varray := reflect.ValueOf(v)
-					if k == "modelArtifact" {
-						modelArtifact := varray.Index(0).Interface().(string)
-						esContent.ModelArtifact = modelArtifact
-					} else {
-						weight := varray.Index(0).Interface().(float64)
-						esScoreFactor[k] = weight
-					}
-				}
-				esContent.ScoreFactors = esScoreFactor
-
-				// Add final score
-				if len(hit.Sort) > 0 {
-					score := (hit.Sort)[0].(float64)
-					esContent.Score = &score
-				}
-				ccIDs = append(ccIDs, esContent.ID)
-				contentCampaigns = append(contentCampaigns, esContent)
-
-			}
+func parseSearchHitsToContentCampaigns(hits []contentcampaign.SearchHit) []*dto.ESContentCampaign {
+	contentCampaigns := make([]*dto.ESContentCampaign, 0, len(hits))
+	for _, hit := range hits {
+		esContent, err := parseESSourceToContentCampaign(hit.Source)
+		if err != nil {
+			core.Logger.WithError(err).Errorf("parseSearchHitsToContentCampaigns() - json parse error. _id: %v", hit.ID)
+			continue
 		}
+
+		// Add score factors, model artifact & final score
+		esContent.ModelArtifact = hit.ModelArtifact
+		esContent.ScoreFactors = hit.ScoreFactors
+		esContent.Score = hit.Score
+		esContent.Highlights = hit.Highlights
+		contentCampaigns = append(contentCampaigns, esContent)
 	}
-	return &contentCampaigns
+	return contentCampaigns
 }
 
 // GetContentCampaignsFromES is Content allocation logic for V3
@@ -252,22 +327,131 @@ func GetContentCampaignsFromES(ctx context.Context, contentReq *dto.ContentArtic
 		return nil, 0, err
 	}
 
-	if searchResult.Hits == nil {
-		return nil, 0, errors.New("GetContentCampaignsFromES() - es search failed")
+	if fetcher.isDefaultFeed() {
+		refreshContentSnapshot(ctx, fetcher)
 	}
 
-	var contentCampaigns []*dto.ESContentCampaign
-
-	if err == nil && searchResult.Hits != nil {
-		contentCampaigns = *parseESHitsToContentCampaigns(contentReq.Session.DeviceID, searchResult)
-	}
+	contentCampaigns := parseSearchHitsToContentCampaigns(searchResult.Hits)
+	contentCampaigns = exploreColdStartCampaigns(contentCampaigns, fetcher.searchReq, searchResult.Hits, fetcher.explorationSlots)
+	applyContentVariants(contentCampaigns)
 
 	// If device is required for intermediate logging
 	if contentReq.GetIsDebugScore() {
 		logDebugScore(contentReq.Session.DeviceID, time.Now().UTC().String(), contentCampaigns)
 	}
 
-	return contentCampaigns, int(searchResult.Hits.TotalHits), nil
+	return contentCampaigns, searchResult.Total, nil
+}
+
+// GetSnapshotContentCampaigns returns the campaigns of the content snapshot of the unit the device can see.
+// It's served instead of GetContentCampaignsFromES when ElasticSearch fails.
+// contentsnapshot.NotFoundError is returned if the request isn't the default feed or the unit has no snapshot
+func GetSnapshotContentCampaigns(ctx context.Context, contentReq *dto.ContentArticlesRequest) ([]*dto.ESContentCampaign, error) {
+	fetcher := (&V3ContentFetcher{}).buildReqWith(ctx, contentReq)
+	unitID, country := fetcher.getSnapshotScope(ctx)
+	if buzzscreen.Service.ContentSnapshotUseCase == nil || !fetcher.isDefaultFeed() {
+		return nil, contentsnapshot.NotFoundError{UnitID: unitID, Country: country}
+	}
+
+	filter := contentsnapshot.DeviceFilter{Gender: contentReq.Gender, Age: contentReq.GetAge(), Now: time.Now()}
+	if activity := contentReq.GetDynamoActivity(); activity != nil {
+		filter.SeenIDs = activity.SeenCampaignIDs
+	}
+	if profile := contentReq.GetDynamoProfile(); profile != nil && profile.HiddenCampaignIDs != nil {
+		filter.ExcludedIDs = *profile.HiddenCampaignIDs
+	}
+
+	hits, err := buzzscreen.Service.ContentSnapshotUseCase.GetCampaigns(unitID, country, filter, fetcher.pageLimit)
+	if err != nil {
+		return nil, err
+	}
+	return parseSearchHitsToContentCampaigns(hits), nil
+}
+
+// refreshContentSnapshot refreshes the snapshot of the unit in the background after the feed is searched successfully
+func refreshContentSnapshot(ctx context.Context, fetcher *V3ContentFetcher) {
+	if buzzscreen.Service.ContentSnapshotUseCase == nil {
+		return
+	}
+
+	unitID, country := fetcher.getSnapshotScope(ctx)
+	buzzscreen.Service.ContentSnapshotUseCase.RefreshIfStale(unitID, country, fetcher.buildSnapshotSearchRequest(ctx))
+}
+
+// applyContentVariants overrides the creatives of the campaigns under test by the variants the bandit selects.
+// The campaigns keep their own creatives if the selection fails
+func applyContentVariants(contentCampaigns []*dto.ESContentCampaign) {
+	variantUseCase := buzzscreen.Service.ContentVariantUseCase
+	if variantUseCase == nil || len(contentCampaigns) == 0 {
+		return
+	}
+
+	campaignIDs := make([]int64, 0, len(contentCampaigns))
+	for _, cc := range contentCampaigns {
+		campaignIDs = append(campaignIDs, cc.ID)
+	}
+
+	variants, err := variantUseCase.SelectVariants(campaignIDs)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("applyContentVariants() - failed to select variants of %v", campaignIDs)
+		return
+	}
+
+	for _, cc := range contentCampaigns {
+		if variant, ok := variants[cc.ID]; ok {
+			cc.ApplyVariant(variant)
+		}
+	}
+}
+
+// exploreColdStartCampaigns fills the slots reserved for exploration with the cold-start campaigns the exploration policy chooses.
+// The chosen campaigns are tagged with ESContentCampaign.Exploration and placed between the ranked ones, never at the end, so that the cursor of the next page stays on a ranked campaign
+func exploreColdStartCampaigns(contentCampaigns []*dto.ESContentCampaign, searchReq contentcampaign.SearchRequest, rankedHits []contentcampaign.SearchHit, slots int) []*dto.ESContentCampaign {
+	if slots <= 0 || buzzscreen.Service.ContentExplorationUseCase == nil {
+		return contentCampaigns
+	}
+
+	hits, err := searchColdStartCandidates(searchReq, rankedHits)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("exploreColdStartCampaigns() - failed to search cold-start campaigns")
+		return contentCampaigns
+	}
+
+	candidateMap := make(map[int64]*dto.ESContentCampaign)
+	candidates := make([]contentexploration.Candidate, 0, len(hits))
+	for _, cc := range parseSearchHitsToContentCampaigns(hits) {
+		candidateMap[cc.ID] = cc
+		candidates = append(candidates, contentexploration.Candidate{CampaignID: cc.ID, Impressions: cc.Impressions, Clicks: cc.Clicks})
+	}
+
+	explored := make([]*dto.ESContentCampaign, 0, slots)
+	for _, choice := range buzzscreen.Service.ContentExplorationUseCase.Choose(candidates, slots) {
+		cc := candidateMap[choice.CampaignID]
+		cc.Exploration = choice.Tag
+		explored = append(explored, cc)
+	}
+	return insertExploredCampaigns(contentCampaigns, explored)
+}
+
+// insertExploredCampaigns spreads the explored campaigns evenly over the ranked ones
+func insertExploredCampaigns(ranked, explored []*dto.ESContentCampaign) []*dto.ESContentCampaign {
+	if len(explored) == 0 {
+		return ranked
+	}
+
+	step := len(ranked) / (len(explored) + 1)
+	if step < 1 {
+		step = 1
+	}
+	merged := make([]*dto.ESContentCampaign, 0, len(ranked)+len(explored))
+	for i, cc := range ranked {
+		merged = append(merged, cc)
+		if len(explored) > 0 && (i+1)%step == 0 && i+1 < len(ranked) {
+			merged = append(merged, explored[0])
+			explored = explored[1:]
+		}
+	}
+	return append(merged, explored...)
 }
 
 func splitAndTrim(commaSeparatedString string) []string {
@@ -2,19 +2,21 @@ package service
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Buzzvil/buzzlib-go/core"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/dto"
-	"github.com/Buzzvil/buzzscreen-api/buzzscreen/env"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/model"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/recovery"
-	"github.com/Buzzvil/buzzscreen-api/buzzscreen/service/es"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/utils"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
-	"gopkg.in/olivere/elastic.v5"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
 )
 
 const defaultContentSize = 30
@@ -22,19 +24,16 @@ const defaultPageLimit = 25
 
 // V3ContentFetcher struct
 type V3ContentFetcher struct {
-	req          *dto.ContentArticlesRequest
-	query        []elastic.Query
-	orgQuery     []elastic.Query
-	pageLimit    int
-	searchSource *elastic.SearchSource
-	scriptSort   *elastic.ScriptSort
+	req       *dto.ContentArticlesRequest
+	pageLimit int
+
+	searchReq        contentcampaign.SearchRequest
+	explorationSlots int // slots of the page reserved for the cold-start campaigns
 }
 
 func (f *V3ContentFetcher) buildReqWith(ctx context.Context, req *dto.ContentArticlesRequest) *V3ContentFetcher {
 	f.req = req
-	f.query = f.buildV3FilterQuery(ctx)
 	f.pageLimit = f.buildPageLimit()
-	f.searchSource, f.scriptSort = f.buildSortScriptAndSearchSource(ctx)
 	return f
 }
 
@@ -46,36 +45,39 @@ func (f *V3ContentFetcher) buildPageLimit() int {
 	return pageLimit
 }
 
-func (f *V3ContentFetcher) fetch(ctx context.Context) (*elastic.SearchResult, error) {
+func (f *V3ContentFetcher) fetch(ctx context.Context) (*contentcampaign.SearchResult, error) {
 	defer recovery.LogRecoverWith(f.req)
 
-	searchService := buzzscreen.Service.ES.Search().Index(env.Config.ElasticSearch.CampaignIndexName).FetchSource(true).
-		SearchSource(f.searchSource).Type("content_campaign").TimeoutInMillis(1000).Preference("_local").Size(f.pageLimit)
-
 	queryKey, queryErr := f.req.GetQueryKey()
 	if queryErr != nil {
 		return nil, queryErr
 	}
 
-	if queryKey != nil {
-		searchService = searchService.From(queryKey.Index)
+	searchReq := contentcampaign.SearchRequest{
+		DeviceID: f.req.Session.DeviceID,
+		Query:    f.buildV3SearchQuery(ctx, queryKey),
+		Ranking:  f.buildSearchRanking(ctx),
+		Size:     f.pageLimit,
 	}
-	searchService = searchService.Query(elastic.NewBoolQuery().Filter(f.buildV3FilterQuery(ctx)...)).SortBy(f.scriptSort)
-
-	if env.IsLocal() {
-		searchService = searchService.Pretty(true)
+	if searchReq.Query.Keyword != "" {
+		searchReq.Highlight = true
 	}
-	searchResult, err := searchService.Do(context.Background())
-
-	if err != nil {
-		return nil, err
+	// 페이지마다 같은 시각으로 점수를 계산해야 커서 이후의 순서가 유지된다
+	searchReq.Ranking.RankedAt = time.Unix(f.req.GetQueryTime(), 0)
+	if queryKey != nil && queryKey.SearchAfter != nil {
+		searchReq.After = &contentcampaign.SearchCursor{Score: queryKey.SearchAfter.Score, ID: queryKey.SearchAfter.ID}
+	} else if queryKey != nil {
+		searchReq.From = queryKey.Index
 	}
 
-	if searchResult.Hits == nil {
-		return nil, errors.New("V3ContentFetcher buildReqWith() - es search failed")
+	// 탐색은 기본 피드의 첫 페이지에서만 한다. 이후 페이지의 Index는 랭킹된 캠페인만 센다
+	if queryKey == nil && searchReq.Ranking.TrendingScores == nil && !searchReq.Highlight {
+		f.explorationSlots = getExplorationSlots(f.pageLimit)
+		searchReq.Size -= f.explorationSlots
 	}
+	f.searchReq = searchReq
 
-	return searchResult, err
+	return buzzscreen.Service.ContentCampaignUseCase.Search(searchReq)
 }
 
 func (f *V3ContentFetcher) getESCreativeType() string {
@@ -88,144 +90,162 @@ func (f *V3ContentFetcher) getESCreativeType() string {
 	}
 }
 
-func (f *V3ContentFetcher) buildV3FilterQuery(ctx context.Context) []elastic.Query {
-	queryBuilder := es.NewESQueryBuilder().WithStartTime("now/h").WithEndTime("now/h").
-		WithUnit(f.req.GetUnit(ctx).ID, f.req.GetUnit(ctx).ContentType == app.ContentTypeAll).
-		WithAppID(f.req.Session.AppID).
-		WithOrgID(f.req.GetUnit(ctx).OrganizationID).
-		WithCountry(GetSupportedCountry(f.req.GetCountry(ctx))).
-		WithCreativeTypes(f.getESCreativeType()).WithImageRatio(1.0).
-		WithAge(f.req.GetAge()).WithGender(f.req.Gender).WithSdk(f.req.SdkVersion).
-		WithRegisteredSeconds(f.req.Session.CreatedSeconds).
-		WithWeekSlot(f.req.GetLocalTime(ctx)).
-		WithCustomTargets(f.req.CustomTarget1, f.req.CustomTarget2, f.req.CustomTarget3).
-		WithOsVersion(f.req.OsVersion).WithBatteryOptimization(f.req.IsInBatteryOpts)
-
-	if profile := f.req.GetDynamoProfile(); profile != nil {
-		if installedPackages := profile.InstalledPackages; installedPackages != nil {
-			queryBuilder = queryBuilder.WithPackages(splitAndTrim(*installedPackages))
-		}
+func (f *V3ContentFetcher) buildV3SearchQuery(ctx context.Context, queryKey *dto.ContentQueryKey) contentcampaign.SearchQuery {
+	unit := f.req.GetUnit(ctx)
+	now := time.Now().Truncate(time.Hour)
+	minImageRatio := 1.0
+
+	query := contentcampaign.SearchQuery{
+		StartsBy:              now,
+		EndsAfter:             now,
+		UnitID:                unit.ID,
+		IncludeGlobUnit:       unit.ContentType == app.ContentTypeAll,
+		AppID:                 f.req.Session.AppID,
+		OrganizationID:        unit.OrganizationID,
+		Country:               GetSupportedCountry(f.req.GetCountry(ctx)),
+		Gender:                f.req.Gender,
+		Age:                   f.req.GetAge(),
+		SdkVersion:            f.req.SdkVersion,
+		RegisteredDays:        getRegisteredDays(f.req.Session.CreatedSeconds),
+		OsVersion:             f.req.OsVersion,
+		LocalTime:             f.req.GetLocalTime(ctx),
+		CustomTargets:         [3]string{f.req.CustomTarget1, f.req.CustomTarget2, f.req.CustomTarget3},
+		InBatteryOptimization: f.req.IsInBatteryOpts,
+		CreativeType:          f.getESCreativeType(),
+		MinImageRatio:         &minImageRatio,
+		Statuses:              contentcampaign.StatusesForLockscreen,
+		Packages:              getInstalledPackages(f.req.GetDynamoProfile()),
+		FrequencyCap:          getFrequencyCap(f.req.GetDynamoActivity()),
+		Keyword:               strings.TrimSpace(f.req.Keyword),
+		TargetLanguages:       legacyLanguages(f.req.GetLanguages()),
+	}
+
+	if unit.UnitType == app.UnitTypeNative {
+		query.RelatedStatuses = contentcampaign.StatusesForFeed
 	}
 
-	queryKey, err := f.req.GetQueryKey()
-	if err != nil {
-		panic(err)
-	}
 	if queryKey != nil {
 		lteTime := time.Unix(int64(queryKey.CreatedAt), 0)
-		queryBuilder = queryBuilder.WithUpdatedTime(&lteTime)
+		query.UpdatedBefore = &lteTime
+		// 첫 페이지에서 탐색으로 노출한 캠페인이 랭킹된 페이지에 다시 나오지 않게 한다
+		query.ExcludedIDs = append(query.ExcludedIDs, queryKey.ExploredIDs...)
 	}
 
 	if f.req.LandingTypes != "" {
-		queryBuilder = queryBuilder.WithLandingTypes(splitAndTrim(f.req.LandingTypes)...)
+		query.LandingTypes = parseInts(splitAndTrim(f.req.LandingTypes))
 	}
 
 	// Category
 	if f.req.Categories != "" {
-		queryBuilder = queryBuilder.WithCategories(true, splitAndTrim(f.req.Categories)...)
+		query.Categories = splitAndTrim(f.req.Categories)
 	} else if f.req.CategoryID != "" {
-		queryBuilder = queryBuilder.WithCategories(true, f.req.CategoryID)
+		query.Categories = []string{f.req.CategoryID}
 	}
 
 	if f.req.FilterCategories != "" {
-		queryBuilder = queryBuilder.WithCategories(false, splitAndTrim(f.req.FilterCategories)...)
+		query.ExcludedCategories = splitAndTrim(f.req.FilterCategories)
 	}
 
 	// ChannelID
 	if f.req.ChannelID > 0 {
-		queryBuilder = queryBuilder.WithChannels(true, strconv.FormatInt(f.req.ChannelID, 10))
+		query.ChannelIDs = []int64{f.req.ChannelID}
 	}
 
 	if f.req.FilterChannelIDs != "" {
-		queryBuilder = queryBuilder.WithChannels(false, splitAndTrim(f.req.FilterChannelIDs)...)
+		query.ExcludedChannelIDs = parseInt64s(splitAndTrim(f.req.FilterChannelIDs))
 	}
 
-	if f.req.GetUnit(ctx).FilteredProviders != nil {
-		queryBuilder = queryBuilder.WithFilteredProviders(splitAndTrim(*f.req.GetUnit(ctx).FilteredProviders)...)
-	}
-
-	activity := f.req.GetDynamoActivity()
-	if activity != nil {
-		filterScript := es.GetScriptLoader().GetFilterScript()
-		queryBuilder = queryBuilder.WithFrequencyCapping(filterScript, *activity)
+	if unit.FilteredProviders != nil {
+		query.ExcludedProviderIDs = parseInt64s(splitAndTrim(*unit.FilteredProviders))
 	}
 
-	queries := *queryBuilder.Build()
-
-	statusQueries := make([]elastic.Query, 0)
-	for _, status := range model.StatusesForLockscreen {
-		statusQueries = append(statusQueries, elastic.NewTermQuery("status", status))
-	}
-
-	if f.req.GetUnit(ctx).UnitType == app.UnitTypeNative {
-		for _, status := range model.StatusesForFeed {
-			statusQueries = append(statusQueries, elastic.NewBoolQuery().Must(elastic.NewTermQuery("status", status), elastic.NewExistsQuery("related")))
-		}
-	}
+	excludeHiddenContents(&query, f.req.GetDynamoProfile())
+	applyPreferenceToQuery(&query, f.req.GetContentPreference())
+	return query
+}
 
-	if len(statusQueries) > 0 {
-		queries = append(queries, elastic.NewBoolQuery().Should(statusQueries...))
+func (f *V3ContentFetcher) buildSearchRanking(ctx context.Context) contentcampaign.SearchRanking {
+	ranking := buildSearchRanking(*f.req.GetModelArtifact(ctx), false, f.req.GetCategoriesScores(), f.req.GetEntityScores(), f.req.GetDynamoActivity(), f.req.GetIsDebugScore())
+	ranking.CampaignScores = getActiveCampaignScores(f.req.GetTarget(ctx))
+	applyPreferenceToRanking(&ranking, f.req.GetContentPreference())
+	if f.req.Sort == dto.ContentSortTrending {
+		ranking.TrendingScores = f.getTrendingScores(ctx)
 	}
+	return ranking
+}
 
-	return queries
+// isDefaultFeed returns true for the first page of the feed without keyword, category, channel or sort. Only the feed is backed by the content snapshot
+func (f *V3ContentFetcher) isDefaultFeed() bool {
+	return f.req.EncryptedQueryKey == "" && strings.TrimSpace(f.req.Keyword) == "" && f.req.Sort == "" &&
+		f.req.Categories == "" && f.req.CategoryID == "" && f.req.ChannelID == 0
 }
 
-func (f *V3ContentFetcher) buildSortScriptAndSearchSource(ctx context.Context) (*elastic.SearchSource, *elastic.ScriptSort) {
-	modelArtifact := *f.req.GetModelArtifact(ctx)
+// getSnapshotScope returns the unit and country the content snapshot of the request is kept for
+func (f *V3ContentFetcher) getSnapshotScope(ctx context.Context) (int64, string) {
+	return f.req.GetUnit(ctx).ID, GetSupportedCountry(f.req.GetCountry(ctx))
+}
 
-	// 1. Build score script for ranking
-	rawScript := es.GetScriptLoader().GetScoreScript(modelArtifact, es.JoinOpAdd)
-	script := elastic.NewScript(rawScript)
-	params := make(map[string]interface{})
+// buildSnapshotSearchRequest returns the request of the top campaigns of the unit and country for the content snapshot.
+// The device is left out so that the snapshot is shared by every device of the unit:
+// gender and age are filtered when the snapshot is served and the other device targeting matches untargeted campaigns only
+func (f *V3ContentFetcher) buildSnapshotSearchRequest(ctx context.Context) contentcampaign.SearchRequest {
+	unit := f.req.GetUnit(ctx)
+	now := time.Now().Truncate(time.Hour)
+	minImageRatio := 1.0
 
-	// Add preferred categories information for personalization
-	if cs := f.req.GetCategoriesScores(); cs != nil {
-		params["categoryProfile"] = *cs
+	query := contentcampaign.SearchQuery{
+		StartsBy:        now,
+		EndsAfter:       now,
+		UnitID:          unit.ID,
+		IncludeGlobUnit: unit.ContentType == app.ContentTypeAll,
+		AppID:           f.req.Session.AppID,
+		OrganizationID:  unit.OrganizationID,
+		Country:         GetSupportedCountry(f.req.GetCountry(ctx)),
+		AnyDevice:       true,
+		CreativeType:    f.getESCreativeType(),
+		MinImageRatio:   &minImageRatio,
+		Statuses:        contentcampaign.StatusesForLockscreen,
 	}
 
-	// Add entity profiles for personalization
-	if es := f.req.GetEntityScores(); es != nil {
-		params["entityProfile"] = *es
+	if unit.UnitType == app.UnitTypeNative {
+		query.RelatedStatuses = contentcampaign.StatusesForFeed
 	}
 
-	// Add seen content ids
-	if activity := f.req.GetDynamoActivity(); activity != nil {
-		if len(activity.SeenCampaignIDs) != 0 {
-			params["seenIDs"] = activity.SeenCampaignIDs
-		}
+	if unit.FilteredProviders != nil {
+		query.ExcludedProviderIDs = parseInt64s(splitAndTrim(*unit.FilteredProviders))
 	}
-	script.Params(params)
-
-	// 2. Set script to searchSource
-	artifactScript := elastic.NewScript(fmt.Sprintf("'%s'", modelArtifact))
-	searchSource := elastic.NewSearchSource().FetchSource(true).ScriptField(elastic.NewScriptField("modelArtifact", artifactScript))
 
-	// 3. Set searchSource for debug scoring if needed
-	// Check if device is required a detailed scoring analysis
-	if f.req.GetIsDebugScore() {
-		searchSource.ScriptFields(es.GetScriptLoader().GetScriptFields(modelArtifact, params)...)
+	return contentcampaign.SearchRequest{
+		Query:   query,
+		Ranking: buildSearchRanking(*f.req.GetModelArtifact(ctx), false, nil, nil, nil, false),
 	}
+}
 
-	scriptSort := elastic.NewScriptSort(script, "number").Desc()
-
-	return searchSource, scriptSort
+// getTrendingScores returns the trending scores of the unit. nil is returned if there's no trending campaign so that articles are ranked as usual
+func (f *V3ContentFetcher) getTrendingScores(ctx context.Context) map[int64]float64 {
+	unit := f.req.GetUnit(ctx)
+	scores, err := buzzscreen.Service.ContentTrendingUseCase.GetTrendingScores(ctx, unit.ID, unit.Country)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("getTrendingScores() - failed to get trending scores of unit %d", unit.ID)
+		return nil
+	} else if len(scores) == 0 {
+		return nil
+	}
+	return scores
 }
 
 // V1ContentFetcher struct definition
 type V1ContentFetcher struct {
-	req          *dto.ContentAllocV1Request
-	query        []elastic.Query
-	orgQuery     []elastic.Query
-	pageLimit    int
-	searchSource *elastic.SearchSource
-	scriptSort   *elastic.ScriptSort
+	req       *dto.ContentAllocV1Request
+	pageLimit int
+
+	searchReq        contentcampaign.SearchRequest
+	explorationSlots int // slots of the page reserved for the cold-start campaigns
 }
 
 func (f *V1ContentFetcher) buildReqWith(ctx context.Context, req *dto.ContentAllocV1Request) *V1ContentFetcher {
 	f.req = req
-	f.query = f.buildV1FilterQuery(ctx, false)
 	f.pageLimit = f.buildPageLimit(ctx)
-	f.searchSource, f.scriptSort = f.buildSortScriptAndSearchSource(ctx)
 	return f
 }
 
@@ -237,119 +257,262 @@ func (f *V1ContentFetcher) buildPageLimit(ctx context.Context) int {
 	return pageLimit
 }
 
-func (f *V1ContentFetcher) fetch() (*elastic.SearchResult, error) {
+func (f *V1ContentFetcher) fetch(ctx context.Context) (*contentcampaign.SearchResult, error) {
 	defer recovery.LogRecoverWith(f.req)
-	ss := buzzscreen.Service.ES.Search().Index(env.Config.ElasticSearch.CampaignIndexName).FetchSource(true).
-		SearchSource(f.searchSource).Type("content_campaign").Query(elastic.NewBoolQuery().Filter(f.query...)).
-		SortBy(f.scriptSort).TimeoutInMillis(1000).Preference("_local").Size(f.pageLimit)
 
-	if env.IsLocal() {
-		ss.Pretty(true)
+	f.explorationSlots = getExplorationSlots(f.pageLimit)
+	f.searchReq = contentcampaign.SearchRequest{
+		DeviceID: f.req.DeviceID,
+		Query:    f.buildV1SearchQuery(ctx),
+		Ranking:  f.buildSearchRanking(ctx),
+		Size:     f.pageLimit - f.explorationSlots,
 	}
-
-	return ss.Do(context.Background())
+	return buzzscreen.Service.ContentCampaignUseCase.Search(f.searchReq)
 }
 
-func (f *V1ContentFetcher) buildV1FilterQuery(ctx context.Context, addOrgFilter bool) []elastic.Query {
+func (f *V1ContentFetcher) buildV1SearchQuery(ctx context.Context) contentcampaign.SearchQuery {
 	allocReq := f.req
 	targetCarrier := allocReq.Carrier
 	if carrierInMap, ok := model.CarrierMap[allocReq.Carrier]; ok {
 		targetCarrier = string(carrierInMap)
 	}
-
-	queryBuilder := es.NewESQueryBuilder().
-		WithStartTime("now/h").WithEndTime("now+1h/h").
-		WithCountry(GetSupportedCountry(allocReq.GetCountry(ctx))).
-		WithGender(allocReq.Gender).WithCarrier(targetCarrier).
-		WithUnit(allocReq.GetUnit(ctx).ID, allocReq.GetUnit(ctx).ContentType != app.ContentTypeUnitOnly). //동아닷컴 (255009465857996, 363944316301025)
-		WithAppID(allocReq.GetAppID(ctx)).
-		WithOrgID(allocReq.GetOrganizationID(ctx)).
-		WithAge(allocReq.GetTargetAge()).WithSdk(allocReq.SdkVersion).
-		WithRegisteredSeconds(allocReq.GetRegisteredSeconds()).
-		WithWeekSlot(allocReq.GetLocalTime(ctx)).WithCreativeTypes(allocReq.GetCreativeType(ctx)).
-		WithCustomTargets(allocReq.CustomTarget1, allocReq.CustomTarget2, allocReq.CustomTarget3).
-		WithOsVersion(allocReq.DeviceOs).WithBatteryOptimization(allocReq.IsInBatteryOpts).
-		WithStatus(model.StatusesForLockscreen...).WithRegion(allocReq.Region)
-
-	if profile := allocReq.GetDynamoProfile(); profile != nil {
-		if installedPackages := profile.InstalledPackages; installedPackages != nil {
-			queryBuilder = queryBuilder.WithPackages(splitAndTrim(*installedPackages))
-		}
+	now := time.Now().Truncate(time.Hour)
+
+	query := contentcampaign.SearchQuery{
+		StartsBy:              now,
+		EndsAfter:             now.Add(time.Hour),
+		UnitID:                allocReq.GetUnit(ctx).ID,
+		IncludeGlobUnit:       allocReq.GetUnit(ctx).ContentType != app.ContentTypeUnitOnly, //동아닷컴 (255009465857996, 363944316301025)
+		AppID:                 allocReq.GetAppID(ctx),
+		OrganizationID:        allocReq.GetOrganizationID(ctx),
+		Country:               GetSupportedCountry(allocReq.GetCountry(ctx)),
+		Gender:                allocReq.Gender,
+		Carrier:               &targetCarrier,
+		Region:                &allocReq.Region,
+		Age:                   allocReq.GetTargetAge(),
+		SdkVersion:            allocReq.SdkVersion,
+		RegisteredDays:        getRegisteredDays(allocReq.GetRegisteredSeconds()),
+		OsVersion:             allocReq.DeviceOs,
+		LocalTime:             allocReq.GetLocalTime(ctx),
+		CustomTargets:         [3]string{allocReq.CustomTarget1, allocReq.CustomTarget2, allocReq.CustomTarget3},
+		InBatteryOptimization: allocReq.IsInBatteryOpts,
+		CreativeType:          allocReq.GetCreativeType(ctx),
+		Statuses:              contentcampaign.StatusesForLockscreen,
+		Packages:              getInstalledPackages(allocReq.GetDynamoProfile()),
+		FrequencyCap:          getFrequencyCap(allocReq.GetDynamoActivity()),
 	}
 
 	if allocReq.Language != "" {
-		queryBuilder = queryBuilder.WithLanguage(splitAndTrim(allocReq.Language)...)
+		query.Languages = splitAndTrim(allocReq.Language)
 	}
 
 	// Category
-	if f.req.Categories != "" {
-		queryBuilder = queryBuilder.WithCategories(true, splitAndTrim(f.req.Categories)...)
+	if allocReq.Categories != "" {
+		query.Categories = splitAndTrim(allocReq.Categories)
 	}
 
 	if allocReq.FilterCategories != "" {
-		queryBuilder = queryBuilder.WithCategories(false, splitAndTrim(allocReq.FilterCategories)...)
+		query.ExcludedCategories = splitAndTrim(allocReq.FilterCategories)
 	}
 
 	if allocReq.FilterChannelIDs != "" {
-		queryBuilder = queryBuilder.WithChannels(false, splitAndTrim(allocReq.FilterChannelIDs)...)
+		query.ExcludedChannelIDs = parseInt64s(splitAndTrim(allocReq.FilterChannelIDs))
 	}
 
 	if allocReq.GetUnit(ctx).FilteredProviders != nil {
-		queryBuilder = queryBuilder.WithFilteredProviders(splitAndTrim(*allocReq.GetUnit(ctx).FilteredProviders)...)
+		query.ExcludedProviderIDs = parseInt64s(splitAndTrim(*allocReq.GetUnit(ctx).FilteredProviders))
+	}
+
+	excludeHiddenContents(&query, allocReq.GetDynamoProfile())
+	applyPreferenceToQuery(&query, allocReq.GetContentPreference())
+	return query
+}
+
+func (f *V1ContentFetcher) buildSearchRanking(ctx context.Context) contentcampaign.SearchRanking {
+	modelArtifact := *f.req.GetModelArtifact(ctx)
+	ranking := buildSearchRanking(modelArtifact, modelArtifact == "v1", f.req.GetCategoriesScores(), f.req.GetEntityScores(), f.req.GetDynamoActivity(), f.req.GetIsDebugScore())
+	applyPreferenceToRanking(&ranking, f.req.GetContentPreference())
+	return ranking
+}
+
+func buildSearchRanking(modelArtifact string, multiplyFactors bool, categoriesScores, entityScores *map[string]float64, activity *device.Activity, isDebug bool) contentcampaign.SearchRanking {
+	ranking := contentcampaign.SearchRanking{
+		ModelArtifact:   modelArtifact,
+		MultiplyFactors: multiplyFactors,
+		Debug:           isDebug,
 	}
 
-	if addOrgFilter {
-		queryBuilder = queryBuilder.WithOrganization(allocReq.GetOrganizationID(ctx))
+	// Add preferred categories information for personalization
+	if categoriesScores != nil {
+		ranking.CategoryProfile = *categoriesScores
+	}
+
+	// Add entity profiles for personalization
+	if entityScores != nil {
+		ranking.EntityProfile = *entityScores
 	}
 
-	activity := allocReq.GetDynamoActivity()
+	// Add seen content ids
 	if activity != nil {
-		filterScript := es.GetScriptLoader().GetFilterScript()
-		queryBuilder = queryBuilder.WithFrequencyCapping(filterScript, *activity)
+		ranking.SeenIDs = activity.SeenCampaignIDs
 	}
 
-	return *queryBuilder.Build()
+	return ranking
 }
 
-func (f *V1ContentFetcher) buildSortScriptAndSearchSource(ctx context.Context) (*elastic.SearchSource, *elastic.ScriptSort) {
-	modelArtifact := *f.req.GetModelArtifact(ctx)
+// getActiveCampaignScores returns the scores of the active content score version. nil is returned if no version is published.
+func getActiveCampaignScores(target *dto.ContentTarget) map[int64]int {
+	if buzzscreen.Service.ContentScoreUseCase == nil {
+		return nil
+	}
 
-	// 1. Build score script for ranking
-	joinOp := es.JoinOpMultiply
-	if modelArtifact != "v1" {
-		joinOp = es.JoinOpAdd
+	scoreSet, err := buzzscreen.Service.ContentScoreUseCase.GetActiveScoreSet(contentscore.Target{
+		Country: target.Country,
+		Gender:  target.Gender,
+		Age:     target.Age,
+	})
+	if err != nil {
+		if _, ok := err.(contentscore.NoActiveVersionError); !ok {
+			core.Logger.WithError(err).Warnf("getActiveCampaignScores() - target: %+v", *target)
+		}
+		return nil
 	}
-	rawScript := es.GetScriptLoader().GetScoreScript(modelArtifact, joinOp)
-	script := elastic.NewScript(rawScript)
-	params := make(map[string]interface{})
+	return scoreSet.Scores
+}
 
-	// Add preferred categories information for personalization
-	if cs := f.req.GetCategoriesScores(); cs != nil {
-		params["categoryProfile"] = *cs
+// getExplorationSlots returns the number of slots reserved for the cold-start campaigns in a page. 0 is returned if exploration isn't configured
+func getExplorationSlots(pageLimit int) int {
+	if buzzscreen.Service.ContentExplorationUseCase == nil {
+		return 0
 	}
+	return buzzscreen.Service.ContentExplorationUseCase.Slots(pageLimit)
+}
 
-	// Add entity profiles for personalization
-	if es := f.req.GetEntityScores(); es != nil {
-		params["entityProfile"] = *es
+// searchColdStartCandidates searches the cold-start campaigns matching the query of searchReq except the ranked ones
+func searchColdStartCandidates(searchReq contentcampaign.SearchRequest, ranked []contentcampaign.SearchHit) ([]contentcampaign.SearchHit, error) {
+	explorationUseCase := buzzscreen.Service.ContentExplorationUseCase
+	coldStart := explorationUseCase.ColdStartFilter(time.Now())
+
+	candidateReq := searchReq
+	candidateReq.Query.ColdStart = &coldStart
+	candidateReq.Query.ExcludedIDs = append([]int64{}, searchReq.Query.ExcludedIDs...)
+	for _, hit := range ranked {
+		candidateReq.Query.ExcludedIDs = append(candidateReq.Query.ExcludedIDs, hit.ID)
 	}
+	candidateReq.After = nil
+	candidateReq.From = 0
+	candidateReq.Size = explorationUseCase.PoolSize()
 
-	// Add seen content ids
-	if activity := f.req.GetDynamoActivity(); activity != nil {
-		if len(activity.SeenCampaignIDs) != 0 {
-			params["seenIDs"] = activity.SeenCampaignIDs
+	result, err := buzzscreen.Service.ContentCampaignUseCase.Search(candidateReq)
+	if err != nil {
+		return nil, err
+	}
+	return result.Hits, nil
+}
+
+func getRegisteredDays(registeredSeconds int64) int {
+	if registeredSeconds > 0 {
+		return utils.GetDaysFrom(registeredSeconds) + 1
+	}
+	return 0
+}
+
+func getInstalledPackages(profile *device.Profile) []string {
+	if profile != nil && profile.InstalledPackages != nil {
+		return splitAndTrim(*profile.InstalledPackages)
+	}
+	return nil
+}
+
+// excludeHiddenContents excludes the campaigns and channels the device has hidden by feedbacks
+func excludeHiddenContents(query *contentcampaign.SearchQuery, profile *device.Profile) {
+	if profile == nil {
+		return
+	}
+	if profile.HiddenCampaignIDs != nil {
+		query.ExcludedIDs = append(query.ExcludedIDs, *profile.HiddenCampaignIDs...)
+	}
+	if profile.HiddenChannelIDs != nil {
+		query.ExcludedChannelIDs = append(query.ExcludedChannelIDs, *profile.HiddenChannelIDs...)
+	}
+}
+
+// applyPreferenceToQuery filters the muted channels, the excluded categories and the languages of the device preference.
+// The preferred languages replace the languages of the device, and campaigns targeting all languages are still allowed.
+// Channels and categories requested explicitly are not filtered
+func applyPreferenceToQuery(query *contentcampaign.SearchQuery, preference *contentpreference.Preference) {
+	if preference == nil {
+		return
+	}
+	for _, channelID := range preference.MutedChannelIDs {
+		if !int64In(channelID, query.ChannelIDs) {
+			query.ExcludedChannelIDs = append(query.ExcludedChannelIDs, channelID)
+		}
+	}
+	for _, category := range preference.ExcludedCategories() {
+		if !stringIn(category, query.Categories) {
+			query.ExcludedCategories = append(query.ExcludedCategories, category)
+		}
+	}
+	if len(preference.Languages) > 0 {
+		query.TargetLanguages = preference.Languages
+	}
+}
+
+// applyPreferenceToRanking boosts the followed channels and demotes the categories of the device preference
+func applyPreferenceToRanking(ranking *contentcampaign.SearchRanking, preference *contentpreference.Preference) {
+	if preference == nil {
+		return
+	}
+	ranking.FollowedChannelIDs = preference.FollowedChannelIDs
+	ranking.CategoryWeights = preference.DemotedCategories()
+}
+
+func getFrequencyCap(activity *device.Activity) *contentcampaign.FrequencyCap {
+	if activity == nil {
+		return nil
+	}
+	return &contentcampaign.FrequencyCap{
+		CountsForHour:     activity.SeenCampaignCountForHour,
+		CountsForDay:      activity.SeenCampaignCountForDay,
+		CountsForLifetime: activity.SeenCampaignCountForLifetime,
+	}
+}
+
+func parseInt64s(strs []string) []int64 {
+	ints := make([]int64, 0, len(strs))
+	for _, str := range strs {
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			ints = append(ints, i)
 		}
 	}
-	script.Params(params)
+	return ints
+}
 
-	// 2. Set searchSource for debug scoring if needed
-	artifactScript := elastic.NewScript(fmt.Sprintf("'%s'", modelArtifact))
-	searchSource := elastic.NewSearchSource().FetchSource(true).ScriptField(elastic.NewScriptField("modelArtifact", artifactScript))
-	// Check if device is required a detailed scoring analysis
-	if f.req.GetIsDebugScore() {
-		searchSource.ScriptFields(es.GetScriptLoader().GetScriptFields(modelArtifact, params)...)
+func parseInts(strs []string) []int {
+	ints := make([]int, 0, len(strs))
+	for _, str := range strs {
+		if i, err := strconv.Atoi(str); err == nil {
+			ints = append(ints, i)
+		}
 	}
+	return ints
+}
 
-	scriptSort := elastic.NewScriptSort(script, "number").Desc()
+func int64In(i int64, ints []int64) bool {
+	for _, v := range ints {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
 
-	return searchSource, scriptSort
+func stringIn(str string, strs []string) bool {
+	for _, v := range strs {
+		if v == str {
+			return true
+		}
+	}
+	return false
 }
@@ -831,7 +831,7 @@ func TestFrequencyCapping(t *testing.T) {
 		dpTable := dyDB.Table(env.Config.DynamoTableProfile)
 		dpr := devicerepo.NewProfileRepo(&dpTable)
 		daTable := dyDB.Table(env.Config.DynamoTableActivity)
-		dar := devicerepo.NewActivityRepo(&daTable)
+		dar := devicerepo.NewActivityRepo(&daTable, buzzscreen.Service.Redis)
 		dr := devicerepo.New(dbdevice.NewSource(buzzscreen.Service.DB))
 		buzzscreen.Service.DeviceUseCase = device.NewUseCase(dr, dpr, dar)
 	}()
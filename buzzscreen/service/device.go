@@ -16,6 +16,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbdevice"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
 )
 
 // DebugScoreForLog type definition
@@ -93,6 +94,23 @@ func HandleNewDevice(d device.Device) (*device.Device, error) {
 	return deviceUpdated, nil
 }
 
+// LinkDeviceIdentifiers records the identifiers of the device to the identity graph.
+// ifv is linked as well since the device keeps it only when the ifa is empty.
+func LinkDeviceIdentifiers(d *device.Device, ifv *string) {
+	igu := buzzscreen.Service.IdentityGraphUseCase
+	if igu == nil {
+		return
+	}
+
+	identifiers := identitygraph.DeviceIdentifiers(d.AppID, d.IFA, d.UnitDeviceToken)
+	if ifv != nil {
+		identifiers = append(identifiers, identitygraph.IFVIdentifier(*ifv))
+	}
+	if err := igu.LinkDevice(d.ID, identifiers, identitygraph.SourceDevice, time.Now()); err != nil {
+		core.Logger.WithError(err).Warnf("LinkDeviceIdentifiers() - failed to link identifiers of device %d", d.ID)
+	}
+}
+
 // LogDevice func definition
 func LogDevice(device *device.Device, country *string, appVersion *int, isInBatteryOpts *bool, IsBackgroundRestricted *bool, HasOverlayPermission *bool) {
 	deviceForLog := DeviceForLog{
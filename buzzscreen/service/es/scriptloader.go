@@ -125,8 +125,8 @@ var scriptLoaderInstance *ScriptLoader
 // GetScriptLoader returns ScriptLoader singleton instance
 func GetScriptLoader() *ScriptLoader {
 	if scriptLoaderInstance == nil {
-		_, filename, _, _ := runtime.Caller(1)
-		scriptLoaderInstance = &ScriptLoader{scripts: make(map[string]string), toPath: path.Dir(filename)}
+		_, filename, _, _ := runtime.Caller(0)
+		scriptLoaderInstance = &ScriptLoader{scripts: make(map[string]string), toPath: path.Dir(path.Dir(filename))}
 	}
 	return scriptLoaderInstance
 }
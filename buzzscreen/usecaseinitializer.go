@@ -1,7 +1,9 @@
 package buzzscreen
 
 import (
+	"net/url"
 	"os"
+	"time"
 
 	authsvc "github.com/Buzzvil/buzzapis/go/auth"
 	pbprofile "github.com/Buzzvil/buzzapis/go/profile"
@@ -9,6 +11,7 @@ import (
 	"github.com/Buzzvil/buzzlib-go/core"
 	"github.com/Buzzvil/buzzlib-go/jwe"
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/env"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/service/es"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/ad"
 	baUserRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/ad/bauserrepo"
 	adRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/ad/repo"
@@ -18,8 +21,25 @@ import (
 	authRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/auth/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/config"
 	configRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/config/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	contentAdminRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentbookmark"
+	contentBookmarkRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentbookmark/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	contentCampaignIndexer "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/indexer"
 	contentCampaignRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/repo"
+	contentCampaignSearchRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/searchrepo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentexploration"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference"
+	contentPreferenceRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+	contentScoreRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentsnapshot"
+	contentSnapshotRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentsnapshot/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contenttrending"
+	contentTrendingRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contenttrending/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
+	contentVariantRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/custompreview"
 	customPreviewRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/custompreview/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbapp"
@@ -31,25 +51,37 @@ import (
 	deviceRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/device/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/event"
 	eventRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/event/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
+	identityGraphRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/impressiondata"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/landing"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
+	localizationRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/localization/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/location"
 	locationRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/location/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/log"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/notiplus"
 	notiplusRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/notiplus/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/payload"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy"
+	privacyRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/profilerequest"
 	profileRequestRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/profilerequest/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/redirect"
+	redirectRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/redirect/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/report"
 	reportRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/report/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/reward"
 	rewardRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/reward/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/session"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
+	shortLinkRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/tracker"
+	trackerRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/tracker/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/trackingdata"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/userreferral"
 	userReferralRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/userreferral/repo"
 
-	"github.com/go-redis/redis"
 	"github.com/go-resty/resty"
 	"google.golang.org/grpc"
 )
@@ -77,23 +109,93 @@ func (bs *Buzzscreen) initConfigUseCase() config.UseCase {
 	return config.NewUseCase(cr)
 }
 
+func (bs *Buzzscreen) initContentAdminUseCase() contentadmin.UseCase {
+	idx := contentCampaignIndexer.New(dbcontentcampaign.NewSource(bs.DB), bs.ES, env.Config.ElasticSearch.CampaignIndexName, 0)
+	return contentadmin.NewUseCase(contentAdminRepo.New(bs.DB), idx, contentAdminRepo.NewCache(bs.Redis))
+}
+
+func (bs *Buzzscreen) initContentBookmarkUseCase(deviceUseCase device.UseCase) contentbookmark.UseCase {
+	return contentbookmark.NewUseCase(contentBookmarkRepo.New(bs.DB), deviceUseCase)
+}
+
 func (bs *Buzzscreen) initContentCampaignUseCase(redisCache *rediscache.RedisCache) contentcampaign.UseCase {
-	client := redis.NewClient(&redis.Options{
-		Addr:     env.Config.StatRedis.Endpoint,
-		Password: "", // no password set
-		DB:       env.Config.StatRedis.DB,
-	})
-	ccRedis := rediscontentcampaign.NewSource(client)
+	ccRedis := rediscontentcampaign.NewSource(env.GetStatRedis())
 	ccDB := dbcontentcampaign.NewSource(bs.DB)
 	ccr := contentCampaignRepo.New(ccDB, redisCache, ccRedis)
-	return contentcampaign.NewUseCase(ccr)
+
+	var ccsr contentcampaign.SearchRepository
+	if useMemorySearchRepository() {
+		ccsr = contentCampaignSearchRepo.NewMemory()
+	} else {
+		ccsr = contentCampaignSearchRepo.NewES(bs.ES, env.Config.ElasticSearch.CampaignIndexName, es.GetScriptLoader())
+	}
+	logger := log.NewStructuredLogger(log.NewFmtWrapper())
+	throttle := contentcampaign.NewCtrThrottle(contentcampaign.DefaultCtrThrottlePolicy, ccr, logger)
+	return contentcampaign.NewUseCase(ccr, ccsr, contentcampaign.NewDefaultRerankers(), logger, throttle)
+}
+
+// useMemorySearchRepository returns true if content campaigns are searched in memory instead of elasticsearch for local runs
+func useMemorySearchRepository() bool {
+	repoType, ok := os.LookupEnv("CONTENT_SEARCH_REPOSITORY")
+	return ok && repoType == "memory"
+}
+
+func (bs *Buzzscreen) initContentExplorationUseCase() contentexploration.UseCase {
+	policy := contentexploration.DefaultPolicy
+	if config := env.Config.ContentExploration; config != nil {
+		if config.Fraction != nil {
+			policy.Fraction = *config.Fraction
+		}
+		if config.MaxAgeHours > 0 {
+			policy.MaxAge = time.Duration(config.MaxAgeHours) * time.Hour
+		}
+		if config.MaxImpressions > 0 {
+			policy.MaxImpressions = config.MaxImpressions
+		}
+		if config.Strategy != "" {
+			policy.Strategy = contentexploration.Strategy(config.Strategy)
+		}
+		if config.Epsilon != nil {
+			policy.Epsilon = *config.Epsilon
+		}
+	}
+
+	if !policy.IsValid() {
+		core.Logger.Errorf("initContentExplorationUseCase() - invalid exploration policy %+v. the default is used", policy)
+		policy = contentexploration.DefaultPolicy
+	}
+	return contentexploration.NewUseCase(policy)
+}
+
+func (bs *Buzzscreen) initContentPreferenceUseCase() contentpreference.UseCase {
+	return contentpreference.NewUseCase(contentPreferenceRepo.New(bs.DB))
+}
+
+func (bs *Buzzscreen) initContentSnapshotUseCase(redisCache *rediscache.RedisCache, contentCampaignUseCase contentcampaign.UseCase) contentsnapshot.UseCase {
+	repo := contentSnapshotRepo.New(redisCache)
+	return contentsnapshot.NewUseCase(repo, contentCampaignUseCase, contentsnapshot.DefaultPolicy)
+}
+
+func (bs *Buzzscreen) initContentTrendingUseCase(appUseCase app.UseCase) contenttrending.UseCase {
+	repo := contentTrendingRepo.New(rediscontentcampaign.NewSource(env.GetStatRedis()))
+	return contenttrending.NewUseCase(repo, appUseCase, contenttrending.DefaultPolicy)
+}
+
+func (bs *Buzzscreen) initContentVariantUseCase(redisCache *rediscache.RedisCache) contentvariant.UseCase {
+	repo := contentVariantRepo.New(bs.DB, redisCache, rediscontentcampaign.NewSource(env.GetStatRedis()))
+	return contentvariant.NewUseCase(repo, contentvariant.DefaultPolicy)
+}
+
+func (bs *Buzzscreen) initContentScoreUseCase() contentscore.UseCase {
+	csr := contentScoreRepo.New(bs.DB)
+	return contentscore.NewUseCase(csr, contentscore.DefaultRetainCount)
 }
 
 func (bs *Buzzscreen) initDeviceUseCase() device.UseCase {
 	dpTable := bs.DynamoDB.Table(env.Config.DynamoTableProfile)
 	dpr := deviceRepo.NewProfileRepo(&dpTable)
 	daTable := bs.DynamoDB.Table(env.Config.DynamoTableActivity)
-	dar := deviceRepo.NewActivityRepo(&daTable)
+	dar := deviceRepo.NewActivityRepo(&daTable, bs.Redis)
 	dr := deviceRepo.New(dbdevice.NewSource(bs.DB))
 	return device.NewUseCase(dr, dpr, dar)
 }
@@ -113,10 +215,22 @@ func (bs *Buzzscreen) initEventUseCase(redisCache *rediscache.RedisCache) event.
 	return event.NewUseCase(repo, manager, log.NewStructuredLogger(log.NewFmtWrapper()))
 }
 
+func (bs *Buzzscreen) initIdentityGraphUseCase() identitygraph.UseCase {
+	return identitygraph.NewUseCase(identityGraphRepo.New(bs.DB), identitygraph.DefaultPolicy)
+}
+
 func (bs *Buzzscreen) initImpressionDataUseCase() impressiondata.UseCase {
 	return impressiondata.NewUseCase()
 }
 
+func (bs *Buzzscreen) initLandingUseCase() landing.UseCase {
+	return landing.NewUseCase()
+}
+
+func (bs *Buzzscreen) initLocalizationUseCase(redisCache *rediscache.RedisCache) localization.UseCase {
+	return localization.NewUseCase(localizationRepo.New(bs.DB, redisCache))
+}
+
 func (bs *Buzzscreen) initLocationUseCase() location.UseCase {
 	lr := locationRepo.New(bs.GeoDB)
 	return location.NewUseCase(lr)
@@ -130,6 +244,46 @@ func (bs *Buzzscreen) initPayloadUseCase() payload.UseCase {
 	return payload.NewUseCase()
 }
 
+// initPrivacyUseCase registers every store keeping personal data of devices.
+// device is the last one since the devices of the subject are found by it
+func (bs *Buzzscreen) initPrivacyUseCase() privacy.UseCase {
+	profileTable := bs.DynamoDB.Table(env.Config.DynamoTableProfile)
+	activityTable := bs.DynamoDB.Table(env.Config.DynamoTableActivity)
+	pointTable := bs.DynamoDB.Table(env.Config.DynamoTablePoint)
+	stores := []privacy.Store{
+		privacyRepo.NewTableStore(bs.DB, "device_update_history", "device_id"),
+		privacyRepo.NewTableStore(bs.DB, "welcome_reward", "device_id"),
+		privacyRepo.NewTableStore(bs.DB, "device_user", "device_id"),
+		privacyRepo.NewTableStore(bs.DB, "content_reported", "device_id"),
+		privacyRepo.NewTableStore(bs.DB, "device_content_config", "device_id"),
+		privacyRepo.NewTableStore(bs.DB, "device_content_preferences", "device_id"),
+		privacyRepo.NewTableStore(bs.DB, "content_bookmarks", "device_id"),
+		privacyRepo.NewTableStore(bs.DB, "identity_links", "device_id"),
+		privacyRepo.NewDynamoStore("profile", &profileTable, "profile"),
+		privacyRepo.NewDynamoStore("activity", &activityTable, "ca"),
+		// points are kept for the settlements without the user id of the publisher
+		privacyRepo.NewDynamoStore("point", &pointTable, "v", "udt"),
+		privacyRepo.NewRedisStore(bs.Redis),
+		privacyRepo.NewDeviceStore(bs.DB),
+	}
+	return privacy.NewUseCase(privacyRepo.New(bs.DB), stores)
+}
+
+func (bs *Buzzscreen) initRedirectUseCase(redisCache *rediscache.RedisCache) redirect.UseCase {
+	// 서명 키가 없으면 서명을 발급하지 않고 모든 리다이렉트를 도메인 허용 목록으로 검증한다
+	signatureKey := os.Getenv("REDIRECT_SIGNATURE_KEY")
+	if signatureKey == "" {
+		core.Logger.Warnf("REDIRECT_SIGNATURE_KEY is not set. signed redirects and deep links are rejected")
+	}
+	defaultDomains := redirect.AllowedDomains{"buzzvil.com", "buzzad.io"}
+	if apiURL, err := url.Parse(bs.BuzzScreenAPIURL); err == nil && apiURL.Hostname() != "" {
+		defaultDomains = append(defaultDomains, apiURL.Hostname())
+	}
+
+	rr := redirectRepo.New(bs.DB, redisCache)
+	return redirect.NewUseCase(rr, signatureKey, defaultDomains)
+}
+
 func (bs *Buzzscreen) initReportUseCase() report.UseCase {
 	rr := reportRepo.New(bs.DB, bs.BuzzAdURL)
 	return report.NewUseCase(rr)
@@ -145,6 +299,21 @@ func (bs *Buzzscreen) initSessionUseCase() session.UseCase {
 	return session.NewUseCase()
 }
 
+func (bs *Buzzscreen) initShortLinkUseCase(redisCache *rediscache.RedisCache) shortlink.UseCase {
+	slr := shortLinkRepo.New(redisCache)
+	return shortlink.NewUseCase(slr, bs.BuzzScreenAPIURL)
+}
+
+func (bs *Buzzscreen) initTrackerUseCase() tracker.UseCase {
+	tr := trackerRepo.New(time.Second * 3)
+	return tracker.NewUseCase(tr, tracker.Config{
+		QueueSize:             10000,
+		MaxRetries:            3,
+		RetryInterval:         time.Millisecond * 500,
+		MaxConcurrencyPerHost: 20,
+	}, bs.Metrics.TrackerDeliveries)
+}
+
 func (bs *Buzzscreen) initTrackingDataUseCase() trackingdata.UseCase {
 	return trackingdata.NewUseCase()
 }
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/env"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	contentAdminRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/indexer"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentfeed"
+	contentFeedRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentfeed/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbcontentcampaign"
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+)
+
+// contentfeed polls RSS and Atom feeds of content_feeds and creates curatable content campaigns of the new entries.
+//
+//	contentfeed                   # poll the feeds due once
+//	contentfeed -interval=1m      # keep polling the feeds due every minute
+func main() {
+	interval := flag.Duration("interval", 0, "interval to check the feeds due. feeds are polled once if not set")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of fetching a feed or a linked page")
+	flag.Parse()
+
+	configPath := os.Getenv("GOPATH") + "/src/github.com/Buzzvil/buzzscreen-api/config/"
+	core.NewServer().Init(configPath, &env.Config)
+	env.LoadServerConfig()
+
+	db, err := env.GetDatabase()
+	if err != nil {
+		core.Logger.WithError(err).Fatal("contentfeed - failed to connect database")
+	}
+	defer db.Close()
+
+	idx := indexer.New(dbcontentcampaign.NewSource(db), env.GetElasticsearch(), env.Config.ElasticSearch.CampaignIndexName, 0)
+	adminUseCase := contentadmin.NewUseCase(contentAdminRepo.New(db), idx, contentAdminRepo.NewCache(env.GetRedis()))
+	useCase := contentfeed.NewUseCase(contentFeedRepo.New(db), contentFeedRepo.NewFetcher(*timeout), adminUseCase)
+
+	for {
+		result, err := useCase.PollFeeds(context.Background(), time.Now())
+		if err != nil {
+			core.Logger.WithError(err).Error("contentfeed - failed to poll feeds")
+		} else {
+			core.Logger.Infof("contentfeed - polled %d feeds. created: %d, skipped: %d, failed: %d", result.Polled, result.Created, result.Skipped, result.Failed)
+		}
+
+		if *interval <= 0 {
+			if err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+		time.Sleep(*interval)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/env"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/indexer"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbcontentcampaign"
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+)
+
+// contentindexer indexes content campaigns of MySQL into the elasticsearch alias of ElasticSearch.CampaignIndexName.
+//
+//	contentindexer -watermark-file=/tmp/watermark    # index campaigns updated since the watermark and save the new one
+//	contentindexer -since=2019-01-01T00:00:00Z        # index campaigns updated since the time
+//	contentindexer -reindex                           # index all campaigns into a new index and swap the alias
+func main() {
+	reindex := flag.Bool("reindex", false, "index all campaigns into a new index and swap the alias")
+	since := flag.String("since", "", "index campaigns updated since the time in RFC3339")
+	watermarkFile := flag.String("watermark-file", "", "file keeping the watermark between runs")
+	batchSize := flag.Int("batch-size", 500, "number of campaigns of a bulk request")
+	flag.Parse()
+
+	configPath := os.Getenv("GOPATH") + "/src/github.com/Buzzvil/buzzscreen-api/config/"
+	core.NewServer().Init(configPath, &env.Config)
+	env.LoadServerConfig()
+
+	db, err := env.GetDatabase()
+	if err != nil {
+		core.Logger.WithError(err).Fatal("contentindexer - failed to connect database")
+	}
+	defer db.Close()
+
+	idx := indexer.New(dbcontentcampaign.NewSource(db), env.GetElasticsearch(), env.Config.ElasticSearch.CampaignIndexName, *batchSize)
+	ctx := context.Background()
+
+	var result *indexer.Result
+	if *reindex {
+		result, err = idx.Reindex(ctx)
+	} else {
+		var watermark time.Time
+		watermark, err = loadWatermark(*since, *watermarkFile)
+		if err != nil {
+			core.Logger.WithError(err).Fatal("contentindexer - invalid watermark")
+		}
+		result, err = idx.IndexUpdatedSince(ctx, watermark)
+	}
+	if err != nil {
+		core.Logger.WithError(err).Fatal("contentindexer - failed to index")
+	}
+
+	core.Logger.Infof("contentindexer - indexed %d campaigns into %s. watermark: %v", result.Indexed, result.Index, result.Watermark)
+	if *watermarkFile != "" {
+		if err := ioutil.WriteFile(*watermarkFile, []byte(result.Watermark.Format(time.RFC3339Nano)), 0644); err != nil {
+			core.Logger.WithError(err).Fatal("contentindexer - failed to save watermark")
+		}
+	}
+}
+
+// loadWatermark returns the watermark from since or the file. zero time is returned if there's no watermark
+func loadWatermark(since string, watermarkFile string) (time.Time, error) {
+	if since == "" && watermarkFile != "" {
+		data, err := ioutil.ReadFile(watermarkFile)
+		if err != nil && !os.IsNotExist(err) {
+			return time.Time{}, err
+		}
+		since = strings.TrimSpace(string(data))
+	}
+
+	if since == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, since)
+}
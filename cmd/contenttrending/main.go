@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/env"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
+	appRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/app/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contenttrending"
+	contentTrendingRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contenttrending/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbapp"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscontentcampaign"
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+)
+
+// contenttrending ranks the trending content campaigns of each unit, country and global from the hourly impression and click counts.
+// The ranked lists are read by sort=trending of /api/v3/content/articles.
+//
+//	contenttrending                   # compute once
+//	contenttrending -interval=5m      # keep computing every 5 minutes
+func main() {
+	interval := flag.Duration("interval", 0, "interval to compute the trending lists. they are computed once if not set")
+	flag.Parse()
+
+	configPath := os.Getenv("GOPATH") + "/src/github.com/Buzzvil/buzzscreen-api/config/"
+	core.NewServer().Init(configPath, &env.Config)
+	env.LoadServerConfig()
+
+	db, err := env.GetDatabase()
+	if err != nil {
+		core.Logger.WithError(err).Fatal("contenttrending - failed to connect database")
+	}
+	defer db.Close()
+
+	appUseCase := app.NewUseCase(appRepo.New(dbapp.NewSource(db), rediscache.NewSource(env.InitRedis())))
+	repo := contentTrendingRepo.New(rediscontentcampaign.NewSource(env.GetStatRedis()))
+	useCase := contenttrending.NewUseCase(repo, appUseCase, contenttrending.DefaultPolicy)
+
+	for {
+		result, err := useCase.Compute(context.Background(), time.Now())
+		if err != nil {
+			core.Logger.WithError(err).Error("contenttrending - failed to compute trending lists")
+		} else {
+			core.Logger.Infof("contenttrending - saved %d lists of %d campaigns", result.Scopes, result.Campaigns)
+		}
+
+		if *interval <= 0 {
+			if err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+		time.Sleep(*interval)
+	}
+}
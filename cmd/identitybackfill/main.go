@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/env"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
+	identityGraphRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph/repo"
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+)
+
+// identitybackfill links the ifas and unit device tokens of device_update_history to the identity graph,
+// so that the identifiers a device had before the graph was introduced are resolved as well.
+//
+//	identitybackfill                          # backfill from the first history
+//	identitybackfill -after=123456 -size=500  # resume after the history id
+func main() {
+	after := flag.Int64("after", 0, "history id to resume the backfill after")
+	size := flag.Int("size", 1000, "number of histories to backfill at once")
+	flag.Parse()
+
+	configPath := os.Getenv("GOPATH") + "/src/github.com/Buzzvil/buzzscreen-api/config/"
+	core.NewServer().Init(configPath, &env.Config)
+	env.LoadServerConfig()
+
+	db, err := env.GetDatabase()
+	if err != nil {
+		core.Logger.WithError(err).Fatal("identitybackfill - failed to connect database")
+	}
+	defer db.Close()
+
+	useCase := identitygraph.NewUseCase(identityGraphRepo.New(db), identitygraph.DefaultPolicy)
+
+	histories, links := 0, 0
+	for {
+		result, err := useCase.BackfillHistories(*after, *size)
+		if err != nil {
+			core.Logger.WithError(err).Fatalf("identitybackfill - failed to backfill histories after %d", *after)
+		}
+		if result.Histories == 0 {
+			break
+		}
+
+		histories += result.Histories
+		links += result.Links
+		*after = result.LastHistoryID
+		core.Logger.Infof("identitybackfill - backfilled up to history %d. histories: %d, links: %d", *after, histories, links)
+	}
+	core.Logger.Infof("identitybackfill - done. histories: %d, links: %d", histories, links)
+}
@@ -3,8 +3,10 @@ package clickredirectsvc
 import (
 	"fmt"
 	"net/http"
+	neturl "net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Buzzvil/buzzlib-go/core"
 	"github.com/Buzzvil/buzzlib-go/network"
@@ -14,11 +16,17 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/event"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/landing"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/payload"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/profilerequest"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/redirect"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/reward"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/tracker"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/trackingdata"
 	"github.com/pkg/errors"
 )
@@ -35,6 +43,12 @@ type Controller struct {
 	DeviceUseCase          device.UseCase
 	ProfileRequestUseCase  profilerequest.UseCase
 	EventUseCase           event.UseCase
+	RedirectUseCase        redirect.UseCase
+	LandingUseCase         landing.UseCase
+	TrackerUseCase         tracker.UseCase
+	ShortLinkUseCase       shortlink.UseCase
+	ContentVariantUseCase  contentvariant.UseCase
+	IdentityGraphUseCase   identitygraph.UseCase
 }
 
 // NewController returns new controller and binds requests to the controller
@@ -48,6 +62,12 @@ func NewController(
 	deviceUseCase device.UseCase,
 	eventUseCase event.UseCase,
 	profileRequestUseCase profilerequest.UseCase,
+	redirectUseCase redirect.UseCase,
+	landingUseCase landing.UseCase,
+	trackerUseCase tracker.UseCase,
+	shortLinkUseCase shortlink.UseCase,
+	contentVariantUseCase contentvariant.UseCase,
+	identityGraphUseCase identitygraph.UseCase,
 	buzzAdURL string,
 ) Controller {
 	con := Controller{
@@ -59,13 +79,47 @@ func NewController(
 		DeviceUseCase:          deviceUseCase,
 		EventUseCase:           eventUseCase,
 		ProfileRequestUseCase:  profileRequestUseCase,
+		RedirectUseCase:        redirectUseCase,
+		LandingUseCase:         landingUseCase,
+		TrackerUseCase:         trackerUseCase,
+		ShortLinkUseCase:       shortLinkUseCase,
+		ContentVariantUseCase:  contentVariantUseCase,
+		IdentityGraphUseCase:   identityGraphUseCase,
 		buzzAdURL:              buzzAdURL,
 	}
 	e.GET("api/click_redirect/", con.ClickRedirect)
 	e.GET("api/external/click_redirect/", con.ClickRedirect)
+	e.GET(strings.TrimPrefix(shortlink.Path, "/")+":id", con.ShortClickRedirect)
 	return con
 }
 
+// ShortClickRedirect resolves the short link and serves it the same way as the original click url
+func (con *Controller) ShortClickRedirect(c core.Context) error {
+	// c.QueryParams()는 처음 호출될 때 query를 캐시하므로, RawQuery를 교체한 뒤 ClickRedirect의 Bind가 원래 query를 보지 않도록 직접 파싱한다
+	longURL, err := con.ShortLinkUseCase.Resolve(c.Param("id"), c.Request().URL.Query())
+	if err != nil {
+		switch err.(type) {
+		case shortlink.NotFoundError:
+			return &core.HttpError{Code: http.StatusNotFound, Message: err.Error()}
+		default:
+			return &core.HttpError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+	}
+
+	parsed, err := neturl.Parse(longURL)
+	if err != nil {
+		return &core.HttpError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	switch parsed.Path {
+	case "/api/click_redirect/", "/api/external/click_redirect/":
+		c.Request().URL.RawQuery = parsed.RawQuery
+		return con.ClickRedirect(c)
+	default:
+		return c.Redirect(http.StatusFound, longURL)
+	}
+}
+
 func (con *Controller) initRequest(c core.Context, req interface{}) error {
 	r := req.(*dto.GetClickRedirectRequest)
 
@@ -123,8 +177,12 @@ func (con *Controller) ClickRedirect(c core.Context) error {
 		return &core.HttpError{Code: http.StatusBadRequest, Message: "invalid unit_id"}
 	}
 
+	if err := con.validateRedirectTarget(req, u); err != nil {
+		return &core.HttpError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+
 	if req.ExternalCampaignID != nil && *req.ExternalCampaignID != "" {
-		return c.Redirect(302, req.RedirectURL)
+		return c.Redirect(302, con.resolveLandingURL(req, u, req.RedirectURL))
 	}
 
 	// 할당때만든 payload가 expire되었거나 content_campaign이 expired되었는지 확인, expired되면 리워드를 적립시켜주지않음
@@ -157,12 +215,9 @@ func (con *Controller) ClickRedirect(c core.Context) error {
 		url := con.replaceInternalBAURL(*req.TrackingURL)
 
 		if req.UseRewardAPI {
-			con.saveTrackingURL(req.DeviceID, req.CampaignID, url)
+			con.saveTrackingURL(req.DeviceID, req.CampaignID, url, con.replaceInternalBAURLs(req.FailTrackers))
 		} else {
-			err := con.callTracker(url)
-			if err != nil {
-				core.Logger.Warnf("ClickRedirect() - err %+v", err)
-			}
+			con.dispatchTracker(req, url)
 		}
 	}
 
@@ -181,6 +236,11 @@ func (con *Controller) ClickRedirect(c core.Context) error {
 	cookieHandler := cookiehandler.CookieHandler{Ctx: c}
 	_, cookieID := cookieHandler.SetCookieIDAndChecksum(req.IFA)
 
+	identifiers := []identitygraph.Identifier{identitygraph.CookieIdentifier(cookieID), identitygraph.IFAIdentifier(req.IFA)}
+	if err := con.IdentityGraphUseCase.LinkDevice(req.DeviceID, identifiers, identitygraph.SourceClick, time.Now()); err != nil {
+		core.Logger.Warnf("ClickRedirect() - failed to link identifiers. deviceID: %d, err: %s", req.DeviceID, err)
+	}
+
 	account := profilerequest.Account{
 		IFA:       req.IFA,
 		AccountID: req.DeviceID,
@@ -197,10 +257,58 @@ func (con *Controller) ClickRedirect(c core.Context) error {
 	}
 
 	if req.UseCleanMode && req.RedirectURLClean != nil && *req.RedirectURLClean != "" {
-		return c.Redirect(http.StatusFound, *req.RedirectURLClean)
+		return c.Redirect(http.StatusFound, con.resolveLandingURL(req, u, *req.RedirectURLClean))
+	}
+
+	return c.Redirect(http.StatusFound, con.resolveLandingURL(req, u, redirectURL))
+}
+
+// resolveLandingURL returns the app landing url if the advertiser's app can be opened on the device
+func (con *Controller) resolveLandingURL(req dto.GetClickRedirectRequest, unit *app.Unit, redirectURL string) string {
+	target := landing.Target{
+		DeepLink:      req.DeepLink,
+		UniversalLink: req.UniversalLink,
+		TargetApp:     req.TargetApp,
+		WebURL:        redirectURL,
+	}
+	if !target.HasAppLink() {
+		return redirectURL
+	}
+
+	var installedPackages *string
+	if profile, err := con.DeviceUseCase.GetProfile(req.DeviceID); err != nil {
+		core.Logger.Warnf("ClickRedirect() - failed to get profile. deviceID: %d, err: %s", req.DeviceID, err)
+	} else if profile != nil {
+		installedPackages = profile.InstalledPackages
 	}
 
-	return c.Redirect(http.StatusFound, redirectURL)
+	resolved := con.LandingUseCase.ResolveLanding(target, landing.NewDevice(unit.Platform, installedPackages))
+	if resolved.URL == "" {
+		return redirectURL
+	}
+	return resolved.URL
+}
+
+// validateRedirectTarget rejects redirect urls that are neither signed at allocation nor allowed for the unit's organization
+func (con *Controller) validateRedirectTarget(req dto.GetClickRedirectRequest, unit *app.Unit) error {
+	target := redirect.Target{
+		CampaignID:    req.CampaignID,
+		UnitID:        req.UnitID,
+		URL:           req.RedirectURL,
+		DeepLink:      req.DeepLink,
+		UniversalLink: req.UniversalLink,
+		TargetApp:     req.TargetApp,
+		FailTrackers:  req.FailTrackers,
+	}
+	if req.RedirectURLClean != nil {
+		target.CleanURL = *req.RedirectURLClean
+	}
+
+	err := con.RedirectUseCase.ValidateTarget(target, req.RedirectSignature, unit.OrganizationID)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("ClickRedirect() - redirect target is rejected. unitID: %d, deviceID: %d, campaignID: %d, ip: %s, redirectURL: %s", req.UnitID, req.DeviceID, req.CampaignID, utils.GetClientIP(req.Request), req.RedirectURL)
+	}
+	return err
 }
 
 // TODO move it to ad domain
@@ -261,6 +369,11 @@ func (con *Controller) increaseClick(req dto.GetClickRedirectRequest, unit *app.
 
 		if !req.IsFalseClick && !isDuplicatedClick {
 			con.ContentCampaignUseCase.IncreaseClick(req.CampaignID, req.UnitID)
+			if campaignPayload != nil && campaignPayload.VariantID > 0 {
+				if err := con.ContentVariantUseCase.RecordClick(req.CampaignID, campaignPayload.VariantID); err != nil {
+					core.Logger.WithError(err).Warnf("increaseClick() - failed to record the click of variant %v", campaignPayload.VariantID)
+				}
+			}
 			con.logClick(req, unit, campaignPayload)
 			con.DeviceUseCase.SaveActivity(req.DeviceID, req.CampaignID, device.ActivityClick)
 		}
@@ -270,10 +383,10 @@ func (con *Controller) increaseClick(req dto.GetClickRedirectRequest, unit *app.
 }
 
 func (con *Controller) logClick(req dto.GetClickRedirectRequest, unit *app.Unit, campaignPayload *payload.Payload) {
-	modelArtifact := ""
+	modelArtifact, exploration := "", ""
 	trackingData, err := con.TrackingDataUseCase.ParseTrackingData(req.TrackingDataStr)
 	if err == nil {
-		modelArtifact = trackingData.ModelArtifact
+		modelArtifact, exploration = trackingData.ModelArtifact, trackingData.Exploration
 	}
 
 	mapForLog := map[string]interface{}{
@@ -290,6 +403,10 @@ func (con *Controller) logClick(req dto.GetClickRedirectRequest, unit *app.Unit,
 		"message":           "click",
 	}
 
+	if exploration != "" {
+		mapForLog["exploration"] = exploration
+	}
+
 	if campaignPayload != nil {
 		if campaignPayload.Gender != nil {
 			mapForLog["sex"] = *campaignPayload.Gender
@@ -300,6 +417,9 @@ func (con *Controller) logClick(req dto.GetClickRedirectRequest, unit *app.Unit,
 		if campaignPayload.Country != "" {
 			mapForLog["country"] = campaignPayload.Country
 		}
+		if campaignPayload.VariantID > 0 {
+			mapForLog["variant_id"] = campaignPayload.VariantID
+		}
 	}
 
 	core.Loggers["click"].WithFields(mapForLog).Info("Log")
@@ -348,22 +468,20 @@ func (con *Controller) validateAndGetRewardReq(req dto.GetClickRedirectRequest)
 	return nil, errors.New("failed to ValidateRequest. checksum is invalid")
 }
 
-func (con *Controller) callTracker(url string) error {
-	core.Logger.Infof("callTracker() url %v", url)
-	res, err := (&network.Request{
-		URL:    url,
-		Method: http.MethodGet,
-	}).MakeRequest()
-	if res != nil && res.Body != nil {
-		defer res.Body.Close()
-	}
-
+func (con *Controller) dispatchTracker(req dto.GetClickRedirectRequest, url string) {
+	err := con.TrackerUseCase.Dispatch(tracker.Request{
+		URLs:     []string{url},
+		FailURLs: con.replaceInternalBAURLs(req.FailTrackers),
+		Macros: tracker.Macros{
+			"{ifa}":          req.IFA,
+			"__ifa__":        req.IFA,
+			"__position__":   req.Position,
+			"__session_id__": req.SessionID,
+		},
+	})
 	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("buzzad tracker api call with url %+v has error : ", url))
-	} else if res.StatusCode/100 != 2 {
-		return fmt.Errorf("buzzad tracker api call with url %+v has status code : %+v", url, res.StatusCode)
+		core.Logger.Warnf("ClickRedirect() - err %+v", err)
 	}
-	return nil
 }
 
 func (con *Controller) replaceInternalBAURL(url string) string {
@@ -373,7 +491,19 @@ func (con *Controller) replaceInternalBAURL(url string) string {
 	return strings.Replace(url, "https://ad.buzzvil.com", con.buzzAdURL, -1)
 }
 
-func (con *Controller) saveTrackingURL(deviceID int64, campaignID int64, trackingURL string) {
+func (con *Controller) replaceInternalBAURLs(urls []string) []string {
+	if len(urls) == 0 {
+		return nil
+	}
+
+	replaced := make([]string, 0, len(urls))
+	for _, url := range urls {
+		replaced = append(replaced, con.replaceInternalBAURL(url))
+	}
+	return replaced
+}
+
+func (con *Controller) saveTrackingURL(deviceID int64, campaignID int64, trackingURL string, failTrackers []string) {
 	// 컨텐츠는 BA tracking URL을 가지지 않음
 	if campaignID < dto.BuzzAdCampaignIDOffset {
 		return
@@ -384,4 +514,7 @@ func (con *Controller) saveTrackingURL(deviceID int64, campaignID int64, trackin
 		Type: event.ResourceTypeAd,
 	}
 	con.EventUseCase.SaveTrackingURL(deviceID, r, trackingURL)
+	if len(failTrackers) > 0 {
+		con.EventUseCase.SaveFailTrackers(deviceID, r, failTrackers)
+	}
 }
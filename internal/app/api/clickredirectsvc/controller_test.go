@@ -8,9 +8,11 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Buzzvil/buzzlib-go/core"
 	"github.com/Buzzvil/buzzlib-go/header"
@@ -19,11 +21,17 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/clickredirectsvc/dto"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/event"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/landing"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/payload"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/profilerequest"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/redirect"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/reward"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/tracker"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/trackingdata"
 	"github.com/Buzzvil/buzzscreen-api/tests"
 	gotestmock "github.com/Buzzvil/go-test/mock"
@@ -53,6 +61,7 @@ func (ts *ControllerTestSuite) Test_ClickRedirect() {
 
 	unit := ts.createUnit(structReq.UnitID)
 	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.AnythingOfType("redirect.Target"), "", unit.OrganizationID).Return(nil).Once()
 
 	ts.contentCampaignUseCase.On("IncreaseClick", structReq.CampaignID, structReq.UnitID).Return(nil).Once()
 	ts.deviceUseCase.On("SaveActivity", structReq.DeviceID, structReq.CampaignID, device.ActivityClick).Return(nil).Once()
@@ -60,11 +69,52 @@ func (ts *ControllerTestSuite) Test_ClickRedirect() {
 	payloadStruct := &payload.Payload{}
 	err := faker.FakeData(&payloadStruct)
 	ts.NoError(err)
+	payloadStruct.VariantID = 0
 	ts.payloadUseCase.On("ParsePayload", mock.AnythingOfType("string")).Return(payloadStruct, nil).Once()
 	ts.payloadUseCase.On("IsPayloadExpired", mock.AnythingOfType("*payload.Payload")).Return(false).Once()
 	ts.trackingDataUseCase.On("ParseTrackingData", structReq.TrackingDataStr).Return(&trackingdata.TrackingData{ModelArtifact: "v1"}, nil).Once()
 	ts.deviceUseCase.On("ValidateUnitDeviceToken", structReq.GetUDT()).Return(true, nil).Once()
 	ts.profileRequestUseCase.On("PopulateProfile", mock.AnythingOfType("profilerequest.Account")).Return(nil).Once()
+	ts.identityGraphUseCase.On("LinkDevice", structReq.DeviceID, mock.AnythingOfType("[]identitygraph.Identifier"), identitygraph.SourceClick, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	err = ts.controller.ClickRedirect(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusFound, rec.Code)
+}
+
+func (ts *ControllerTestSuite) Test_ClickRedirect_Variant() {
+	clientPatcher := ts.getBuzzAdMock(nil)
+	defer clientPatcher.RemovePatch()
+	core.Loggers["click"] = logrus.New()
+
+	structReq := ts.buildBaseRequest()
+	/* overwrite request parameters */
+	/* overwrite request parameters end */
+	networkReq := ts.buildNetworkRequest(structReq)
+	ctx, rec := ts.buildContextAndRecorder(networkReq.GetHTTPRequest())
+
+	ts.rewardUseCase.On("ValidateRequest", mock.AnythingOfType("reward.RequestIngredients")).Return(nil).Once()
+	ts.rewardUseCase.On("GiveReward", mock.AnythingOfType("reward.RequestIngredients")).Return(structReq.Reward, nil).Once()
+
+	unit := ts.createUnit(structReq.UnitID)
+	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.AnythingOfType("redirect.Target"), "", unit.OrganizationID).Return(nil).Once()
+
+	ts.contentCampaignUseCase.On("IncreaseClick", structReq.CampaignID, structReq.UnitID).Return(nil).Once()
+	ts.deviceUseCase.On("SaveActivity", structReq.DeviceID, structReq.CampaignID, device.ActivityClick).Return(nil).Once()
+
+	payloadStruct := &payload.Payload{}
+	err := faker.FakeData(&payloadStruct)
+	ts.NoError(err)
+	payloadStruct.VariantID = 7
+	ts.contentVariantUseCase.On("RecordClick", structReq.CampaignID, int64(7)).Return(nil).Once()
+	ts.payloadUseCase.On("ParsePayload", mock.AnythingOfType("string")).Return(payloadStruct, nil).Once()
+	ts.payloadUseCase.On("IsPayloadExpired", mock.AnythingOfType("*payload.Payload")).Return(false).Once()
+	ts.trackingDataUseCase.On("ParseTrackingData", structReq.TrackingDataStr).Return(&trackingdata.TrackingData{ModelArtifact: "v1"}, nil).Once()
+	ts.deviceUseCase.On("ValidateUnitDeviceToken", structReq.GetUDT()).Return(true, nil).Once()
+	ts.profileRequestUseCase.On("PopulateProfile", mock.AnythingOfType("profilerequest.Account")).Return(nil).Once()
+	ts.identityGraphUseCase.On("LinkDevice", structReq.DeviceID, mock.AnythingOfType("[]identitygraph.Identifier"), identitygraph.SourceClick, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 	err = ts.controller.ClickRedirect(ctx)
 
@@ -89,6 +139,7 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_NoPayload() {
 
 	unit := ts.createUnit(structReq.UnitID)
 	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.AnythingOfType("redirect.Target"), "", unit.OrganizationID).Return(nil).Once()
 
 	contentCampaign := &contentcampaign.ContentCampaign{ID: structReq.CampaignID}
 	ts.contentCampaignUseCase.On("GetContentCampaignByID", structReq.CampaignID).Return(contentCampaign, nil).Once()
@@ -97,10 +148,12 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_NoPayload() {
 	ts.deviceUseCase.On("SaveActivity", structReq.DeviceID, structReq.CampaignID, device.ActivityClick).Return(nil).Once()
 	ts.deviceUseCase.On("ValidateUnitDeviceToken", structReq.GetUDT()).Return(true, nil).Once()
 	ts.profileRequestUseCase.On("PopulateProfile", mock.AnythingOfType("profilerequest.Account")).Return(nil).Once()
+	ts.identityGraphUseCase.On("LinkDevice", structReq.DeviceID, mock.AnythingOfType("[]identitygraph.Identifier"), identitygraph.SourceClick, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 	payloadStruct := &payload.Payload{}
 	err := faker.FakeData(&payloadStruct)
 	ts.NoError(err)
+	payloadStruct.VariantID = 0
 	ts.payloadUseCase.On("ParsePayload", structReq.PayloadStr).Return((*payload.Payload)(nil), errors.New("invalid payload")).Once()
 	ts.trackingDataUseCase.On("ParseTrackingData", structReq.TrackingDataStr).Return(&trackingdata.TrackingData{ModelArtifact: "v1"}, nil).Once()
 
@@ -126,6 +179,7 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_Expired() {
 
 	unit := ts.createUnit(structReq.UnitID)
 	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.AnythingOfType("redirect.Target"), "", unit.OrganizationID).Return(nil).Once()
 
 	ts.contentCampaignUseCase.On("IncreaseClick", structReq.CampaignID, structReq.UnitID).Return(nil).Once()
 	ts.deviceUseCase.On("SaveActivity", structReq.DeviceID, structReq.CampaignID, device.ActivityClick).Return(nil).Once()
@@ -133,10 +187,12 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_Expired() {
 	payloadStruct := &payload.Payload{}
 	err := faker.FakeData(&payloadStruct)
 	ts.NoError(err)
+	payloadStruct.VariantID = 0
 	ts.payloadUseCase.On("ParsePayload", mock.AnythingOfType("string")).Return(payloadStruct, nil).Once()
 	ts.payloadUseCase.On("IsPayloadExpired", mock.AnythingOfType("*payload.Payload")).Return(true).Once()
 	ts.trackingDataUseCase.On("ParseTrackingData", structReq.TrackingDataStr).Return(&trackingdata.TrackingData{ModelArtifact: "v1"}, nil).Once()
 	ts.profileRequestUseCase.On("PopulateProfile", mock.AnythingOfType("profilerequest.Account")).Return(nil).Once()
+	ts.identityGraphUseCase.On("LinkDevice", structReq.DeviceID, mock.AnythingOfType("[]identitygraph.Identifier"), identitygraph.SourceClick, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 	err = ts.controller.ClickRedirect(ctx)
 
@@ -161,6 +217,7 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_NoReward() {
 
 	unit := ts.createUnit(structReq.UnitID)
 	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.AnythingOfType("redirect.Target"), "", unit.OrganizationID).Return(nil).Once()
 
 	ts.contentCampaignUseCase.On("IncreaseClick", structReq.CampaignID, structReq.UnitID).Return(nil).Once()
 	ts.deviceUseCase.On("SaveActivity", structReq.DeviceID, structReq.CampaignID, device.ActivityClick).Return(nil).Once()
@@ -168,10 +225,12 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_NoReward() {
 	payloadStruct := &payload.Payload{}
 	err := faker.FakeData(&payloadStruct)
 	ts.NoError(err)
+	payloadStruct.VariantID = 0
 	ts.payloadUseCase.On("ParsePayload", mock.AnythingOfType("string")).Return(payloadStruct, nil).Once()
 	ts.payloadUseCase.On("IsPayloadExpired", mock.AnythingOfType("*payload.Payload")).Return(false).Once()
 	ts.trackingDataUseCase.On("ParseTrackingData", structReq.TrackingDataStr).Return(&trackingdata.TrackingData{ModelArtifact: "v1"}, nil).Once()
 	ts.profileRequestUseCase.On("PopulateProfile", mock.AnythingOfType("profilerequest.Account")).Return(nil).Once()
+	ts.identityGraphUseCase.On("LinkDevice", structReq.DeviceID, mock.AnythingOfType("[]identitygraph.Identifier"), identitygraph.SourceClick, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 	err = ts.controller.ClickRedirect(ctx)
 
@@ -203,8 +262,7 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_DeactivatedUnit() {
 }
 
 func (ts *ControllerTestSuite) Test_ClickRedirect_Tracker() {
-	trackEventCalled := false
-	clientPatcher := ts.getBuzzAdMock(&trackEventCalled)
+	clientPatcher := ts.getBuzzAdMock(nil)
 	defer clientPatcher.RemovePatch()
 	core.Loggers["click"] = logrus.New()
 
@@ -214,6 +272,7 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_Tracker() {
 	structReq.BaseReward = 0
 	trackingURL := "https://ad.buzzvil.com/api/track_event?token=DC-2AG_LBRrgc_ttbRtyZJtL1ZkIR1EhQ1lGT815HVgOinjI_prlunpLEI-IW0xNW7bkdeKaCJrYrmqmbcpr3Bph01Y89cwt-YNa-znz-5K2Uu1BXwXCepF-e9dgf-0QSU3ICpRR-1JOUGb1KwkI-05BBX8a4MNz2HNqgE5ccgz4awhJNbJRsbf0dJxD_SwSlRBIpA9B7TvrT_Eq9kdjn7-qlWZtarlNI_HU5BvEpC-OpsWKNdvKIXjKii8RfQ9o8aFelPnRnshP1T3hsbYw1gussGw4t0m9ViuQfK8wCT2SjcriBtaP4mYHvCJuZWyi"
 	structReq.TrackingURL = &trackingURL
+	structReq.FailTrackers = []string{"https://ad.buzzvil.com/api/fail_tracker?ifa=__ifa__"}
 	/* overwrite request parameters end */
 	networkReq := ts.buildNetworkRequest(structReq)
 	ctx, rec := ts.buildContextAndRecorder(networkReq.GetHTTPRequest())
@@ -222,6 +281,9 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_Tracker() {
 
 	unit := ts.createUnit(structReq.UnitID)
 	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.MatchedBy(func(target redirect.Target) bool {
+		return reflect.DeepEqual(target.FailTrackers, structReq.FailTrackers)
+	}), "", unit.OrganizationID).Return(nil).Once()
 
 	contentCampaign := &contentcampaign.ContentCampaign{ID: structReq.CampaignID}
 	ts.contentCampaignUseCase.On("GetContentCampaignByID", structReq.CampaignID).Return(contentCampaign, nil).Once()
@@ -230,21 +292,24 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_Tracker() {
 	ts.deviceUseCase.On("SaveActivity", structReq.DeviceID, structReq.CampaignID, device.ActivityClick).Return(nil).Once()
 	ts.deviceUseCase.On("ValidateUnitDeviceToken", structReq.GetUDT()).Return(true, nil).Once()
 	ts.profileRequestUseCase.On("PopulateProfile", mock.AnythingOfType("profilerequest.Account")).Return(nil).Once()
+	ts.identityGraphUseCase.On("LinkDevice", structReq.DeviceID, mock.AnythingOfType("[]identitygraph.Identifier"), identitygraph.SourceClick, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 	payloadStruct := &payload.Payload{}
 	err := faker.FakeData(&payloadStruct)
 	ts.NoError(err)
+	payloadStruct.VariantID = 0
 	ts.payloadUseCase.On("ParsePayload", structReq.PayloadStr).Return((*payload.Payload)(nil), errors.New("invalid payload")).Once()
 	ts.trackingDataUseCase.On("ParseTrackingData", structReq.TrackingDataStr).Return(&trackingdata.TrackingData{ModelArtifact: "v1"}, nil).Once()
 
+	ts.trackerUseCase.On("Dispatch", mock.MatchedBy(func(req tracker.Request) bool {
+		return len(req.URLs) == 1 && req.URLs[0] == ts.replaceInternalBAURL(trackingURL) && req.Macros["__ifa__"] == structReq.IFA &&
+			len(req.FailURLs) == 1 && req.FailURLs[0] == ts.replaceInternalBAURL(structReq.FailTrackers[0])
+	})).Return(nil).Once()
+
 	err = ts.controller.ClickRedirect(ctx)
 
 	ts.NoError(err)
 	ts.Equal(http.StatusFound, rec.Code)
-
-	/* case specific flag */
-	ts.True(trackEventCalled)
-	/* case specific flag end */
 }
 
 func (ts *ControllerTestSuite) Test_ClickRedirect_SaveTrackURL() {
@@ -260,6 +325,7 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_SaveTrackURL() {
 	trackingURL := "https://ad.buzzvil.com/api/track_event?token=DC-2AG_LBRrgc_ttbRtyZJtL1ZkIR1EhQ1lGT815HVgOinjI_prlunpLEI-IW0xNW7bkdeKaCJrYrmqmbcpr3Bph01Y89cwt-YNa-znz-5K2Uu1BXwXCepF-e9dgf-0QSU3ICpRR-1JOUGb1KwkI-05BBX8a4MNz2HNqgE5ccgz4awhJNbJRsbf0dJxD_SwSlRBIpA9B7TvrT_Eq9kdjn7-qlWZtarlNI_HU5BvEpC-OpsWKNdvKIXjKii8RfQ9o8aFelPnRnshP1T3hsbYw1gussGw4t0m9ViuQfK8wCT2SjcriBtaP4mYHvCJuZWyi"
 	structReq.TrackingURL = &trackingURL
 	structReq.UseRewardAPI = true
+	structReq.FailTrackers = []string{"https://ad.buzzvil.com/api/fail_tracker?ifa=__ifa__"}
 	/* overwrite request parameters end */
 	networkReq := ts.buildNetworkRequest(structReq)
 	ctx, rec := ts.buildContextAndRecorder(networkReq.GetHTTPRequest())
@@ -268,6 +334,9 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_SaveTrackURL() {
 
 	unit := ts.createUnit(structReq.UnitID)
 	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.MatchedBy(func(target redirect.Target) bool {
+		return reflect.DeepEqual(target.FailTrackers, structReq.FailTrackers)
+	}), "", unit.OrganizationID).Return(nil).Once()
 
 	contentCampaign := &contentcampaign.ContentCampaign{ID: structReq.CampaignID}
 	ts.contentCampaignUseCase.On("GetContentCampaignByID", structReq.CampaignID).Return(contentCampaign, nil).Once()
@@ -276,13 +345,16 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_SaveTrackURL() {
 	ts.deviceUseCase.On("SaveActivity", structReq.DeviceID, structReq.CampaignID, device.ActivityClick).Return(nil).Once()
 	ts.deviceUseCase.On("ValidateUnitDeviceToken", structReq.GetUDT()).Return(true, nil).Once()
 	ts.eventUseCase.On("SaveTrackingURL", structReq.DeviceID, ts.buildResource(structReq.CampaignID), ts.replaceInternalBAURL(trackingURL)).Once()
+	ts.eventUseCase.On("SaveFailTrackers", structReq.DeviceID, ts.buildResource(structReq.CampaignID), []string{ts.replaceInternalBAURL(structReq.FailTrackers[0])}).Once()
 
 	payloadStruct := &payload.Payload{}
 	err := faker.FakeData(&payloadStruct)
 	ts.NoError(err)
+	payloadStruct.VariantID = 0
 	ts.payloadUseCase.On("ParsePayload", structReq.PayloadStr).Return((*payload.Payload)(nil), errors.New("invalid payload")).Once()
 	ts.trackingDataUseCase.On("ParseTrackingData", structReq.TrackingDataStr).Return(&trackingdata.TrackingData{ModelArtifact: "v1"}, nil).Once()
 	ts.profileRequestUseCase.On("PopulateProfile", mock.AnythingOfType("profilerequest.Account")).Return(nil).Once()
+	ts.identityGraphUseCase.On("LinkDevice", structReq.DeviceID, mock.AnythingOfType("[]identitygraph.Identifier"), identitygraph.SourceClick, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
 	err = ts.controller.ClickRedirect(ctx)
 
@@ -293,6 +365,238 @@ func (ts *ControllerTestSuite) Test_ClickRedirect_SaveTrackURL() {
 	/* case specific flag end */
 }
 
+func (ts *ControllerTestSuite) Test_ClickRedirect_AppLanding() {
+	clientPatcher := ts.getBuzzAdMock(nil)
+	defer clientPatcher.RemovePatch()
+	core.Loggers["click"] = logrus.New()
+
+	structReq := ts.buildBaseRequest()
+	/* overwrite request parameters */
+	structReq.RedirectURL = "https://www.example.com/article/1"
+	structReq.DeepLink = "myapp://article/1"
+	structReq.TargetApp = "com.example.myapp"
+	/* overwrite request parameters end */
+	networkReq := ts.buildNetworkRequest(structReq)
+	ctx, rec := ts.buildContextAndRecorder(networkReq.GetHTTPRequest())
+
+	ts.rewardUseCase.On("ValidateRequest", mock.AnythingOfType("reward.RequestIngredients")).Return(nil).Once()
+	ts.rewardUseCase.On("GiveReward", mock.AnythingOfType("reward.RequestIngredients")).Return(structReq.Reward, nil).Once()
+
+	unit := ts.createUnit(structReq.UnitID)
+	unit.Platform = app.PlatformAndroid
+	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.AnythingOfType("redirect.Target"), "", unit.OrganizationID).Return(nil).Once()
+
+	ts.contentCampaignUseCase.On("IncreaseClick", structReq.CampaignID, structReq.UnitID).Return(nil).Once()
+	ts.deviceUseCase.On("SaveActivity", structReq.DeviceID, structReq.CampaignID, device.ActivityClick).Return(nil).Once()
+
+	payloadStruct := &payload.Payload{}
+	err := faker.FakeData(&payloadStruct)
+	ts.NoError(err)
+	payloadStruct.VariantID = 0
+	ts.payloadUseCase.On("ParsePayload", mock.AnythingOfType("string")).Return(payloadStruct, nil).Once()
+	ts.payloadUseCase.On("IsPayloadExpired", mock.AnythingOfType("*payload.Payload")).Return(false).Once()
+	ts.trackingDataUseCase.On("ParseTrackingData", structReq.TrackingDataStr).Return(&trackingdata.TrackingData{ModelArtifact: "v1"}, nil).Once()
+	ts.deviceUseCase.On("ValidateUnitDeviceToken", structReq.GetUDT()).Return(true, nil).Once()
+	ts.profileRequestUseCase.On("PopulateProfile", mock.AnythingOfType("profilerequest.Account")).Return(nil).Once()
+	ts.identityGraphUseCase.On("LinkDevice", structReq.DeviceID, mock.AnythingOfType("[]identitygraph.Identifier"), identitygraph.SourceClick, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	installedPackages := "com.example.myapp"
+	ts.deviceUseCase.On("GetProfile", structReq.DeviceID).Return(&device.Profile{InstalledPackages: &installedPackages}, nil).Once()
+	target := landing.Target{DeepLink: structReq.DeepLink, TargetApp: structReq.TargetApp, WebURL: structReq.RedirectURL}
+	intentURI := "intent://article/1#Intent;scheme=myapp;package=com.example.myapp;end"
+	ts.landingUseCase.On("ResolveLanding", target, landing.NewDevice(app.PlatformAndroid, &installedPackages)).Return(landing.Landing{Type: landing.TypeIntent, URL: intentURI}).Once()
+
+	err = ts.controller.ClickRedirect(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusFound, rec.Code)
+	ts.Equal(intentURI, rec.Header().Get("Location"))
+}
+
+func (ts *ControllerTestSuite) Test_ClickRedirect_AppLanding_CleanMode() {
+	clientPatcher := ts.getBuzzAdMock(nil)
+	defer clientPatcher.RemovePatch()
+	core.Loggers["click"] = logrus.New()
+
+	structReq := ts.buildBaseRequest()
+	/* overwrite request parameters */
+	cleanURL := "https://www.example.com/article/1/clean"
+	structReq.RedirectURL = "https://www.example.com/article/1"
+	structReq.RedirectURLClean = &cleanURL
+	structReq.UseCleanMode = true
+	structReq.DeepLink = "myapp://article/1"
+	structReq.TargetApp = "com.example.myapp"
+	/* overwrite request parameters end */
+	networkReq := ts.buildNetworkRequest(structReq)
+	ctx, rec := ts.buildContextAndRecorder(networkReq.GetHTTPRequest())
+
+	ts.rewardUseCase.On("ValidateRequest", mock.AnythingOfType("reward.RequestIngredients")).Return(nil).Once()
+	ts.rewardUseCase.On("GiveReward", mock.AnythingOfType("reward.RequestIngredients")).Return(structReq.Reward, nil).Once()
+
+	unit := ts.createUnit(structReq.UnitID)
+	unit.Platform = app.PlatformAndroid
+	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.AnythingOfType("redirect.Target"), "", unit.OrganizationID).Return(nil).Once()
+
+	ts.contentCampaignUseCase.On("IncreaseClick", structReq.CampaignID, structReq.UnitID).Return(nil).Once()
+	ts.deviceUseCase.On("SaveActivity", structReq.DeviceID, structReq.CampaignID, device.ActivityClick).Return(nil).Once()
+
+	payloadStruct := &payload.Payload{}
+	err := faker.FakeData(&payloadStruct)
+	ts.NoError(err)
+	payloadStruct.VariantID = 0
+	ts.payloadUseCase.On("ParsePayload", mock.AnythingOfType("string")).Return(payloadStruct, nil).Once()
+	ts.payloadUseCase.On("IsPayloadExpired", mock.AnythingOfType("*payload.Payload")).Return(false).Once()
+	ts.trackingDataUseCase.On("ParseTrackingData", structReq.TrackingDataStr).Return(&trackingdata.TrackingData{ModelArtifact: "v1"}, nil).Once()
+	ts.deviceUseCase.On("ValidateUnitDeviceToken", structReq.GetUDT()).Return(true, nil).Once()
+	ts.profileRequestUseCase.On("PopulateProfile", mock.AnythingOfType("profilerequest.Account")).Return(nil).Once()
+	ts.identityGraphUseCase.On("LinkDevice", structReq.DeviceID, mock.AnythingOfType("[]identitygraph.Identifier"), identitygraph.SourceClick, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	installedPackages := "com.example.myapp"
+	ts.deviceUseCase.On("GetProfile", structReq.DeviceID).Return(&device.Profile{InstalledPackages: &installedPackages}, nil).Once()
+	target := landing.Target{DeepLink: structReq.DeepLink, TargetApp: structReq.TargetApp, WebURL: cleanURL}
+	intentURI := "intent://article/1#Intent;scheme=myapp;package=com.example.myapp;end"
+	ts.landingUseCase.On("ResolveLanding", target, landing.NewDevice(app.PlatformAndroid, &installedPackages)).Return(landing.Landing{Type: landing.TypeIntent, URL: intentURI}).Once()
+
+	err = ts.controller.ClickRedirect(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusFound, rec.Code)
+	ts.Equal(intentURI, rec.Header().Get("Location"))
+}
+
+func (ts *ControllerTestSuite) Test_ClickRedirect_AppLanding_ExternalCampaign() {
+	core.Loggers["click"] = logrus.New()
+
+	structReq := ts.buildBaseRequest()
+	/* overwrite request parameters */
+	externalCampaignID := "EXTERNAL_CAMPAIGN_ID"
+	structReq.ExternalCampaignID = &externalCampaignID
+	structReq.RedirectURL = "https://www.example.com/article/1"
+	structReq.DeepLink = "myapp://article/1"
+	structReq.TargetApp = "com.example.myapp"
+	/* overwrite request parameters end */
+	networkReq := ts.buildNetworkRequest(structReq)
+	ctx, rec := ts.buildContextAndRecorder(networkReq.GetHTTPRequest())
+
+	ts.rewardUseCase.On("ValidateRequest", mock.AnythingOfType("reward.RequestIngredients")).Return(nil).Once()
+	ts.deviceUseCase.On("ValidateUnitDeviceToken", structReq.GetUDT()).Return(true, nil).Once()
+
+	unit := ts.createUnit(structReq.UnitID)
+	unit.Platform = app.PlatformAndroid
+	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.AnythingOfType("redirect.Target"), "", unit.OrganizationID).Return(nil).Once()
+
+	ts.deviceUseCase.On("GetProfile", structReq.DeviceID).Return(&device.Profile{}, nil).Once()
+	target := landing.Target{DeepLink: structReq.DeepLink, TargetApp: structReq.TargetApp, WebURL: structReq.RedirectURL}
+	ts.landingUseCase.On("ResolveLanding", target, landing.NewDevice(app.PlatformAndroid, nil)).Return(landing.Landing{Type: landing.TypeWeb, URL: structReq.RedirectURL}).Once()
+
+	err := ts.controller.ClickRedirect(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusFound, rec.Code)
+	ts.Equal(structReq.RedirectURL, rec.Header().Get("Location"))
+}
+
+func (ts *ControllerTestSuite) Test_ClickRedirect_InvalidRedirectTarget() {
+	core.Loggers["click"] = logrus.New()
+
+	structReq := ts.buildBaseRequest()
+	/* overwrite request parameters */
+	externalCampaignID := "EXTERNAL_CAMPAIGN_ID"
+	structReq.ExternalCampaignID = &externalCampaignID
+	structReq.RedirectURL = "https://evil.example.com"
+	/* overwrite request parameters end */
+	networkReq := ts.buildNetworkRequest(structReq)
+	ctx, rec := ts.buildContextAndRecorder(networkReq.GetHTTPRequest())
+
+	ts.rewardUseCase.On("ValidateRequest", mock.AnythingOfType("reward.RequestIngredients")).Return(nil).Once()
+	ts.deviceUseCase.On("ValidateUnitDeviceToken", structReq.GetUDT()).Return(true, nil).Once()
+
+	unit := ts.createUnit(structReq.UnitID)
+	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	target := redirect.Target{CampaignID: structReq.CampaignID, UnitID: structReq.UnitID, URL: structReq.RedirectURL}
+	ts.redirectUseCase.On("ValidateTarget", target, "", unit.OrganizationID).Return(redirect.InvalidTargetError{URL: structReq.RedirectURL}).Once()
+
+	err := ts.controller.ClickRedirect(ctx)
+
+	ts.Error(err)
+	ts.NotEqual(http.StatusFound, rec.Code)
+}
+
+func (ts *ControllerTestSuite) Test_ShortClickRedirect_NotFound() {
+	httpRequest := httptest.NewRequest(http.MethodGet, "/c/abcdefghijklmnop", nil)
+	ctx, _ := ts.buildContextAndRecorder(httpRequest)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("abcdefghijklmnop")
+
+	ts.shortLinkUseCase.On("Resolve", "abcdefghijklmnop", mock.Anything).Return("", shortlink.NotFoundError{ID: "abcdefghijklmnop"}).Once()
+
+	err := ts.controller.ShortClickRedirect(ctx)
+
+	ts.Equal(http.StatusNotFound, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) Test_ShortClickRedirect_ExternalURL() {
+	httpRequest := httptest.NewRequest(http.MethodGet, "/c/abcdefghijklmnop", nil)
+	ctx, rec := ts.buildContextAndRecorder(httpRequest)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("abcdefghijklmnop")
+
+	ts.shortLinkUseCase.On("Resolve", "abcdefghijklmnop", mock.Anything).Return("https://www.buzzvil.com/", nil).Once()
+
+	err := ts.controller.ShortClickRedirect(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusFound, rec.Code)
+	ts.Equal("https://www.buzzvil.com/", rec.Header().Get("Location"))
+}
+
+func (ts *ControllerTestSuite) Test_ShortClickRedirect_ClickRedirect() {
+	clientPatcher := ts.getBuzzAdMock(nil)
+	defer clientPatcher.RemovePatch()
+	core.Loggers["click"] = logrus.New()
+
+	structReq := ts.buildBaseRequest()
+	longReq := ts.buildNetworkRequest(structReq).GetHTTPRequest()
+	longURL := "https://screen.buzzvil.com" + longReq.URL.RequestURI()
+
+	httpRequest := httptest.NewRequest(http.MethodGet, "/c/abcdefghijklmnop?slot=1", nil)
+	httpRequest.Header = longReq.Header
+	ctx, rec := ts.buildContextAndRecorder(httpRequest)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("abcdefghijklmnop")
+
+	ts.shortLinkUseCase.On("Resolve", "abcdefghijklmnop", url.Values{"slot": {"1"}}).Return(longURL, nil).Once()
+
+	ts.rewardUseCase.On("ValidateRequest", mock.AnythingOfType("reward.RequestIngredients")).Return(nil).Once()
+	ts.rewardUseCase.On("GiveReward", mock.AnythingOfType("reward.RequestIngredients")).Return(structReq.Reward, nil).Once()
+
+	unit := ts.createUnit(structReq.UnitID)
+	ts.appUseCase.On("GetUnitByID", structReq.UnitID).Return(unit, nil)
+	ts.redirectUseCase.On("ValidateTarget", mock.AnythingOfType("redirect.Target"), "", unit.OrganizationID).Return(nil).Once()
+
+	ts.contentCampaignUseCase.On("IncreaseClick", structReq.CampaignID, structReq.UnitID).Return(nil).Once()
+	ts.deviceUseCase.On("SaveActivity", structReq.DeviceID, structReq.CampaignID, device.ActivityClick).Return(nil).Once()
+
+	payloadStruct := &payload.Payload{}
+	err := faker.FakeData(&payloadStruct)
+	ts.NoError(err)
+	payloadStruct.VariantID = 0
+	ts.payloadUseCase.On("ParsePayload", mock.AnythingOfType("string")).Return(payloadStruct, nil).Once()
+	ts.payloadUseCase.On("IsPayloadExpired", mock.AnythingOfType("*payload.Payload")).Return(false).Once()
+	ts.trackingDataUseCase.On("ParseTrackingData", structReq.TrackingDataStr).Return(&trackingdata.TrackingData{ModelArtifact: "v1"}, nil).Once()
+	ts.deviceUseCase.On("ValidateUnitDeviceToken", structReq.GetUDT()).Return(true, nil).Once()
+	ts.profileRequestUseCase.On("PopulateProfile", mock.AnythingOfType("profilerequest.Account")).Return(nil).Once()
+	ts.identityGraphUseCase.On("LinkDevice", structReq.DeviceID, mock.AnythingOfType("[]identitygraph.Identifier"), identitygraph.SourceClick, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	err = ts.controller.ShortClickRedirect(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusFound, rec.Code)
+}
+
 func (ts *ControllerTestSuite) buildResource(campaignID int64) event.Resource {
 	if dto.BuzzAdCampaignIDOffset < campaignID {
 		return event.Resource{
@@ -385,10 +689,18 @@ func (ts *ControllerTestSuite) buildNetworkRequest(req *dto.GetClickRedirectRequ
 	if req.RedirectURLClean != nil {
 		(*params)["redirect_url_clean"] = []string{*req.RedirectURLClean}
 	}
+	if req.UseCleanMode {
+		(*params)["use_clean_mode"] = []string{"1"}
+	}
+	if req.DeepLink != "" {
+		(*params)["deep_link"] = []string{req.DeepLink}
+		(*params)["target_app"] = []string{req.TargetApp}
+	}
 
 	if req.TrackingURL != nil {
 		(*params)["tracking_url"] = []string{*req.TrackingURL}
 		(*params)["use_reward_api"] = []string{strconv.FormatBool(req.UseRewardAPI)}
+		(*params)["fail_tracker"] = req.FailTrackers
 	}
 
 	networkReq := &network.Request{
@@ -438,6 +750,12 @@ type ControllerTestSuite struct {
 	deviceUseCase          *mockDeviceUseCase
 	eventUseCase           *mockEventUseCase
 	profileRequestUseCase  *mockProfileRequestUseCase
+	redirectUseCase        *mockRedirectUseCase
+	landingUseCase         *mockLandingUseCase
+	trackerUseCase         *mockTrackerUseCase
+	shortLinkUseCase       *mockShortLinkUseCase
+	contentVariantUseCase  *mockContentVariantUseCase
+	identityGraphUseCase   *mockIdentityGraphUseCase
 }
 
 func (ts *ControllerTestSuite) buildContextAndRecorder(httpRequest *http.Request) (ctx core.Context, rec *httptest.ResponseRecorder) {
@@ -461,6 +779,12 @@ func (ts *ControllerTestSuite) SetupTest() {
 	ts.deviceUseCase = new(mockDeviceUseCase)
 	ts.eventUseCase = new(mockEventUseCase)
 	ts.profileRequestUseCase = new(mockProfileRequestUseCase)
+	ts.redirectUseCase = new(mockRedirectUseCase)
+	ts.landingUseCase = new(mockLandingUseCase)
+	ts.trackerUseCase = new(mockTrackerUseCase)
+	ts.shortLinkUseCase = new(mockShortLinkUseCase)
+	ts.contentVariantUseCase = new(mockContentVariantUseCase)
+	ts.identityGraphUseCase = new(mockIdentityGraphUseCase)
 
 	ts.controller = clickredirectsvc.NewController(
 		ts.engine,
@@ -472,6 +796,12 @@ func (ts *ControllerTestSuite) SetupTest() {
 		ts.deviceUseCase,
 		ts.eventUseCase,
 		ts.profileRequestUseCase,
+		ts.redirectUseCase,
+		ts.landingUseCase,
+		ts.trackerUseCase,
+		ts.shortLinkUseCase,
+		ts.contentVariantUseCase,
+		ts.identityGraphUseCase,
 		ts.buzzAdURL,
 	)
 }
@@ -484,6 +814,12 @@ func (ts *ControllerTestSuite) AfterTest(_, _ string) {
 	ts.trackingDataUseCase.AssertExpectations(ts.T())
 	ts.deviceUseCase.AssertExpectations(ts.T())
 	ts.profileRequestUseCase.AssertExpectations(ts.T())
+	ts.redirectUseCase.AssertExpectations(ts.T())
+	ts.landingUseCase.AssertExpectations(ts.T())
+	ts.trackerUseCase.AssertExpectations(ts.T())
+	ts.shortLinkUseCase.AssertExpectations(ts.T())
+	ts.contentVariantUseCase.AssertExpectations(ts.T())
+	ts.identityGraphUseCase.AssertExpectations(ts.T())
 }
 
 var _ reward.UseCase = &mockRewardUseCase{}
@@ -569,6 +905,22 @@ func (u *mockContentCampaignUseCase) IsContentCampaignExpired(contentCampaign *c
 	return ret.Get(0).(bool)
 }
 
+func (u *mockContentCampaignUseCase) Search(req contentcampaign.SearchRequest) (*contentcampaign.SearchResult, error) {
+	ret := u.Called(req)
+	return ret.Get(0).(*contentcampaign.SearchResult), ret.Error(1)
+}
+
+func (u *mockContentCampaignUseCase) SearchByIDs(campaignIDs ...int64) (*contentcampaign.SearchResult, error) {
+	ret := u.Called(campaignIDs)
+	return ret.Get(0).(*contentcampaign.SearchResult), ret.Error(1)
+}
+
+func (u *mockContentCampaignUseCase) Explain(campaignID int64, req contentcampaign.SearchRequest) (*contentcampaign.Explanation, error) {
+	ret := u.Called(campaignID, req)
+	explanation, _ := ret.Get(0).(*contentcampaign.Explanation)
+	return explanation, ret.Error(1)
+}
+
 type mockPayloadUseCase struct {
 	mock.Mock
 }
@@ -641,6 +993,11 @@ func (u *mockDeviceUseCase) DeleteProfile(dp device.Profile) error {
 	return ret.Error(0)
 }
 
+func (u *mockDeviceUseCase) ApplyContentFeedback(deviceID int64, feedback device.ContentFeedback, relevance device.ContentRelevance) (*device.Profile, error) {
+	ret := u.Called(deviceID, feedback, relevance)
+	return ret.Get(0).(*device.Profile), ret.Error(1)
+}
+
 func (u *mockDeviceUseCase) GetByID(deviceID int64) (*device.Device, error) {
 	ret := u.Called(deviceID)
 	return ret.Get(0).(*device.Device), ret.Error(1)
@@ -694,6 +1051,15 @@ func (u *mockEventUseCase) GetTrackingURL(deviceID int64, resource event.Resourc
 	return ret.Get(0).(string), ret.Error(1)
 }
 
+func (u *mockEventUseCase) SaveFailTrackers(deviceID int64, resource event.Resource, failTrackers []string) {
+	u.Called(deviceID, resource, failTrackers)
+}
+
+func (u *mockEventUseCase) GetFailTrackers(deviceID int64, resource event.Resource) ([]string, error) {
+	ret := u.Called(deviceID, resource)
+	return ret.Get(0).([]string), ret.Error(1)
+}
+
 type mockProfileRequestUseCase struct {
 	mock.Mock
 }
@@ -702,3 +1068,129 @@ func (u *mockProfileRequestUseCase) PopulateProfile(account profilerequest.Accou
 	ret := u.Called(account)
 	return ret.Error(0)
 }
+
+type mockRedirectUseCase struct {
+	mock.Mock
+}
+
+func (u *mockRedirectUseCase) SignTarget(target redirect.Target) string {
+	ret := u.Called(target)
+	return ret.Get(0).(string)
+}
+
+func (u *mockRedirectUseCase) ValidateTarget(target redirect.Target, signature string, organizationID int64) error {
+	ret := u.Called(target, signature, organizationID)
+	return ret.Error(0)
+}
+
+type mockLandingUseCase struct {
+	mock.Mock
+}
+
+func (u *mockLandingUseCase) ResolveLanding(target landing.Target, device landing.Device) landing.Landing {
+	ret := u.Called(target, device)
+	return ret.Get(0).(landing.Landing)
+}
+
+type mockTrackerUseCase struct {
+	mock.Mock
+}
+
+func (u *mockTrackerUseCase) Dispatch(req tracker.Request) error {
+	ret := u.Called(req)
+	return ret.Error(0)
+}
+
+func (u *mockTrackerUseCase) Wait() {
+	u.Called()
+}
+
+type mockShortLinkUseCase struct {
+	mock.Mock
+}
+
+func (u *mockShortLinkUseCase) Shorten(longURL string) (string, error) {
+	ret := u.Called(longURL)
+	return ret.String(0), ret.Error(1)
+}
+
+func (u *mockShortLinkUseCase) ShortenIfLonger(longURL string, maxLength int) string {
+	ret := u.Called(longURL, maxLength)
+	return ret.String(0)
+}
+
+func (u *mockShortLinkUseCase) Resolve(id string, query url.Values) (string, error) {
+	ret := u.Called(id, query)
+	return ret.String(0), ret.Error(1)
+}
+
+type mockContentVariantUseCase struct {
+	mock.Mock
+}
+
+func (u *mockContentVariantUseCase) GetReport(campaignID int64) (*contentvariant.Report, error) {
+	ret := u.Called(campaignID)
+	return ret.Get(0).(*contentvariant.Report), ret.Error(1)
+}
+
+func (u *mockContentVariantUseCase) GetVariants(campaignID int64) ([]contentvariant.Variant, error) {
+	ret := u.Called(campaignID)
+	return ret.Get(0).([]contentvariant.Variant), ret.Error(1)
+}
+
+func (u *mockContentVariantUseCase) CreateVariant(variant contentvariant.Variant) (*contentvariant.Variant, error) {
+	ret := u.Called(variant)
+	return ret.Get(0).(*contentvariant.Variant), ret.Error(1)
+}
+
+func (u *mockContentVariantUseCase) UpdateVariant(variant contentvariant.Variant) (*contentvariant.Variant, error) {
+	ret := u.Called(variant)
+	return ret.Get(0).(*contentvariant.Variant), ret.Error(1)
+}
+
+func (u *mockContentVariantUseCase) DeleteVariant(campaignID int64, variantID int64) error {
+	return u.Called(campaignID, variantID).Error(0)
+}
+
+func (u *mockContentVariantUseCase) SelectVariants(campaignIDs []int64) (map[int64]contentvariant.Variant, error) {
+	ret := u.Called(campaignIDs)
+	return ret.Get(0).(map[int64]contentvariant.Variant), ret.Error(1)
+}
+
+func (u *mockContentVariantUseCase) RecordImpression(campaignID int64, variantID int64) error {
+	return u.Called(campaignID, variantID).Error(0)
+}
+
+func (u *mockContentVariantUseCase) RecordClick(campaignID int64, variantID int64) error {
+	return u.Called(campaignID, variantID).Error(0)
+}
+
+var _ identitygraph.UseCase = &mockIdentityGraphUseCase{}
+
+type mockIdentityGraphUseCase struct {
+	mock.Mock
+}
+
+func (u *mockIdentityGraphUseCase) LinkDevice(deviceID int64, identifiers []identitygraph.Identifier, source identitygraph.Source, seenAt time.Time) error {
+	return u.Called(deviceID, identifiers, source, seenAt).Error(0)
+}
+
+func (u *mockIdentityGraphUseCase) GetLinks(deviceID int64) ([]identitygraph.Link, error) {
+	ret := u.Called(deviceID)
+	return ret.Get(0).([]identitygraph.Link), ret.Error(1)
+}
+
+func (u *mockIdentityGraphUseCase) Resolve(identifier identitygraph.Identifier) (*identitygraph.Account, error) {
+	ret := u.Called(identifier)
+	return ret.Get(0).(*identitygraph.Account), ret.Error(1)
+}
+
+func (u *mockIdentityGraphUseCase) ResolveDevice(deviceID int64) (*identitygraph.Account, error) {
+	ret := u.Called(deviceID)
+	return ret.Get(0).(*identitygraph.Account), ret.Error(1)
+}
+
+func (u *mockIdentityGraphUseCase) BackfillHistories(afterID int64, size int) (*identitygraph.BackfillResult, error) {
+	ret := u.Called(afterID, size)
+	return ret.Get(0).(*identitygraph.BackfillResult), ret.Error(1)
+}
@@ -66,12 +66,21 @@ type GetClickRedirectRequest struct {
 
 	TrackingDataStr string `query:"tracking_data"`
 
-	TrackingURL  *string `query:"tracking_url"`
-	UseRewardAPI bool    `query:"use_reward_api"`
+	TrackingURL  *string  `query:"tracking_url"`
+	UseRewardAPI bool     `query:"use_reward_api"`
+	FailTrackers []string `query:"fail_tracker"`
 
 	Request *http.Request `form:"-" query:"-"`
 
 	Checksum string `query:"check"`
+
+	// RedirectSignature binds redirect_url and redirect_url_clean to the campaign and unit at allocation time
+	RedirectSignature string `query:"redirect_signature"`
+
+	// DeepLink, UniversalLink and TargetApp are used to land on the advertiser's app if it is installed
+	DeepLink      string `query:"deep_link"`
+	UniversalLink string `query:"universal_link"`
+	TargetApp     string `query:"target_app"`
 }
 
 // GetUDT returns UnitDeviceTokenClient or UnitDeviceToken
@@ -1,6 +1,9 @@
 package common
 
 import (
+	"crypto/subtle"
+	"os"
+
 	"github.com/Buzzvil/buzzlib-go/core"
 )
 
@@ -25,3 +28,15 @@ func (con *ControllerBase) Bind(c core.Context, model interface{}, callbacks ...
 	}
 	return nil
 }
+
+// IsAuthorized returns true if the request has the basic authorization value of internal APIs
+// Every request is rejected when BASIC_AUTHORIZATION_VALUE is not set
+func (con *ControllerBase) IsAuthorized(c core.Context) bool {
+	authorization := os.Getenv("BASIC_AUTHORIZATION_VALUE")
+	if authorization == "" {
+		return false
+	}
+
+	authValues := c.Request().Header["Authorization"]
+	return len(authValues) > 0 && subtle.ConstantTimeCompare([]byte(authValues[0]), []byte(authorization)) == 1
+}
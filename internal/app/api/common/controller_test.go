@@ -0,0 +1,58 @@
+package common_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/stretchr/testify/suite"
+)
+
+const testAuthorization = "TEST_BASIC_AUTHORIZATION_VALUE"
+
+func (ts *ControllerBaseTestSuite) Test_IsAuthorized() {
+	os.Setenv("BASIC_AUTHORIZATION_VALUE", testAuthorization)
+
+	ts.True(ts.controller.IsAuthorized(ts.buildContext(testAuthorization)))
+	ts.False(ts.controller.IsAuthorized(ts.buildContext("WRONG_VALUE")))
+	ts.False(ts.controller.IsAuthorized(ts.buildContext("")))
+}
+
+func (ts *ControllerBaseTestSuite) Test_IsAuthorized_NotConfigured() {
+	os.Setenv("BASIC_AUTHORIZATION_VALUE", "")
+
+	ts.False(ts.controller.IsAuthorized(ts.buildContext("")))
+	ts.False(ts.controller.IsAuthorized(ts.buildContext(testAuthorization)))
+}
+
+func TestControllerBaseSuite(t *testing.T) {
+	suite.Run(t, new(ControllerBaseTestSuite))
+}
+
+type ControllerBaseTestSuite struct {
+	suite.Suite
+	engine        *core.Engine
+	controller    *common.ControllerBase
+	authorization string
+}
+
+func (ts *ControllerBaseTestSuite) SetupSuite() {
+	ts.engine = core.NewEngine(nil)
+	ts.controller = &common.ControllerBase{}
+	ts.authorization = os.Getenv("BASIC_AUTHORIZATION_VALUE")
+}
+
+func (ts *ControllerBaseTestSuite) TearDownSuite() {
+	os.Setenv("BASIC_AUTHORIZATION_VALUE", ts.authorization)
+}
+
+func (ts *ControllerBaseTestSuite) buildContext(authorization string) core.Context {
+	httpRequest := httptest.NewRequest(http.MethodGet, "/api/internal/", nil)
+	if authorization != "" {
+		httpRequest.Header.Set("Authorization", authorization)
+	}
+	return ts.engine.NewContext(httpRequest, httptest.NewRecorder())
+}
@@ -0,0 +1,387 @@
+package contentadminsvc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentadminsvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+)
+
+const (
+	actorHeader          = "X-Actor"
+	defaultAuditLogLimit = 50
+)
+
+// Controller type definition
+type Controller struct {
+	*common.ControllerBase
+	useCase contentadmin.UseCase
+}
+
+// NewController returns new controller and binds requests to the controller
+func NewController(e *core.Engine, uc contentadmin.UseCase) Controller {
+	con := Controller{useCase: uc}
+	e.GET("/api/internal/content/campaigns/:id", con.GetCampaign)
+	e.POST("/api/internal/content/campaigns", con.PostCampaign)
+	e.PUT("/api/internal/content/campaigns/:id", con.PutCampaign)
+	e.DELETE("/api/internal/content/campaigns/:id", con.DeleteCampaign)
+	e.GET("/api/internal/content/categories", con.GetCategories)
+	e.GET("/api/internal/content/categories/:id", con.GetCategory)
+	e.POST("/api/internal/content/categories", con.PostCategory)
+	e.PUT("/api/internal/content/categories/:id", con.PutCategory)
+	e.DELETE("/api/internal/content/categories/:id", con.DeleteCategory)
+	e.GET("/api/internal/content/channels/:id", con.GetChannel)
+	e.POST("/api/internal/content/channels", con.PostChannel)
+	e.PUT("/api/internal/content/channels/:id", con.PutChannel)
+	e.DELETE("/api/internal/content/channels/:id", con.DeleteChannel)
+	e.GET("/api/internal/content/audit_logs", con.GetAuditLogs)
+	return con
+}
+
+// GetCampaign returns the content campaign
+func (con *Controller) GetCampaign(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	id, err := con.parseID(c)
+	if err != nil {
+		return err
+	}
+
+	campaign, err := con.useCase.GetCampaign(id)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, dto.Campaign(*campaign))
+}
+
+// PostCampaign creates the content campaign and indexes it
+func (con *Controller) PostCampaign(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	actor, err := con.getActor(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.Campaign
+	if err := con.Bind(c, &req); err != nil {
+		return err
+	}
+
+	campaign, err := con.useCase.CreateCampaign(actor, contentadmin.Campaign(req))
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, dto.Campaign(*campaign))
+}
+
+// PutCampaign replaces all fields of the content campaign and reindexes it
+func (con *Controller) PutCampaign(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	actor, err := con.getActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := con.parseID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.Campaign
+	if err := con.Bind(c, &req); err != nil {
+		return err
+	}
+	req.ID = id
+
+	campaign, err := con.useCase.UpdateCampaign(actor, contentadmin.Campaign(req))
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, dto.Campaign(*campaign))
+}
+
+// DeleteCampaign deletes the content campaign and removes it from the index
+func (con *Controller) DeleteCampaign(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	actor, err := con.getActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := con.parseID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := con.useCase.DeleteCampaign(actor, id); err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// GetCategories returns all content categories
+func (con *Controller) GetCategories(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	categories, err := con.useCase.GetCategories()
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+
+	res := make([]dto.Category, 0, len(categories))
+	for _, category := range categories {
+		res = append(res, dto.Category(category))
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// GetCategory returns the content category
+func (con *Controller) GetCategory(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	category, err := con.useCase.GetCategory(c.Param("id"))
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, dto.Category(*category))
+}
+
+// PostCategory creates the content category
+func (con *Controller) PostCategory(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	actor, err := con.getActor(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.Category
+	if err := con.Bind(c, &req); err != nil {
+		return err
+	}
+
+	category, err := con.useCase.CreateCategory(actor, contentadmin.Category(req))
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, dto.Category(*category))
+}
+
+// PutCategory replaces all fields of the content category
+func (con *Controller) PutCategory(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	actor, err := con.getActor(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.Category
+	if err := con.Bind(c, &req); err != nil {
+		return err
+	}
+	req.ID = c.Param("id")
+
+	category, err := con.useCase.UpdateCategory(actor, contentadmin.Category(req))
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, dto.Category(*category))
+}
+
+// DeleteCategory deletes the content category
+func (con *Controller) DeleteCategory(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	actor, err := con.getActor(c)
+	if err != nil {
+		return err
+	}
+
+	if err := con.useCase.DeleteCategory(actor, c.Param("id")); err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// GetChannel returns the content channel
+func (con *Controller) GetChannel(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	id, err := con.parseID(c)
+	if err != nil {
+		return err
+	}
+
+	channel, err := con.useCase.GetChannel(id)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, dto.Channel(*channel))
+}
+
+// PostChannel creates the content channel
+func (con *Controller) PostChannel(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	actor, err := con.getActor(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.Channel
+	if err := con.Bind(c, &req); err != nil {
+		return err
+	}
+
+	channel, err := con.useCase.CreateChannel(actor, contentadmin.Channel(req))
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, dto.Channel(*channel))
+}
+
+// PutChannel replaces all fields of the content channel and reindexes the campaigns of the channel
+func (con *Controller) PutChannel(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	actor, err := con.getActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := con.parseID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.Channel
+	if err := con.Bind(c, &req); err != nil {
+		return err
+	}
+	req.ID = id
+
+	channel, err := con.useCase.UpdateChannel(actor, contentadmin.Channel(req))
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, dto.Channel(*channel))
+}
+
+// DeleteChannel deletes the content channel which no campaign has
+func (con *Controller) DeleteChannel(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	actor, err := con.getActor(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := con.parseID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := con.useCase.DeleteChannel(actor, id); err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// GetAuditLogs returns the latest changes of the entity
+func (con *Controller) GetAuditLogs(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	var req dto.GetAuditLogsRequest
+	if err := con.Bind(c, &req); err != nil {
+		return err
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultAuditLogLimit
+	}
+
+	auditLogs, err := con.useCase.GetAuditLogs(contentadmin.EntityType(req.EntityType), req.EntityID, req.Limit)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+
+	res := make([]dto.AuditLog, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		res = append(res, dto.AuditLog{
+			ID:         auditLog.ID,
+			Actor:      auditLog.Actor,
+			Action:     string(auditLog.Action),
+			EntityType: string(auditLog.EntityType),
+			EntityID:   auditLog.EntityID,
+			Before:     auditLog.Before,
+			After:      auditLog.After,
+			CreatedAt:  auditLog.CreatedAt,
+		})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// getActor returns the operator of the change recorded in the audit log
+func (con *Controller) getActor(c core.Context) (string, error) {
+	actor := c.Request().Header.Get(actorHeader)
+	if actor == "" {
+		return "", common.NewValidationErrorf("%s header is required.", actorHeader)
+	}
+	return actor, nil
+}
+
+func (con *Controller) parseID(c core.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, common.NewBindError(err)
+	}
+	return id, nil
+}
+
+func (con *Controller) toHTTPError(err error) error {
+	switch err.(type) {
+	case contentadmin.ValidationError:
+		return common.NewValidationErrorf("%s", err.Error())
+	case contentadmin.CampaignNotFoundError, contentadmin.CategoryNotFoundError, contentadmin.ChannelNotFoundError:
+		return common.NewNotFoundErrorf("%s", err.Error())
+	case contentadmin.CategoryExistsError, contentadmin.ChannelInUseError:
+		return &core.HttpError{Code: http.StatusConflict, Message: err.Error()}
+	default:
+		core.Logger.WithError(err).Error("contentadminsvc - failed to manage content")
+		return common.NewInternalServerError(err)
+	}
+}
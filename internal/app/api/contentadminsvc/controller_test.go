@@ -0,0 +1,260 @@
+package contentadminsvc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentadminsvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentadminsvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func (ts *ControllerTestSuite) Test_PostCampaign() {
+	startDate := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ageMin := 20
+	expected := contentadmin.Campaign{
+		Title:        "title",
+		StartDate:    startDate,
+		EndDate:      startDate.AddDate(0, 1, 0),
+		WeekSlot:     "0-23",
+		TargetAgeMin: &ageMin,
+	}
+	created := expected
+	created.ID = 10
+	ts.useCase.On("CreateCampaign", "admin@buzzvil.com", expected).Return(&created, nil).Once()
+
+	body := `{"title": "title", "start_date": "2019-01-01T00:00:00Z", "end_date": "2019-02-01T00:00:00Z", "week_slot": "0-23", "target_age_min": 20}`
+	ctx, rec := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/content/campaigns", body)
+
+	err := ts.controller.PostCampaign(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+
+	var res dto.Campaign
+	ts.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	ts.Equal(int64(10), res.ID)
+	ts.Equal(20, *res.TargetAgeMin)
+	ts.useCase.AssertExpectations(ts.T())
+}
+
+func (ts *ControllerTestSuite) Test_PostCampaign_Invalid() {
+	ts.useCase.On("CreateCampaign", "admin@buzzvil.com", mock.Anything).
+		Return(nil, contentadmin.ValidationError{Field: "week_slot", Reason: "has invalid slot mon"}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/content/campaigns", `{"week_slot": "mon"}`)
+
+	err := ts.controller.PostCampaign(ctx)
+
+	ts.Equal(http.StatusBadRequest, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) Test_PostCampaign_WithoutActor() {
+	ctx, _ := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/content/campaigns", `{}`)
+	ctx.Request().Header.Del("X-Actor")
+
+	err := ts.controller.PostCampaign(ctx)
+
+	ts.Equal(http.StatusBadRequest, err.(*core.HttpError).Code)
+	ts.useCase.AssertNotCalled(ts.T(), "CreateCampaign", mock.Anything, mock.Anything)
+}
+
+func (ts *ControllerTestSuite) Test_PutCampaign_NotFound() {
+	ts.useCase.On("UpdateCampaign", "admin@buzzvil.com", mock.MatchedBy(func(campaign contentadmin.Campaign) bool {
+		return campaign.ID == 10
+	})).Return(nil, contentadmin.CampaignNotFoundError{ID: 10}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder(http.MethodPut, "/api/internal/content/campaigns/10", `{"title": "title"}`)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("10")
+
+	err := ts.controller.PutCampaign(ctx)
+
+	ts.Equal(http.StatusNotFound, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) Test_DeleteCampaign_Forbidden() {
+	ctx, rec := ts.buildContextAndRecorder(http.MethodDelete, "/api/internal/content/campaigns/10", "")
+	ctx.Request().Header.Del("Authorization")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("10")
+
+	err := ts.controller.DeleteCampaign(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusForbidden, rec.Code)
+	ts.useCase.AssertNotCalled(ts.T(), "DeleteCampaign", mock.Anything, mock.Anything)
+}
+
+func (ts *ControllerTestSuite) Test_DeleteChannel_InUse() {
+	ts.useCase.On("DeleteChannel", "admin@buzzvil.com", int64(7)).Return(contentadmin.ChannelInUseError{ID: 7, CampaignCount: 3}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder(http.MethodDelete, "/api/internal/content/channels/7", "")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("7")
+
+	err := ts.controller.DeleteChannel(ctx)
+
+	ts.Equal(http.StatusConflict, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) Test_PutCategory() {
+	category := contentadmin.Category{ID: "news", Name: "News", IconURL: "http://icon.png"}
+	ts.useCase.On("UpdateCategory", "admin@buzzvil.com", category).Return(&category, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder(http.MethodPut, "/api/internal/content/categories/news", `{"name": "News", "icon_url": "http://icon.png"}`)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("news")
+
+	err := ts.controller.PutCategory(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+	ts.useCase.AssertExpectations(ts.T())
+}
+
+func (ts *ControllerTestSuite) Test_GetAuditLogs() {
+	ts.useCase.On("GetAuditLogs", contentadmin.EntityTypeCampaign, "10", 50).Return([]contentadmin.AuditLog{{
+		ID:         1,
+		Actor:      "admin@buzzvil.com",
+		Action:     contentadmin.AuditActionUpdate,
+		EntityType: contentadmin.EntityTypeCampaign,
+		EntityID:   "10",
+		Before:     `{"ID":10}`,
+		After:      `{"ID":10}`,
+	}}, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder(http.MethodGet, "/api/internal/content/audit_logs?entity_type=campaign&entity_id=10", "")
+
+	err := ts.controller.GetAuditLogs(ctx)
+
+	ts.NoError(err)
+	var res []dto.AuditLog
+	ts.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	ts.Len(res, 1)
+	ts.Equal("update", res[0].Action)
+}
+
+func (ts *ControllerTestSuite) Test_GetAuditLogs_UnknownEntityType() {
+	ctx, _ := ts.buildContextAndRecorder(http.MethodGet, "/api/internal/content/audit_logs?entity_type=provider", "")
+
+	err := ts.controller.GetAuditLogs(ctx)
+
+	ts.Equal(http.StatusBadRequest, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) buildContextAndRecorder(method string, target string, body string) (core.Context, *httptest.ResponseRecorder) {
+	httpRequest := httptest.NewRequest(method, target, strings.NewReader(body))
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", os.Getenv("BASIC_AUTHORIZATION_VALUE"))
+	httpRequest.Header.Set("X-Actor", "admin@buzzvil.com")
+	rec := httptest.NewRecorder()
+	return ts.engine.NewContext(httpRequest, rec), rec
+}
+
+func TestControllerSuite(t *testing.T) {
+	suite.Run(t, new(ControllerTestSuite))
+}
+
+type ControllerTestSuite struct {
+	suite.Suite
+	useCase    *mockUseCase
+	engine     *core.Engine
+	controller contentadminsvc.Controller
+}
+
+func (ts *ControllerTestSuite) SetupTest() {
+	ts.useCase = new(mockUseCase)
+	ts.engine = core.NewEngine(nil)
+	ts.controller = contentadminsvc.NewController(ts.engine, ts.useCase)
+}
+
+var _ contentadmin.UseCase = &mockUseCase{}
+
+type mockUseCase struct {
+	mock.Mock
+}
+
+func (u *mockUseCase) GetCampaign(id int64) (*contentadmin.Campaign, error) {
+	ret := u.Called(id)
+	campaign, _ := ret.Get(0).(*contentadmin.Campaign)
+	return campaign, ret.Error(1)
+}
+
+func (u *mockUseCase) CreateCampaign(actor string, campaign contentadmin.Campaign) (*contentadmin.Campaign, error) {
+	ret := u.Called(actor, campaign)
+	created, _ := ret.Get(0).(*contentadmin.Campaign)
+	return created, ret.Error(1)
+}
+
+func (u *mockUseCase) UpdateCampaign(actor string, campaign contentadmin.Campaign) (*contentadmin.Campaign, error) {
+	ret := u.Called(actor, campaign)
+	updated, _ := ret.Get(0).(*contentadmin.Campaign)
+	return updated, ret.Error(1)
+}
+
+func (u *mockUseCase) DeleteCampaign(actor string, id int64) error {
+	return u.Called(actor, id).Error(0)
+}
+
+func (u *mockUseCase) GetCategories() ([]contentadmin.Category, error) {
+	ret := u.Called()
+	return ret.Get(0).([]contentadmin.Category), ret.Error(1)
+}
+
+func (u *mockUseCase) GetCategory(id string) (*contentadmin.Category, error) {
+	ret := u.Called(id)
+	category, _ := ret.Get(0).(*contentadmin.Category)
+	return category, ret.Error(1)
+}
+
+func (u *mockUseCase) CreateCategory(actor string, category contentadmin.Category) (*contentadmin.Category, error) {
+	ret := u.Called(actor, category)
+	created, _ := ret.Get(0).(*contentadmin.Category)
+	return created, ret.Error(1)
+}
+
+func (u *mockUseCase) UpdateCategory(actor string, category contentadmin.Category) (*contentadmin.Category, error) {
+	ret := u.Called(actor, category)
+	updated, _ := ret.Get(0).(*contentadmin.Category)
+	return updated, ret.Error(1)
+}
+
+func (u *mockUseCase) DeleteCategory(actor string, id string) error {
+	return u.Called(actor, id).Error(0)
+}
+
+func (u *mockUseCase) GetChannel(id int64) (*contentadmin.Channel, error) {
+	ret := u.Called(id)
+	channel, _ := ret.Get(0).(*contentadmin.Channel)
+	return channel, ret.Error(1)
+}
+
+func (u *mockUseCase) CreateChannel(actor string, channel contentadmin.Channel) (*contentadmin.Channel, error) {
+	ret := u.Called(actor, channel)
+	created, _ := ret.Get(0).(*contentadmin.Channel)
+	return created, ret.Error(1)
+}
+
+func (u *mockUseCase) UpdateChannel(actor string, channel contentadmin.Channel) (*contentadmin.Channel, error) {
+	ret := u.Called(actor, channel)
+	updated, _ := ret.Get(0).(*contentadmin.Channel)
+	return updated, ret.Error(1)
+}
+
+func (u *mockUseCase) DeleteChannel(actor string, id int64) error {
+	return u.Called(actor, id).Error(0)
+}
+
+func (u *mockUseCase) GetAuditLogs(entityType contentadmin.EntityType, entityID string, limit int) ([]contentadmin.AuditLog, error) {
+	ret := u.Called(entityType, entityID, limit)
+	return ret.Get(0).([]contentadmin.AuditLog), ret.Error(1)
+}
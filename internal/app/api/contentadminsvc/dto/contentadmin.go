@@ -0,0 +1,100 @@
+package dto
+
+import "time"
+
+// Campaign is the request and response body of the content campaign. week_slot is comma separated hours of the week e.g. "0-23,48"
+type Campaign struct {
+	ID             int64      `json:"id"`
+	Categories     string     `json:"categories"`
+	ChannelID      *int64     `json:"channel_id"`
+	CleanMode      int        `json:"clean_mode"`
+	ClickURL       string     `json:"click_url"`
+	CleanLink      string     `json:"clean_link"`
+	Country        string     `json:"country"`
+	Description    string     `json:"description"`
+	DisplayType    string     `json:"display_type"`
+	DisplayWeight  int        `json:"display_weight"`
+	EndDate        time.Time  `json:"end_date"`
+	Ipu            *int       `json:"ipu"`
+	IsCtrFilterOff bool       `json:"is_ctr_filter_off"`
+	IsEnabled      bool       `json:"is_enabled"`
+	Image          string     `json:"image"`
+	JSON           string     `json:"json"`
+	LandingReward  int        `json:"landing_reward"`
+	LandingType    int        `json:"landing_type"`
+	Name           string     `json:"name"`
+	OrganizationID int64      `json:"organization_id"`
+	OwnerID        int64      `json:"owner_id"`
+	ProviderID     *int64     `json:"provider_id"`
+	PublishedAt    *time.Time `json:"published_at"`
+	StartDate      time.Time  `json:"start_date"`
+	Status         int        `json:"status"`
+	Tags           string     `json:"tags"`
+	Title          string     `json:"title"`
+	Timezone       string     `json:"timezone"`
+	Tipu           *int       `json:"tipu"`
+	Type           string     `json:"type"`
+	WeekSlot       string     `json:"week_slot"`
+
+	TargetApp                 string `json:"target_app"`
+	TargetAgeMin              *int   `json:"target_age_min"`
+	TargetAgeMax              *int   `json:"target_age_max"`
+	TargetSdkMin              *int   `json:"target_sdk_min"`
+	TargetSdkMax              *int   `json:"target_sdk_max"`
+	RegisteredDaysMin         *int   `json:"registered_days_min"`
+	RegisteredDaysMax         *int   `json:"registered_days_max"`
+	TargetGender              string `json:"target_gender"`
+	TargetLanguage            string `json:"target_language"`
+	TargetCarrier             string `json:"target_carrier"`
+	TargetRegion              string `json:"target_region"`
+	CustomTarget1             string `json:"custom_target_1"`
+	CustomTarget2             string `json:"custom_target_2"`
+	CustomTarget3             string `json:"custom_target_3"`
+	TargetUnit                string `json:"target_unit"`
+	TargetAppID               string `json:"target_app_id"`
+	TargetOrg                 string `json:"target_org"`
+	TargetOsMin               *int   `json:"target_os_min"`
+	TargetOsMax               *int   `json:"target_os_max"`
+	TargetBatteryOptimization bool   `json:"target_battery_optimization"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Category type definition
+type Category struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Translation string    `json:"translation"`
+	IconURL     string    `json:"icon_url"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Channel type definition
+type Channel struct {
+	ID        int64  `json:"id"`
+	Category  string `json:"category"`
+	Logo      string `json:"logo"`
+	Name      string `json:"name"`
+	Publisher string `json:"publisher"`
+}
+
+// GetAuditLogsRequest type definition
+type GetAuditLogsRequest struct {
+	EntityType string `query:"entity_type" validate:"required,oneof=campaign category channel"`
+	EntityID   string `query:"entity_id"`
+	Limit      int    `query:"limit"`
+}
+
+// AuditLog type definition. before and after are json of the entity
+type AuditLog struct {
+	ID         int64     `json:"id"`
+	Actor      string    `json:"actor"`
+	Action     string    `json:"action"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Before     string    `json:"before,omitempty"`
+	After      string    `json:"after,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,255 @@
+package contentcampaignsvc
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentcampaignsvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
+)
+
+const (
+	defaultModelArtifact       = "v4_a"
+	ocbModelArtifact           = "v3"
+	ocbOrganizationID    int64 = 148
+)
+
+// Controller type definition
+type Controller struct {
+	*common.ControllerBase
+	useCase             contentcampaign.UseCase
+	appUseCase          app.UseCase
+	deviceUseCase       device.UseCase
+	contentScoreUseCase contentscore.UseCase
+}
+
+// NewController returns new controller and binds requests to the controller
+func NewController(e *core.Engine, uc contentcampaign.UseCase, appUseCase app.UseCase, deviceUseCase device.UseCase, contentScoreUseCase contentscore.UseCase) Controller {
+	con := Controller{useCase: uc, appUseCase: appUseCase, deviceUseCase: deviceUseCase, contentScoreUseCase: contentScoreUseCase}
+	e.GET("/api/internal/content/explain", con.GetExplanation)
+	return con
+}
+
+// GetExplanation returns the targeting clauses the campaign passes or fails for the unit and device with the ranking score.
+// The search request is built as v3 content articles allocation
+func (con *Controller) GetExplanation(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	var req dto.ExplainRequest
+	if err := con.Bind(c, &req); err != nil {
+		return err
+	}
+
+	unit, err := con.appUseCase.GetUnitByID(c.Request().Context(), req.UnitID)
+	if err != nil {
+		return common.NewInternalServerError(err)
+	} else if unit == nil {
+		return common.NewNotFoundErrorf("unit %d is not found", req.UnitID)
+	}
+
+	searchReq, err := con.buildSearchRequest(req, *unit)
+	if err != nil {
+		return common.NewInternalServerError(err)
+	}
+
+	explanation, err := con.useCase.Explain(req.CampaignID, *searchReq)
+	if err != nil {
+		if _, ok := err.(contentcampaign.CampaignNotIndexedError); ok {
+			return common.NewNotFoundErrorf("%s", err.Error())
+		}
+		core.Logger.WithError(err).Error("contentcampaignsvc - failed to explain content campaign")
+		return common.NewInternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, con.toDTOExplanation(req.UnitID, *explanation))
+}
+
+func (con *Controller) buildSearchRequest(req dto.ExplainRequest, unit app.Unit) (*contentcampaign.SearchRequest, error) {
+	requestedAt := time.Now()
+	if req.Time > 0 {
+		requestedAt = time.Unix(req.Time, 0)
+	}
+	localTime := requestedAt
+	if loc, err := time.LoadLocation(unit.Timezone); err == nil {
+		localTime = requestedAt.In(loc)
+	}
+	hour := requestedAt.Truncate(time.Hour)
+	minImageRatio := 1.0
+
+	country := req.Country
+	if country == "" {
+		country = unit.Country
+	}
+
+	query := contentcampaign.SearchQuery{
+		StartsBy:              hour,
+		EndsAfter:             hour,
+		UnitID:                unit.ID,
+		IncludeGlobUnit:       unit.ContentType == app.ContentTypeAll,
+		AppID:                 unit.AppID,
+		OrganizationID:        unit.OrganizationID,
+		Country:               country,
+		Gender:                req.Gender,
+		Age:                   req.Age,
+		SdkVersion:            req.SdkVersion,
+		RegisteredDays:        req.RegisteredDays,
+		OsVersion:             req.OsVersion,
+		LocalTime:             &localTime,
+		CustomTargets:         [3]string{req.CustomTarget1, req.CustomTarget2, req.CustomTarget3},
+		InBatteryOptimization: req.IsInBatteryOpts,
+		CreativeType:          req.CreativeType,
+		MinImageRatio:         &minImageRatio,
+		Statuses:              contentcampaign.StatusesForLockscreen,
+	}
+
+	if unit.UnitType == app.UnitTypeNative {
+		query.RelatedStatuses = contentcampaign.StatusesForFeed
+	}
+	if req.Carrier != "" {
+		query.Carrier = &req.Carrier
+	}
+	if req.Region != "" {
+		query.Region = &req.Region
+	}
+	if req.Language != "" {
+		query.Languages = splitAndTrim(req.Language)
+	}
+	if req.Packages != "" {
+		query.Packages = splitAndTrim(req.Packages)
+	}
+	if req.Categories != "" {
+		query.Categories = splitAndTrim(req.Categories)
+	}
+	if req.FilterCategories != "" {
+		query.ExcludedCategories = splitAndTrim(req.FilterCategories)
+	}
+	if req.ChannelID > 0 {
+		query.ChannelIDs = []int64{req.ChannelID}
+	}
+	if req.FilterChannelIDs != "" {
+		query.ExcludedChannelIDs = parseInt64s(splitAndTrim(req.FilterChannelIDs))
+	}
+	if req.LandingTypes != "" {
+		for _, landingType := range splitAndTrim(req.LandingTypes) {
+			if i, err := strconv.Atoi(landingType); err == nil {
+				query.LandingTypes = append(query.LandingTypes, i)
+			}
+		}
+	}
+	if unit.FilteredProviders != nil {
+		query.ExcludedProviderIDs = parseInt64s(splitAndTrim(*unit.FilteredProviders))
+	}
+
+	ranking := contentcampaign.SearchRanking{
+		ModelArtifact:  req.ModelArtifact,
+		CampaignScores: con.getActiveCampaignScores(country, req.Gender, req.Age),
+		RankedAt:       requestedAt,
+	}
+
+	if req.DeviceID > 0 {
+		if err := con.applyDevice(req.DeviceID, &query, &ranking); err != nil {
+			return nil, err
+		}
+	}
+
+	if ranking.ModelArtifact == "" {
+		ranking.ModelArtifact = defaultModelArtifact
+		if unit.OrganizationID == ocbOrganizationID {
+			ranking.ModelArtifact = ocbModelArtifact
+		}
+	}
+
+	return &contentcampaign.SearchRequest{DeviceID: req.DeviceID, Query: query, Ranking: ranking}, nil
+}
+
+// applyDevice sets installed packages, profiles and seen campaigns of the device as content allocation does
+func (con *Controller) applyDevice(deviceID int64, query *contentcampaign.SearchQuery, ranking *contentcampaign.SearchRanking) error {
+	profile, err := con.deviceUseCase.GetProfile(deviceID)
+	if err != nil {
+		return err
+	}
+	if profile != nil {
+		if query.Packages == nil && profile.InstalledPackages != nil {
+			query.Packages = splitAndTrim(*profile.InstalledPackages)
+		}
+		if profile.CategoriesScores != nil {
+			ranking.CategoryProfile = *profile.CategoriesScores
+		}
+		if profile.EntityScores != nil {
+			ranking.EntityProfile = *profile.EntityScores
+		}
+		if ranking.ModelArtifact == "" && profile.ModelArtifact != nil {
+			ranking.ModelArtifact = *profile.ModelArtifact
+		}
+	}
+
+	activity, err := con.deviceUseCase.GetActivity(deviceID)
+	if err != nil {
+		return err
+	}
+	if activity != nil {
+		ranking.SeenIDs = activity.SeenCampaignIDs
+		query.FrequencyCap = &contentcampaign.FrequencyCap{
+			CountsForHour:     activity.SeenCampaignCountForHour,
+			CountsForDay:      activity.SeenCampaignCountForDay,
+			CountsForLifetime: activity.SeenCampaignCountForLifetime,
+		}
+	}
+	return nil
+}
+
+// getActiveCampaignScores returns the scores of the active content score version. nil is returned if no version is published.
+func (con *Controller) getActiveCampaignScores(country string, gender string, age int) map[int64]int {
+	scoreSet, err := con.contentScoreUseCase.GetActiveScoreSet(contentscore.Target{Country: country, Gender: gender, Age: age})
+	if err != nil {
+		if _, ok := err.(contentscore.NoActiveVersionError); !ok {
+			core.Logger.WithError(err).Warnf("contentcampaignsvc - failed to get content scores")
+		}
+		return nil
+	}
+	return scoreSet.Scores
+}
+
+func (con *Controller) toDTOExplanation(unitID int64, explanation contentcampaign.Explanation) dto.Explanation {
+	clauses := make([]dto.Clause, 0, len(explanation.Clauses))
+	for _, result := range explanation.Clauses {
+		clauses = append(clauses, dto.Clause{Clause: string(result.Clause), Passed: result.Passed})
+	}
+
+	return dto.Explanation{
+		CampaignID:    explanation.CampaignID,
+		UnitID:        unitID,
+		Matched:       explanation.Matched(),
+		Clauses:       clauses,
+		ModelArtifact: explanation.Hit.ModelArtifact,
+		Score:         explanation.Hit.Score,
+		ScoreFactors:  explanation.Hit.ScoreFactors,
+	}
+}
+
+func splitAndTrim(commaSeparatedString string) []string {
+	splittedStrings := strings.Split(commaSeparatedString, ",")
+	for i := range splittedStrings {
+		splittedStrings[i] = strings.TrimSpace(splittedStrings[i])
+	}
+	return splittedStrings
+}
+
+func parseInt64s(strs []string) []int64 {
+	ints := make([]int64, 0, len(strs))
+	for _, str := range strs {
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			ints = append(ints, i)
+		}
+	}
+	return ints
+}
@@ -0,0 +1,305 @@
+package contentcampaignsvc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentcampaignsvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentcampaignsvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var unit = &app.Unit{ID: 100, AppID: 1000, OrganizationID: 1, Country: "KR", Timezone: "Asia/Seoul", ContentType: app.ContentTypeAll}
+
+func (ts *ControllerTestSuite) Test_GetExplanation() {
+	packages := "com.buzzvil.a,com.buzzvil.b"
+	modelArtifact := "r1"
+	score := 2.5
+	ts.appUseCase.On("GetUnitByID", unit.ID).Return(unit, nil).Once()
+	ts.contentScoreUseCase.On("GetActiveScoreSet", contentscore.Target{Country: "KR", Gender: "M", Age: 30}).
+		Return(&contentscore.ScoreSet{Scores: map[int64]int{1: 10}}, nil).Once()
+	ts.deviceUseCase.On("GetProfile", int64(7)).Return(&device.Profile{InstalledPackages: &packages, ModelArtifact: &modelArtifact}, nil).Once()
+	ts.deviceUseCase.On("GetActivity", int64(7)).Return(&device.Activity{SeenCampaignIDs: map[string]bool{"1": true}}, nil).Once()
+	ts.useCase.On("Explain", int64(1), mock.MatchedBy(func(req contentcampaign.SearchRequest) bool {
+		return req.DeviceID == 7 && req.Query.UnitID == unit.ID && req.Query.AppID == unit.AppID && req.Query.IncludeGlobUnit &&
+			req.Query.Country == "KR" && req.Query.Age == 30 && req.Query.LocalTime.Location().String() == "Asia/Seoul" &&
+			len(req.Query.Packages) == 2 && req.Query.FrequencyCap != nil && req.Query.ChannelIDs[0] == 3 &&
+			req.Ranking.ModelArtifact == "r1" && req.Ranking.SeenIDs["1"] && req.Ranking.CampaignScores[1] == 10
+	})).Return(&contentcampaign.Explanation{
+		CampaignID: 1,
+		Clauses: []contentcampaign.ClauseResult{
+			{Clause: contentcampaign.ClauseCountry, Passed: true},
+			{Clause: contentcampaign.ClauseChannels, Passed: false},
+		},
+		Hit: contentcampaign.SearchHit{ID: 1, Score: &score, ModelArtifact: "r1", ScoreFactors: map[string]float64{"linear": 2.5}},
+	}, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder("/api/internal/content/explain?campaign_id=1&unit_id=100&device_id=7&gender=M&age=30&channel_id=3")
+
+	err := ts.controller.GetExplanation(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+
+	var res dto.Explanation
+	ts.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	ts.False(res.Matched)
+	ts.Equal([]dto.Clause{{Clause: "country", Passed: true}, {Clause: "channels", Passed: false}}, res.Clauses)
+	ts.Equal(score, *res.Score)
+	ts.Equal("r1", res.ModelArtifact)
+	ts.Equal(2.5, res.ScoreFactors["linear"])
+	ts.useCase.AssertExpectations(ts.T())
+	ts.deviceUseCase.AssertExpectations(ts.T())
+}
+
+func (ts *ControllerTestSuite) Test_GetExplanation_NotIndexed() {
+	ts.appUseCase.On("GetUnitByID", unit.ID).Return(unit, nil).Once()
+	ts.contentScoreUseCase.On("GetActiveScoreSet", mock.Anything).Return(nil, contentscore.NoActiveVersionError{}).Once()
+	ts.useCase.On("Explain", int64(1), mock.Anything).Return(nil, contentcampaign.CampaignNotIndexedError{CampaignID: 1}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder("/api/internal/content/explain?campaign_id=1&unit_id=100")
+
+	err := ts.controller.GetExplanation(ctx)
+
+	ts.Equal(http.StatusNotFound, err.(*core.HttpError).Code)
+	ts.deviceUseCase.AssertNotCalled(ts.T(), "GetProfile", mock.Anything)
+}
+
+func (ts *ControllerTestSuite) Test_GetExplanation_UnitNotFound() {
+	ts.appUseCase.On("GetUnitByID", unit.ID).Return(nil, nil).Once()
+
+	ctx, _ := ts.buildContextAndRecorder("/api/internal/content/explain?campaign_id=1&unit_id=100")
+
+	err := ts.controller.GetExplanation(ctx)
+
+	ts.Equal(http.StatusNotFound, err.(*core.HttpError).Code)
+	ts.useCase.AssertNotCalled(ts.T(), "Explain", mock.Anything, mock.Anything)
+}
+
+func (ts *ControllerTestSuite) Test_GetExplanation_BindError() {
+	ctx, _ := ts.buildContextAndRecorder("/api/internal/content/explain?unit_id=100")
+
+	err := ts.controller.GetExplanation(ctx)
+
+	ts.Equal(http.StatusBadRequest, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) Test_GetExplanation_Forbidden() {
+	ctx, rec := ts.buildContextAndRecorder("/api/internal/content/explain?campaign_id=1&unit_id=100")
+	ctx.Request().Header.Del("Authorization")
+
+	err := ts.controller.GetExplanation(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (ts *ControllerTestSuite) buildContextAndRecorder(target string) (core.Context, *httptest.ResponseRecorder) {
+	httpRequest := httptest.NewRequest(http.MethodGet, target, nil)
+	httpRequest.Header.Set("Authorization", os.Getenv("BASIC_AUTHORIZATION_VALUE"))
+	rec := httptest.NewRecorder()
+	return ts.engine.NewContext(httpRequest, rec), rec
+}
+
+func TestControllerSuite(t *testing.T) {
+	suite.Run(t, new(ControllerTestSuite))
+}
+
+type ControllerTestSuite struct {
+	suite.Suite
+	useCase             *mockContentCampaignUseCase
+	appUseCase          *mockAppUseCase
+	deviceUseCase       *mockDeviceUseCase
+	contentScoreUseCase *mockContentScoreUseCase
+	engine              *core.Engine
+	controller          contentcampaignsvc.Controller
+}
+
+func (ts *ControllerTestSuite) SetupTest() {
+	ts.useCase = new(mockContentCampaignUseCase)
+	ts.appUseCase = new(mockAppUseCase)
+	ts.deviceUseCase = new(mockDeviceUseCase)
+	ts.contentScoreUseCase = new(mockContentScoreUseCase)
+	ts.engine = core.NewEngine(nil)
+	ts.controller = contentcampaignsvc.NewController(ts.engine, ts.useCase, ts.appUseCase, ts.deviceUseCase, ts.contentScoreUseCase)
+}
+
+var _ contentcampaign.UseCase = &mockContentCampaignUseCase{}
+
+type mockContentCampaignUseCase struct {
+	mock.Mock
+}
+
+func (u *mockContentCampaignUseCase) GetContentCampaignByID(campaignID int64) (*contentcampaign.ContentCampaign, error) {
+	ret := u.Called(campaignID)
+	return ret.Get(0).(*contentcampaign.ContentCampaign), ret.Error(1)
+}
+
+func (u *mockContentCampaignUseCase) IncreaseClick(campaignID int64, unitID int64) error {
+	return u.Called(campaignID, unitID).Error(0)
+}
+
+func (u *mockContentCampaignUseCase) IncreaseImpression(campaignID int64, unitID int64) error {
+	return u.Called(campaignID, unitID).Error(0)
+}
+
+func (u *mockContentCampaignUseCase) IsContentCampaignExpired(contentCampaign *contentcampaign.ContentCampaign) bool {
+	return u.Called(contentCampaign).Bool(0)
+}
+
+func (u *mockContentCampaignUseCase) Search(req contentcampaign.SearchRequest) (*contentcampaign.SearchResult, error) {
+	ret := u.Called(req)
+	return ret.Get(0).(*contentcampaign.SearchResult), ret.Error(1)
+}
+
+func (u *mockContentCampaignUseCase) SearchByIDs(campaignIDs ...int64) (*contentcampaign.SearchResult, error) {
+	ret := u.Called(campaignIDs)
+	return ret.Get(0).(*contentcampaign.SearchResult), ret.Error(1)
+}
+
+func (u *mockContentCampaignUseCase) Explain(campaignID int64, req contentcampaign.SearchRequest) (*contentcampaign.Explanation, error) {
+	ret := u.Called(campaignID, req)
+	explanation, _ := ret.Get(0).(*contentcampaign.Explanation)
+	return explanation, ret.Error(1)
+}
+
+var _ app.UseCase = &mockAppUseCase{}
+
+type mockAppUseCase struct {
+	mock.Mock
+}
+
+func (u *mockAppUseCase) GetAppByID(ctx context.Context, appID int64) (*app.App, error) {
+	ret := u.Called(appID)
+	return ret.Get(0).(*app.App), ret.Error(1)
+}
+
+func (u *mockAppUseCase) GetRewardableWelcomeRewardConfig(ctx context.Context, unitID int64, country string, unitRegisterSeconds int64) (*app.WelcomeRewardConfig, error) {
+	ret := u.Called(unitID, country, unitRegisterSeconds)
+	return ret.Get(0).(*app.WelcomeRewardConfig), ret.Error(1)
+}
+
+func (u *mockAppUseCase) GetActiveWelcomeRewardConfigs(ctx context.Context, unitID int64) (app.WelcomeRewardConfigs, error) {
+	ret := u.Called(unitID)
+	return ret.Get(0).(app.WelcomeRewardConfigs), ret.Error(1)
+}
+
+func (u *mockAppUseCase) GetReferralRewardConfig(ctx context.Context, appID int64) (*app.ReferralRewardConfig, error) {
+	ret := u.Called(appID)
+	return ret.Get(0).(*app.ReferralRewardConfig), ret.Error(1)
+}
+
+func (u *mockAppUseCase) GetUnitByID(ctx context.Context, unitID int64) (*app.Unit, error) {
+	ret := u.Called(unitID)
+	unit, _ := ret.Get(0).(*app.Unit)
+	return unit, ret.Error(1)
+}
+
+func (u *mockAppUseCase) GetUnitByAppID(ctx context.Context, appID int64) (*app.Unit, error) {
+	ret := u.Called(appID)
+	return ret.Get(0).(*app.Unit), ret.Error(1)
+}
+
+func (u *mockAppUseCase) GetUnitByAppIDAndType(ctx context.Context, appID int64, unitType app.UnitType) (*app.Unit, error) {
+	ret := u.Called(appID, unitType)
+	return ret.Get(0).(*app.Unit), ret.Error(1)
+}
+
+var _ device.UseCase = &mockDeviceUseCase{}
+
+type mockDeviceUseCase struct {
+	mock.Mock
+}
+
+func (u *mockDeviceUseCase) GetProfile(deviceID int64) (*device.Profile, error) {
+	ret := u.Called(deviceID)
+	return ret.Get(0).(*device.Profile), ret.Error(1)
+}
+
+func (u *mockDeviceUseCase) GetActivity(deviceID int64) (*device.Activity, error) {
+	ret := u.Called(deviceID)
+	return ret.Get(0).(*device.Activity), ret.Error(1)
+}
+
+func (u *mockDeviceUseCase) SaveActivity(deviceID int64, campaignID int64, activityType device.ActivityType) error {
+	return u.Called(deviceID, campaignID, activityType).Error(0)
+}
+
+func (u *mockDeviceUseCase) SaveProfile(dp device.Profile) error {
+	return u.Called(dp).Error(0)
+}
+
+func (u *mockDeviceUseCase) SaveProfilePackage(dp device.Profile) error {
+	return u.Called(dp).Error(0)
+}
+
+func (u *mockDeviceUseCase) SaveProfileUnitRegisteredSeconds(dp device.Profile) error {
+	return u.Called(dp).Error(0)
+}
+
+func (u *mockDeviceUseCase) DeleteProfile(dp device.Profile) error {
+	return u.Called(dp).Error(0)
+}
+
+func (u *mockDeviceUseCase) ApplyContentFeedback(deviceID int64, feedback device.ContentFeedback, relevance device.ContentRelevance) (*device.Profile, error) {
+	ret := u.Called(deviceID, feedback, relevance)
+	return ret.Get(0).(*device.Profile), ret.Error(1)
+}
+
+func (u *mockDeviceUseCase) GetByID(deviceID int64) (*device.Device, error) {
+	ret := u.Called(deviceID)
+	return ret.Get(0).(*device.Device), ret.Error(1)
+}
+
+func (u *mockDeviceUseCase) GetByParams(params device.Params) (*device.Device, error) {
+	ret := u.Called(params)
+	return ret.Get(0).(*device.Device), ret.Error(1)
+}
+
+func (u *mockDeviceUseCase) UpsertDevice(d device.Device) (*device.Device, error) {
+	ret := u.Called(d)
+	return ret.Get(0).(*device.Device), ret.Error(1)
+}
+
+func (u *mockDeviceUseCase) ValidateUnitDeviceToken(unitDeviceToken string) (bool, error) {
+	ret := u.Called(unitDeviceToken)
+	return ret.Bool(0), ret.Error(1)
+}
+
+var _ contentscore.UseCase = &mockContentScoreUseCase{}
+
+type mockContentScoreUseCase struct {
+	mock.Mock
+}
+
+func (u *mockContentScoreUseCase) SaveScores(versionName string, target contentscore.Target, scores map[int64]int) error {
+	return u.Called(versionName, target, scores).Error(0)
+}
+
+func (u *mockContentScoreUseCase) Publish(versionName string) (*contentscore.Version, error) {
+	ret := u.Called(versionName)
+	version, _ := ret.Get(0).(*contentscore.Version)
+	return version, ret.Error(1)
+}
+
+func (u *mockContentScoreUseCase) RollBack() (*contentscore.Version, error) {
+	ret := u.Called()
+	version, _ := ret.Get(0).(*contentscore.Version)
+	return version, ret.Error(1)
+}
+
+func (u *mockContentScoreUseCase) GetActiveScoreSet(target contentscore.Target) (*contentscore.ScoreSet, error) {
+	ret := u.Called(target)
+	scoreSet, _ := ret.Get(0).(*contentscore.ScoreSet)
+	return scoreSet, ret.Error(1)
+}
@@ -0,0 +1,48 @@
+package dto
+
+// ExplainRequest is the device parameters of a content allocation request to explain the targeting of a campaign
+type ExplainRequest struct {
+	CampaignID int64 `query:"campaign_id" validate:"required"`
+	UnitID     int64 `query:"unit_id" validate:"required"`
+	DeviceID   int64 `query:"device_id"` // installed packages, profiles and seen campaigns of the device are used if set
+
+	Country          string `query:"country"` // country of the unit if not set
+	Gender           string `query:"gender"`
+	Age              int    `query:"age"`
+	SdkVersion       int    `query:"sdk_version"`
+	OsVersion        int    `query:"os_version"`
+	RegisteredDays   int    `query:"registered_days"`
+	Carrier          string `query:"carrier"`
+	Region           string `query:"region"`
+	Language         string `query:"language"`
+	CustomTarget1    string `query:"custom_target_1"`
+	CustomTarget2    string `query:"custom_target_2"`
+	CustomTarget3    string `query:"custom_target_3"`
+	IsInBatteryOpts  bool   `query:"is_in_battery_opts"`
+	CreativeType     string `query:"creative_type"`
+	Packages         string `query:"packages"` // comma separated. installed packages of the device are used if not set
+	Categories       string `query:"categories"`
+	FilterCategories string `query:"filter_categories"`
+	ChannelID        int64  `query:"channel_id"`
+	FilterChannelIDs string `query:"filter_channel_ids"`
+	LandingTypes     string `query:"landing_types"`
+	Time             int64  `query:"time"` // unix seconds the allocation is requested at. now if not set
+	ModelArtifact    string `query:"model_artifact"`
+}
+
+// Clause type definition
+type Clause struct {
+	Clause string `json:"clause"`
+	Passed bool   `json:"passed"`
+}
+
+// Explanation type definition
+type Explanation struct {
+	CampaignID    int64              `json:"campaign_id"`
+	UnitID        int64              `json:"unit_id"`
+	Matched       bool               `json:"matched"`
+	Clauses       []Clause           `json:"clauses"`
+	ModelArtifact string             `json:"model_artifact"`
+	Score         *float64           `json:"score"`
+	ScoreFactors  map[string]float64 `json:"score_factors"`
+}
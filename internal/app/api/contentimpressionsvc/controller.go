@@ -7,6 +7,7 @@ import (
 
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/utils"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/impressiondata"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/trackingdata"
 
@@ -22,15 +23,17 @@ type Controller struct {
 	ImpressionDataUseCase  impressiondata.UseCase
 	ContentCampaignUseCase contentcampaign.UseCase
 	DeviceUseCase          device.UseCase
+	ContentVariantUseCase  contentvariant.UseCase
 }
 
 // NewController returns new controller and binds requests to the controller
-func NewController(e *core.Engine, trackingDataUseCase trackingdata.UseCase, impressionDataUseCase impressiondata.UseCase, contentCampaignUseCase contentcampaign.UseCase, deviceUseCase device.UseCase) Controller {
+func NewController(e *core.Engine, trackingDataUseCase trackingdata.UseCase, impressionDataUseCase impressiondata.UseCase, contentCampaignUseCase contentcampaign.UseCase, deviceUseCase device.UseCase, contentVariantUseCase contentvariant.UseCase) Controller {
 	con := Controller{
 		TrackingDataUseCase:    trackingDataUseCase,
 		ImpressionDataUseCase:  impressionDataUseCase,
 		ContentCampaignUseCase: contentCampaignUseCase,
 		DeviceUseCase:          deviceUseCase,
+		ContentVariantUseCase:  contentVariantUseCase,
 	}
 	e.GET("api/content_impression/", con.ContentImpression)
 	return con
@@ -77,6 +80,11 @@ func (con *Controller) ContentImpression(c core.Context) error {
 	}
 
 	con.ContentCampaignUseCase.IncreaseImpression(req.ImpressionData.CampaignID, req.ImpressionData.UnitID)
+	if req.ImpressionData.VariantID > 0 {
+		if err := con.ContentVariantUseCase.RecordImpression(req.ImpressionData.CampaignID, req.ImpressionData.VariantID); err != nil {
+			core.Logger.WithError(err).Warnf("ContentImpression() - failed to record the impression of variant %v", req.ImpressionData.VariantID)
+		}
+	}
 	con.logImpression(req)
 	con.DeviceUseCase.SaveActivity(req.ImpressionData.DeviceID, req.ImpressionData.CampaignID, device.ActivityImpression)
 
@@ -105,6 +113,9 @@ func (con *Controller) logImpression(req dto.GetContentImpressionRequest) {
 	if req.ImpressionData.Gender != nil {
 		mapForLog["sex"] = *req.ImpressionData.Gender
 	}
+	if req.ImpressionData.VariantID > 0 {
+		mapForLog["variant_id"] = req.ImpressionData.VariantID
+	}
 	if req.Place != nil {
 		mapForLog["place"] = *req.Place
 	}
@@ -116,6 +127,9 @@ func (con *Controller) logImpression(req dto.GetContentImpressionRequest) {
 	}
 	if req.TrackingData != nil {
 		mapForLog["model_artifact"] = req.TrackingData.ModelArtifact
+		if req.TrackingData.Exploration != "" {
+			mapForLog["exploration"] = req.TrackingData.Exploration
+		}
 	}
 
 	core.Loggers["impression"].WithFields(mapForLog).Info("Log")
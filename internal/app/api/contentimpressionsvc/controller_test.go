@@ -11,6 +11,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentimpressionsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentimpressionsvc/dto"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/impressiondata"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/payload"
@@ -75,6 +76,29 @@ func (ts *ControllerTestSuite) Test_ContentImpression_NoTrackingData() {
 	ts.Equal(http.StatusOK, rec.Code)
 }
 
+func (ts *ControllerTestSuite) Test_ContentImpression_Variant() {
+	core.Loggers["impression"] = logrus.New()
+
+	structReq, trackingData, impressionData := ts.buildBaseRequest()
+	/* overwrite request parameters */
+	impressionData.VariantID = 7
+	/* overwrite request parameters end */
+	networkReq := ts.buildNetworkRequest(structReq)
+	ctx, rec := ts.buildContextAndRecorder(networkReq.GetHTTPRequest())
+
+	ts.trackingDataUseCase.On("ParseTrackingData", *structReq.TrackingDataStr).Return(trackingData, nil).Once()
+	ts.impressionDataUseCase.On("ParseImpressionData", structReq.ImpressionDataStr).Return(impressionData, nil).Once()
+	ts.contentCampaignUseCase.On("IncreaseImpression", impressionData.CampaignID, impressionData.UnitID).Return(nil).Once()
+	ts.contentVariantUseCase.On("RecordImpression", impressionData.CampaignID, int64(7)).Return(nil).Once()
+	ts.deviceUseCase.On("SaveActivity", impressionData.DeviceID, impressionData.CampaignID, device.ActivityImpression).Return(nil).Once()
+	ts.deviceUseCase.On("ValidateUnitDeviceToken", impressionData.UnitDeviceToken).Return(true, nil).Once()
+
+	err := ts.controller.ContentImpression(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+}
+
 func (ts *ControllerTestSuite) buildNetworkRequest(req *dto.GetContentImpressionRequest) *network.Request {
 	params := &url.Values{"data": {req.ImpressionDataStr}}
 	if req.TrackingDataStr != nil {
@@ -114,6 +138,7 @@ func (ts *ControllerTestSuite) buildBaseRequest() (*dto.GetContentImpressionRequ
 	trackingData := &trackingdata.TrackingData{ModelArtifact: "v1"}
 	impressionData := &impressiondata.ImpressionData{}
 	faker.FakeData(&impressionData)
+	impressionData.VariantID = 0
 
 	place := "__place__"
 	position := "__position__"
@@ -133,6 +158,7 @@ type ControllerTestSuite struct {
 	contentCampaignUseCase *mockContentCampaignUseCase
 	trackingDataUseCase    *mockTrackingDataUseCase
 	deviceUseCase          *mockDeviceUseCase
+	contentVariantUseCase  *mockContentVariantUseCase
 }
 
 func (ts *ControllerTestSuite) buildContextAndRecorder(httpRequest *http.Request) (ctx core.Context, rec *httptest.ResponseRecorder) {
@@ -147,8 +173,9 @@ func (ts *ControllerTestSuite) SetupTest() {
 	ts.trackingDataUseCase = new(mockTrackingDataUseCase)
 	ts.deviceUseCase = new(mockDeviceUseCase)
 	ts.impressionDataUseCase = new(mockImpressionDataUseCase)
+	ts.contentVariantUseCase = new(mockContentVariantUseCase)
 
-	ts.controller = contentimpressionsvc.NewController(ts.engine, ts.trackingDataUseCase, ts.impressionDataUseCase, ts.contentCampaignUseCase, ts.deviceUseCase)
+	ts.controller = contentimpressionsvc.NewController(ts.engine, ts.trackingDataUseCase, ts.impressionDataUseCase, ts.contentCampaignUseCase, ts.deviceUseCase, ts.contentVariantUseCase)
 }
 
 func (ts *ControllerTestSuite) AfterTest(_, _ string) {
@@ -156,6 +183,7 @@ func (ts *ControllerTestSuite) AfterTest(_, _ string) {
 	ts.contentCampaignUseCase.AssertExpectations(ts.T())
 	ts.trackingDataUseCase.AssertExpectations(ts.T())
 	ts.deviceUseCase.AssertExpectations(ts.T())
+	ts.contentVariantUseCase.AssertExpectations(ts.T())
 }
 
 type mockImpressionDataUseCase struct {
@@ -195,6 +223,22 @@ func (u *mockContentCampaignUseCase) IsContentCampaignExpired(contentCampaign *c
 	return ret.Get(0).(bool)
 }
 
+func (u *mockContentCampaignUseCase) Search(req contentcampaign.SearchRequest) (*contentcampaign.SearchResult, error) {
+	ret := u.Called(req)
+	return ret.Get(0).(*contentcampaign.SearchResult), ret.Error(1)
+}
+
+func (u *mockContentCampaignUseCase) SearchByIDs(campaignIDs ...int64) (*contentcampaign.SearchResult, error) {
+	ret := u.Called(campaignIDs)
+	return ret.Get(0).(*contentcampaign.SearchResult), ret.Error(1)
+}
+
+func (u *mockContentCampaignUseCase) Explain(campaignID int64, req contentcampaign.SearchRequest) (*contentcampaign.Explanation, error) {
+	ret := u.Called(campaignID, req)
+	explanation, _ := ret.Get(0).(*contentcampaign.Explanation)
+	return explanation, ret.Error(1)
+}
+
 type mockTrackingDataUseCase struct {
 	mock.Mock
 }
@@ -248,6 +292,11 @@ func (u *mockDeviceUseCase) DeleteProfile(dp device.Profile) error {
 	return ret.Error(0)
 }
 
+func (u *mockDeviceUseCase) ApplyContentFeedback(deviceID int64, feedback device.ContentFeedback, relevance device.ContentRelevance) (*device.Profile, error) {
+	ret := u.Called(deviceID, feedback, relevance)
+	return ret.Get(0).(*device.Profile), ret.Error(1)
+}
+
 func (u *mockDeviceUseCase) GetByID(deviceID int64) (*device.Device, error) {
 	ret := u.Called(deviceID)
 	return ret.Get(0).(*device.Device), ret.Error(1)
@@ -267,3 +316,44 @@ func (u *mockDeviceUseCase) ValidateUnitDeviceToken(unitDeviceToken string) (boo
 	ret := u.Called(unitDeviceToken)
 	return ret.Get(0).(bool), ret.Error(1)
 }
+
+type mockContentVariantUseCase struct {
+	mock.Mock
+}
+
+func (u *mockContentVariantUseCase) GetReport(campaignID int64) (*contentvariant.Report, error) {
+	ret := u.Called(campaignID)
+	return ret.Get(0).(*contentvariant.Report), ret.Error(1)
+}
+
+func (u *mockContentVariantUseCase) GetVariants(campaignID int64) ([]contentvariant.Variant, error) {
+	ret := u.Called(campaignID)
+	return ret.Get(0).([]contentvariant.Variant), ret.Error(1)
+}
+
+func (u *mockContentVariantUseCase) CreateVariant(variant contentvariant.Variant) (*contentvariant.Variant, error) {
+	ret := u.Called(variant)
+	return ret.Get(0).(*contentvariant.Variant), ret.Error(1)
+}
+
+func (u *mockContentVariantUseCase) UpdateVariant(variant contentvariant.Variant) (*contentvariant.Variant, error) {
+	ret := u.Called(variant)
+	return ret.Get(0).(*contentvariant.Variant), ret.Error(1)
+}
+
+func (u *mockContentVariantUseCase) DeleteVariant(campaignID int64, variantID int64) error {
+	return u.Called(campaignID, variantID).Error(0)
+}
+
+func (u *mockContentVariantUseCase) SelectVariants(campaignIDs []int64) (map[int64]contentvariant.Variant, error) {
+	ret := u.Called(campaignIDs)
+	return ret.Get(0).(map[int64]contentvariant.Variant), ret.Error(1)
+}
+
+func (u *mockContentVariantUseCase) RecordImpression(campaignID int64, variantID int64) error {
+	return u.Called(campaignID, variantID).Error(0)
+}
+
+func (u *mockContentVariantUseCase) RecordClick(campaignID int64, variantID int64) error {
+	return u.Called(campaignID, variantID).Error(0)
+}
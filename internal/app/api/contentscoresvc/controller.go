@@ -0,0 +1,149 @@
+package contentscoresvc
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentscoresvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+)
+
+// Controller type definition
+type Controller struct {
+	*common.ControllerBase
+	useCase contentscore.UseCase
+}
+
+// NewController returns new controller and binds requests to the controller
+func NewController(e *core.Engine, uc contentscore.UseCase) Controller {
+	con := Controller{useCase: uc}
+	e.GET("/api/internal/content/scores", con.GetActiveScoreSet)
+	e.POST("/api/internal/content/scores/versions/:version/targets", con.PostScores)
+	e.POST("/api/internal/content/scores/versions/:version/publish", con.PublishVersion)
+	e.POST("/api/internal/content/scores/rollback", con.RollBack)
+	return con
+}
+
+// PostScores stages the scores of the target into the version
+func (con *Controller) PostScores(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	target, err := con.parseTarget(c)
+	if err != nil {
+		return common.NewBindError(err)
+	}
+
+	var req dto.PostScoresRequest
+	if err := c.Bind(&req); err != nil {
+		return common.NewBindError(err)
+	} else if len(req.Scores) == 0 {
+		return common.NewValidationErrorf("Score is not provided.")
+	}
+
+	if err := con.useCase.SaveScores(c.Param("version"), *target, req.Scores); err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// PublishVersion activates the score set of the version
+func (con *Controller) PublishVersion(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	version, err := con.useCase.Publish(c.Param("version"))
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, con.toDTOVersion(*version))
+}
+
+// RollBack activates the previously published version
+func (con *Controller) RollBack(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	version, err := con.useCase.RollBack()
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, con.toDTOVersion(*version))
+}
+
+// GetActiveScoreSet returns the scores of the target in the active version which allocation uses
+func (con *Controller) GetActiveScoreSet(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	target, err := con.parseTarget(c)
+	if err != nil {
+		return common.NewBindError(err)
+	}
+
+	scoreSet, err := con.useCase.GetActiveScoreSet(*target)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+
+	scores := scoreSet.Scores
+	if scores == nil {
+		scores = make(map[int64]int)
+	}
+	return c.JSON(http.StatusOK, dto.ScoreSet{
+		Version: con.toDTOVersion(scoreSet.Version),
+		Target: dto.Target{
+			Country: scoreSet.Target.Country,
+			Gender:  scoreSet.Target.Gender,
+			Age:     scoreSet.Target.Age,
+		},
+		Scores: scores,
+	})
+}
+
+// parseTarget parses the target of query params. age is parsed as float for the scoring job sending "30.0"
+func (con *Controller) parseTarget(c core.Context) (*contentscore.Target, error) {
+	country := c.QueryParam("country")
+	if country == "" {
+		return nil, fmt.Errorf("country is required")
+	}
+
+	age, err := strconv.ParseFloat(c.QueryParam("age"), 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contentscore.Target{
+		Country: country,
+		Gender:  c.QueryParam("gender"),
+		Age:     int(age),
+	}, nil
+}
+
+func (con *Controller) toDTOVersion(version contentscore.Version) dto.Version {
+	return dto.Version{
+		Name:        version.Name,
+		Status:      string(version.Status),
+		CreatedAt:   version.CreatedAt,
+		PublishedAt: version.PublishedAt,
+	}
+}
+
+func (con *Controller) toHTTPError(err error) error {
+	switch err.(type) {
+	case contentscore.VersionNotFoundError, contentscore.NoActiveVersionError:
+		return common.NewNotFoundErrorf("%s", err.Error())
+	case contentscore.VersionPublishedError, contentscore.EmptyVersionError, contentscore.NoPreviousVersionError:
+		return &core.HttpError{Code: http.StatusConflict, Message: err.Error()}
+	default:
+		core.Logger.WithError(err).Error("contentscoresvc - failed to handle content scores")
+		return common.NewInternalServerError(err)
+	}
+}
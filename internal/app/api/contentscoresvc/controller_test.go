@@ -0,0 +1,143 @@
+package contentscoresvc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentscoresvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentscoresvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var target = contentscore.Target{Country: "KR", Gender: "M", Age: 30}
+
+func (ts *ControllerTestSuite) Test_PostScores() {
+	ts.useCase.On("SaveScores", "v2", target, map[int64]int{1111: 10, 2222: 20}).Return(nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/content/scores/versions/v2/targets?country=KR&gender=M&age=30.0", `{"scores": {"1111": 10, "2222": 20}}`)
+	ctx.SetParamNames("version")
+	ctx.SetParamValues("v2")
+
+	err := ts.controller.PostScores(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+	ts.useCase.AssertExpectations(ts.T())
+}
+
+func (ts *ControllerTestSuite) Test_PostScores_Forbidden() {
+	ctx, rec := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/content/scores/versions/v2/targets?country=KR&gender=M&age=30", `{"scores": {"1111": 10}}`)
+	ctx.Request().Header.Del("Authorization")
+
+	err := ts.controller.PostScores(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusForbidden, rec.Code)
+	ts.useCase.AssertNotCalled(ts.T(), "SaveScores", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (ts *ControllerTestSuite) Test_PublishVersion_Published() {
+	ts.useCase.On("Publish", "v2").Return(nil, contentscore.VersionPublishedError{Name: "v2"}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/content/scores/versions/v2/publish", "")
+	ctx.SetParamNames("version")
+	ctx.SetParamValues("v2")
+
+	err := ts.controller.PublishVersion(ctx)
+
+	ts.Equal(http.StatusConflict, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) Test_GetActiveScoreSet() {
+	publishedAt := time.Now().UTC().Truncate(time.Second)
+	ts.useCase.On("GetActiveScoreSet", target).Return(&contentscore.ScoreSet{
+		Version: contentscore.Version{ID: 2, Name: "v2", Status: contentscore.StatusPublished, PublishedAt: &publishedAt},
+		Target:  target,
+		Scores:  map[int64]int{1111: 10},
+	}, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder(http.MethodGet, "/api/internal/content/scores?country=KR&gender=M&age=30", "")
+
+	err := ts.controller.GetActiveScoreSet(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+
+	var res dto.ScoreSet
+	ts.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	ts.Equal("v2", res.Version.Name)
+	ts.Equal(publishedAt, *res.Version.PublishedAt)
+	ts.Equal(dto.Target{Country: "KR", Gender: "M", Age: 30}, res.Target)
+	ts.Equal(map[int64]int{1111: 10}, res.Scores)
+}
+
+func (ts *ControllerTestSuite) Test_GetActiveScoreSet_NoActiveVersion() {
+	ts.useCase.On("GetActiveScoreSet", target).Return(nil, contentscore.NoActiveVersionError{}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder(http.MethodGet, "/api/internal/content/scores?country=KR&gender=M&age=30", "")
+
+	err := ts.controller.GetActiveScoreSet(ctx)
+
+	ts.Equal(http.StatusNotFound, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) buildContextAndRecorder(method string, target string, body string) (core.Context, *httptest.ResponseRecorder) {
+	httpRequest := httptest.NewRequest(method, target, strings.NewReader(body))
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", os.Getenv("BASIC_AUTHORIZATION_VALUE"))
+	rec := httptest.NewRecorder()
+	return ts.engine.NewContext(httpRequest, rec), rec
+}
+
+func TestControllerSuite(t *testing.T) {
+	suite.Run(t, new(ControllerTestSuite))
+}
+
+type ControllerTestSuite struct {
+	suite.Suite
+	useCase    *mockUseCase
+	engine     *core.Engine
+	controller contentscoresvc.Controller
+}
+
+func (ts *ControllerTestSuite) SetupTest() {
+	ts.useCase = new(mockUseCase)
+	ts.engine = core.NewEngine(nil)
+	ts.controller = contentscoresvc.NewController(ts.engine, ts.useCase)
+}
+
+var _ contentscore.UseCase = &mockUseCase{}
+
+type mockUseCase struct {
+	mock.Mock
+}
+
+func (u *mockUseCase) SaveScores(versionName string, target contentscore.Target, scores map[int64]int) error {
+	return u.Called(versionName, target, scores).Error(0)
+}
+
+func (u *mockUseCase) Publish(versionName string) (*contentscore.Version, error) {
+	ret := u.Called(versionName)
+	version, _ := ret.Get(0).(*contentscore.Version)
+	return version, ret.Error(1)
+}
+
+func (u *mockUseCase) RollBack() (*contentscore.Version, error) {
+	ret := u.Called()
+	version, _ := ret.Get(0).(*contentscore.Version)
+	return version, ret.Error(1)
+}
+
+func (u *mockUseCase) GetActiveScoreSet(target contentscore.Target) (*contentscore.ScoreSet, error) {
+	ret := u.Called(target)
+	scoreSet, _ := ret.Get(0).(*contentscore.ScoreSet)
+	return scoreSet, ret.Error(1)
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// PostScoresRequest type definition
+type PostScoresRequest struct {
+	Scores map[int64]int `json:"scores"`
+}
+
+// Version type definition
+type Version struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// Target type definition
+type Target struct {
+	Country string `json:"country"`
+	Gender  string `json:"gender"`
+	Age     int    `json:"age"`
+}
+
+// ScoreSet type definition
+type ScoreSet struct {
+	Version Version       `json:"version"`
+	Target  Target        `json:"target"`
+	Scores  map[int64]int `json:"scores"`
+}
@@ -0,0 +1,168 @@
+package contentvariantsvc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentvariantsvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
+)
+
+// Controller type definition
+type Controller struct {
+	*common.ControllerBase
+	useCase contentvariant.UseCase
+}
+
+// NewController returns new controller and binds requests to the controller
+func NewController(e *core.Engine, uc contentvariant.UseCase) Controller {
+	con := Controller{useCase: uc}
+	e.GET("/api/internal/content/campaigns/:id/variants", con.GetReport)
+	e.POST("/api/internal/content/campaigns/:id/variants", con.PostVariant)
+	e.PUT("/api/internal/content/campaigns/:id/variants/:variant_id", con.PutVariant)
+	e.DELETE("/api/internal/content/campaigns/:id/variants/:variant_id", con.DeleteVariant)
+	return con
+}
+
+// GetReport returns the performance of the enabled variants of the campaign and the current winner
+func (con *Controller) GetReport(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	campaignID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return common.NewBindError(err)
+	}
+
+	report, err := con.useCase.GetReport(campaignID)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+
+	res := dto.Report{
+		CampaignID: report.CampaignID,
+		Variants:   make([]dto.Performance, 0, len(report.Performances)),
+		WinnerID:   report.WinnerID,
+		Conclusive: report.Conclusive,
+	}
+	for _, performance := range report.Performances {
+		res.Variants = append(res.Variants, dto.Performance{
+			Variant:        con.toDTOVariant(performance.Variant),
+			Impressions:    performance.Counts.Impressions,
+			Clicks:         performance.Counts.Clicks,
+			CTR:            performance.Counts.CTR(),
+			WinProbability: performance.WinProbability,
+		})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// PostVariant adds a creative variant to the campaign
+func (con *Controller) PostVariant(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	variant, err := con.bindVariant(c)
+	if err != nil {
+		return common.NewBindError(err)
+	}
+
+	created, err := con.useCase.CreateVariant(*variant)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusCreated, con.toDTOVariant(*created))
+}
+
+// PutVariant replaces the creative of the variant
+func (con *Controller) PutVariant(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	variant, err := con.bindVariant(c)
+	if err != nil {
+		return common.NewBindError(err)
+	}
+	if variant.ID, err = strconv.ParseInt(c.Param("variant_id"), 10, 64); err != nil {
+		return common.NewBindError(err)
+	}
+
+	updated, err := con.useCase.UpdateVariant(*variant)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, con.toDTOVariant(*updated))
+}
+
+// DeleteVariant removes the variant from the campaign. Its counts are kept for the history
+func (con *Controller) DeleteVariant(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	campaignID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return common.NewBindError(err)
+	}
+	variantID, err := strconv.ParseInt(c.Param("variant_id"), 10, 64)
+	if err != nil {
+		return common.NewBindError(err)
+	}
+
+	if err := con.useCase.DeleteVariant(campaignID, variantID); err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func (con *Controller) bindVariant(c core.Context) (*contentvariant.Variant, error) {
+	campaignID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var req dto.VariantRequest
+	if err := c.Bind(&req); err != nil {
+		return nil, err
+	}
+
+	return &contentvariant.Variant{
+		CampaignID:  campaignID,
+		Title:       req.Title,
+		Description: req.Description,
+		ImageURL:    req.ImageURL,
+		Enabled:     req.Enabled == nil || *req.Enabled,
+	}, nil
+}
+
+func (con *Controller) toDTOVariant(variant contentvariant.Variant) dto.Variant {
+	return dto.Variant{
+		ID:          variant.ID,
+		CampaignID:  variant.CampaignID,
+		Title:       variant.Title,
+		Description: variant.Description,
+		ImageURL:    variant.ImageURL,
+		Enabled:     variant.Enabled,
+		CreatedAt:   variant.CreatedAt,
+		UpdatedAt:   variant.UpdatedAt,
+	}
+}
+
+func (con *Controller) toHTTPError(err error) error {
+	switch err.(type) {
+	case contentvariant.VariantNotFoundError:
+		return common.NewNotFoundErrorf("%s", err.Error())
+	case contentvariant.ValidationError:
+		return common.NewValidationErrorf("%s", err.Error())
+	case contentvariant.TooManyVariantsError:
+		return &core.HttpError{Code: http.StatusConflict, Message: err.Error()}
+	default:
+		core.Logger.WithError(err).Error("contentvariantsvc - failed to handle content variants")
+		return common.NewInternalServerError(err)
+	}
+}
@@ -0,0 +1,194 @@
+package contentvariantsvc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentvariantsvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/contentvariantsvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func (ts *ControllerTestSuite) Test_GetReport() {
+	ts.useCase.On("GetReport", int64(1)).Return(&contentvariant.Report{
+		CampaignID: 1,
+		Performances: []contentvariant.Performance{
+			{Variant: contentvariant.Variant{ID: 10, CampaignID: 1, Title: "a", Enabled: true}, Counts: contentvariant.Counts{Impressions: 100, Clicks: 5}, WinProbability: 0.2},
+			{Variant: contentvariant.Variant{ID: 11, CampaignID: 1, Title: "b", Enabled: true}, Counts: contentvariant.Counts{Impressions: 100, Clicks: 10}, WinProbability: 0.8},
+		},
+		WinnerID: 11,
+	}, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder(http.MethodGet, "/api/internal/content/campaigns/1/variants", "")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("1")
+
+	err := ts.controller.GetReport(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+
+	var res dto.Report
+	ts.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	ts.Equal(int64(11), res.WinnerID)
+	ts.False(res.Conclusive)
+	ts.Len(res.Variants, 2)
+	ts.Equal(0.1, res.Variants[1].CTR)
+	ts.Equal(0.8, res.Variants[1].WinProbability)
+}
+
+func (ts *ControllerTestSuite) Test_GetReport_Forbidden() {
+	ctx, rec := ts.buildContextAndRecorder(http.MethodGet, "/api/internal/content/campaigns/1/variants", "")
+	ctx.Request().Header.Del("Authorization")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("1")
+
+	err := ts.controller.GetReport(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusForbidden, rec.Code)
+	ts.useCase.AssertNotCalled(ts.T(), "GetReport", mock.Anything)
+}
+
+func (ts *ControllerTestSuite) Test_PostVariant() {
+	ts.useCase.On("CreateVariant", contentvariant.Variant{CampaignID: 1, Title: "a", ImageURL: "https://image", Enabled: true}).
+		Return(&contentvariant.Variant{ID: 10, CampaignID: 1, Title: "a", ImageURL: "https://image", Enabled: true}, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/content/campaigns/1/variants", `{"title": "a", "image_url": "https://image"}`)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("1")
+
+	err := ts.controller.PostVariant(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusCreated, rec.Code)
+
+	var res dto.Variant
+	ts.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	ts.Equal(int64(10), res.ID)
+}
+
+func (ts *ControllerTestSuite) Test_PostVariant_TooMany() {
+	ts.useCase.On("CreateVariant", mock.AnythingOfType("contentvariant.Variant")).Return(nil, contentvariant.TooManyVariantsError{CampaignID: 1, Limit: 5}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/content/campaigns/1/variants", `{"title": "a"}`)
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("1")
+
+	err := ts.controller.PostVariant(ctx)
+
+	ts.Equal(http.StatusConflict, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) Test_PutVariant_NotFound() {
+	ts.useCase.On("UpdateVariant", contentvariant.Variant{ID: 10, CampaignID: 1, Title: "a", Enabled: false}).Return(nil, contentvariant.VariantNotFoundError{CampaignID: 1, VariantID: 10}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder(http.MethodPut, "/api/internal/content/campaigns/1/variants/10", `{"title": "a", "enabled": false}`)
+	ctx.SetParamNames("id", "variant_id")
+	ctx.SetParamValues("1", "10")
+
+	err := ts.controller.PutVariant(ctx)
+
+	ts.Equal(http.StatusNotFound, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) Test_DeleteVariant() {
+	ts.useCase.On("DeleteVariant", int64(1), int64(10)).Return(nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder(http.MethodDelete, "/api/internal/content/campaigns/1/variants/10", "")
+	ctx.SetParamNames("id", "variant_id")
+	ctx.SetParamValues("1", "10")
+
+	err := ts.controller.DeleteVariant(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+}
+
+func (ts *ControllerTestSuite) buildContextAndRecorder(method string, target string, body string) (core.Context, *httptest.ResponseRecorder) {
+	httpRequest := httptest.NewRequest(method, target, strings.NewReader(body))
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", os.Getenv("BASIC_AUTHORIZATION_VALUE"))
+	rec := httptest.NewRecorder()
+	return ts.engine.NewContext(httpRequest, rec), rec
+}
+
+func TestControllerSuite(t *testing.T) {
+	suite.Run(t, new(ControllerTestSuite))
+}
+
+type ControllerTestSuite struct {
+	suite.Suite
+	useCase    *mockUseCase
+	engine     *core.Engine
+	controller contentvariantsvc.Controller
+}
+
+func (ts *ControllerTestSuite) SetupTest() {
+	ts.useCase = new(mockUseCase)
+	ts.engine = core.NewEngine(nil)
+	ts.controller = contentvariantsvc.NewController(ts.engine, ts.useCase)
+}
+
+func (ts *ControllerTestSuite) TearDownTest() {
+	ts.useCase.AssertExpectations(ts.T())
+}
+
+var _ contentvariant.UseCase = &mockUseCase{}
+
+type mockUseCase struct {
+	mock.Mock
+}
+
+func (u *mockUseCase) GetReport(campaignID int64) (*contentvariant.Report, error) {
+	ret := u.Called(campaignID)
+	if report := ret.Get(0); report != nil {
+		return report.(*contentvariant.Report), ret.Error(1)
+	}
+	return nil, ret.Error(1)
+}
+
+func (u *mockUseCase) GetVariants(campaignID int64) ([]contentvariant.Variant, error) {
+	ret := u.Called(campaignID)
+	return ret.Get(0).([]contentvariant.Variant), ret.Error(1)
+}
+
+func (u *mockUseCase) CreateVariant(variant contentvariant.Variant) (*contentvariant.Variant, error) {
+	ret := u.Called(variant)
+	if created := ret.Get(0); created != nil {
+		return created.(*contentvariant.Variant), ret.Error(1)
+	}
+	return nil, ret.Error(1)
+}
+
+func (u *mockUseCase) UpdateVariant(variant contentvariant.Variant) (*contentvariant.Variant, error) {
+	ret := u.Called(variant)
+	if updated := ret.Get(0); updated != nil {
+		return updated.(*contentvariant.Variant), ret.Error(1)
+	}
+	return nil, ret.Error(1)
+}
+
+func (u *mockUseCase) DeleteVariant(campaignID int64, variantID int64) error {
+	return u.Called(campaignID, variantID).Error(0)
+}
+
+func (u *mockUseCase) SelectVariants(campaignIDs []int64) (map[int64]contentvariant.Variant, error) {
+	ret := u.Called(campaignIDs)
+	return ret.Get(0).(map[int64]contentvariant.Variant), ret.Error(1)
+}
+
+func (u *mockUseCase) RecordImpression(campaignID int64, variantID int64) error {
+	return u.Called(campaignID, variantID).Error(0)
+}
+
+func (u *mockUseCase) RecordClick(campaignID int64, variantID int64) error {
+	return u.Called(campaignID, variantID).Error(0)
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// VariantRequest type definition
+type VariantRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url"`
+	Enabled     *bool  `json:"enabled"` // enabled by default
+}
+
+// Variant type definition
+type Variant struct {
+	ID          int64     `json:"id"`
+	CampaignID  int64     `json:"campaign_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	ImageURL    string    `json:"image_url"`
+	Enabled     bool      `json:"enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Performance type definition
+type Performance struct {
+	Variant
+	Impressions    int64   `json:"impressions"`
+	Clicks         int64   `json:"clicks"`
+	CTR            float64 `json:"ctr"`
+	WinProbability float64 `json:"win_probability"`
+}
+
+// Report type definition
+type Report struct {
+	CampaignID int64         `json:"campaign_id"`
+	Variants   []Performance `json:"variants"`
+	WinnerID   int64         `json:"winner_id,omitempty"`
+	Conclusive bool          `json:"conclusive"`
+}
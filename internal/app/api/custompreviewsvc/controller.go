@@ -9,6 +9,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/custompreviewsvc/dto"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/custompreview"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
 )
 
 // TODO Solve daylight saving time (summer time) case
@@ -18,14 +19,15 @@ import (
 // Controller struct definition
 type Controller struct {
 	*common.ControllerBase
-	useCase custompreview.UseCase
-	mapper  dto.Mapper
+	useCase             custompreview.UseCase
+	localizationUseCase localization.UseCase
+	mapper              dto.Mapper
 	freezableClock
 }
 
 // NewController returns new controller and binds requests to the controller
-func NewController(e *core.Engine, uc custompreview.UseCase, freezedTime *time.Time) Controller {
-	c := Controller{useCase: uc, freezableClock: newFreezableClock(freezedTime)}
+func NewController(e *core.Engine, uc custompreview.UseCase, localizationUseCase localization.UseCase, freezedTime *time.Time) Controller {
+	c := Controller{useCase: uc, localizationUseCase: localizationUseCase, freezableClock: newFreezableClock(freezedTime)}
 	e.GET("/api/v3/custom-preview-message/config", c.GetConfig)
 	e.GET("/api/v3/custom-preview/config", c.DeprecatedGetConfig)
 	return c
@@ -51,11 +53,26 @@ func (c *Controller) GetConfig(ctx core.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": fmt.Sprintf("config not found with unit id %v", req.UnitID)})
 	}
 
+	dtoConfig := c.mapper.ConfigToDTOConfig(*config)
+	dtoConfig.Message = c.localizeMessage(*config, localization.Negotiate(req.Locale, ctx.Request().Header.Get("Accept-Language")))
 	return ctx.JSON(http.StatusOK, dto.GetConfigRes{
-		Config: *c.mapper.ConfigToDTOConfig(*config),
+		Config: *dtoConfig,
 	})
 }
 
+// localizeMessage returns the message translated to the languages. The message of the config is returned if it's not translated
+func (c *Controller) localizeMessage(config custompreview.Config, languages localization.Languages) string {
+	if c.localizationUseCase == nil {
+		return config.Message
+	}
+	textsMap, err := c.localizationUseCase.Localize(localization.ResourceTypeCustomPreview, []int64{config.ID}, languages)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("localizeMessage() - failed to localize custom preview %d", config.ID)
+		return config.Message
+	}
+	return textsMap[config.ID].Get(localization.FieldMessage, config.Message)
+}
+
 // DeprecatedGetConfig returns status code 410
 func (c *Controller) DeprecatedGetConfig(ctx core.Context) error {
 	return ctx.JSON(http.StatusGone, map[string]interface{}{"error": fmt.Sprintf("this api is deprecated")})
@@ -15,6 +15,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/custompreviewsvc"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/custompreviewsvc/dto"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/custompreview"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
 	"github.com/bxcodec/faker"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -33,16 +34,20 @@ type ControllerTestSuite struct {
 	controller custompreviewsvc.Controller
 	engine     *core.Engine
 	useCase    *mockUseCase
+
+	localizationUseCase *mockLocalizationUseCase
 }
 
 func (ts *ControllerTestSuite) SetupTest() {
 	ts.engine = core.NewEngine(nil)
 	ts.useCase = new(mockUseCase)
-	ts.controller = custompreviewsvc.NewController(ts.engine, ts.useCase, nil)
+	ts.localizationUseCase = new(mockLocalizationUseCase)
+	ts.controller = custompreviewsvc.NewController(ts.engine, ts.useCase, ts.localizationUseCase, nil)
 }
 
 func (ts *ControllerTestSuite) AfterTest() {
 	ts.useCase.AssertExpectations(ts.T())
+	ts.localizationUseCase.AssertExpectations(ts.T())
 }
 
 func (ts *ControllerTestSuite) Test_GetConfig() {
@@ -54,6 +59,7 @@ func (ts *ControllerTestSuite) Test_GetConfig() {
 		ctx, rec := ts.buildContextAndRecorder(req.Build().GetHTTPRequest())
 		ts.useCase.On("GetClock").Return()
 		ts.useCase.On("GetConfigByUnitID", config.UnitID, timezone, mock.Anything).Return(config, nil).Once()
+		ts.localizationUseCase.On("Localize", localization.ResourceTypeCustomPreview, []int64{config.ID}, localization.Languages(nil)).Return(map[int64]localization.Texts{}, nil).Once()
 
 		err := ts.controller.GetConfig(ctx)
 		ts.NoError(err)
@@ -65,6 +71,24 @@ func (ts *ControllerTestSuite) Test_GetConfig() {
 		ts.equalConfig(dtoconfig, result.Config)
 	})
 
+	ts.Run("localized", func() {
+		localizedReq := ts.buildGetConfigReq(dtoconfig.UnitID, timezone)
+		localizedReq.Header.Set("Accept-Language", "ko-KR,en;q=0.5")
+		ctx, rec := ts.buildContextAndRecorder(localizedReq.Build().GetHTTPRequest())
+		ts.useCase.On("GetConfigByUnitID", config.UnitID, timezone, mock.Anything).Return(config, nil).Once()
+		ts.localizationUseCase.On("Localize", localization.ResourceTypeCustomPreview, []int64{config.ID}, localization.Languages{"ko-KR", "ko", "en"}).
+			Return(map[int64]localization.Texts{config.ID: {localization.FieldMessage: "안녕하세요"}}, nil).Once()
+
+		err := ts.controller.GetConfig(ctx)
+		ts.NoError(err)
+
+		result := dto.GetConfigRes{}
+		json.Unmarshal(rec.Body.Bytes(), &result)
+
+		ts.Equal(http.StatusOK, rec.Code)
+		ts.Equal("안녕하세요", result.Message)
+	})
+
 	ts.Run("config not found", func() {
 		ctx, rec := ts.buildContextAndRecorder(req.Build().GetHTTPRequest())
 		ts.useCase.On("GetConfigByUnitID", config.UnitID, timezone, mock.Anything).Return(nil, nil).Once()
@@ -98,6 +122,18 @@ func (u *mockUseCase) GetConfigByUnitID(unitID int64, timezone string, now time.
 	return ret.Get(0).(*custompreview.Config), ret.Error(1)
 }
 
+type mockLocalizationUseCase struct {
+	mock.Mock
+}
+
+func (u *mockLocalizationUseCase) Localize(resourceType localization.ResourceType, resourceIDs []int64, languages localization.Languages) (map[int64]localization.Texts, error) {
+	ret := u.Called(resourceType, resourceIDs, languages)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(map[int64]localization.Texts), ret.Error(1)
+}
+
 func (ts *ControllerTestSuite) buildGetConfigReq(unitID int64, timezone string) network.Request {
 	req := network.Request{
 		Header: &http.Header{"Time-Zone": []string{timezone}},
@@ -4,7 +4,8 @@ import "time"
 
 // GetConfigReq struct definition
 type GetConfigReq struct {
-	UnitID int64 `query:"unit_id" validate:"required"`
+	UnitID int64  `query:"unit_id" validate:"required"`
+	Locale string `query:"locale"` // the message is localized by Accept-Language if empty
 }
 
 // GetConfigRes struct definition
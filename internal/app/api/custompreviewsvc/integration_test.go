@@ -63,7 +63,7 @@ func (ts *IntegrationTestSuite) Test_Main() {
 	for _, tc := range cases {
 		ts.Run(tc.description, func() {
 			// Apply Precondition
-			ts.controller = custompreviewsvc.NewController(ts.engine, ts.uc, &tc.preCondition.currentTime)
+			ts.controller = custompreviewsvc.NewController(ts.engine, ts.uc, nil, &tc.preCondition.currentTime)
 			for _, config := range tc.preCondition.dbStatus {
 				ts.gdb.Create(&config)
 			}
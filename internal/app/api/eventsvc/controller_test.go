@@ -366,6 +366,15 @@ func (u *mockEventUseCase) GetTrackingURL(deviceID int64, resource event.Resourc
 	return ret.Get(0).(string), ret.Error(1)
 }
 
+func (u *mockEventUseCase) SaveFailTrackers(deviceID int64, resource event.Resource, failTrackers []string) {
+	u.Called(deviceID, resource, failTrackers)
+}
+
+func (u *mockEventUseCase) GetFailTrackers(deviceID int64, resource event.Resource) ([]string, error) {
+	ret := u.Called(deviceID, resource)
+	return ret.Get(0).([]string), ret.Error(1)
+}
+
 type mockTokenEncrypter struct {
 	mock.Mock
 }
@@ -403,6 +412,22 @@ func (u *mockContentCampaignUseCase) IsContentCampaignExpired(contentCampaign *c
 	return ret.Get(0).(bool)
 }
 
+func (u *mockContentCampaignUseCase) Search(req contentcampaign.SearchRequest) (*contentcampaign.SearchResult, error) {
+	ret := u.Called(req)
+	return ret.Get(0).(*contentcampaign.SearchResult), ret.Error(1)
+}
+
+func (u *mockContentCampaignUseCase) SearchByIDs(campaignIDs ...int64) (*contentcampaign.SearchResult, error) {
+	ret := u.Called(campaignIDs)
+	return ret.Get(0).(*contentcampaign.SearchResult), ret.Error(1)
+}
+
+func (u *mockContentCampaignUseCase) Explain(campaignID int64, req contentcampaign.SearchRequest) (*contentcampaign.Explanation, error) {
+	ret := u.Called(campaignID, req)
+	explanation, _ := ret.Get(0).(*contentcampaign.Explanation)
+	return explanation, ret.Error(1)
+}
+
 type mockAdUsecase struct {
 	mock.Mock
 }
@@ -483,6 +508,11 @@ func (u *mockDeviceUseCase) DeleteProfile(dp device.Profile) error {
 	return ret.Error(0)
 }
 
+func (u *mockDeviceUseCase) ApplyContentFeedback(deviceID int64, feedback device.ContentFeedback, relevance device.ContentRelevance) (*device.Profile, error) {
+	ret := u.Called(deviceID, feedback, relevance)
+	return ret.Get(0).(*device.Profile), ret.Error(1)
+}
+
 func (u *mockDeviceUseCase) GetByID(deviceID int64) (*device.Device, error) {
 	ret := u.Called(deviceID)
 	return ret.Get(0).(*device.Device), ret.Error(1)
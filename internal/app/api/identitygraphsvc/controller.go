@@ -0,0 +1,111 @@
+package identitygraphsvc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/identitygraphsvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
+)
+
+const resolveTypeDevice = "device"
+
+// Controller type definition
+type Controller struct {
+	*common.ControllerBase
+	useCase identitygraph.UseCase
+}
+
+// NewController returns new controller and binds requests to the controller
+func NewController(e *core.Engine, uc identitygraph.UseCase) Controller {
+	con := Controller{useCase: uc}
+	e.GET("/api/internal/identities/devices/:id", con.GetDeviceLinks)
+	e.GET("/api/internal/identities/resolve", con.Resolve)
+	return con
+}
+
+// GetDeviceLinks returns every identifier ever associated with the device
+func (con *Controller) GetDeviceLinks(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	deviceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return common.NewBindError(err)
+	}
+
+	links, err := con.useCase.GetLinks(deviceID)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, dto.DeviceLinks{DeviceID: deviceID, Links: con.toDTOLinks(links)})
+}
+
+// Resolve returns the account of the devices connected to the identifier for reward fraud and support lookups
+func (con *Controller) Resolve(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	var req dto.ResolveRequest
+	if err := con.Bind(c, &req); err != nil {
+		return err
+	}
+
+	var account *identitygraph.Account
+	var err error
+	switch identitygraph.IdentifierType(req.Type) {
+	case resolveTypeDevice:
+		deviceID, parseErr := strconv.ParseInt(req.Value, 10, 64)
+		if parseErr != nil {
+			return common.NewBindError(parseErr)
+		}
+		account, err = con.useCase.ResolveDevice(deviceID)
+	case identitygraph.IdentifierUnitDeviceToken:
+		if req.AppID == 0 {
+			return common.NewValidationErrorf("app_id is required for %s", req.Type)
+		}
+		account, err = con.useCase.Resolve(identitygraph.UnitDeviceTokenIdentifier(req.AppID, req.Value))
+	default:
+		account, err = con.useCase.Resolve(identitygraph.Identifier{Type: identitygraph.IdentifierType(req.Type), Value: req.Value})
+	}
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.Account{
+		CanonicalDeviceID: account.CanonicalDeviceID,
+		DeviceIDs:         account.DeviceIDs,
+		Links:             con.toDTOLinks(account.Links),
+		Truncated:         account.Truncated,
+	})
+}
+
+func (con *Controller) toDTOLinks(links []identitygraph.Link) []dto.Link {
+	dtoLinks := make([]dto.Link, 0, len(links))
+	for _, link := range links {
+		dtoLinks = append(dtoLinks, dto.Link{
+			DeviceID:    link.DeviceID,
+			Type:        string(link.Identifier.Type),
+			AppID:       link.Identifier.AppID,
+			Value:       link.Identifier.Value,
+			Source:      string(link.Source),
+			FirstSeenAt: link.FirstSeenAt,
+			LastSeenAt:  link.LastSeenAt,
+		})
+	}
+	return dtoLinks
+}
+
+func (con *Controller) toHTTPError(err error) error {
+	switch err.(type) {
+	case identitygraph.NotFoundError:
+		return common.NewNotFoundErrorf("%s", err.Error())
+	default:
+		core.Logger.WithError(err).Error("identitygraphsvc - failed to look up identity graph")
+		return common.NewInternalServerError(err)
+	}
+}
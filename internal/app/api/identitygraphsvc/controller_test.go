@@ -0,0 +1,171 @@
+package identitygraphsvc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/identitygraphsvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/identitygraphsvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var seenAt = time.Date(2019, 5, 1, 10, 30, 0, 0, time.UTC)
+
+func (ts *ControllerTestSuite) Test_GetDeviceLinks() {
+	ts.useCase.On("GetLinks", int64(1)).Return([]identitygraph.Link{
+		{ID: 10, DeviceID: 1, Identifier: identitygraph.UnitDeviceTokenIdentifier(100, "token"), Source: identitygraph.SourceDevice, FirstSeenAt: seenAt, LastSeenAt: seenAt},
+	}, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder("/api/internal/identities/devices/1")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("1")
+
+	err := ts.controller.GetDeviceLinks(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+
+	var res dto.DeviceLinks
+	ts.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	ts.Equal(dto.DeviceLinks{DeviceID: 1, Links: []dto.Link{
+		{DeviceID: 1, Type: "unit_device_token", AppID: 100, Value: "token", Source: "device", FirstSeenAt: seenAt, LastSeenAt: seenAt},
+	}}, res)
+}
+
+func (ts *ControllerTestSuite) Test_GetDeviceLinks_Forbidden() {
+	ctx, rec := ts.buildContextAndRecorder("/api/internal/identities/devices/1")
+	ctx.Request().Header.Del("Authorization")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("1")
+
+	err := ts.controller.GetDeviceLinks(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusForbidden, rec.Code)
+	ts.useCase.AssertNotCalled(ts.T(), "GetLinks", mock.Anything)
+}
+
+func (ts *ControllerTestSuite) Test_Resolve() {
+	ts.useCase.On("Resolve", identitygraph.IFAIdentifier("ifa-a")).Return(&identitygraph.Account{
+		CanonicalDeviceID: 1,
+		DeviceIDs:         []int64{1, 2},
+		Links: []identitygraph.Link{
+			{ID: 10, DeviceID: 1, Identifier: identitygraph.IFAIdentifier("ifa-a"), Source: identitygraph.SourceDevice, FirstSeenAt: seenAt, LastSeenAt: seenAt},
+			{ID: 11, DeviceID: 2, Identifier: identitygraph.IFAIdentifier("ifa-a"), Source: identitygraph.SourceClick, FirstSeenAt: seenAt, LastSeenAt: seenAt},
+		},
+	}, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder("/api/internal/identities/resolve?type=ifa&value=ifa-a")
+
+	err := ts.controller.Resolve(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+
+	var res dto.Account
+	ts.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	ts.Equal(int64(1), res.CanonicalDeviceID)
+	ts.Equal([]int64{1, 2}, res.DeviceIDs)
+	ts.Len(res.Links, 2)
+	ts.False(res.Truncated)
+}
+
+func (ts *ControllerTestSuite) Test_Resolve_Device() {
+	ts.useCase.On("ResolveDevice", int64(1)).Return(&identitygraph.Account{CanonicalDeviceID: 1, DeviceIDs: []int64{1}}, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder("/api/internal/identities/resolve?type=device&value=1")
+
+	err := ts.controller.Resolve(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+}
+
+func (ts *ControllerTestSuite) Test_Resolve_UnitDeviceTokenWithoutApp() {
+	ctx, _ := ts.buildContextAndRecorder("/api/internal/identities/resolve?type=unit_device_token&value=token")
+
+	err := ts.controller.Resolve(ctx)
+
+	ts.Equal(http.StatusBadRequest, err.(*core.HttpError).Code)
+	ts.useCase.AssertNotCalled(ts.T(), "Resolve", mock.Anything)
+}
+
+func (ts *ControllerTestSuite) Test_Resolve_NotFound() {
+	identifier := identitygraph.UnitDeviceTokenIdentifier(100, "token")
+	ts.useCase.On("Resolve", identifier).Return(nil, identitygraph.NotFoundError{Identifier: identifier.String()}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder("/api/internal/identities/resolve?type=unit_device_token&value=token&app_id=100")
+
+	err := ts.controller.Resolve(ctx)
+
+	ts.Equal(http.StatusNotFound, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) buildContextAndRecorder(target string) (core.Context, *httptest.ResponseRecorder) {
+	httpRequest := httptest.NewRequest(http.MethodGet, target, strings.NewReader(""))
+	httpRequest.Header.Set("Authorization", os.Getenv("BASIC_AUTHORIZATION_VALUE"))
+	rec := httptest.NewRecorder()
+	return ts.engine.NewContext(httpRequest, rec), rec
+}
+
+func TestControllerSuite(t *testing.T) {
+	suite.Run(t, new(ControllerTestSuite))
+}
+
+type ControllerTestSuite struct {
+	suite.Suite
+	useCase    *mockUseCase
+	engine     *core.Engine
+	controller identitygraphsvc.Controller
+}
+
+func (ts *ControllerTestSuite) SetupTest() {
+	ts.useCase = new(mockUseCase)
+	ts.engine = core.NewEngine(nil)
+	ts.controller = identitygraphsvc.NewController(ts.engine, ts.useCase)
+}
+
+func (ts *ControllerTestSuite) TearDownTest() {
+	ts.useCase.AssertExpectations(ts.T())
+}
+
+var _ identitygraph.UseCase = &mockUseCase{}
+
+type mockUseCase struct {
+	mock.Mock
+}
+
+func (u *mockUseCase) LinkDevice(deviceID int64, identifiers []identitygraph.Identifier, source identitygraph.Source, seenAt time.Time) error {
+	return u.Called(deviceID, identifiers, source, seenAt).Error(0)
+}
+
+func (u *mockUseCase) GetLinks(deviceID int64) ([]identitygraph.Link, error) {
+	ret := u.Called(deviceID)
+	return ret.Get(0).([]identitygraph.Link), ret.Error(1)
+}
+
+func (u *mockUseCase) Resolve(identifier identitygraph.Identifier) (*identitygraph.Account, error) {
+	ret := u.Called(identifier)
+	account, _ := ret.Get(0).(*identitygraph.Account)
+	return account, ret.Error(1)
+}
+
+func (u *mockUseCase) ResolveDevice(deviceID int64) (*identitygraph.Account, error) {
+	ret := u.Called(deviceID)
+	account, _ := ret.Get(0).(*identitygraph.Account)
+	return account, ret.Error(1)
+}
+
+func (u *mockUseCase) BackfillHistories(afterID int64, size int) (*identitygraph.BackfillResult, error) {
+	ret := u.Called(afterID, size)
+	result, _ := ret.Get(0).(*identitygraph.BackfillResult)
+	return result, ret.Error(1)
+}
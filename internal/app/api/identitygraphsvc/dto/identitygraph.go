@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// ResolveRequest is the identifier to resolve the account of. device resolves the device of the id in value
+type ResolveRequest struct {
+	Type  string `query:"type" validate:"required,oneof=device ifa ifv unit_device_token cookie"`
+	Value string `query:"value" validate:"required"`
+	AppID int64  `query:"app_id"` // required for unit_device_token
+}
+
+// Link type definition
+type Link struct {
+	DeviceID    int64     `json:"device_id"`
+	Type        string    `json:"type"`
+	AppID       int64     `json:"app_id,omitempty"`
+	Value       string    `json:"value"`
+	Source      string    `json:"source"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// DeviceLinks is every identifier ever associated with the device
+type DeviceLinks struct {
+	DeviceID int64  `json:"device_id"`
+	Links    []Link `json:"links"`
+}
+
+// Account type definition
+type Account struct {
+	CanonicalDeviceID int64   `json:"canonical_device_id"`
+	DeviceIDs         []int64 `json:"device_ids"`
+	Links             []Link  `json:"links"`
+	Truncated         bool    `json:"truncated"` // true if the devices are cut by the policy of the graph
+}
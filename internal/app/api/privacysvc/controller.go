@@ -0,0 +1,139 @@
+package privacysvc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/privacysvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy"
+)
+
+// Controller type definition
+type Controller struct {
+	*common.ControllerBase
+	useCase privacy.UseCase
+}
+
+// NewController returns new controller and binds requests to the controller
+func NewController(e *core.Engine, uc privacy.UseCase) Controller {
+	con := Controller{useCase: uc}
+	e.POST("/api/internal/privacy/requests", con.PostRequest)
+	e.GET("/api/internal/privacy/requests/:id", con.GetRequest)
+	e.POST("/api/internal/privacy/requests/:id/retry", con.RetryRequest)
+	return con
+}
+
+// PostRequest exports or erases the data of the subject in every store. The request is returned with the progress of each store
+func (con *Controller) PostRequest(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	var req dto.CreateRequest
+	if err := con.Bind(c, &req); err != nil {
+		return err
+	}
+
+	subject := privacy.Subject{AppID: req.AppID, IFA: req.IFA, PubUserID: req.PubUserID}
+	if !subject.IsValid() {
+		return common.NewValidationErrorf("ifa or publisher_user_id is required")
+	}
+
+	request, err := con.useCase.CreateRequest(privacy.RequestType(req.Type), subject)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusCreated, con.toDTORequest(*request))
+}
+
+// GetRequest returns the audit record of the request including the export
+func (con *Controller) GetRequest(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return common.NewBindError(err)
+	}
+
+	request, err := con.useCase.GetRequest(id)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, con.toDTORequest(*request))
+}
+
+// RetryRequest runs the failed request again on the stores that haven't completed
+func (con *Controller) RetryRequest(c core.Context) error {
+	if !con.IsAuthorized(c) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return common.NewBindError(err)
+	}
+
+	request, err := con.useCase.RetryRequest(id)
+	if err != nil {
+		return con.toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, con.toDTORequest(*request))
+}
+
+func (con *Controller) toDTORequest(request privacy.Request) dto.Request {
+	res := dto.Request{
+		ID:   request.ID,
+		Type: string(request.Type),
+		Subject: dto.Subject{
+			AppID:     request.Subject.AppID,
+			IFA:       request.Subject.IFA,
+			PubUserID: request.Subject.PubUserID,
+		},
+		Status:      string(request.Status),
+		DeviceIDs:   request.DeviceIDs,
+		Steps:       make([]dto.Step, 0, len(request.Steps)),
+		CreatedAt:   request.CreatedAt,
+		UpdatedAt:   request.UpdatedAt,
+		CompletedAt: request.CompletedAt,
+	}
+	for _, step := range request.Steps {
+		res.Steps = append(res.Steps, dto.Step{
+			Store:       step.Store,
+			Status:      string(step.Status),
+			Records:     step.Records,
+			Error:       step.Error,
+			CompletedAt: step.CompletedAt,
+		})
+	}
+
+	if request.Export != nil {
+		res.Export = &dto.Export{
+			GeneratedAt: request.Export.GeneratedAt,
+			Stores:      make(map[string][]map[string]interface{}, len(request.Export.Stores)),
+		}
+		for store, records := range request.Export.Stores {
+			rows := make([]map[string]interface{}, 0, len(records))
+			for _, record := range records {
+				rows = append(rows, record)
+			}
+			res.Export.Stores[store] = rows
+		}
+	}
+	return res
+}
+
+func (con *Controller) toHTTPError(err error) error {
+	switch err.(type) {
+	case privacy.NotFoundError:
+		return common.NewNotFoundErrorf("%s", err.Error())
+	case privacy.NotRetryableError:
+		return common.NewValidationErrorf("%s", err.Error())
+	default:
+		core.Logger.WithError(err).Error("privacysvc - failed to process privacy request")
+		return common.NewInternalServerError(err)
+	}
+}
@@ -0,0 +1,181 @@
+package privacysvc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/privacysvc"
+	"github.com/Buzzvil/buzzscreen-api/internal/app/api/privacysvc/dto"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var createdAt = time.Date(2019, 5, 1, 10, 30, 0, 0, time.UTC)
+
+func (ts *ControllerTestSuite) Test_PostRequest() {
+	subject := privacy.Subject{AppID: 100, IFA: "ifa-a"}
+	ts.useCase.On("CreateRequest", privacy.RequestTypeExport, subject).Return(&privacy.Request{
+		ID:        7,
+		Type:      privacy.RequestTypeExport,
+		Subject:   subject,
+		Status:    privacy.StatusCompleted,
+		DeviceIDs: []int64{1},
+		Steps:     []privacy.Step{{Store: "device", Status: privacy.StatusCompleted, Records: 1, CompletedAt: &createdAt}},
+		Export: &privacy.Export{
+			GeneratedAt: createdAt,
+			Stores:      map[string][]privacy.Record{"device": {{"id": 1, "ifa": "ifa-a"}}},
+		},
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+		CompletedAt: &createdAt,
+	}, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/privacy/requests", `{"type": "export", "app_id": 100, "ifa": "ifa-a"}`)
+
+	err := ts.controller.PostRequest(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusCreated, rec.Code)
+
+	var res dto.Request
+	ts.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	ts.Equal(int64(7), res.ID)
+	ts.Equal("completed", res.Status)
+	ts.Equal(dto.Subject{AppID: 100, IFA: "ifa-a"}, res.Subject)
+	ts.Equal([]dto.Step{{Store: "device", Status: "completed", Records: 1, CompletedAt: &createdAt}}, res.Steps)
+	ts.Equal(map[string][]map[string]interface{}{"device": {{"id": float64(1), "ifa": "ifa-a"}}}, res.Export.Stores)
+}
+
+func (ts *ControllerTestSuite) Test_PostRequest_NoIdentifier() {
+	ctx, _ := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/privacy/requests", `{"type": "erasure", "app_id": 100}`)
+
+	err := ts.controller.PostRequest(ctx)
+
+	ts.Equal(http.StatusBadRequest, err.(*core.HttpError).Code)
+	ts.useCase.AssertNotCalled(ts.T(), "CreateRequest", mock.Anything, mock.Anything)
+}
+
+func (ts *ControllerTestSuite) Test_PostRequest_InvalidType() {
+	ctx, _ := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/privacy/requests", `{"type": "delete", "app_id": 100, "ifa": "ifa-a"}`)
+
+	err := ts.controller.PostRequest(ctx)
+
+	ts.Equal(http.StatusBadRequest, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) Test_PostRequest_Forbidden() {
+	ctx, rec := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/privacy/requests", `{"type": "erasure", "app_id": 100, "ifa": "ifa-a"}`)
+	ctx.Request().Header.Del("Authorization")
+
+	err := ts.controller.PostRequest(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (ts *ControllerTestSuite) Test_GetRequest_NotFound() {
+	ts.useCase.On("GetRequest", int64(7)).Return(nil, privacy.NotFoundError{RequestID: 7}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder(http.MethodGet, "/api/internal/privacy/requests/7", "")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("7")
+
+	err := ts.controller.GetRequest(ctx)
+
+	ts.Equal(http.StatusNotFound, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) Test_RetryRequest() {
+	ts.useCase.On("RetryRequest", int64(7)).Return(&privacy.Request{
+		ID:     7,
+		Type:   privacy.RequestTypeErasure,
+		Status: privacy.StatusCompleted,
+		Steps:  []privacy.Step{{Store: "device", Status: privacy.StatusCompleted, Records: 2}},
+	}, nil).Once()
+
+	ctx, rec := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/privacy/requests/7/retry", "")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("7")
+
+	err := ts.controller.RetryRequest(ctx)
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+
+	var res dto.Request
+	ts.NoError(json.Unmarshal(rec.Body.Bytes(), &res))
+	ts.Equal("completed", res.Status)
+	ts.Nil(res.Export)
+}
+
+func (ts *ControllerTestSuite) Test_RetryRequest_NotRetryable() {
+	ts.useCase.On("RetryRequest", int64(7)).Return(nil, privacy.NotRetryableError{RequestID: 7, Status: privacy.StatusCompleted}).Once()
+
+	ctx, _ := ts.buildContextAndRecorder(http.MethodPost, "/api/internal/privacy/requests/7/retry", "")
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("7")
+
+	err := ts.controller.RetryRequest(ctx)
+
+	ts.Equal(http.StatusBadRequest, err.(*core.HttpError).Code)
+}
+
+func (ts *ControllerTestSuite) buildContextAndRecorder(method string, target string, body string) (core.Context, *httptest.ResponseRecorder) {
+	httpRequest := httptest.NewRequest(method, target, strings.NewReader(body))
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", os.Getenv("BASIC_AUTHORIZATION_VALUE"))
+	rec := httptest.NewRecorder()
+	return ts.engine.NewContext(httpRequest, rec), rec
+}
+
+func TestControllerSuite(t *testing.T) {
+	suite.Run(t, new(ControllerTestSuite))
+}
+
+type ControllerTestSuite struct {
+	suite.Suite
+	useCase    *mockUseCase
+	engine     *core.Engine
+	controller privacysvc.Controller
+}
+
+func (ts *ControllerTestSuite) SetupTest() {
+	ts.useCase = new(mockUseCase)
+	ts.engine = core.NewEngine(nil)
+	ts.controller = privacysvc.NewController(ts.engine, ts.useCase)
+}
+
+func (ts *ControllerTestSuite) TearDownTest() {
+	ts.useCase.AssertExpectations(ts.T())
+}
+
+var _ privacy.UseCase = &mockUseCase{}
+
+type mockUseCase struct {
+	mock.Mock
+}
+
+func (u *mockUseCase) CreateRequest(requestType privacy.RequestType, subject privacy.Subject) (*privacy.Request, error) {
+	ret := u.Called(requestType, subject)
+	request, _ := ret.Get(0).(*privacy.Request)
+	return request, ret.Error(1)
+}
+
+func (u *mockUseCase) GetRequest(id int64) (*privacy.Request, error) {
+	ret := u.Called(id)
+	request, _ := ret.Get(0).(*privacy.Request)
+	return request, ret.Error(1)
+}
+
+func (u *mockUseCase) RetryRequest(id int64) (*privacy.Request, error) {
+	ret := u.Called(id)
+	request, _ := ret.Get(0).(*privacy.Request)
+	return request, ret.Error(1)
+}
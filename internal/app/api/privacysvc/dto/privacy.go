@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// CreateRequest is the data subject request of the person identified by ifa or publisher_user_id in the app
+type CreateRequest struct {
+	Type      string `json:"type" validate:"required,oneof=export erasure"`
+	AppID     int64  `json:"app_id" validate:"required"`
+	IFA       string `json:"ifa"`
+	PubUserID string `json:"publisher_user_id"`
+}
+
+// Subject type definition
+type Subject struct {
+	AppID     int64  `json:"app_id"`
+	IFA       string `json:"ifa,omitempty"`
+	PubUserID string `json:"publisher_user_id,omitempty"`
+}
+
+// Step is the progress of a store
+type Step struct {
+	Store       string     `json:"store"`
+	Status      string     `json:"status"`
+	Records     int        `json:"records"`
+	Error       string     `json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Export is the machine-readable data of the subject. Stores holds the records by store name
+type Export struct {
+	GeneratedAt time.Time                           `json:"generated_at"`
+	Stores      map[string][]map[string]interface{} `json:"stores"`
+}
+
+// Request is the audit record of the request
+type Request struct {
+	ID          int64      `json:"id"`
+	Type        string     `json:"type"`
+	Subject     Subject    `json:"subject"`
+	Status      string     `json:"status"`
+	DeviceIDs   []int64    `json:"device_ids"`
+	Steps       []Step     `json:"steps"`
+	Export      *Export    `json:"export,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
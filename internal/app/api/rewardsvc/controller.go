@@ -6,28 +6,30 @@ import (
 	"strings"
 
 	"github.com/Buzzvil/buzzlib-go/core"
-	"github.com/Buzzvil/buzzlib-go/network"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/common"
 	"github.com/Buzzvil/buzzscreen-api/internal/app/api/rewardsvc/dto"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/event"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/reward"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/tracker"
 )
 
 // Controller struct definition
 type Controller struct {
 	*common.ControllerBase
-	appUseCase    app.UseCase
-	eventUseCase  event.UseCase
-	rewardUseCase reward.UseCase
+	appUseCase     app.UseCase
+	eventUseCase   event.UseCase
+	rewardUseCase  reward.UseCase
+	trackerUseCase tracker.UseCase
 }
 
 // NewController returns new controller and binds requests to the controller
-func NewController(e *core.Engine, appUseCase app.UseCase, eventUseCase event.UseCase, rewardUseCase reward.UseCase) Controller {
+func NewController(e *core.Engine, appUseCase app.UseCase, eventUseCase event.UseCase, rewardUseCase reward.UseCase, trackerUseCase tracker.UseCase) Controller {
 	con := Controller{
-		appUseCase:    appUseCase,
-		eventUseCase:  eventUseCase,
-		rewardUseCase: rewardUseCase,
+		appUseCase:     appUseCase,
+		eventUseCase:   eventUseCase,
+		rewardUseCase:  rewardUseCase,
+		trackerUseCase: trackerUseCase,
 	}
 	e.POST("/api/rewards", con.PostReward)
 	return con
@@ -49,7 +51,7 @@ func (con *Controller) PostReward(c core.Context) error {
 		return &core.HttpError{Code: http.StatusBadRequest, Message: err}
 	}
 
-	ok := con.callTrackingURL(req.DeviceID, req.CampaignID)
+	ok := con.dispatchTrackingURL(req)
 	if ok {
 		rewardIngredients.Reward = rewardIngredients.BaseReward // trackingURL 호출성공했을땐 baseReward만 지급
 	}
@@ -138,29 +140,36 @@ func (con *Controller) validateAndGetRewardReq(req dto.PostRewardReq) (*reward.R
 	return nil, errors.New("failed to ValidateRequest. checksum is invalid")
 }
 
-func (con *Controller) callTrackingURL(deviceID int64, campaignID int64) bool {
+func (con *Controller) dispatchTrackingURL(req dto.PostRewardReq) bool {
 	// 컨텐츠는 BA trackingURL없음
-	if campaignID < dto.BuzzAdCampaignIDOffset {
+	if req.CampaignID < dto.BuzzAdCampaignIDOffset {
 		return false
 	}
 
 	resource := event.Resource{
-		ID:   campaignID - dto.BuzzAdCampaignIDOffset,
+		ID:   req.CampaignID - dto.BuzzAdCampaignIDOffset,
 		Type: event.ResourceTypeAd,
 	}
 
-	url, err := con.eventUseCase.GetTrackingURL(deviceID, resource)
+	url, err := con.eventUseCase.GetTrackingURL(req.DeviceID, resource)
 	if err != nil || url == "" {
 		return false
 	}
 
+	failTrackers, err := con.eventUseCase.GetFailTrackers(req.DeviceID, resource)
+	if err != nil {
+		core.Logger.Warnf("PostReward() - failed to get fail trackers. err %+v", err)
+	}
+
 	// bs-point를 통한 중복 적립 방지를 위해 trackingURL호출에 성공한것으로 간주
-	req := &network.Request{URL: url, Method: http.MethodGet}
-	res, err := req.MakeRequest()
+	err = con.trackerUseCase.Dispatch(tracker.Request{
+		URLs:     []string{url},
+		FailURLs: failTrackers,
+		Macros:   tracker.Macros{"{ifa}": req.IFA, "__ifa__": req.IFA},
+	})
 	if err != nil {
-		return true
+		core.Logger.Warnf("PostReward() - err %+v", err)
 	}
-	defer res.Body.Close()
 
 	return true
 }
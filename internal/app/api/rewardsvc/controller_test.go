@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bxcodec/faker"
 
@@ -18,10 +19,12 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/event"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/reward"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/tracker"
 
 	"github.com/Buzzvil/buzzlib-go/core"
 	"github.com/Buzzvil/buzzlib-go/header"
 	"github.com/Buzzvil/buzzlib-go/network"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -51,6 +54,10 @@ func (ts *ControllerTestSuite) Test_PostRewardCallTrackingURL() {
 	reqIngr.BaseReward = 0
 	ts.rewardUseCase.On("ValidateRequest", reqIngr).Return(nil).Once()
 	ts.eventUseCase.On("GetTrackingURL", reqIngr.DeviceID, ts.buildResource(reqIngr)).Return("test.url", nil).Once()
+	ts.eventUseCase.On("GetFailTrackers", reqIngr.DeviceID, ts.buildResource(reqIngr)).Return([]string{"fail.url"}, nil).Once()
+	ts.trackerUseCase.On("Dispatch", mock.MatchedBy(func(req tracker.Request) bool {
+		return len(req.URLs) == 1 && req.URLs[0] == "test.url" && len(req.FailURLs) == 1 && req.FailURLs[0] == "fail.url"
+	})).Return(nil).Once()
 	req := ts.buildRequest(reqIngr)
 	ctx, rec := ts.buildContextAndRecorder(req.GetHTTPRequest())
 
@@ -59,6 +66,36 @@ func (ts *ControllerTestSuite) Test_PostRewardCallTrackingURL() {
 	ts.Equal(http.StatusOK, rec.Code)
 }
 
+func (ts *ControllerTestSuite) Test_PostRewardFailTrackers() {
+	reqIngr := ts.buildRequestIngredients()
+	reqIngr.Checksum = reward.TestCheckSum
+	reqIngr.BaseReward = 0
+	trackingURL := "https://tracker.example.com/landed?ifa=__ifa__"
+	failTracker := "https://tracker.example.com/fail?ifa=__ifa__"
+	ts.rewardUseCase.On("ValidateRequest", reqIngr).Return(nil).Once()
+	ts.eventUseCase.On("GetTrackingURL", reqIngr.DeviceID, ts.buildResource(reqIngr)).Return(trackingURL, nil).Once()
+	ts.eventUseCase.On("GetFailTrackers", reqIngr.DeviceID, ts.buildResource(reqIngr)).Return([]string{failTracker}, nil).Once()
+
+	// 실제 tracker UseCase로 재시도가 모두 실패한 뒤 fail tracker가 호출되는지 확인한다
+	trackerRepo := new(mockTrackerRepo)
+	expandedURL := tracker.Macros{"__ifa__": reqIngr.IFA}.Expand(trackingURL)
+	trackerRepo.On("Fire", expandedURL).Return(tracker.DeliveryError{URL: expandedURL, StatusCode: http.StatusServiceUnavailable}).Times(3)
+	trackerRepo.On("Fire", tracker.Macros{"__ifa__": reqIngr.IFA}.Expand(failTracker)).Return(nil).Once()
+	deliveries := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "tracker_deliveries"}, []string{"result"})
+	trackerUseCase := tracker.NewUseCase(trackerRepo, tracker.Config{QueueSize: 10, MaxRetries: 2, RetryInterval: time.Millisecond}, deliveries)
+	controller := rewardsvc.NewController(ts.engine, ts.appUseCase, ts.eventUseCase, ts.rewardUseCase, trackerUseCase)
+
+	req := ts.buildRequest(reqIngr)
+	ctx, rec := ts.buildContextAndRecorder(req.GetHTTPRequest())
+
+	err := controller.PostReward(ctx)
+	trackerUseCase.Wait()
+
+	ts.NoError(err)
+	ts.Equal(http.StatusOK, rec.Code)
+	trackerRepo.AssertExpectations(ts.T())
+}
+
 func (ts *ControllerTestSuite) Test_PostReward_DeactivatedUnit() {
 	reqIngr := ts.buildRequestIngredients()
 	reqIngr.Checksum = reward.TestCheckSum
@@ -173,11 +210,12 @@ func (ts *ControllerTestSuite) buildContextAndRecorder(httpRequest *http.Request
 
 type ControllerTestSuite struct {
 	suite.Suite
-	controller    rewardsvc.Controller
-	engine        *core.Engine
-	rewardUseCase *mockRewardUseCase
-	appUseCase    *mockAppUseCase
-	eventUseCase  *mockEventUseCase
+	controller     rewardsvc.Controller
+	engine         *core.Engine
+	rewardUseCase  *mockRewardUseCase
+	appUseCase     *mockAppUseCase
+	eventUseCase   *mockEventUseCase
+	trackerUseCase *mockTrackerUseCase
 }
 
 func (ts *ControllerTestSuite) SetupTest() {
@@ -185,13 +223,24 @@ func (ts *ControllerTestSuite) SetupTest() {
 	ts.rewardUseCase = new(mockRewardUseCase)
 	ts.appUseCase = new(mockAppUseCase)
 	ts.eventUseCase = new(mockEventUseCase)
-	ts.controller = rewardsvc.NewController(ts.engine, ts.appUseCase, ts.eventUseCase, ts.rewardUseCase)
+	ts.trackerUseCase = new(mockTrackerUseCase)
+	ts.controller = rewardsvc.NewController(ts.engine, ts.appUseCase, ts.eventUseCase, ts.rewardUseCase, ts.trackerUseCase)
 }
 
 func (ts *ControllerTestSuite) AfterTest(_, _ string) {
 	ts.rewardUseCase.AssertExpectations(ts.T())
 	ts.appUseCase.AssertExpectations(ts.T())
 	ts.eventUseCase.AssertExpectations(ts.T())
+	ts.trackerUseCase.AssertExpectations(ts.T())
+}
+
+type mockTrackerRepo struct {
+	mock.Mock
+}
+
+func (r *mockTrackerRepo) Fire(trackerURL string) error {
+	ret := r.Called(trackerURL)
+	return ret.Error(0)
 }
 
 type mockRewardUseCase struct {
@@ -284,3 +333,25 @@ func (u *mockEventUseCase) GetTrackingURL(deviceID int64, resource event.Resourc
 	ret := u.Called(deviceID, resource)
 	return ret.Get(0).(string), ret.Error(1)
 }
+
+func (u *mockEventUseCase) SaveFailTrackers(deviceID int64, resource event.Resource, failTrackers []string) {
+	u.Called(deviceID, resource, failTrackers)
+}
+
+func (u *mockEventUseCase) GetFailTrackers(deviceID int64, resource event.Resource) ([]string, error) {
+	ret := u.Called(deviceID, resource)
+	return ret.Get(0).([]string), ret.Error(1)
+}
+
+type mockTrackerUseCase struct {
+	mock.Mock
+}
+
+func (u *mockTrackerUseCase) Dispatch(req tracker.Request) error {
+	ret := u.Called(req)
+	return ret.Error(0)
+}
+
+func (u *mockTrackerUseCase) Wait() {
+	u.Called()
+}
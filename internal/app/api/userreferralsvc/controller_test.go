@@ -569,6 +569,11 @@ func (u *mockDeviceUseCase) DeleteProfile(dp device.Profile) error {
 	return ret.Error(0)
 }
 
+func (u *mockDeviceUseCase) ApplyContentFeedback(deviceID int64, feedback device.ContentFeedback, relevance device.ContentRelevance) (*device.Profile, error) {
+	ret := u.Called(deviceID, feedback, relevance)
+	return ret.Get(0).(*device.Profile), ret.Error(1)
+}
+
 func (u *mockDeviceUseCase) GetByID(deviceID int64) (*device.Device, error) {
 	ret := u.Called(deviceID)
 	if ret.Get(0) == nil {
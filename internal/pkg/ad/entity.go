@@ -34,6 +34,7 @@ type AdV1 struct {
 	Carrier               string                   `json:"carrier"`
 	ClickBeacons          []string                 `json:"click_beacons"`
 	ClickURL              string                   `json:"click_url"`
+	DeepLink              string                   `json:"deep_link"`
 	DeviceName            string                   `json:"device_name"`
 	Dipu                  float64                  `json:"dipu"`
 	DisplayType           string                   `json:"display_type"`
@@ -66,6 +67,7 @@ type AdV1 struct {
 	Tipu                  int                      `json:"tipu"`
 	Type                  string                   `json:"type"`
 	UnitPrice             float64                  `json:"unit_price"`
+	UniversalLink         string                   `json:"universal_link"`
 	UseWebUa              bool                     `json:"use_web_ua"`
 	BannerAd              *BannerAdV1Settings      `json:"banner_ad"`
 	WebHTML               *map[string]interface{}  `json:"web_html,omitempty"`
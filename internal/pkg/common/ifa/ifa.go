@@ -5,6 +5,8 @@ import "fmt"
 const (
 	// EmptyIFA defines empty ifa caused by iOS
 	EmptyIFA = "00000000-0000-0000-0000-000000000000"
+	// IFVPrefix is prepended to the IFV replacing the empty IFA
+	IFVPrefix = "ifv"
 )
 
 // ShouldReplaceIFAWithIFV func definition
@@ -15,5 +17,5 @@ func ShouldReplaceIFAWithIFV(IFA string, IFV *string) bool {
 
 // GetDeviceIFV func definition
 func GetDeviceIFV(IFV string) string {
-	return fmt.Sprintf("ifv%v", IFV)
+	return fmt.Sprintf("%s%v", IFVPrefix, IFV)
 }
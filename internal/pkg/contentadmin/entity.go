@@ -0,0 +1,114 @@
+package contentadmin
+
+import "time"
+
+// Campaign is the content campaign record managed through the admin api
+type Campaign struct {
+	ID             int64
+	Categories     string
+	ChannelID      *int64
+	CleanMode      int
+	ClickURL       string
+	CleanLink      string
+	Country        string
+	Description    string
+	DisplayType    string
+	DisplayWeight  int
+	EndDate        time.Time
+	Ipu            *int
+	IsCtrFilterOff bool
+	IsEnabled      bool
+	Image          string
+	JSON           string
+	LandingReward  int
+	LandingType    int
+	Name           string
+	OrganizationID int64
+	OwnerID        int64
+	ProviderID     *int64
+	PublishedAt    *time.Time
+	StartDate      time.Time
+	Status         int
+	Tags           string
+	Title          string
+	Timezone       string
+	Tipu           *int
+	Type           string
+	WeekSlot       string
+
+	TargetApp                 string
+	TargetAgeMin              *int
+	TargetAgeMax              *int
+	TargetSdkMin              *int
+	TargetSdkMax              *int
+	RegisteredDaysMin         *int
+	RegisteredDaysMax         *int
+	TargetGender              string
+	TargetLanguage            string
+	TargetCarrier             string
+	TargetRegion              string
+	CustomTarget1             string
+	CustomTarget2             string
+	CustomTarget3             string
+	TargetUnit                string
+	TargetAppID               string
+	TargetOrg                 string
+	TargetOsMin               *int
+	TargetOsMax               *int
+	TargetBatteryOptimization bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Category is the content category which campaigns refer to by ID in Categories
+type Category struct {
+	ID          string
+	Name        string
+	Translation string
+	IconURL     string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Channel is the content channel embedded in the indexed campaigns
+type Channel struct {
+	ID        int64
+	Category  string
+	Logo      string
+	Name      string
+	Publisher string
+}
+
+// EntityType type definition
+type EntityType string
+
+// EntityType constants
+const (
+	EntityTypeCampaign EntityType = "campaign"
+	EntityTypeCategory EntityType = "category"
+	EntityTypeChannel  EntityType = "channel"
+)
+
+// AuditAction type definition
+type AuditAction string
+
+// AuditAction constants
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+// AuditLog records a change of the managed entities. Before and After are json of the entity and empty if it doesn't exist
+type AuditLog struct {
+	ID         int64
+	Actor      string
+	Action     AuditAction
+	EntityType EntityType
+	EntityID   string
+	Before     string
+	After      string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,74 @@
+package contentadmin
+
+import "fmt"
+
+var (
+	_ error = ValidationError{}
+	_ error = CampaignNotFoundError{}
+	_ error = CategoryNotFoundError{}
+	_ error = CategoryExistsError{}
+	_ error = ChannelNotFoundError{}
+	_ error = ChannelInUseError{}
+)
+
+// ValidationError will be returned when a field of the entity is invalid
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+// Error func definition
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Reason)
+}
+
+// CampaignNotFoundError will be returned when the campaign doesn't exist
+type CampaignNotFoundError struct {
+	ID int64
+}
+
+// Error func definition
+func (e CampaignNotFoundError) Error() string {
+	return fmt.Sprintf("content campaign %d is not found", e.ID)
+}
+
+// CategoryNotFoundError will be returned when the category doesn't exist
+type CategoryNotFoundError struct {
+	ID string
+}
+
+// Error func definition
+func (e CategoryNotFoundError) Error() string {
+	return fmt.Sprintf("content category %s is not found", e.ID)
+}
+
+// CategoryExistsError will be returned when the category of the same id is created again
+type CategoryExistsError struct {
+	ID string
+}
+
+// Error func definition
+func (e CategoryExistsError) Error() string {
+	return fmt.Sprintf("content category %s already exists", e.ID)
+}
+
+// ChannelNotFoundError will be returned when the channel doesn't exist
+type ChannelNotFoundError struct {
+	ID int64
+}
+
+// Error func definition
+func (e ChannelNotFoundError) Error() string {
+	return fmt.Sprintf("content channel %d is not found", e.ID)
+}
+
+// ChannelInUseError will be returned when the channel of campaigns is deleted
+type ChannelInUseError struct {
+	ID            int64
+	CampaignCount int
+}
+
+// Error func definition
+func (e ChannelInUseError) Error() string {
+	return fmt.Sprintf("content channel %d is used by %d campaigns", e.ID, e.CampaignCount)
+}
@@ -0,0 +1,58 @@
+package repo
+
+import (
+	"fmt"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	"github.com/go-redis/redis"
+)
+
+// Cache keys written by contentcampaign repo and buzzscreen/service
+const (
+	campaignCacheKey         = "CACHE_GO_CONTENTCAMPAIGN-%v"
+	categoriesCacheKeyPrefix = "CACHE_GO_GET_CATEGORIES_"
+	channelCacheKey          = "CACHE_GO_CHANNEL_%v"
+	scanCount                = 100
+)
+
+// Cache deletes the content caches in redis
+type Cache struct {
+	client *redis.Client
+}
+
+// DeleteCampaign func definition
+func (c *Cache) DeleteCampaign(id int64) error {
+	return c.client.Del(fmt.Sprintf(campaignCacheKey, id)).Err()
+}
+
+// DeleteCategories deletes the categories cached for every language
+func (c *Cache) DeleteCategories() error {
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Scan(cursor, categoriesCacheKeyPrefix+"*", scanCount).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := c.client.Del(keys...).Err(); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// DeleteChannel func definition
+func (c *Cache) DeleteChannel(id int64) error {
+	return c.client.Del(fmt.Sprintf(channelCacheKey, id)).Err()
+}
+
+// NewCache returns Cache struct
+func NewCache(client *redis.Client) *Cache {
+	return &Cache{client: client}
+}
+
+var _ contentadmin.Cache = &Cache{}
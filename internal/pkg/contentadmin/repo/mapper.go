@@ -0,0 +1,195 @@
+package repo
+
+import (
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbcontentcampaign"
+)
+
+type entityMapper struct {
+}
+
+func (m *entityMapper) dbCampaignToCampaign(cc dbcontentcampaign.ContentCampaign) contentadmin.Campaign {
+	return contentadmin.Campaign{
+		ID:             cc.ID,
+		Categories:     cc.Categories,
+		ChannelID:      cc.ChannelID,
+		CleanMode:      cc.CleanMode,
+		ClickURL:       cc.ClickURL,
+		CleanLink:      cc.CleanLink,
+		Country:        cc.Country,
+		Description:    cc.Description,
+		DisplayType:    cc.DisplayType,
+		DisplayWeight:  cc.DisplayWeight,
+		EndDate:        cc.EndDate,
+		Ipu:            cc.Ipu,
+		IsCtrFilterOff: cc.IsCtrFilterOff,
+		IsEnabled:      cc.IsEnabled,
+		Image:          cc.Image,
+		JSON:           cc.JSON,
+		LandingReward:  cc.LandingReward,
+		LandingType:    cc.LandingType,
+		Name:           cc.Name,
+		OrganizationID: cc.OrganizationID,
+		OwnerID:        cc.OwnerID,
+		ProviderID:     cc.ProviderID,
+		PublishedAt:    cc.PublishedAt,
+		StartDate:      cc.StartDate,
+		Status:         cc.Status,
+		Tags:           cc.Tags,
+		Title:          cc.Title,
+		Timezone:       cc.Timezone,
+		Tipu:           cc.Tipu,
+		Type:           cc.Type,
+		WeekSlot:       cc.WeekSlot,
+
+		TargetApp:                 cc.TargetApp,
+		TargetAgeMin:              cc.TargetAgeMin,
+		TargetAgeMax:              cc.TargetAgeMax,
+		TargetSdkMin:              cc.TargetSdkMin,
+		TargetSdkMax:              cc.TargetSdkMax,
+		RegisteredDaysMin:         cc.RegisteredDaysMin,
+		RegisteredDaysMax:         cc.RegisteredDaysMax,
+		TargetGender:              cc.TargetGender,
+		TargetLanguage:            cc.TargetLanguage,
+		TargetCarrier:             cc.TargetCarrier,
+		TargetRegion:              cc.TargetRegion,
+		CustomTarget1:             cc.CustomTarget1,
+		CustomTarget2:             cc.CustomTarget2,
+		CustomTarget3:             cc.CustomTarget3,
+		TargetUnit:                cc.TargetUnit,
+		TargetAppID:               cc.TargetAppID,
+		TargetOrg:                 cc.TargetOrg,
+		TargetOsMin:               cc.TargetOsMin,
+		TargetOsMax:               cc.TargetOsMax,
+		TargetBatteryOptimization: cc.TargetBatteryOptimization,
+
+		CreatedAt: cc.CreatedAt,
+		UpdatedAt: cc.UpdatedAt,
+	}
+}
+
+func (m *entityMapper) campaignToDBCampaign(c contentadmin.Campaign) dbcontentcampaign.ContentCampaign {
+	return dbcontentcampaign.ContentCampaign{
+		ID:             c.ID,
+		Categories:     c.Categories,
+		ChannelID:      c.ChannelID,
+		CleanMode:      c.CleanMode,
+		ClickURL:       c.ClickURL,
+		CleanLink:      c.CleanLink,
+		Country:        c.Country,
+		Description:    c.Description,
+		DisplayType:    c.DisplayType,
+		DisplayWeight:  c.DisplayWeight,
+		EndDate:        c.EndDate,
+		Ipu:            c.Ipu,
+		IsCtrFilterOff: c.IsCtrFilterOff,
+		IsEnabled:      c.IsEnabled,
+		Image:          c.Image,
+		JSON:           c.JSON,
+		LandingReward:  c.LandingReward,
+		LandingType:    c.LandingType,
+		Name:           c.Name,
+		OrganizationID: c.OrganizationID,
+		OwnerID:        c.OwnerID,
+		ProviderID:     c.ProviderID,
+		PublishedAt:    c.PublishedAt,
+		StartDate:      c.StartDate,
+		Status:         c.Status,
+		Tags:           c.Tags,
+		Title:          c.Title,
+		Timezone:       c.Timezone,
+		Tipu:           c.Tipu,
+		Type:           c.Type,
+		WeekSlot:       c.WeekSlot,
+
+		TargetApp:                 c.TargetApp,
+		TargetAgeMin:              c.TargetAgeMin,
+		TargetAgeMax:              c.TargetAgeMax,
+		TargetSdkMin:              c.TargetSdkMin,
+		TargetSdkMax:              c.TargetSdkMax,
+		RegisteredDaysMin:         c.RegisteredDaysMin,
+		RegisteredDaysMax:         c.RegisteredDaysMax,
+		TargetGender:              c.TargetGender,
+		TargetLanguage:            c.TargetLanguage,
+		TargetCarrier:             c.TargetCarrier,
+		TargetRegion:              c.TargetRegion,
+		CustomTarget1:             c.CustomTarget1,
+		CustomTarget2:             c.CustomTarget2,
+		CustomTarget3:             c.CustomTarget3,
+		TargetUnit:                c.TargetUnit,
+		TargetAppID:               c.TargetAppID,
+		TargetOrg:                 c.TargetOrg,
+		TargetOsMin:               c.TargetOsMin,
+		TargetOsMax:               c.TargetOsMax,
+		TargetBatteryOptimization: c.TargetBatteryOptimization,
+
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func (m *entityMapper) dbCategoryToCategory(dbCategory DBCategory) contentadmin.Category {
+	return contentadmin.Category{
+		ID:          dbCategory.ID,
+		Name:        dbCategory.Name,
+		Translation: dbCategory.Translation,
+		IconURL:     dbCategory.IconURL,
+		CreatedAt:   dbCategory.CreatedAt,
+		UpdatedAt:   dbCategory.UpdatedAt,
+	}
+}
+
+func (m *entityMapper) categoryToDBCategory(category contentadmin.Category) DBCategory {
+	return DBCategory{
+		ID:          category.ID,
+		Name:        category.Name,
+		Translation: category.Translation,
+		IconURL:     category.IconURL,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
+
+func (m *entityMapper) dbChannelToChannel(dbChannel dbcontentcampaign.ContentChannel) contentadmin.Channel {
+	return contentadmin.Channel{
+		ID:        dbChannel.ID,
+		Category:  dbChannel.Category,
+		Logo:      dbChannel.Logo,
+		Name:      dbChannel.Name,
+		Publisher: dbChannel.Publisher,
+	}
+}
+
+func (m *entityMapper) channelToDBChannel(channel contentadmin.Channel) dbcontentcampaign.ContentChannel {
+	return dbcontentcampaign.ContentChannel{
+		ID:        channel.ID,
+		Category:  channel.Category,
+		Logo:      channel.Logo,
+		Name:      channel.Name,
+		Publisher: channel.Publisher,
+	}
+}
+
+func (m *entityMapper) dbAuditLogToAuditLog(dbAuditLog DBAuditLog) contentadmin.AuditLog {
+	return contentadmin.AuditLog{
+		ID:         dbAuditLog.ID,
+		Actor:      dbAuditLog.Actor,
+		Action:     contentadmin.AuditAction(dbAuditLog.Action),
+		EntityType: contentadmin.EntityType(dbAuditLog.EntityType),
+		EntityID:   dbAuditLog.EntityID,
+		Before:     dbAuditLog.Before,
+		After:      dbAuditLog.After,
+		CreatedAt:  dbAuditLog.CreatedAt,
+	}
+}
+
+func (m *entityMapper) auditLogToDBAuditLog(auditLog contentadmin.AuditLog) DBAuditLog {
+	return DBAuditLog{
+		Actor:      auditLog.Actor,
+		Action:     string(auditLog.Action),
+		EntityType: string(auditLog.EntityType),
+		EntityID:   auditLog.EntityID,
+		Before:     auditLog.Before,
+		After:      auditLog.After,
+	}
+}
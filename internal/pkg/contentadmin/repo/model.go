@@ -0,0 +1,37 @@
+package repo
+
+import "time"
+
+// DBCategory struct definition
+type DBCategory struct {
+	ID          string `gorm:"primary_key"`
+	Name        string
+	Translation string
+	IconURL     string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName func definition
+func (DBCategory) TableName() string {
+	return "content_categories"
+}
+
+// DBAuditLog struct definition
+type DBAuditLog struct {
+	ID         int64 `gorm:"primary_key"`
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     string `gorm:"type:text"`
+	After      string `gorm:"type:text"`
+
+	CreatedAt time.Time
+}
+
+// TableName func definition
+func (DBAuditLog) TableName() string {
+	return "content_audit_logs"
+}
@@ -0,0 +1,206 @@
+package repo
+
+import (
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbcontentcampaign"
+	"github.com/jinzhu/gorm"
+)
+
+// Repository struct definition
+type Repository struct {
+	db     *gorm.DB
+	mapper *entityMapper
+}
+
+// Transaction runs fn with the repository of a transaction
+func (r *Repository) Transaction(fn func(repo contentadmin.Repository) error) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := fn(&Repository{db: tx, mapper: r.mapper}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// GetCampaign returns the campaign of the id. nil is returned if it doesn't exist
+func (r *Repository) GetCampaign(id int64) (*contentadmin.Campaign, error) {
+	var dbCampaign dbcontentcampaign.ContentCampaign
+	err := r.db.Where("id = ?", id).First(&dbCampaign).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	campaign := r.mapper.dbCampaignToCampaign(dbCampaign)
+	return &campaign, nil
+}
+
+// CreateCampaign inserts the campaign and returns it with the assigned id
+func (r *Repository) CreateCampaign(campaign contentadmin.Campaign) (*contentadmin.Campaign, error) {
+	dbCampaign := r.mapper.campaignToDBCampaign(campaign)
+	if err := r.db.Create(&dbCampaign).Error; err != nil {
+		return nil, err
+	}
+
+	created := r.mapper.dbCampaignToCampaign(dbCampaign)
+	return &created, nil
+}
+
+// UpdateCampaign saves all fields of the campaign
+func (r *Repository) UpdateCampaign(campaign contentadmin.Campaign) (*contentadmin.Campaign, error) {
+	dbCampaign := r.mapper.campaignToDBCampaign(campaign)
+	if err := r.db.Save(&dbCampaign).Error; err != nil {
+		return nil, err
+	}
+
+	updated := r.mapper.dbCampaignToCampaign(dbCampaign)
+	return &updated, nil
+}
+
+// DeleteCampaign deletes the campaign of the id
+func (r *Repository) DeleteCampaign(id int64) error {
+	return r.db.Where("id = ?", id).Delete(dbcontentcampaign.ContentCampaign{}).Error
+}
+
+// GetCampaignIDsByChannelID returns ids of the campaigns of the channel
+func (r *Repository) GetCampaignIDsByChannelID(channelID int64) ([]int64, error) {
+	var campaignIDs []int64
+	err := r.db.Model(&dbcontentcampaign.ContentCampaign{}).Where("channel_id = ?", channelID).Pluck("id", &campaignIDs).Error
+	return campaignIDs, err
+}
+
+// GetCategories returns all categories ordered by id
+func (r *Repository) GetCategories() ([]contentadmin.Category, error) {
+	var dbCategories []DBCategory
+	if err := r.db.Order("id").Find(&dbCategories).Error; err != nil {
+		return nil, err
+	}
+
+	categories := make([]contentadmin.Category, 0, len(dbCategories))
+	for _, dbCategory := range dbCategories {
+		categories = append(categories, r.mapper.dbCategoryToCategory(dbCategory))
+	}
+	return categories, nil
+}
+
+// GetCategory returns the category of the id. nil is returned if it doesn't exist
+func (r *Repository) GetCategory(id string) (*contentadmin.Category, error) {
+	var dbCategory DBCategory
+	err := r.db.Where("id = ?", id).First(&dbCategory).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	category := r.mapper.dbCategoryToCategory(dbCategory)
+	return &category, nil
+}
+
+// CreateCategory inserts the category
+func (r *Repository) CreateCategory(category contentadmin.Category) (*contentadmin.Category, error) {
+	dbCategory := r.mapper.categoryToDBCategory(category)
+	if err := r.db.Create(&dbCategory).Error; err != nil {
+		return nil, err
+	}
+
+	created := r.mapper.dbCategoryToCategory(dbCategory)
+	return &created, nil
+}
+
+// UpdateCategory saves all fields of the category
+func (r *Repository) UpdateCategory(category contentadmin.Category) (*contentadmin.Category, error) {
+	dbCategory := r.mapper.categoryToDBCategory(category)
+	if err := r.db.Save(&dbCategory).Error; err != nil {
+		return nil, err
+	}
+
+	updated := r.mapper.dbCategoryToCategory(dbCategory)
+	return &updated, nil
+}
+
+// DeleteCategory deletes the category of the id
+func (r *Repository) DeleteCategory(id string) error {
+	return r.db.Where("id = ?", id).Delete(DBCategory{}).Error
+}
+
+// GetChannel returns the channel of the id. nil is returned if it doesn't exist
+func (r *Repository) GetChannel(id int64) (*contentadmin.Channel, error) {
+	var dbChannel dbcontentcampaign.ContentChannel
+	err := r.db.Where("id = ?", id).First(&dbChannel).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	channel := r.mapper.dbChannelToChannel(dbChannel)
+	return &channel, nil
+}
+
+// CreateChannel inserts the channel and returns it with the assigned id
+func (r *Repository) CreateChannel(channel contentadmin.Channel) (*contentadmin.Channel, error) {
+	dbChannel := r.mapper.channelToDBChannel(channel)
+	if err := r.db.Create(&dbChannel).Error; err != nil {
+		return nil, err
+	}
+
+	created := r.mapper.dbChannelToChannel(dbChannel)
+	return &created, nil
+}
+
+// UpdateChannel saves all fields of the channel
+func (r *Repository) UpdateChannel(channel contentadmin.Channel) (*contentadmin.Channel, error) {
+	dbChannel := r.mapper.channelToDBChannel(channel)
+	if err := r.db.Save(&dbChannel).Error; err != nil {
+		return nil, err
+	}
+
+	updated := r.mapper.dbChannelToChannel(dbChannel)
+	return &updated, nil
+}
+
+// DeleteChannel deletes the channel of the id
+func (r *Repository) DeleteChannel(id int64) error {
+	return r.db.Where("id = ?", id).Delete(dbcontentcampaign.ContentChannel{}).Error
+}
+
+// CreateAuditLog inserts the audit log
+func (r *Repository) CreateAuditLog(auditLog contentadmin.AuditLog) error {
+	dbAuditLog := r.mapper.auditLogToDBAuditLog(auditLog)
+	return r.db.Create(&dbAuditLog).Error
+}
+
+// GetAuditLogs returns the latest audit logs of the entity type. All entities of the type are returned if entityID is empty
+func (r *Repository) GetAuditLogs(entityType contentadmin.EntityType, entityID string, limit int) ([]contentadmin.AuditLog, error) {
+	query := r.db.Where("entity_type = ?", string(entityType))
+	if entityID != "" {
+		query = query.Where("entity_id = ?", entityID)
+	}
+
+	var dbAuditLogs []DBAuditLog
+	if err := query.Order("id DESC").Limit(limit).Find(&dbAuditLogs).Error; err != nil {
+		return nil, err
+	}
+
+	auditLogs := make([]contentadmin.AuditLog, 0, len(dbAuditLogs))
+	for _, dbAuditLog := range dbAuditLogs {
+		auditLogs = append(auditLogs, r.mapper.dbAuditLogToAuditLog(dbAuditLog))
+	}
+	return auditLogs, nil
+}
+
+// New returns content admin repository
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db:     db,
+		mapper: &entityMapper{},
+	}
+}
+
+var _ contentadmin.Repository = &Repository{}
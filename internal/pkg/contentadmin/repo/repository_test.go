@@ -0,0 +1,132 @@
+package repo
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	db   *gorm.DB
+	repo contentadmin.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	ts.NoError(err)
+	ts.mock = mock
+	ts.db, err = gorm.Open("mysql", db)
+	ts.NoError(err)
+	ts.repo = New(ts.db)
+}
+
+func (ts *RepoTestSuite) AfterTest() {
+	_ = ts.db.Close()
+}
+
+func (ts *RepoTestSuite) Test_GetCampaign() {
+	req := "SELECT * FROM `content_campaigns` WHERE (id = ?) ORDER BY `content_campaigns`.`id` ASC LIMIT 1"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "week_slot", "target_age_min"}).AddRow(10, "title", "0-23", 20))
+
+	campaign, err := ts.repo.GetCampaign(10)
+
+	ts.NoError(err)
+	ts.Equal(int64(10), campaign.ID)
+	ts.Equal("0-23", campaign.WeekSlot)
+	ts.Equal(20, *campaign.TargetAgeMin)
+}
+
+func (ts *RepoTestSuite) Test_GetCampaign_NotFound() {
+	ts.mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	campaign, err := ts.repo.GetCampaign(10)
+
+	ts.NoError(err)
+	ts.Nil(campaign)
+}
+
+func (ts *RepoTestSuite) Test_GetCampaignIDsByChannelID() {
+	req := "SELECT id FROM `content_campaigns` WHERE (channel_id = ?)"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	campaignIDs, err := ts.repo.GetCampaignIDsByChannelID(7)
+
+	ts.NoError(err)
+	ts.Equal([]int64{1, 2}, campaignIDs)
+}
+
+func (ts *RepoTestSuite) Test_Transaction_Commit() {
+	ts.mock.ExpectBegin()
+	ts.mock.ExpectExec(ts.fixedFullRe("DELETE FROM `content_categories` WHERE (id = ?)")).
+		WithArgs("news").WillReturnResult(sqlmock.NewResult(0, 1))
+	ts.mock.ExpectExec(ts.fixedFullRe("INSERT INTO `content_audit_logs` (`actor`,`action`,`entity_type`,`entity_id`,`before`,`after`,`created_at`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs("admin", "delete", "category", "news", `{"ID":"news"}`, "", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	ts.mock.ExpectCommit()
+
+	err := ts.repo.Transaction(func(repo contentadmin.Repository) error {
+		if err := repo.DeleteCategory("news"); err != nil {
+			return err
+		}
+		return repo.CreateAuditLog(contentadmin.AuditLog{
+			Actor:      "admin",
+			Action:     contentadmin.AuditActionDelete,
+			EntityType: contentadmin.EntityTypeCategory,
+			EntityID:   "news",
+			Before:     `{"ID":"news"}`,
+		})
+	})
+
+	ts.NoError(err)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_Transaction_Rollback() {
+	ts.mock.ExpectBegin()
+	ts.mock.ExpectExec(ts.fixedFullRe("DELETE FROM `content_channels` WHERE (id = ?)")).
+		WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	ts.mock.ExpectRollback()
+
+	err := ts.repo.Transaction(func(repo contentadmin.Repository) error {
+		if err := repo.DeleteChannel(7); err != nil {
+			return err
+		}
+		return contentadmin.ChannelInUseError{ID: 7, CampaignCount: 1}
+	})
+
+	ts.Equal(contentadmin.ChannelInUseError{ID: 7, CampaignCount: 1}, err)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_GetAuditLogs() {
+	req := "SELECT * FROM `content_audit_logs` WHERE (entity_type = ?) AND (entity_id = ?) ORDER BY id DESC LIMIT 20"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs("campaign", "10").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor", "action", "entity_type", "entity_id"}).AddRow(3, "admin", "update", "campaign", "10"))
+
+	auditLogs, err := ts.repo.GetAuditLogs(contentadmin.EntityTypeCampaign, "10", 20)
+
+	ts.NoError(err)
+	ts.Equal([]contentadmin.AuditLog{{
+		ID:         3,
+		Actor:      "admin",
+		Action:     contentadmin.AuditActionUpdate,
+		EntityType: contentadmin.EntityTypeCampaign,
+		EntityID:   "10",
+	}}, auditLogs)
+}
+
+func (ts *RepoTestSuite) fixedFullRe(s string) string {
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(s))
+}
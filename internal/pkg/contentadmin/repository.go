@@ -0,0 +1,42 @@
+package contentadmin
+
+import "context"
+
+// Repository interface definition. Get methods return nil if the entity doesn't exist
+type Repository interface {
+	// Transaction runs fn with the repository of a transaction. The transaction is rolled back if fn returns an error
+	Transaction(fn func(repo Repository) error) error
+
+	GetCampaign(id int64) (*Campaign, error)
+	CreateCampaign(campaign Campaign) (*Campaign, error)
+	UpdateCampaign(campaign Campaign) (*Campaign, error)
+	DeleteCampaign(id int64) error
+	GetCampaignIDsByChannelID(channelID int64) ([]int64, error)
+
+	GetCategories() ([]Category, error)
+	GetCategory(id string) (*Category, error)
+	CreateCategory(category Category) (*Category, error)
+	UpdateCategory(category Category) (*Category, error)
+	DeleteCategory(id string) error
+
+	GetChannel(id int64) (*Channel, error)
+	CreateChannel(channel Channel) (*Channel, error)
+	UpdateChannel(channel Channel) (*Channel, error)
+	DeleteChannel(id int64) error
+
+	CreateAuditLog(auditLog AuditLog) error
+	GetAuditLogs(entityType EntityType, entityID string, limit int) ([]AuditLog, error)
+}
+
+// Cache removes the entities cached for allocation so that the changes are served right away
+type Cache interface {
+	DeleteCampaign(id int64) error
+	DeleteCategories() error
+	DeleteChannel(id int64) error
+}
+
+// Indexer reflects the changed campaigns into the search index
+type Indexer interface {
+	IndexCampaigns(ctx context.Context, campaignIDs []int64) error
+	DeleteCampaign(ctx context.Context, campaignID int64) error
+}
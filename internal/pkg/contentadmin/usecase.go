@@ -0,0 +1,392 @@
+package contentadmin
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+)
+
+const indexTimeout = 5 * time.Second
+
+// UseCase interface definition. actor is the operator who requested the change and recorded in the audit log
+type UseCase interface {
+	GetCampaign(id int64) (*Campaign, error)
+	CreateCampaign(actor string, campaign Campaign) (*Campaign, error)
+	UpdateCampaign(actor string, campaign Campaign) (*Campaign, error)
+	DeleteCampaign(actor string, id int64) error
+
+	GetCategories() ([]Category, error)
+	GetCategory(id string) (*Category, error)
+	CreateCategory(actor string, category Category) (*Category, error)
+	UpdateCategory(actor string, category Category) (*Category, error)
+	DeleteCategory(actor string, id string) error
+
+	GetChannel(id int64) (*Channel, error)
+	CreateChannel(actor string, channel Channel) (*Channel, error)
+	UpdateChannel(actor string, channel Channel) (*Channel, error)
+	DeleteChannel(actor string, id int64) error
+
+	GetAuditLogs(entityType EntityType, entityID string, limit int) ([]AuditLog, error)
+}
+
+type useCase struct {
+	repo    Repository
+	indexer Indexer
+	cache   Cache
+}
+
+// GetCampaign returns CampaignNotFoundError if the campaign doesn't exist
+func (u *useCase) GetCampaign(id int64) (*Campaign, error) {
+	campaign, err := u.repo.GetCampaign(id)
+	if err != nil {
+		return nil, err
+	} else if campaign == nil {
+		return nil, CampaignNotFoundError{ID: id}
+	}
+	return campaign, nil
+}
+
+// CreateCampaign creates the campaign and indexes it
+func (u *useCase) CreateCampaign(actor string, campaign Campaign) (*Campaign, error) {
+	campaign.ID = 0
+	if err := u.validateCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	var created *Campaign
+	err := u.repo.Transaction(func(repo Repository) error {
+		var err error
+		if created, err = repo.CreateCampaign(campaign); err != nil {
+			return err
+		}
+		return u.audit(repo, actor, AuditActionCreate, EntityTypeCampaign, formatID(created.ID), nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.indexCampaigns([]int64{created.ID})
+	return created, nil
+}
+
+// UpdateCampaign replaces all fields of the campaign and reindexes it
+func (u *useCase) UpdateCampaign(actor string, campaign Campaign) (*Campaign, error) {
+	if err := u.validateCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	var updated *Campaign
+	err := u.repo.Transaction(func(repo Repository) error {
+		old, err := repo.GetCampaign(campaign.ID)
+		if err != nil {
+			return err
+		} else if old == nil {
+			return CampaignNotFoundError{ID: campaign.ID}
+		}
+
+		campaign.CreatedAt = old.CreatedAt
+		if updated, err = repo.UpdateCampaign(campaign); err != nil {
+			return err
+		}
+		return u.audit(repo, actor, AuditActionUpdate, EntityTypeCampaign, formatID(campaign.ID), old, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.invalidateCache("campaign", formatID(updated.ID), func() error { return u.cache.DeleteCampaign(updated.ID) })
+	u.indexCampaigns([]int64{updated.ID})
+	return updated, nil
+}
+
+// DeleteCampaign deletes the campaign and its document of the index
+func (u *useCase) DeleteCampaign(actor string, id int64) error {
+	err := u.repo.Transaction(func(repo Repository) error {
+		old, err := repo.GetCampaign(id)
+		if err != nil {
+			return err
+		} else if old == nil {
+			return CampaignNotFoundError{ID: id}
+		}
+
+		if err := repo.DeleteCampaign(id); err != nil {
+			return err
+		}
+		return u.audit(repo, actor, AuditActionDelete, EntityTypeCampaign, formatID(id), old, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.invalidateCache("campaign", formatID(id), func() error { return u.cache.DeleteCampaign(id) })
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+	if err := u.indexer.DeleteCampaign(ctx, id); err != nil {
+		core.Logger.WithError(err).Errorf("DeleteCampaign() - failed to delete campaign %d from the index", id)
+	}
+	return nil
+}
+
+// GetCategories returns all categories ordered by id
+func (u *useCase) GetCategories() ([]Category, error) {
+	return u.repo.GetCategories()
+}
+
+// GetCategory returns CategoryNotFoundError if the category doesn't exist
+func (u *useCase) GetCategory(id string) (*Category, error) {
+	category, err := u.repo.GetCategory(id)
+	if err != nil {
+		return nil, err
+	} else if category == nil {
+		return nil, CategoryNotFoundError{ID: id}
+	}
+	return category, nil
+}
+
+// CreateCategory creates the category. CategoryExistsError is returned if the id is taken
+func (u *useCase) CreateCategory(actor string, category Category) (*Category, error) {
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	var created *Category
+	err := u.repo.Transaction(func(repo Repository) error {
+		old, err := repo.GetCategory(category.ID)
+		if err != nil {
+			return err
+		} else if old != nil {
+			return CategoryExistsError{ID: category.ID}
+		}
+
+		if created, err = repo.CreateCategory(category); err != nil {
+			return err
+		}
+		return u.audit(repo, actor, AuditActionCreate, EntityTypeCategory, category.ID, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.invalidateCache("categories", category.ID, u.cache.DeleteCategories)
+	return created, nil
+}
+
+// UpdateCategory replaces all fields of the category
+func (u *useCase) UpdateCategory(actor string, category Category) (*Category, error) {
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *Category
+	err := u.repo.Transaction(func(repo Repository) error {
+		old, err := repo.GetCategory(category.ID)
+		if err != nil {
+			return err
+		} else if old == nil {
+			return CategoryNotFoundError{ID: category.ID}
+		}
+
+		category.CreatedAt = old.CreatedAt
+		if updated, err = repo.UpdateCategory(category); err != nil {
+			return err
+		}
+		return u.audit(repo, actor, AuditActionUpdate, EntityTypeCategory, category.ID, old, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.invalidateCache("categories", category.ID, u.cache.DeleteCategories)
+	return updated, nil
+}
+
+// DeleteCategory deletes the category. Campaigns keep the category id in Categories
+func (u *useCase) DeleteCategory(actor string, id string) error {
+	err := u.repo.Transaction(func(repo Repository) error {
+		old, err := repo.GetCategory(id)
+		if err != nil {
+			return err
+		} else if old == nil {
+			return CategoryNotFoundError{ID: id}
+		}
+
+		if err := repo.DeleteCategory(id); err != nil {
+			return err
+		}
+		return u.audit(repo, actor, AuditActionDelete, EntityTypeCategory, id, old, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.invalidateCache("categories", id, u.cache.DeleteCategories)
+	return nil
+}
+
+// GetChannel returns ChannelNotFoundError if the channel doesn't exist
+func (u *useCase) GetChannel(id int64) (*Channel, error) {
+	channel, err := u.repo.GetChannel(id)
+	if err != nil {
+		return nil, err
+	} else if channel == nil {
+		return nil, ChannelNotFoundError{ID: id}
+	}
+	return channel, nil
+}
+
+// CreateChannel creates the channel
+func (u *useCase) CreateChannel(actor string, channel Channel) (*Channel, error) {
+	channel.ID = 0
+	if err := channel.Validate(); err != nil {
+		return nil, err
+	}
+
+	var created *Channel
+	err := u.repo.Transaction(func(repo Repository) error {
+		var err error
+		if created, err = repo.CreateChannel(channel); err != nil {
+			return err
+		}
+		return u.audit(repo, actor, AuditActionCreate, EntityTypeChannel, formatID(created.ID), nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateChannel replaces all fields of the channel and reindexes the campaigns of the channel since the channel is embedded in the documents
+func (u *useCase) UpdateChannel(actor string, channel Channel) (*Channel, error) {
+	if err := channel.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *Channel
+	err := u.repo.Transaction(func(repo Repository) error {
+		old, err := repo.GetChannel(channel.ID)
+		if err != nil {
+			return err
+		} else if old == nil {
+			return ChannelNotFoundError{ID: channel.ID}
+		}
+
+		if updated, err = repo.UpdateChannel(channel); err != nil {
+			return err
+		}
+		return u.audit(repo, actor, AuditActionUpdate, EntityTypeChannel, formatID(channel.ID), old, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.invalidateCache("channel", formatID(channel.ID), func() error { return u.cache.DeleteChannel(channel.ID) })
+
+	campaignIDs, err := u.repo.GetCampaignIDsByChannelID(channel.ID)
+	if err != nil {
+		core.Logger.WithError(err).Errorf("UpdateChannel() - failed to get campaigns of channel %d", channel.ID)
+	} else if len(campaignIDs) > 0 {
+		u.indexCampaigns(campaignIDs)
+	}
+	return updated, nil
+}
+
+// DeleteChannel deletes the channel. ChannelInUseError is returned if any campaign has the channel
+func (u *useCase) DeleteChannel(actor string, id int64) error {
+	err := u.repo.Transaction(func(repo Repository) error {
+		old, err := repo.GetChannel(id)
+		if err != nil {
+			return err
+		} else if old == nil {
+			return ChannelNotFoundError{ID: id}
+		}
+
+		campaignIDs, err := repo.GetCampaignIDsByChannelID(id)
+		if err != nil {
+			return err
+		} else if len(campaignIDs) > 0 {
+			return ChannelInUseError{ID: id, CampaignCount: len(campaignIDs)}
+		}
+
+		if err := repo.DeleteChannel(id); err != nil {
+			return err
+		}
+		return u.audit(repo, actor, AuditActionDelete, EntityTypeChannel, formatID(id), old, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.invalidateCache("channel", formatID(id), func() error { return u.cache.DeleteChannel(id) })
+	return nil
+}
+
+// GetAuditLogs returns the latest audit logs of the entity
+func (u *useCase) GetAuditLogs(entityType EntityType, entityID string, limit int) ([]AuditLog, error) {
+	return u.repo.GetAuditLogs(entityType, entityID, limit)
+}
+
+// validateCampaign validates the fields and the channel of the campaign
+func (u *useCase) validateCampaign(campaign Campaign) error {
+	if err := campaign.Validate(); err != nil {
+		return err
+	}
+
+	if campaign.ChannelID != nil {
+		channel, err := u.repo.GetChannel(*campaign.ChannelID)
+		if err != nil {
+			return err
+		} else if channel == nil {
+			return ValidationError{Field: "channel_id", Reason: "is not found"}
+		}
+	}
+	return nil
+}
+
+// audit records the change. before and after are nil if the entity doesn't exist
+func (u *useCase) audit(repo Repository, actor string, action AuditAction, entityType EntityType, entityID string, before interface{}, after interface{}) error {
+	auditLog := AuditLog{Actor: actor, Action: action, EntityType: entityType, EntityID: entityID}
+	for _, field := range []struct {
+		entity interface{}
+		json   *string
+	}{{before, &auditLog.Before}, {after, &auditLog.After}} {
+		if field.entity == nil {
+			continue
+		}
+		encoded, err := json.Marshal(field.entity)
+		if err != nil {
+			return err
+		}
+		*field.json = string(encoded)
+	}
+	return repo.CreateAuditLog(auditLog)
+}
+
+// indexCampaigns reflects the campaigns into the index right away.
+// Failures are only logged since the periodic indexer picks up the campaigns by updated_at
+func (u *useCase) indexCampaigns(campaignIDs []int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+	if err := u.indexer.IndexCampaigns(ctx, campaignIDs); err != nil {
+		core.Logger.WithError(err).Warnf("indexCampaigns() - failed to index campaigns %v", campaignIDs)
+	}
+}
+
+// invalidateCache removes the cached entity after the change is committed.
+// Failures are only logged since the caches expire by themselves
+func (u *useCase) invalidateCache(entity string, id string, deleteCache func() error) {
+	if err := deleteCache(); err != nil {
+		core.Logger.WithError(err).Warnf("invalidateCache() - failed to delete %s cache of %s", entity, id)
+	}
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// NewUseCase returns content admin use case
+func NewUseCase(repo Repository, indexer Indexer, cache Cache) UseCase {
+	return &useCase{repo: repo, indexer: indexer, cache: cache}
+}
@@ -0,0 +1,333 @@
+package contentadmin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func (ts *UseCaseTestSuite) Test_CreateCampaign() {
+	campaign := newCampaign(0)
+	created := newCampaign(10)
+	ts.repo.On("CreateCampaign", campaign).Return(&created, nil).Once()
+	ts.repo.On("CreateAuditLog", mock.MatchedBy(func(auditLog contentadmin.AuditLog) bool {
+		return auditLog.Actor == "admin" && auditLog.Action == contentadmin.AuditActionCreate &&
+			auditLog.EntityType == contentadmin.EntityTypeCampaign && auditLog.EntityID == "10" &&
+			auditLog.Before == "" && auditLog.After != ""
+	})).Return(nil).Once()
+	ts.indexer.On("IndexCampaigns", mock.Anything, []int64{10}).Return(nil).Once()
+
+	result, err := ts.useCase.CreateCampaign("admin", campaign)
+
+	ts.NoError(err)
+	ts.Equal(int64(10), result.ID)
+	ts.repo.AssertExpectations(ts.T())
+	ts.indexer.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_CreateCampaign_Invalid() {
+	campaign := newCampaign(0)
+	campaign.WeekSlot = "0-200"
+
+	_, err := ts.useCase.CreateCampaign("admin", campaign)
+
+	ts.Equal(contentadmin.ValidationError{Field: "week_slot", Reason: "has invalid range 0-200"}, err)
+	ts.repo.AssertNotCalled(ts.T(), "Transaction", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_CreateCampaign_ChannelNotFound() {
+	campaign := newCampaign(0)
+	channelID := int64(7)
+	campaign.ChannelID = &channelID
+	ts.repo.On("GetChannel", channelID).Return(nil, nil).Once()
+
+	_, err := ts.useCase.CreateCampaign("admin", campaign)
+
+	ts.Equal(contentadmin.ValidationError{Field: "channel_id", Reason: "is not found"}, err)
+	ts.repo.AssertNotCalled(ts.T(), "CreateCampaign", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_UpdateCampaign_IndexFailed() {
+	old := newCampaign(10)
+	old.CreatedAt = time.Now().Add(-time.Hour)
+	campaign := newCampaign(10)
+	campaign.Title = "updated"
+	expected := campaign
+	expected.CreatedAt = old.CreatedAt
+
+	ts.repo.On("GetCampaign", int64(10)).Return(&old, nil).Once()
+	ts.repo.On("UpdateCampaign", expected).Return(&expected, nil).Once()
+	ts.repo.On("CreateAuditLog", mock.AnythingOfType("contentadmin.AuditLog")).Return(nil).Once()
+	ts.cache.On("DeleteCampaign", int64(10)).Return(nil).Once()
+	ts.indexer.On("IndexCampaigns", mock.Anything, []int64{10}).Return(errors.New("timeout")).Once()
+
+	// 색인에 실패해도 주기적인 색인이 반영하므로 성공한다
+	result, err := ts.useCase.UpdateCampaign("admin", campaign)
+
+	ts.NoError(err)
+	ts.Equal("updated", result.Title)
+	ts.repo.AssertExpectations(ts.T())
+	ts.indexer.AssertExpectations(ts.T())
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_UpdateCampaign_NotFound() {
+	ts.repo.On("GetCampaign", int64(10)).Return(nil, nil).Once()
+
+	_, err := ts.useCase.UpdateCampaign("admin", newCampaign(10))
+
+	ts.Equal(contentadmin.CampaignNotFoundError{ID: 10}, err)
+	ts.indexer.AssertNotCalled(ts.T(), "IndexCampaigns", mock.Anything, mock.Anything)
+	ts.cache.AssertNotCalled(ts.T(), "DeleteCampaign", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_DeleteCampaign() {
+	old := newCampaign(10)
+	ts.repo.On("GetCampaign", int64(10)).Return(&old, nil).Once()
+	ts.repo.On("DeleteCampaign", int64(10)).Return(nil).Once()
+	ts.repo.On("CreateAuditLog", mock.MatchedBy(func(auditLog contentadmin.AuditLog) bool {
+		return auditLog.Action == contentadmin.AuditActionDelete && auditLog.Before != "" && auditLog.After == ""
+	})).Return(nil).Once()
+	ts.indexer.On("DeleteCampaign", mock.Anything, int64(10)).Return(nil).Once()
+	ts.cache.On("DeleteCampaign", int64(10)).Return(nil).Once()
+
+	err := ts.useCase.DeleteCampaign("admin", 10)
+
+	ts.NoError(err)
+	ts.repo.AssertExpectations(ts.T())
+	ts.indexer.AssertExpectations(ts.T())
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_CreateCategory_Exists() {
+	category := contentadmin.Category{ID: "news", Name: "News"}
+	ts.repo.On("GetCategory", "news").Return(&category, nil).Once()
+
+	_, err := ts.useCase.CreateCategory("admin", category)
+
+	ts.Equal(contentadmin.CategoryExistsError{ID: "news"}, err)
+	ts.repo.AssertNotCalled(ts.T(), "CreateCategory", mock.Anything)
+	ts.cache.AssertNotCalled(ts.T(), "DeleteCategories")
+}
+
+func (ts *UseCaseTestSuite) Test_UpdateCategory_CacheFailed() {
+	old := contentadmin.Category{ID: "news", Name: "News"}
+	category := contentadmin.Category{ID: "news", Name: "Daily News"}
+	ts.repo.On("GetCategory", "news").Return(&old, nil).Once()
+	ts.repo.On("UpdateCategory", category).Return(&category, nil).Once()
+	ts.repo.On("CreateAuditLog", mock.AnythingOfType("contentadmin.AuditLog")).Return(nil).Once()
+	ts.cache.On("DeleteCategories").Return(errors.New("redis timeout")).Once()
+
+	// 캐시 삭제에 실패해도 만료되면 반영되므로 성공한다
+	result, err := ts.useCase.UpdateCategory("admin", category)
+
+	ts.NoError(err)
+	ts.Equal("Daily News", result.Name)
+	ts.repo.AssertExpectations(ts.T())
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_DeleteCategory() {
+	ts.repo.On("GetCategory", "news").Return(&contentadmin.Category{ID: "news", Name: "News"}, nil).Once()
+	ts.repo.On("DeleteCategory", "news").Return(nil).Once()
+	ts.repo.On("CreateAuditLog", mock.AnythingOfType("contentadmin.AuditLog")).Return(nil).Once()
+	ts.cache.On("DeleteCategories").Return(nil).Once()
+
+	err := ts.useCase.DeleteCategory("admin", "news")
+
+	ts.NoError(err)
+	ts.repo.AssertExpectations(ts.T())
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_UpdateChannel_ReindexesCampaigns() {
+	old := contentadmin.Channel{ID: 7, Name: "buzzvil"}
+	channel := contentadmin.Channel{ID: 7, Name: "buzzvil news"}
+	ts.repo.On("GetChannel", int64(7)).Return(&old, nil).Once()
+	ts.repo.On("UpdateChannel", channel).Return(&channel, nil).Once()
+	ts.repo.On("CreateAuditLog", mock.AnythingOfType("contentadmin.AuditLog")).Return(nil).Once()
+	ts.repo.On("GetCampaignIDsByChannelID", int64(7)).Return([]int64{1, 2}, nil).Once()
+	ts.indexer.On("IndexCampaigns", mock.Anything, []int64{1, 2}).Return(nil).Once()
+	ts.cache.On("DeleteChannel", int64(7)).Return(nil).Once()
+
+	_, err := ts.useCase.UpdateChannel("admin", channel)
+
+	ts.NoError(err)
+	ts.repo.AssertExpectations(ts.T())
+	ts.indexer.AssertExpectations(ts.T())
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_DeleteChannel_InUse() {
+	ts.repo.On("GetChannel", int64(7)).Return(&contentadmin.Channel{ID: 7, Name: "buzzvil"}, nil).Once()
+	ts.repo.On("GetCampaignIDsByChannelID", int64(7)).Return([]int64{1}, nil).Once()
+
+	err := ts.useCase.DeleteChannel("admin", 7)
+
+	ts.Equal(contentadmin.ChannelInUseError{ID: 7, CampaignCount: 1}, err)
+	ts.repo.AssertNotCalled(ts.T(), "DeleteChannel", mock.Anything)
+	ts.cache.AssertNotCalled(ts.T(), "DeleteChannel", mock.Anything)
+}
+
+func newCampaign(id int64) contentadmin.Campaign {
+	startDate := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	return contentadmin.Campaign{
+		ID:        id,
+		Title:     "title",
+		StartDate: startDate,
+		EndDate:   startDate.AddDate(0, 1, 0),
+		WeekSlot:  "0-23,48",
+	}
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	indexer *mockIndexer
+	cache   *mockCache
+	useCase contentadmin.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.indexer = new(mockIndexer)
+	ts.cache = new(mockCache)
+	ts.useCase = contentadmin.NewUseCase(ts.repo, ts.indexer, ts.cache)
+}
+
+var _ contentadmin.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+// Transaction runs fn with the mock itself so that the calls in the transaction are asserted
+func (r *mockRepo) Transaction(fn func(repo contentadmin.Repository) error) error {
+	return fn(r)
+}
+
+func (r *mockRepo) GetCampaign(id int64) (*contentadmin.Campaign, error) {
+	ret := r.Called(id)
+	campaign, _ := ret.Get(0).(*contentadmin.Campaign)
+	return campaign, ret.Error(1)
+}
+
+func (r *mockRepo) CreateCampaign(campaign contentadmin.Campaign) (*contentadmin.Campaign, error) {
+	ret := r.Called(campaign)
+	created, _ := ret.Get(0).(*contentadmin.Campaign)
+	return created, ret.Error(1)
+}
+
+func (r *mockRepo) UpdateCampaign(campaign contentadmin.Campaign) (*contentadmin.Campaign, error) {
+	ret := r.Called(campaign)
+	updated, _ := ret.Get(0).(*contentadmin.Campaign)
+	return updated, ret.Error(1)
+}
+
+func (r *mockRepo) DeleteCampaign(id int64) error {
+	return r.Called(id).Error(0)
+}
+
+func (r *mockRepo) GetCampaignIDsByChannelID(channelID int64) ([]int64, error) {
+	ret := r.Called(channelID)
+	return ret.Get(0).([]int64), ret.Error(1)
+}
+
+func (r *mockRepo) GetCategories() ([]contentadmin.Category, error) {
+	ret := r.Called()
+	return ret.Get(0).([]contentadmin.Category), ret.Error(1)
+}
+
+func (r *mockRepo) GetCategory(id string) (*contentadmin.Category, error) {
+	ret := r.Called(id)
+	category, _ := ret.Get(0).(*contentadmin.Category)
+	return category, ret.Error(1)
+}
+
+func (r *mockRepo) CreateCategory(category contentadmin.Category) (*contentadmin.Category, error) {
+	ret := r.Called(category)
+	created, _ := ret.Get(0).(*contentadmin.Category)
+	return created, ret.Error(1)
+}
+
+func (r *mockRepo) UpdateCategory(category contentadmin.Category) (*contentadmin.Category, error) {
+	ret := r.Called(category)
+	updated, _ := ret.Get(0).(*contentadmin.Category)
+	return updated, ret.Error(1)
+}
+
+func (r *mockRepo) DeleteCategory(id string) error {
+	return r.Called(id).Error(0)
+}
+
+func (r *mockRepo) GetChannel(id int64) (*contentadmin.Channel, error) {
+	ret := r.Called(id)
+	channel, _ := ret.Get(0).(*contentadmin.Channel)
+	return channel, ret.Error(1)
+}
+
+func (r *mockRepo) CreateChannel(channel contentadmin.Channel) (*contentadmin.Channel, error) {
+	ret := r.Called(channel)
+	created, _ := ret.Get(0).(*contentadmin.Channel)
+	return created, ret.Error(1)
+}
+
+func (r *mockRepo) UpdateChannel(channel contentadmin.Channel) (*contentadmin.Channel, error) {
+	ret := r.Called(channel)
+	updated, _ := ret.Get(0).(*contentadmin.Channel)
+	return updated, ret.Error(1)
+}
+
+func (r *mockRepo) DeleteChannel(id int64) error {
+	return r.Called(id).Error(0)
+}
+
+func (r *mockRepo) CreateAuditLog(auditLog contentadmin.AuditLog) error {
+	return r.Called(auditLog).Error(0)
+}
+
+func (r *mockRepo) GetAuditLogs(entityType contentadmin.EntityType, entityID string, limit int) ([]contentadmin.AuditLog, error) {
+	ret := r.Called(entityType, entityID, limit)
+	return ret.Get(0).([]contentadmin.AuditLog), ret.Error(1)
+}
+
+var _ contentadmin.Indexer = &mockIndexer{}
+
+type mockIndexer struct {
+	mock.Mock
+}
+
+func (i *mockIndexer) IndexCampaigns(ctx context.Context, campaignIDs []int64) error {
+	return i.Called(ctx, campaignIDs).Error(0)
+}
+
+func (i *mockIndexer) DeleteCampaign(ctx context.Context, campaignID int64) error {
+	return i.Called(ctx, campaignID).Error(0)
+}
+
+var _ contentadmin.Cache = &mockCache{}
+
+type mockCache struct {
+	mock.Mock
+}
+
+func (c *mockCache) DeleteCampaign(id int64) error {
+	return c.Called(id).Error(0)
+}
+
+func (c *mockCache) DeleteCategories() error {
+	return c.Called().Error(0)
+}
+
+func (c *mockCache) DeleteChannel(id int64) error {
+	return c.Called(id).Error(0)
+}
@@ -0,0 +1,126 @@
+package contentadmin
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	weekSlotCount = 7 * 24
+	maxTargetAge  = 150
+)
+
+var categoryIDPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Validate returns ValidationError of the first invalid field
+func (c Campaign) Validate() error {
+	if c.StartDate.IsZero() {
+		return ValidationError{Field: "start_date", Reason: "is required"}
+	} else if c.EndDate.IsZero() {
+		return ValidationError{Field: "end_date", Reason: "is required"}
+	} else if !c.StartDate.Before(c.EndDate) {
+		return ValidationError{Field: "end_date", Reason: "should be after start_date"}
+	}
+
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return ValidationError{Field: "timezone", Reason: "is unknown"}
+		}
+	}
+
+	if err := validateWeekSlot(c.WeekSlot); err != nil {
+		return err
+	}
+
+	ranges := []struct {
+		field    string
+		min, max *int
+		upper    int
+	}{
+		{"target_age", c.TargetAgeMin, c.TargetAgeMax, maxTargetAge},
+		{"target_sdk", c.TargetSdkMin, c.TargetSdkMax, 0},
+		{"target_os", c.TargetOsMin, c.TargetOsMax, 0},
+		{"registered_days", c.RegisteredDaysMin, c.RegisteredDaysMax, 0},
+	}
+	for _, r := range ranges {
+		if err := validateRange(r.field, r.min, r.max, r.upper); err != nil {
+			return err
+		}
+	}
+
+	if c.Ipu != nil && *c.Ipu <= 0 {
+		return ValidationError{Field: "ipu", Reason: "should be positive"}
+	} else if c.Tipu != nil && *c.Tipu <= 0 {
+		return ValidationError{Field: "tipu", Reason: "should be positive"}
+	}
+	return nil
+}
+
+// Validate returns ValidationError of the first invalid field
+func (c Category) Validate() error {
+	if !categoryIDPattern.MatchString(c.ID) {
+		return ValidationError{Field: "id", Reason: "should consist of lowercase letters, digits and underscores"}
+	} else if strings.TrimSpace(c.Name) == "" {
+		return ValidationError{Field: "name", Reason: "is required"}
+	}
+	return nil
+}
+
+// Validate returns ValidationError of the first invalid field
+func (c Channel) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return ValidationError{Field: "name", Reason: "is required"}
+	}
+	return nil
+}
+
+// validateWeekSlot checks the comma separated hours of the week. e.g. "0-2,30". Empty week slot targets all hours
+func validateWeekSlot(weekSlot string) error {
+	for _, item := range strings.Split(weekSlot, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		bounds := strings.SplitN(item, "-", 2)
+		from, err := parseWeekSlot(bounds[0])
+		if err != nil {
+			return ValidationError{Field: "week_slot", Reason: "has invalid slot " + item}
+		}
+		if len(bounds) == 2 {
+			to, err := parseWeekSlot(bounds[1])
+			if err != nil || from > to {
+				return ValidationError{Field: "week_slot", Reason: "has invalid range " + item}
+			}
+		}
+	}
+	return nil
+}
+
+func parseWeekSlot(value string) (int, error) {
+	slot, err := strconv.Atoi(strings.TrimSpace(value))
+	if err == nil && (slot < 0 || slot >= weekSlotCount) {
+		err = strconv.ErrRange
+	}
+	return slot, err
+}
+
+// validateRange checks the optional bounds are not negative and min <= max. upper is ignored if it's 0
+func validateRange(field string, min *int, max *int, upper int) error {
+	for _, bound := range []*int{min, max} {
+		if bound == nil {
+			continue
+		}
+		if *bound < 0 {
+			return ValidationError{Field: field, Reason: "should not be negative"}
+		} else if upper > 0 && *bound > upper {
+			return ValidationError{Field: field, Reason: "should not be greater than " + strconv.Itoa(upper)}
+		}
+	}
+
+	if min != nil && max != nil && *min > *max {
+		return ValidationError{Field: field, Reason: "min should not be greater than max"}
+	}
+	return nil
+}
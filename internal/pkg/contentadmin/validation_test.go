@@ -0,0 +1,54 @@
+package contentadmin_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaign_Validate(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	startDate := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		modify func(c *contentadmin.Campaign)
+		field  string
+	}{
+		{"valid", func(c *contentadmin.Campaign) {}, ""},
+		{"week slot of a single hour and ranges", func(c *contentadmin.Campaign) { c.WeekSlot = "0, 3-5,167" }, ""},
+		{"week slot out of the week", func(c *contentadmin.Campaign) { c.WeekSlot = "168" }, "week_slot"},
+		{"week slot of reversed range", func(c *contentadmin.Campaign) { c.WeekSlot = "5-3" }, "week_slot"},
+		{"week slot of non number", func(c *contentadmin.Campaign) { c.WeekSlot = "mon" }, "week_slot"},
+		{"end date before start date", func(c *contentadmin.Campaign) { c.EndDate = startDate.Add(-time.Hour) }, "end_date"},
+		{"missing start date", func(c *contentadmin.Campaign) { c.StartDate = time.Time{} }, "start_date"},
+		{"age range", func(c *contentadmin.Campaign) { c.TargetAgeMin, c.TargetAgeMax = intPtr(20), intPtr(29) }, ""},
+		{"reversed age range", func(c *contentadmin.Campaign) { c.TargetAgeMin, c.TargetAgeMax = intPtr(30), intPtr(20) }, "target_age"},
+		{"too old age", func(c *contentadmin.Campaign) { c.TargetAgeMax = intPtr(200) }, "target_age"},
+		{"negative sdk", func(c *contentadmin.Campaign) { c.TargetSdkMin = intPtr(-1) }, "target_sdk"},
+		{"reversed sdk range", func(c *contentadmin.Campaign) { c.TargetSdkMin, c.TargetSdkMax = intPtr(3000), intPtr(2000) }, "target_sdk"},
+		{"unknown timezone", func(c *contentadmin.Campaign) { c.Timezone = "Asia/Nowhere" }, "timezone"},
+		{"zero ipu", func(c *contentadmin.Campaign) { c.Ipu = intPtr(0) }, "ipu"},
+	}
+
+	for _, test := range tests {
+		campaign := contentadmin.Campaign{StartDate: startDate, EndDate: startDate.AddDate(0, 1, 0)}
+		test.modify(&campaign)
+
+		err := campaign.Validate()
+
+		if test.field == "" {
+			assert.NoError(t, err, test.name)
+		} else if assert.IsType(t, contentadmin.ValidationError{}, err, test.name) {
+			assert.Equal(t, test.field, err.(contentadmin.ValidationError).Field, test.name)
+		}
+	}
+}
+
+func TestCategory_Validate(t *testing.T) {
+	assert.NoError(t, contentadmin.Category{ID: "fun_news", Name: "Fun News"}.Validate())
+	assert.Equal(t, "id", contentadmin.Category{ID: "Fun News", Name: "Fun News"}.Validate().(contentadmin.ValidationError).Field)
+	assert.Equal(t, "name", contentadmin.Category{ID: "news"}.Validate().(contentadmin.ValidationError).Field)
+}
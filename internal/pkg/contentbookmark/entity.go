@@ -0,0 +1,51 @@
+package contentbookmark
+
+import "time"
+
+// Page size and limit of the bookmarks of a device
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+	MaxBookmarks    = 1000
+)
+
+// Bookmark is an article a device saved to read later
+type Bookmark struct {
+	ID         int64
+	DeviceID   int64
+	CampaignID int64
+	Snapshot   Snapshot
+	CreatedAt  time.Time
+}
+
+// Snapshot is the campaign at the time it's bookmarked. Bookmarks are shown from the snapshot even after the campaign ends
+type Snapshot struct {
+	Name           string
+	Title          string
+	Description    string
+	ImageURL       string
+	ClickURL       string
+	CleanLink      string
+	CleanMode      int
+	LandingType    int
+	Categories     string
+	PublishedAt    string
+	EndDate        string
+	Timezone       string
+	OrganizationID int64
+	OwnerID        int64
+	Channel        *Channel
+}
+
+// Channel is the channel of the campaign at the time it's bookmarked
+type Channel struct {
+	ID   int64
+	Name string
+	Logo string
+}
+
+// Page is the bookmarks of a device from the latest one
+type Page struct {
+	Bookmarks  []Bookmark
+	NextCursor int64 // 0 if there's no more bookmark
+}
@@ -0,0 +1,17 @@
+package contentbookmark
+
+import "fmt"
+
+var (
+	_ error = TooManyBookmarksError{}
+)
+
+// TooManyBookmarksError will be returned when a device bookmarks more than MaxBookmarks campaigns
+type TooManyBookmarksError struct {
+	DeviceID int64
+}
+
+// Error func definition
+func (e TooManyBookmarksError) Error() string {
+	return fmt.Sprintf("device %d has more than %d bookmarks", e.DeviceID, MaxBookmarks)
+}
@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"encoding/json"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentbookmark"
+)
+
+type entityMapper struct {
+}
+
+func (m *entityMapper) dbBookmarkToBookmark(dbBookmark DBBookmark) (contentbookmark.Bookmark, error) {
+	var snapshot dbSnapshot
+	if err := json.Unmarshal([]byte(dbBookmark.Snapshot), &snapshot); err != nil {
+		return contentbookmark.Bookmark{}, err
+	}
+
+	bookmark := contentbookmark.Bookmark{
+		ID:         dbBookmark.ID,
+		DeviceID:   dbBookmark.DeviceID,
+		CampaignID: dbBookmark.CampaignID,
+		Snapshot: contentbookmark.Snapshot{
+			Name:           snapshot.Name,
+			Title:          snapshot.Title,
+			Description:    snapshot.Description,
+			ImageURL:       snapshot.ImageURL,
+			ClickURL:       snapshot.ClickURL,
+			CleanLink:      snapshot.CleanLink,
+			CleanMode:      snapshot.CleanMode,
+			LandingType:    snapshot.LandingType,
+			Categories:     snapshot.Categories,
+			PublishedAt:    snapshot.PublishedAt,
+			EndDate:        snapshot.EndDate,
+			Timezone:       snapshot.Timezone,
+			OrganizationID: snapshot.OrganizationID,
+			OwnerID:        snapshot.OwnerID,
+		},
+		CreatedAt: dbBookmark.CreatedAt,
+	}
+	if snapshot.Channel != nil {
+		bookmark.Snapshot.Channel = &contentbookmark.Channel{ID: snapshot.Channel.ID, Name: snapshot.Channel.Name, Logo: snapshot.Channel.Logo}
+	}
+	return bookmark, nil
+}
+
+func (m *entityMapper) bookmarkToDBBookmark(bookmark contentbookmark.Bookmark) (DBBookmark, error) {
+	snapshot := dbSnapshot{
+		Name:           bookmark.Snapshot.Name,
+		Title:          bookmark.Snapshot.Title,
+		Description:    bookmark.Snapshot.Description,
+		ImageURL:       bookmark.Snapshot.ImageURL,
+		ClickURL:       bookmark.Snapshot.ClickURL,
+		CleanLink:      bookmark.Snapshot.CleanLink,
+		CleanMode:      bookmark.Snapshot.CleanMode,
+		LandingType:    bookmark.Snapshot.LandingType,
+		Categories:     bookmark.Snapshot.Categories,
+		PublishedAt:    bookmark.Snapshot.PublishedAt,
+		EndDate:        bookmark.Snapshot.EndDate,
+		Timezone:       bookmark.Snapshot.Timezone,
+		OrganizationID: bookmark.Snapshot.OrganizationID,
+		OwnerID:        bookmark.Snapshot.OwnerID,
+	}
+	if channel := bookmark.Snapshot.Channel; channel != nil {
+		snapshot.Channel = &dbChannel{ID: channel.ID, Name: channel.Name, Logo: channel.Logo}
+	}
+
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return DBBookmark{}, err
+	}
+	return DBBookmark{
+		ID:         bookmark.ID,
+		DeviceID:   bookmark.DeviceID,
+		CampaignID: bookmark.CampaignID,
+		Snapshot:   string(snapshotJSON),
+		CreatedAt:  bookmark.CreatedAt,
+	}, nil
+}
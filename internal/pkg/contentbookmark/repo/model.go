@@ -0,0 +1,42 @@
+package repo
+
+import "time"
+
+// DBBookmark struct definition
+type DBBookmark struct {
+	ID         int64 `gorm:"primary_key"`
+	DeviceID   int64
+	CampaignID int64
+	Snapshot   string // JSON of dbSnapshot
+
+	CreatedAt time.Time
+}
+
+// TableName func definition
+func (DBBookmark) TableName() string {
+	return "content_bookmarks"
+}
+
+type dbSnapshot struct {
+	Name           string     `json:"name"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	ImageURL       string     `json:"image_url"`
+	ClickURL       string     `json:"click_url"`
+	CleanLink      string     `json:"clean_link,omitempty"`
+	CleanMode      int        `json:"clean_mode"`
+	LandingType    int        `json:"landing_type"`
+	Categories     string     `json:"categories"`
+	PublishedAt    string     `json:"published_at,omitempty"`
+	EndDate        string     `json:"end_date,omitempty"`
+	Timezone       string     `json:"timezone,omitempty"`
+	OrganizationID int64      `json:"organization_id"`
+	OwnerID        int64      `json:"owner_id"`
+	Channel        *dbChannel `json:"channel,omitempty"`
+}
+
+type dbChannel struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Logo string `json:"logo"`
+}
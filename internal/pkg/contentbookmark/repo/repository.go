@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentbookmark"
+	"github.com/jinzhu/gorm"
+)
+
+// Repository struct definition
+type Repository struct {
+	db     *gorm.DB
+	mapper *entityMapper
+}
+
+// GetBookmark returns the bookmark of the campaign. nil is returned if it doesn't exist
+func (r *Repository) GetBookmark(deviceID int64, campaignID int64) (*contentbookmark.Bookmark, error) {
+	var dbBookmark DBBookmark
+	err := r.db.Where("device_id = ? AND campaign_id = ?", deviceID, campaignID).First(&dbBookmark).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	bookmark, err := r.mapper.dbBookmarkToBookmark(dbBookmark)
+	if err != nil {
+		return nil, err
+	}
+	return &bookmark, nil
+}
+
+// GetBookmarks returns the bookmarks of ids less than the cursor ordered by id desc
+func (r *Repository) GetBookmarks(deviceID int64, cursor int64, size int) ([]contentbookmark.Bookmark, error) {
+	query := r.db.Where("device_id = ?", deviceID)
+	if cursor > 0 {
+		query = query.Where("id < ?", cursor)
+	}
+
+	var dbBookmarks []DBBookmark
+	if err := query.Order("id desc").Limit(size).Find(&dbBookmarks).Error; err != nil {
+		return nil, err
+	}
+
+	bookmarks := make([]contentbookmark.Bookmark, 0, len(dbBookmarks))
+	for _, dbBookmark := range dbBookmarks {
+		bookmark, err := r.mapper.dbBookmarkToBookmark(dbBookmark)
+		if err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, bookmark)
+	}
+	return bookmarks, nil
+}
+
+// CountBookmarks func definition
+func (r *Repository) CountBookmarks(deviceID int64) (int, error) {
+	var count int
+	err := r.db.Model(&DBBookmark{}).Where("device_id = ?", deviceID).Count(&count).Error
+	return count, err
+}
+
+// CreateBookmark inserts the bookmark and returns it with the assigned id
+func (r *Repository) CreateBookmark(bookmark contentbookmark.Bookmark) (*contentbookmark.Bookmark, error) {
+	dbBookmark, err := r.mapper.bookmarkToDBBookmark(bookmark)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Create(&dbBookmark).Error; err != nil {
+		return nil, err
+	}
+
+	bookmark.ID = dbBookmark.ID
+	return &bookmark, nil
+}
+
+// DeleteBookmark func definition
+func (r *Repository) DeleteBookmark(deviceID int64, campaignID int64) error {
+	return r.db.Where("device_id = ? AND campaign_id = ?", deviceID, campaignID).Delete(DBBookmark{}).Error
+}
+
+// New returns content bookmark repository
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db:     db,
+		mapper: &entityMapper{},
+	}
+}
+
+var _ contentbookmark.Repository = &Repository{}
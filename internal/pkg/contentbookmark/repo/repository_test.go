@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentbookmark"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	db   *gorm.DB
+	repo contentbookmark.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	ts.NoError(err)
+	ts.mock = mock
+	ts.db, err = gorm.Open("mysql", db)
+	ts.NoError(err)
+	ts.repo = New(ts.db)
+}
+
+func (ts *RepoTestSuite) AfterTest() {
+	_ = ts.db.Close()
+}
+
+func (ts *RepoTestSuite) Test_GetBookmark_NotFound() {
+	req := "SELECT * FROM `content_bookmarks` WHERE (device_id = ? AND campaign_id = ?) ORDER BY `content_bookmarks`.`id` ASC LIMIT 1"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	bookmark, err := ts.repo.GetBookmark(1, 2)
+
+	ts.NoError(err)
+	ts.Nil(bookmark)
+}
+
+func (ts *RepoTestSuite) Test_GetBookmarks() {
+	createdAt := time.Now()
+	req := "SELECT * FROM `content_bookmarks` WHERE (device_id = ?) AND (id < ?) ORDER BY id desc LIMIT 3"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs(1, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "device_id", "campaign_id", "snapshot", "created_at"}).
+			AddRow(9, 1, 2, `{"title":"title","image_url":"http://image","channel":{"id":3,"name":"buzz"}}`, createdAt))
+
+	bookmarks, err := ts.repo.GetBookmarks(1, 10, 3)
+
+	ts.NoError(err)
+	ts.Equal([]contentbookmark.Bookmark{{
+		ID:         9,
+		DeviceID:   1,
+		CampaignID: 2,
+		Snapshot: contentbookmark.Snapshot{
+			Title:    "title",
+			ImageURL: "http://image",
+			Channel:  &contentbookmark.Channel{ID: 3, Name: "buzz"},
+		},
+		CreatedAt: createdAt,
+	}}, bookmarks)
+}
+
+func (ts *RepoTestSuite) Test_CreateBookmark() {
+	createdAt := time.Now()
+	snapshot := `{"name":"","title":"title","description":"","image_url":"","click_url":"http://click","clean_mode":0,"landing_type":1,"categories":"","organization_id":0,"owner_id":0}`
+	ts.mock.ExpectBegin()
+	ts.mock.ExpectExec(ts.fixedFullRe("INSERT INTO `content_bookmarks` (`device_id`,`campaign_id`,`snapshot`,`created_at`) VALUES (?,?,?,?)")).
+		WithArgs(1, 2, snapshot, createdAt).WillReturnResult(sqlmock.NewResult(10, 1))
+	ts.mock.ExpectCommit()
+
+	bookmark, err := ts.repo.CreateBookmark(contentbookmark.Bookmark{
+		DeviceID:   1,
+		CampaignID: 2,
+		Snapshot:   contentbookmark.Snapshot{Title: "title", ClickURL: "http://click", LandingType: 1},
+		CreatedAt:  createdAt,
+	})
+
+	ts.NoError(err)
+	ts.Equal(int64(10), bookmark.ID)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_DeleteBookmark() {
+	ts.mock.ExpectBegin()
+	ts.mock.ExpectExec(ts.fixedFullRe("DELETE FROM `content_bookmarks` WHERE (device_id = ? AND campaign_id = ?)")).
+		WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	ts.mock.ExpectCommit()
+
+	err := ts.repo.DeleteBookmark(1, 2)
+
+	ts.NoError(err)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) fixedFullRe(s string) string {
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(s))
+}
@@ -0,0 +1,19 @@
+package contentbookmark
+
+import "github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
+
+// Repository interface definition
+type Repository interface {
+	// GetBookmark returns the bookmark of the campaign. nil is returned if the device hasn't bookmarked it
+	GetBookmark(deviceID int64, campaignID int64) (*Bookmark, error)
+	// GetBookmarks returns the bookmarks older than the cursor from the latest one. cursor 0 means the first page
+	GetBookmarks(deviceID int64, cursor int64, size int) ([]Bookmark, error)
+	CountBookmarks(deviceID int64) (int, error)
+	CreateBookmark(bookmark Bookmark) (*Bookmark, error)
+	DeleteBookmark(deviceID int64, campaignID int64) error
+}
+
+// FeedbackApplier updates the category scores of the device by the bookmark. device.UseCase satisfies it
+type FeedbackApplier interface {
+	ApplyContentFeedback(deviceID int64, feedback device.ContentFeedback, relevance device.ContentRelevance) (*device.Profile, error)
+}
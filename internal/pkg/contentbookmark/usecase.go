@@ -0,0 +1,92 @@
+package contentbookmark
+
+import (
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
+)
+
+// UseCase interface definition
+type UseCase interface {
+	// AddBookmark saves the snapshot of the campaign for the device. The existing bookmark is returned if it's already bookmarked
+	AddBookmark(deviceID int64, campaignID int64, snapshot Snapshot, relevance device.ContentRelevance) (*Bookmark, error)
+	RemoveBookmark(deviceID int64, campaignID int64) error
+	// GetBookmarks returns a page of the bookmarks older than the cursor. cursor 0 means the first page
+	GetBookmarks(deviceID int64, cursor int64, size int) (*Page, error)
+}
+
+type useCase struct {
+	repo     Repository
+	feedback FeedbackApplier
+}
+
+// AddBookmark func definition
+func (u *useCase) AddBookmark(deviceID int64, campaignID int64, snapshot Snapshot, relevance device.ContentRelevance) (*Bookmark, error) {
+	existing, err := u.repo.GetBookmark(deviceID, campaignID)
+	if err != nil {
+		return nil, err
+	} else if existing != nil {
+		return existing, nil
+	}
+
+	count, err := u.repo.CountBookmarks(deviceID)
+	if err != nil {
+		return nil, err
+	} else if count >= MaxBookmarks {
+		return nil, TooManyBookmarksError{DeviceID: deviceID}
+	}
+
+	now := time.Now()
+	bookmark, err := u.repo.CreateBookmark(Bookmark{
+		DeviceID:   deviceID,
+		CampaignID: campaignID,
+		Snapshot:   snapshot,
+		CreatedAt:  now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 북마크는 저장되었으므로 profile 반영에 실패해도 에러를 반환하지 않는다
+	feedback := device.ContentFeedback{Type: device.FeedbackBookmark, CampaignID: campaignID, CreatedAt: now.Unix()}
+	if snapshot.Channel != nil {
+		feedback.ChannelID = snapshot.Channel.ID
+	}
+	if _, err := u.feedback.ApplyContentFeedback(deviceID, feedback, relevance); err != nil {
+		core.Logger.WithError(err).Warnf("AddBookmark() - failed to apply bookmark of campaign %d to device %d", campaignID, deviceID)
+	}
+	return bookmark, nil
+}
+
+// RemoveBookmark func definition
+func (u *useCase) RemoveBookmark(deviceID int64, campaignID int64) error {
+	return u.repo.DeleteBookmark(deviceID, campaignID)
+}
+
+// GetBookmarks func definition
+func (u *useCase) GetBookmarks(deviceID int64, cursor int64, size int) (*Page, error) {
+	if size <= 0 {
+		size = DefaultPageSize
+	} else if size > MaxPageSize {
+		size = MaxPageSize
+	}
+
+	// 다음 페이지가 있는지 확인하기 위해 하나 더 조회한다
+	bookmarks, err := u.repo.GetBookmarks(deviceID, cursor, size+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Bookmarks: bookmarks}
+	if len(bookmarks) > size {
+		page.Bookmarks = bookmarks[:size]
+		page.NextCursor = page.Bookmarks[size-1].ID
+	}
+	return page, nil
+}
+
+// NewUseCase returns content bookmark use case
+func NewUseCase(repo Repository, feedback FeedbackApplier) UseCase {
+	return &useCase{repo: repo, feedback: feedback}
+}
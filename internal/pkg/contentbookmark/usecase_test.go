@@ -0,0 +1,142 @@
+package contentbookmark_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentbookmark"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func (ts *UseCaseTestSuite) Test_AddBookmark() {
+	snapshot := contentbookmark.Snapshot{Title: "title", Channel: &contentbookmark.Channel{ID: 3}}
+	relevance := device.ContentRelevance{CategoryScores: map[string]float64{"/sports": 1}}
+	ts.repo.On("GetBookmark", int64(1), int64(2)).Return(nil, nil).Once()
+	ts.repo.On("CountBookmarks", int64(1)).Return(0, nil).Once()
+	ts.repo.On("CreateBookmark", mock.AnythingOfType("contentbookmark.Bookmark")).Return(&contentbookmark.Bookmark{ID: 10, DeviceID: 1, CampaignID: 2, Snapshot: snapshot}, nil).Once()
+	ts.feedback.On("ApplyContentFeedback", int64(1), mock.AnythingOfType("device.ContentFeedback"), relevance).Return(nil, errors.New("dynamo error")).Once()
+
+	bookmark, err := ts.useCase.AddBookmark(1, 2, snapshot, relevance)
+
+	ts.NoError(err)
+	ts.Equal(int64(10), bookmark.ID)
+	feedback := ts.feedback.Calls[0].Arguments.Get(1).(device.ContentFeedback)
+	ts.Equal(device.FeedbackBookmark, feedback.Type)
+	ts.Equal(int64(3), feedback.ChannelID)
+}
+
+func (ts *UseCaseTestSuite) Test_AddBookmark_Exists() {
+	ts.repo.On("GetBookmark", int64(1), int64(2)).Return(&contentbookmark.Bookmark{ID: 10}, nil).Once()
+
+	bookmark, err := ts.useCase.AddBookmark(1, 2, contentbookmark.Snapshot{}, device.ContentRelevance{})
+
+	ts.NoError(err)
+	ts.Equal(int64(10), bookmark.ID)
+	ts.repo.AssertNotCalled(ts.T(), "CreateBookmark", mock.Anything)
+	ts.feedback.AssertNotCalled(ts.T(), "ApplyContentFeedback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_AddBookmark_TooMany() {
+	ts.repo.On("GetBookmark", int64(1), int64(2)).Return(nil, nil).Once()
+	ts.repo.On("CountBookmarks", int64(1)).Return(contentbookmark.MaxBookmarks, nil).Once()
+
+	bookmark, err := ts.useCase.AddBookmark(1, 2, contentbookmark.Snapshot{}, device.ContentRelevance{})
+
+	ts.IsType(contentbookmark.TooManyBookmarksError{}, err)
+	ts.Nil(bookmark)
+}
+
+func (ts *UseCaseTestSuite) Test_GetBookmarks() {
+	ts.Run("next page", func() {
+		ts.repo.On("GetBookmarks", int64(1), int64(0), 3).Return([]contentbookmark.Bookmark{{ID: 9}, {ID: 7}, {ID: 4}}, nil).Once()
+
+		page, err := ts.useCase.GetBookmarks(1, 0, 2)
+
+		ts.NoError(err)
+		ts.Len(page.Bookmarks, 2)
+		ts.Equal(int64(7), page.NextCursor)
+	})
+	ts.Run("last page", func() {
+		ts.repo.On("GetBookmarks", int64(1), int64(7), contentbookmark.DefaultPageSize+1).Return([]contentbookmark.Bookmark{{ID: 4}}, nil).Once()
+
+		page, err := ts.useCase.GetBookmarks(1, 7, 0)
+
+		ts.NoError(err)
+		ts.Len(page.Bookmarks, 1)
+		ts.Zero(page.NextCursor)
+	})
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo     *mockRepo
+	feedback *mockFeedbackApplier
+	useCase  contentbookmark.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.feedback = new(mockFeedbackApplier)
+	ts.useCase = contentbookmark.NewUseCase(ts.repo, ts.feedback)
+}
+
+func (ts *UseCaseTestSuite) TearDownTest() {
+	ts.repo.AssertExpectations(ts.T())
+	ts.feedback.AssertExpectations(ts.T())
+}
+
+var _ contentbookmark.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) GetBookmark(deviceID int64, campaignID int64) (*contentbookmark.Bookmark, error) {
+	ret := r.Called(deviceID, campaignID)
+	if bookmark := ret.Get(0); bookmark != nil {
+		return bookmark.(*contentbookmark.Bookmark), ret.Error(1)
+	}
+	return nil, ret.Error(1)
+}
+
+func (r *mockRepo) GetBookmarks(deviceID int64, cursor int64, size int) ([]contentbookmark.Bookmark, error) {
+	ret := r.Called(deviceID, cursor, size)
+	return ret.Get(0).([]contentbookmark.Bookmark), ret.Error(1)
+}
+
+func (r *mockRepo) CountBookmarks(deviceID int64) (int, error) {
+	ret := r.Called(deviceID)
+	return ret.Int(0), ret.Error(1)
+}
+
+func (r *mockRepo) CreateBookmark(bookmark contentbookmark.Bookmark) (*contentbookmark.Bookmark, error) {
+	ret := r.Called(bookmark)
+	if created := ret.Get(0); created != nil {
+		return created.(*contentbookmark.Bookmark), ret.Error(1)
+	}
+	return nil, ret.Error(1)
+}
+
+func (r *mockRepo) DeleteBookmark(deviceID int64, campaignID int64) error {
+	return r.Called(deviceID, campaignID).Error(0)
+}
+
+var _ contentbookmark.FeedbackApplier = &mockFeedbackApplier{}
+
+type mockFeedbackApplier struct {
+	mock.Mock
+}
+
+func (a *mockFeedbackApplier) ApplyContentFeedback(deviceID int64, feedback device.ContentFeedback, relevance device.ContentRelevance) (*device.Profile, error) {
+	ret := a.Called(deviceID, feedback, relevance)
+	if profile := ret.Get(0); profile != nil {
+		return profile.(*device.Profile), ret.Error(1)
+	}
+	return nil, ret.Error(1)
+}
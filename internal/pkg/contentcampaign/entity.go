@@ -97,3 +97,17 @@ type ContentCampaign struct {
 
 	ExtraData *map[string]interface{} `gorm:"-"`
 }
+
+// Stat is the impression and click counts of a campaign for all units
+type Stat struct {
+	Impressions int64
+	Clicks      int64
+}
+
+// CTR returns clicks per impression. 0 if no impression
+func (s Stat) CTR() float64 {
+	if s.Impressions == 0 {
+		return 0
+	}
+	return float64(s.Clicks) / float64(s.Impressions)
+}
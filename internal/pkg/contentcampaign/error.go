@@ -1,7 +1,10 @@
 package contentcampaign
 
+import "fmt"
+
 var (
 	_ error = RemoteESError{}
+	_ error = CampaignNotIndexedError{}
 )
 
 // RemoteESError will be returned when the Content campaign response is invalid from the content elastic search.
@@ -13,3 +16,13 @@ type RemoteESError struct {
 func (ree RemoteESError) Error() string {
 	return ree.Err.Error()
 }
+
+// CampaignNotIndexedError will be returned when the campaign to explain is not in the search repository.
+type CampaignNotIndexedError struct {
+	CampaignID int64
+}
+
+// Error func definition
+func (e CampaignNotIndexedError) Error() string {
+	return fmt.Sprintf("content campaign %d is not indexed", e.CampaignID)
+}
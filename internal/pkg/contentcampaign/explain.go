@@ -0,0 +1,69 @@
+package contentcampaign
+
+// TargetingClause is the name of a targeting filter of SearchQuery
+type TargetingClause string
+
+// TargetingClause constants. Clauses are applied in this order.
+const (
+	ClauseEnabled             TargetingClause = "enabled"
+	ClauseStartDate           TargetingClause = "start_date"
+	ClauseEndDate             TargetingClause = "end_date"
+	ClauseCountry             TargetingClause = "country"
+	ClauseGender              TargetingClause = "gender"
+	ClauseUnit                TargetingClause = "unit"
+	ClauseApp                 TargetingClause = "app"
+	ClauseOrganization        TargetingClause = "organization"
+	ClauseAge                 TargetingClause = "age"
+	ClauseSdk                 TargetingClause = "sdk"
+	ClauseRegisteredDays      TargetingClause = "registered_days"
+	ClauseWeekSlot            TargetingClause = "week_slot"
+	ClauseCreativeType        TargetingClause = "creative_type"
+	ClauseCustomTargets       TargetingClause = "custom_targets"
+	ClauseOs                  TargetingClause = "os"
+	ClauseBatteryOptimization TargetingClause = "battery_optimization"
+	ClauseLanguage            TargetingClause = "language"
+	ClauseTargetLanguage      TargetingClause = "target_language"
+	ClauseCategories          TargetingClause = "categories"
+	ClauseExcludedCategories  TargetingClause = "excluded_categories"
+	ClauseChannels            TargetingClause = "channels"
+	ClauseExcludedChannels    TargetingClause = "excluded_channels"
+	ClauseExcludedProviders   TargetingClause = "excluded_providers"
+	ClauseExcludedIDs         TargetingClause = "excluded_ids"
+	ClauseUpdatedAt           TargetingClause = "updated_at"
+	ClauseColdStart           TargetingClause = "cold_start"
+	ClauseCarrier             TargetingClause = "carrier"
+	ClauseRegion              TargetingClause = "region"
+	ClauseImageRatio          TargetingClause = "image_ratio"
+	ClausePackages            TargetingClause = "packages"
+	ClauseLandingTypes        TargetingClause = "landing_types"
+	ClauseFrequencyCap        TargetingClause = "frequency_cap"
+	ClauseStatus              TargetingClause = "status"
+	ClauseKeyword             TargetingClause = "keyword"
+
+	// ClauseHardFrequencyCap is the tipu cap applied after retrieval. refer to FrequencyCap.Check
+	ClauseHardFrequencyCap TargetingClause = "hard_frequency_cap"
+)
+
+// ClauseResult type definition
+type ClauseResult struct {
+	Clause TargetingClause
+	Passed bool
+}
+
+// Explanation is the result of the targeting clauses of a search request applied to a campaign.
+// Only the clauses applied by the query are included. e.g. packages are not checked if SearchQuery.Packages is nil
+type Explanation struct {
+	CampaignID int64
+	Clauses    []ClauseResult
+	Hit        SearchHit // the campaign scored by the ranking of the request
+}
+
+// Matched returns true if the campaign passes every clause
+func (e Explanation) Matched() bool {
+	for _, result := range e.Clauses {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package contentcampaign
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// maxBackfillPages is the number of next pages searched when frequency caps remove campaigns of a page
+const maxBackfillPages = 3
+
+// FrequencyCapType type definition
+type FrequencyCapType string
+
+// FrequencyCapType constants
+// ipu(hourly) and dipu(daily) are applied by the search. refer to es/script/filter/filter.painless
+const (
+	FrequencyCapTipu FrequencyCapType = "tipu" // total impressions per user
+)
+
+// FrequencyCapHit is the campaign removed by the frequency cap
+type FrequencyCapHit struct {
+	CampaignID int64
+	Type       FrequencyCapType
+	Limit      int
+	Count      int
+}
+
+// frequencyCapSource is the fields of SearchHit.Source used for frequency capping
+type frequencyCapSource struct {
+	Tipu *int `json:"tipu"`
+}
+
+// Check returns the cap hit if the device has seen the campaign of the hit as many as its tipu. nil: not capped
+func (f FrequencyCap) Check(hit SearchHit) *FrequencyCapHit {
+	var source frequencyCapSource
+	if err := json.Unmarshal(hit.Source, &source); err != nil {
+		return nil
+	}
+
+	id := strconv.FormatInt(hit.ID, 10)
+	if count := f.CountsForLifetime[id]; isCapped(source.Tipu, count) {
+		return &FrequencyCapHit{CampaignID: hit.ID, Type: FrequencyCapTipu, Limit: *source.Tipu, Count: count}
+	}
+	return nil
+}
+
+// isCapped returns true if count reaches the limit. 0 or null limit is not capped same as es/script/filter/filter.painless
+func isCapped(limit *int, count int) bool {
+	return limit != nil && *limit > 0 && count >= *limit
+}
+
+// searchWithFrequencyCap removes the capped campaigns from the searched page and fills it up from the next pages
+func (u *useCase) searchWithFrequencyCap(req SearchRequest, frequencyCap FrequencyCap) (*SearchResult, error) {
+	result := &SearchResult{Hits: make([]SearchHit, 0, req.Size)}
+	pageReq := req
+	capped := 0
+
+	for page := 0; page <= maxBackfillPages; page++ {
+		searched, err := u.search(pageReq)
+		if err != nil {
+			return nil, err
+		}
+		if page == 0 {
+			result.Total = searched.Total
+		}
+
+		for _, hit := range searched.Hits {
+			if len(result.Hits) == req.Size {
+				break
+			}
+			if capHit := frequencyCap.Check(hit); capHit != nil {
+				u.logFrequencyCapHit(req, *capHit)
+				capped++
+				continue
+			}
+			result.Hits = append(result.Hits, hit)
+		}
+
+		if len(result.Hits) == req.Size || len(searched.Hits) < pageReq.Size {
+			break
+		}
+
+		// 다음 페이지로 채운다
+		last := searched.Hits[len(searched.Hits)-1]
+		if cursor := NewSearchCursor(last); cursor != nil {
+			pageReq.After, pageReq.From = cursor, 0
+		} else {
+			pageReq.From += pageReq.Size
+		}
+	}
+
+	result.Total -= capped
+	return result, nil
+}
+
+func (u *useCase) logFrequencyCapHit(req SearchRequest, capHit FrequencyCapHit) {
+	if u.logger == nil {
+		return
+	}
+
+	u.logger.Log(map[string]interface{}{
+		"type":        "content_frequency_cap",
+		"device_id":   req.DeviceID,
+		"unit_id":     req.Query.UnitID,
+		"campaign_id": capHit.CampaignID,
+		"cap_type":    string(capHit.Type),
+		"cap_limit":   capHit.Limit,
+		"cap_count":   capHit.Count,
+		"event_at":    time.Now().Unix(),
+	})
+}
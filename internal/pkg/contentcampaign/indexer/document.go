@@ -0,0 +1,282 @@
+package indexer
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbcontentcampaign"
+)
+
+const (
+	esTimeFormat = "2006-01-02T15:04:05-07:00"
+
+	// globString is indexed for the targeting fields which targets all
+	globString = "__GLOB__"
+	// detargetPrefix marks the detargeted item of the target fields in the database. e.g. "__GLOB__,-123"
+	detargetPrefix = "-"
+	// null values of the ranged targeting fields. refer to the index mapping
+	nullShortMin = -32768
+	nullShortMax = 32767
+	nullIntMin   = -2147483648
+	nullIntMax   = 2147483647
+
+	// defaultCreativeType is the creative type of the campaign image without creative_links in json
+	defaultCreativeType = "R"
+	weekSlotCount       = 7 * 24
+)
+
+// Document is the content campaign document indexed into elasticsearch
+type Document struct {
+	ID             int64   `json:"id"`
+	Categories     string  `json:"categories"`
+	ChannelID      *int64  `json:"channel_id"`
+	CleanMode      int     `json:"clean_mode"`
+	ClickURL       string  `json:"click_url"`
+	CleanLink      string  `json:"clean_link"`
+	CreatedAt      string  `json:"created_at"`
+	Country        string  `json:"country"`
+	Description    string  `json:"description"`
+	DisplayType    string  `json:"display_type"`
+	DisplayWeight  int     `json:"display_weight"`
+	EndDate        string  `json:"end_date"`
+	Ipu            *int    `json:"ipu"`
+	IsCtrFilterOff bool    `json:"is_ctr_filter_off"`
+	IsEnabled      bool    `json:"is_enabled"`
+	Image          string  `json:"image"`
+	JSON           string  `json:"json"`
+	LandingReward  int     `json:"landing_reward"`
+	LandingType    int     `json:"landing_type"`
+	Name           string  `json:"name"`
+	OrganizationID int64   `json:"organization_id"`
+	OwnerID        int64   `json:"owner_id"`
+	ProviderID     *int64  `json:"provider_id"`
+	PublishedAt    *string `json:"published_at"`
+	StartDate      string  `json:"start_date"`
+	Status         int     `json:"status"`
+	Tags           string  `json:"tags"`
+	Title          string  `json:"title"`
+	Timezone       string  `json:"timezone"`
+	Tipu           *int    `json:"tipu"`
+	Type           string  `json:"type"`
+	UpdatedAt      string  `json:"updated_at"`
+	WeekSlot       string  `json:"week_slot"`
+
+	TargetApp                 string `json:"target_app"`
+	TargetAgeMin              int    `json:"target_age_min"`
+	TargetAgeMax              int    `json:"target_age_max"`
+	TargetSdkMin              int    `json:"target_sdk_min"`
+	TargetSdkMax              int    `json:"target_sdk_max"`
+	RegisteredDaysMin         int    `json:"registered_days_min"`
+	RegisteredDaysMax         int    `json:"registered_days_max"`
+	TargetGender              string `json:"target_gender"`
+	TargetLanguage            string `json:"target_language"`
+	TargetCarrier             string `json:"target_carrier"`
+	TargetRegion              string `json:"target_region"`
+	CustomTarget1             string `json:"custom_target_1"`
+	CustomTarget2             string `json:"custom_target_2"`
+	CustomTarget3             string `json:"custom_target_3"`
+	TargetUnit                string `json:"target_unit"`
+	TargetAppID               string `json:"target_app_id"`
+	TargetOrg                 string `json:"target_org"`
+	TargetOsMin               int    `json:"target_os_min"`
+	TargetOsMax               int    `json:"target_os_max"`
+	TargetBatteryOptimization bool   `json:"target_battery_optimization"`
+
+	DetargetApp   string `json:"detarget_app"`
+	DetargetUnit  string `json:"detarget_unit"`
+	DetargetAppID string `json:"detarget_app_id"`
+	DetargetOrg   string `json:"detarget_org"`
+
+	Channel       *Channel            `json:"channel"`
+	CreativeTypes string              `json:"creative_types"`
+	CreativeLinks map[string][]string `json:"creative_links"`
+	ImageWidth    *int                `json:"image_width,omitempty"`
+	ImageHeight   *int                `json:"image_height,omitempty"`
+	ImageRatio    *float64            `json:"image_ratio"`
+}
+
+// Channel is the content channel embedded in the document
+type Channel struct {
+	ID   int64  `json:"id"`
+	Logo string `json:"logo"`
+	Name string `json:"name"`
+}
+
+// extraData is the fields of ContentCampaign.JSON used for indexing
+type extraData struct {
+	ImgW          *int                `json:"imgW"`
+	ImgH          *int                `json:"imgH"`
+	CreativeLinks map[string][]string `json:"creative_links"`
+}
+
+// NewDocument maps the content campaign record into the document. channel is nil if the campaign has no channel.
+func NewDocument(cc dbcontentcampaign.ContentCampaign, channel *dbcontentcampaign.ContentChannel) Document {
+	doc := Document{
+		ID:             cc.ID,
+		Categories:     orGlob(normalizeItems(cc.Categories)),
+		ChannelID:      cc.ChannelID,
+		CleanMode:      cc.CleanMode,
+		ClickURL:       cc.ClickURL,
+		CleanLink:      cc.CleanLink,
+		CreatedAt:      formatTime(cc.CreatedAt),
+		Country:        orGlob(strings.TrimSpace(cc.Country)),
+		Description:    cc.Description,
+		DisplayType:    cc.DisplayType,
+		DisplayWeight:  cc.DisplayWeight,
+		EndDate:        formatTime(cc.EndDate),
+		Ipu:            cc.Ipu,
+		IsCtrFilterOff: cc.IsCtrFilterOff,
+		IsEnabled:      cc.IsEnabled,
+		Image:          cc.Image,
+		JSON:           cc.JSON,
+		LandingReward:  cc.LandingReward,
+		LandingType:    cc.LandingType,
+		Name:           cc.Name,
+		OrganizationID: cc.OrganizationID,
+		OwnerID:        cc.OwnerID,
+		ProviderID:     cc.ProviderID,
+		StartDate:      formatTime(cc.StartDate),
+		Status:         cc.Status,
+		Tags:           orGlob(normalizeItems(cc.Tags)),
+		Title:          cc.Title,
+		Timezone:       cc.Timezone,
+		Tipu:           cc.Tipu,
+		Type:           cc.Type,
+		UpdatedAt:      formatTime(cc.UpdatedAt),
+		WeekSlot:       orGlob(normalizeWeekSlot(cc.WeekSlot)),
+
+		TargetAgeMin:              intOrNull(cc.TargetAgeMin, nullShortMin),
+		TargetAgeMax:              intOrNull(cc.TargetAgeMax, nullShortMax),
+		TargetSdkMin:              intOrNull(cc.TargetSdkMin, nullIntMin),
+		TargetSdkMax:              intOrNull(cc.TargetSdkMax, nullIntMax),
+		RegisteredDaysMin:         intOrNull(cc.RegisteredDaysMin, nullShortMin),
+		RegisteredDaysMax:         intOrNull(cc.RegisteredDaysMax, nullShortMax),
+		TargetGender:              orGlob(strings.TrimSpace(cc.TargetGender)),
+		TargetLanguage:            orGlob(strings.TrimSpace(cc.TargetLanguage)),
+		TargetCarrier:             orGlob(normalizeItems(cc.TargetCarrier)),
+		TargetRegion:              orGlob(normalizeItems(cc.TargetRegion)),
+		CustomTarget1:             orGlob(normalizeItems(cc.CustomTarget1)),
+		CustomTarget2:             orGlob(normalizeItems(cc.CustomTarget2)),
+		CustomTarget3:             orGlob(normalizeItems(cc.CustomTarget3)),
+		TargetOsMin:               intOrNull(cc.TargetOsMin, nullIntMin),
+		TargetOsMax:               intOrNull(cc.TargetOsMax, nullIntMax),
+		TargetBatteryOptimization: cc.TargetBatteryOptimization,
+	}
+
+	doc.TargetApp, doc.DetargetApp = splitTargets(cc.TargetApp)
+	doc.TargetUnit, doc.DetargetUnit = splitTargets(cc.TargetUnit)
+	doc.TargetAppID, doc.DetargetAppID = splitTargets(cc.TargetAppID)
+	doc.TargetOrg, doc.DetargetOrg = splitTargets(cc.TargetOrg)
+
+	if cc.PublishedAt != nil {
+		publishedAt := formatTime(*cc.PublishedAt)
+		doc.PublishedAt = &publishedAt
+	}
+
+	if channel != nil {
+		doc.Channel = &Channel{ID: channel.ID, Logo: channel.Logo, Name: channel.Name}
+	}
+
+	doc.setCreatives(cc.Image, cc.JSON)
+	return doc
+}
+
+func (doc *Document) setCreatives(image string, rawJSON string) {
+	var extra extraData
+	if rawJSON != "" {
+		// JSON이 깨진 경우 이미지 정보 없이 색인한다
+		_ = json.Unmarshal([]byte(rawJSON), &extra)
+	}
+
+	doc.CreativeLinks = extra.CreativeLinks
+	if len(doc.CreativeLinks) == 0 && image != "" {
+		doc.CreativeLinks = map[string][]string{defaultCreativeType: {image}}
+	}
+
+	creativeTypes := make([]string, 0, len(doc.CreativeLinks))
+	for creativeType := range doc.CreativeLinks {
+		creativeTypes = append(creativeTypes, creativeType)
+	}
+	sort.Strings(creativeTypes)
+	doc.CreativeTypes = strings.Join(creativeTypes, ",")
+
+	doc.ImageWidth, doc.ImageHeight = extra.ImgW, extra.ImgH
+	if extra.ImgW != nil && extra.ImgH != nil && *extra.ImgH > 0 {
+		ratio := float64(*extra.ImgW) / float64(*extra.ImgH)
+		doc.ImageRatio = &ratio
+	}
+}
+
+// splitTargets splits the target field of the database into target and detarget fields of the document
+func splitTargets(value string) (string, string) {
+	targets := make([]string, 0)
+	detargets := make([]string, 0)
+	for _, item := range splitItems(value) {
+		if strings.HasPrefix(item, detargetPrefix) {
+			if detarget := strings.TrimPrefix(item, detargetPrefix); detarget != "" {
+				detargets = append(detargets, detarget)
+			}
+		} else {
+			targets = append(targets, item)
+		}
+	}
+	return orGlob(strings.Join(targets, ",")), orGlob(strings.Join(detargets, ","))
+}
+
+// normalizeWeekSlot expands the ranges of week slots. e.g. "0-2,30" -> "0,1,2,30"
+func normalizeWeekSlot(value string) string {
+	slots := make([]string, 0)
+	for _, item := range splitItems(value) {
+		bounds := strings.SplitN(item, "-", 2)
+		if len(bounds) != 2 {
+			slots = append(slots, item)
+			continue
+		}
+
+		from, fromErr := strconv.Atoi(bounds[0])
+		to, toErr := strconv.Atoi(bounds[1])
+		if fromErr != nil || toErr != nil || from > to || to >= weekSlotCount {
+			slots = append(slots, item)
+			continue
+		}
+		for slot := from; slot <= to; slot++ {
+			slots = append(slots, strconv.Itoa(slot))
+		}
+	}
+	return strings.Join(slots, ",")
+}
+
+func normalizeItems(value string) string {
+	return strings.Join(splitItems(value), ",")
+}
+
+func splitItems(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func orGlob(value string) string {
+	if value == "" {
+		return globString
+	}
+	return value
+}
+
+func intOrNull(value *int, null int) int {
+	if value == nil {
+		return null
+	}
+	return *value
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(esTimeFormat)
+}
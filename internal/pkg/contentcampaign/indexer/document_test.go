@@ -0,0 +1,122 @@
+package indexer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/indexer"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/searchrepo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbcontentcampaign"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDocument_Targets(t *testing.T) {
+	cc := newContentCampaign(1)
+	cc.TargetUnit = "__GLOB__, -100,200"
+	cc.TargetApp = "-com.buzzvil.a"
+	cc.TargetOrg = "3"
+
+	doc := indexer.NewDocument(cc, nil)
+
+	assert.Equal(t, "__GLOB__,200", doc.TargetUnit)
+	assert.Equal(t, "100", doc.DetargetUnit)
+	assert.Equal(t, "__GLOB__", doc.TargetApp)
+	assert.Equal(t, "com.buzzvil.a", doc.DetargetApp)
+	assert.Equal(t, "3", doc.TargetOrg)
+	assert.Equal(t, "__GLOB__", doc.DetargetOrg)
+	assert.Equal(t, "__GLOB__", doc.TargetAppID)
+	assert.Equal(t, "__GLOB__", doc.DetargetAppID)
+	assert.Equal(t, "__GLOB__", doc.TargetGender)
+	assert.Equal(t, "__GLOB__", doc.CustomTarget1)
+}
+
+func TestNewDocument_NullValues(t *testing.T) {
+	cc := newContentCampaign(1)
+	ageMin := 20
+	cc.TargetAgeMin = &ageMin
+
+	doc := indexer.NewDocument(cc, nil)
+
+	assert.Equal(t, 20, doc.TargetAgeMin)
+	assert.Equal(t, 32767, doc.TargetAgeMax)
+	assert.Equal(t, -2147483648, doc.TargetSdkMin)
+	assert.Equal(t, 2147483647, doc.TargetSdkMax)
+	assert.Equal(t, -32768, doc.RegisteredDaysMin)
+	assert.Equal(t, -2147483648, doc.TargetOsMin)
+	assert.Equal(t, 2147483647, doc.TargetOsMax)
+}
+
+func TestNewDocument_WeekSlot(t *testing.T) {
+	cc := newContentCampaign(1)
+
+	cc.WeekSlot = "0-2, 30"
+	assert.Equal(t, "0,1,2,30", indexer.NewDocument(cc, nil).WeekSlot)
+
+	cc.WeekSlot = ""
+	assert.Equal(t, "__GLOB__", indexer.NewDocument(cc, nil).WeekSlot)
+}
+
+func TestNewDocument_ChannelAndCreatives(t *testing.T) {
+	cc := newContentCampaign(1)
+	channelID := int64(7)
+	cc.ChannelID = &channelID
+	cc.Image = "http://image.jpg"
+	cc.JSON = `{"imgW": 800, "imgH": 400}`
+
+	doc := indexer.NewDocument(cc, &dbcontentcampaign.ContentChannel{ID: 7, Name: "buzzvil", Logo: "http://logo.png"})
+
+	assert.Equal(t, &indexer.Channel{ID: 7, Name: "buzzvil", Logo: "http://logo.png"}, doc.Channel)
+	assert.Equal(t, "R", doc.CreativeTypes)
+	assert.Equal(t, map[string][]string{"R": {"http://image.jpg"}}, doc.CreativeLinks)
+	assert.Equal(t, 2.0, *doc.ImageRatio)
+
+	cc.JSON = `{"creative_links": {"R": ["http://r.jpg"], "A": ["http://a.jpg"]}}`
+	doc = indexer.NewDocument(cc, nil)
+
+	assert.Nil(t, doc.Channel)
+	assert.Equal(t, "A,R", doc.CreativeTypes)
+	assert.Nil(t, doc.ImageRatio)
+}
+
+func TestNewDocument_Searchable(t *testing.T) {
+	repo := searchrepo.NewMemory()
+	cc := newContentCampaign(1)
+	cc.TargetUnit = "__GLOB__,-100"
+	assert.NoError(t, repo.Index(indexer.NewDocument(cc, nil)))
+	assert.NoError(t, repo.Index(indexer.NewDocument(newContentCampaign(2), nil)))
+
+	now := time.Now().Truncate(time.Hour)
+	result, err := repo.Search(contentcampaign.SearchRequest{
+		Query: contentcampaign.SearchQuery{
+			StartsBy:              now,
+			EndsAfter:             now,
+			UnitID:                100,
+			IncludeGlobUnit:       true,
+			Country:               "KR",
+			CreativeType:          "R",
+			InBatteryOptimization: true,
+			Statuses:              contentcampaign.StatusesForLockscreen,
+		},
+		Size: 10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Total)
+	assert.Equal(t, int64(2), result.Hits[0].ID)
+}
+
+func newContentCampaign(id int64) dbcontentcampaign.ContentCampaign {
+	now := time.Now()
+	return dbcontentcampaign.ContentCampaign{
+		ID:        id,
+		Country:   "KR",
+		IsEnabled: true,
+		Image:     "http://image.jpg",
+		JSON:      "{}",
+		StartDate: now.Add(-24 * time.Hour),
+		EndDate:   now.Add(24 * time.Hour),
+		Status:    int(contentcampaign.StatusManual),
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,29 @@
+package indexer
+
+import "fmt"
+
+var (
+	_ error = BulkError{}
+	_ error = ConcreteIndexError{}
+)
+
+// BulkError will be returned when some documents of a bulk request are failed to be indexed
+type BulkError struct {
+	Index  string
+	Failed []string // ids of the failed documents
+}
+
+// Error func definition
+func (e BulkError) Error() string {
+	return fmt.Sprintf("failed to index %d documents into %s. ids: %v", len(e.Failed), e.Index, e.Failed)
+}
+
+// ConcreteIndexError will be returned when the alias to reindex is a concrete index, not an alias
+type ConcreteIndexError struct {
+	Name string
+}
+
+// Error func definition
+func (e ConcreteIndexError) Error() string {
+	return fmt.Sprintf("%s is a concrete index. it should be an alias to be reindexed", e.Name)
+}
@@ -0,0 +1,218 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbcontentcampaign"
+	"gopkg.in/olivere/elastic.v5"
+)
+
+const (
+	docType          = "content_campaign"
+	defaultBatchSize = 500
+	indexTimeFormat  = "20060102150405"
+)
+
+// Result is the result of an indexing run
+type Result struct {
+	Index     string
+	Indexed   int
+	Watermark time.Time // updated_at of the last indexed campaign. next run should start from here
+}
+
+// Indexer indexes content campaign records of the database into the elasticsearch alias
+type Indexer struct {
+	dbSource  dbcontentcampaign.DBSource
+	client    *elastic.Client
+	alias     string
+	batchSize int
+}
+
+// IndexUpdatedSince indexes campaigns updated since the watermark into the alias.
+// Campaigns updated exactly at the watermark are indexed again since indexing is idempotent.
+func (i *Indexer) IndexUpdatedSince(ctx context.Context, watermark time.Time) (*Result, error) {
+	return i.indexInto(ctx, i.alias, watermark)
+}
+
+// Reindex indexes all campaigns into a new index and swaps the alias to it.
+// The indices previously pointed by the alias are deleted after the swap.
+func (i *Indexer) Reindex(ctx context.Context) (*Result, error) {
+	startedAt := time.Now()
+	oldIndices, err := i.getAliasedIndices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newIndex := fmt.Sprintf("%s_%s", i.alias, startedAt.UTC().Format(indexTimeFormat))
+	if _, err := i.client.CreateIndex(newIndex).Body(Mapping).Do(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := i.indexInto(ctx, newIndex, time.Time{})
+	if err == nil {
+		_, err = i.client.Refresh(newIndex).Do(ctx)
+	}
+	if err == nil {
+		err = i.swapAlias(ctx, newIndex, oldIndices)
+	}
+	if err != nil {
+		if _, deleteErr := i.client.DeleteIndex(newIndex).Do(ctx); deleteErr != nil {
+			core.Logger.WithError(deleteErr).Errorf("Reindex() - failed to delete %s", newIndex)
+		}
+		return nil, err
+	}
+
+	if len(oldIndices) > 0 {
+		if _, err := i.client.DeleteIndex(oldIndices...).Do(ctx); err != nil {
+			core.Logger.WithError(err).Errorf("Reindex() - failed to delete old indices %v", oldIndices)
+		}
+	}
+
+	// 재색인 도중 변경된 캠페인을 반영한다
+	updated, err := i.indexInto(ctx, i.alias, startedAt)
+	if err != nil {
+		return nil, err
+	}
+	result.Indexed += updated.Indexed
+	if updated.Watermark.After(result.Watermark) {
+		result.Watermark = updated.Watermark
+	}
+	return result, nil
+}
+
+// IndexCampaigns indexes the campaigns of the ids into the alias right away. Campaigns not in the database are skipped.
+func (i *Indexer) IndexCampaigns(ctx context.Context, campaignIDs []int64) error {
+	campaigns, err := i.dbSource.GetContentCampaignsByIDs(campaignIDs)
+	if err != nil || len(campaigns) == 0 {
+		return err
+	}
+	return i.bulkIndex(ctx, i.alias, campaigns)
+}
+
+// DeleteCampaign deletes the campaign document from the alias. It's not an error if the campaign is not indexed.
+func (i *Indexer) DeleteCampaign(ctx context.Context, campaignID int64) error {
+	_, err := i.client.Delete().Index(i.alias).Type(docType).Id(strconv.FormatInt(campaignID, 10)).Do(ctx)
+	if elastic.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (i *Indexer) indexInto(ctx context.Context, indexName string, since time.Time) (*Result, error) {
+	result := &Result{Index: indexName, Watermark: since}
+	afterID := int64(0)
+	for {
+		campaigns, err := i.dbSource.GetContentCampaignsUpdatedSince(since, afterID, i.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(campaigns) == 0 {
+			break
+		}
+
+		if err := i.bulkIndex(ctx, indexName, campaigns); err != nil {
+			return nil, err
+		}
+
+		last := campaigns[len(campaigns)-1]
+		since, afterID = last.UpdatedAt, last.ID
+		result.Indexed += len(campaigns)
+		result.Watermark = last.UpdatedAt
+
+		if len(campaigns) < i.batchSize {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (i *Indexer) bulkIndex(ctx context.Context, indexName string, campaigns []dbcontentcampaign.ContentCampaign) error {
+	channels, err := i.getChannels(campaigns)
+	if err != nil {
+		return err
+	}
+
+	bulkRequest := i.client.Bulk()
+	for _, cc := range campaigns {
+		var channel *dbcontentcampaign.ContentChannel
+		if cc.ChannelID != nil {
+			channel = channels[*cc.ChannelID]
+		}
+		bulkRequest = bulkRequest.Add(elastic.NewBulkIndexRequest().Index(indexName).Type(docType).
+			Id(strconv.FormatInt(cc.ID, 10)).Doc(NewDocument(cc, channel)))
+	}
+
+	bulkResponse, err := bulkRequest.Do(ctx)
+	if err != nil {
+		return err
+	}
+
+	if failed := bulkResponse.Failed(); len(failed) > 0 {
+		ids := make([]string, 0, len(failed))
+		for _, item := range failed {
+			ids = append(ids, item.Id)
+		}
+		return BulkError{Index: indexName, Failed: ids}
+	}
+	return nil
+}
+
+func (i *Indexer) getChannels(campaigns []dbcontentcampaign.ContentCampaign) (map[int64]*dbcontentcampaign.ContentChannel, error) {
+	channelIDs := make([]int64, 0)
+	seen := make(map[int64]bool)
+	for _, cc := range campaigns {
+		if cc.ChannelID != nil && !seen[*cc.ChannelID] {
+			seen[*cc.ChannelID] = true
+			channelIDs = append(channelIDs, *cc.ChannelID)
+		}
+	}
+
+	channels, err := i.dbSource.GetContentChannelsByIDs(channelIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	channelMap := make(map[int64]*dbcontentcampaign.ContentChannel)
+	for idx := range channels {
+		channelMap[channels[idx].ID] = &channels[idx]
+	}
+	return channelMap, nil
+}
+
+func (i *Indexer) getAliasedIndices(ctx context.Context) ([]string, error) {
+	aliases, err := i.client.Aliases().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := aliases.Indices[i.alias]; ok {
+		return nil, ConcreteIndexError{Name: i.alias}
+	}
+	return aliases.IndicesByAlias(i.alias), nil
+}
+
+func (i *Indexer) swapAlias(ctx context.Context, newIndex string, oldIndices []string) error {
+	aliasService := i.client.Alias().Add(newIndex, i.alias)
+	for _, oldIndex := range oldIndices {
+		aliasService = aliasService.Remove(oldIndex, i.alias)
+	}
+	_, err := aliasService.Do(ctx)
+	return err
+}
+
+// New returns the indexer writing into the alias. batchSize is the number of campaigns of a bulk request.
+func New(dbSource dbcontentcampaign.DBSource, client *elastic.Client, alias string, batchSize int) *Indexer {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &Indexer{
+		dbSource:  dbSource,
+		client:    client,
+		alias:     alias,
+		batchSize: batchSize,
+	}
+}
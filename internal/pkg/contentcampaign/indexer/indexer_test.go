@@ -0,0 +1,277 @@
+package indexer_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/indexer"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbcontentcampaign"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/olivere/elastic.v5"
+)
+
+const testAlias = "content_campaigns"
+
+func (ts *IndexerTestSuite) Test_IndexUpdatedSince() {
+	watermark := time.Now().Add(-time.Hour)
+	first, second := newContentCampaign(1), newContentCampaign(2)
+	channelID := int64(7)
+	first.ChannelID = &channelID
+	second.UpdatedAt = first.UpdatedAt.Add(time.Minute)
+
+	ts.dbSource.On("GetContentCampaignsUpdatedSince", watermark, int64(0), 2).
+		Return([]dbcontentcampaign.ContentCampaign{first, second}, nil).Once()
+	ts.dbSource.On("GetContentChannelsByIDs", []int64{7}).
+		Return([]dbcontentcampaign.ContentChannel{{ID: 7, Name: "buzzvil"}}, nil).Once()
+	ts.dbSource.On("GetContentCampaignsUpdatedSince", second.UpdatedAt, int64(2), 2).
+		Return([]dbcontentcampaign.ContentCampaign{}, nil).Once()
+
+	result, err := ts.indexer.IndexUpdatedSince(context.Background(), watermark)
+
+	ts.NoError(err)
+	ts.Equal(2, result.Indexed)
+	ts.Equal(testAlias, result.Index)
+	ts.True(second.UpdatedAt.Equal(result.Watermark))
+	ts.Len(ts.es.indexed[testAlias], 2)
+	ts.Equal("buzzvil", ts.es.indexed[testAlias]["1"].Channel.Name)
+	ts.Nil(ts.es.indexed[testAlias]["2"].Channel)
+	ts.dbSource.AssertExpectations(ts.T())
+}
+
+func (ts *IndexerTestSuite) Test_IndexUpdatedSince_BulkError() {
+	ts.es.failedIDs = map[string]bool{"1": true}
+	ts.dbSource.On("GetContentCampaignsUpdatedSince", mock.Anything, int64(0), 2).
+		Return([]dbcontentcampaign.ContentCampaign{newContentCampaign(1)}, nil).Once()
+	ts.dbSource.On("GetContentChannelsByIDs", []int64{}).Return([]dbcontentcampaign.ContentChannel{}, nil).Once()
+
+	result, err := ts.indexer.IndexUpdatedSince(context.Background(), time.Time{})
+
+	ts.Nil(result)
+	ts.Equal(indexer.BulkError{Index: testAlias, Failed: []string{"1"}}, err)
+}
+
+func (ts *IndexerTestSuite) Test_Reindex() {
+	ts.es.aliases = map[string][]string{"content_campaigns_old": {testAlias}, "others": {}}
+	ts.dbSource.On("GetContentCampaignsUpdatedSince", time.Time{}, int64(0), 2).
+		Return([]dbcontentcampaign.ContentCampaign{newContentCampaign(1)}, nil).Once()
+	ts.dbSource.On("GetContentCampaignsUpdatedSince", mock.Anything, int64(0), 2).
+		Return([]dbcontentcampaign.ContentCampaign{}, nil).Once()
+	ts.dbSource.On("GetContentChannelsByIDs", []int64{}).Return([]dbcontentcampaign.ContentChannel{}, nil).Once()
+
+	result, err := ts.indexer.Reindex(context.Background())
+
+	ts.NoError(err)
+	ts.Equal(1, result.Indexed)
+	ts.True(strings.HasPrefix(result.Index, testAlias+"_"))
+	ts.Equal([]string{result.Index}, ts.es.created)
+	ts.Len(ts.es.indexed[result.Index], 1)
+	ts.Equal([]string{"content_campaigns_old"}, ts.es.deleted)
+	ts.Equal([]string{
+		fmt.Sprintf("add %s %s", result.Index, testAlias),
+		fmt.Sprintf("remove content_campaigns_old %s", testAlias),
+	}, ts.es.aliasActions)
+	ts.dbSource.AssertExpectations(ts.T())
+}
+
+func (ts *IndexerTestSuite) Test_Reindex_ConcreteIndex() {
+	ts.es.aliases = map[string][]string{testAlias: {}}
+
+	result, err := ts.indexer.Reindex(context.Background())
+
+	ts.Nil(result)
+	ts.Equal(indexer.ConcreteIndexError{Name: testAlias}, err)
+	ts.Empty(ts.es.created)
+}
+
+func (ts *IndexerTestSuite) Test_Reindex_FailedToIndex() {
+	ts.es.failedIDs = map[string]bool{"1": true}
+	ts.dbSource.On("GetContentCampaignsUpdatedSince", time.Time{}, int64(0), 2).
+		Return([]dbcontentcampaign.ContentCampaign{newContentCampaign(1)}, nil).Once()
+	ts.dbSource.On("GetContentChannelsByIDs", []int64{}).Return([]dbcontentcampaign.ContentChannel{}, nil).Once()
+
+	_, err := ts.indexer.Reindex(context.Background())
+
+	ts.IsType(indexer.BulkError{}, err)
+	ts.Equal(ts.es.created, ts.es.deleted)
+	ts.Empty(ts.es.aliasActions)
+}
+
+func (ts *IndexerTestSuite) Test_IndexCampaigns() {
+	ts.dbSource.On("GetContentCampaignsByIDs", []int64{1, 3}).
+		Return([]dbcontentcampaign.ContentCampaign{newContentCampaign(1)}, nil).Once()
+	ts.dbSource.On("GetContentChannelsByIDs", []int64{}).Return([]dbcontentcampaign.ContentChannel{}, nil).Once()
+
+	err := ts.indexer.IndexCampaigns(context.Background(), []int64{1, 3})
+
+	ts.NoError(err)
+	ts.Len(ts.es.indexed[testAlias], 1)
+	ts.Equal(int64(1), ts.es.indexed[testAlias]["1"].ID)
+	ts.dbSource.AssertExpectations(ts.T())
+}
+
+func (ts *IndexerTestSuite) Test_DeleteCampaign() {
+	ts.NoError(ts.indexer.DeleteCampaign(context.Background(), 1))
+	ts.Equal([]string{testAlias + "/content_campaign/1"}, ts.es.deleted)
+
+	// 색인되지 않은 캠페인은 무시한다
+	ts.es.missingIDs = map[string]bool{"2": true}
+	ts.NoError(ts.indexer.DeleteCampaign(context.Background(), 2))
+}
+
+func TestIndexerSuite(t *testing.T) {
+	suite.Run(t, new(IndexerTestSuite))
+}
+
+type IndexerTestSuite struct {
+	suite.Suite
+	dbSource *mockDBSource
+	es       *fakeES
+	server   *httptest.Server
+	indexer  *indexer.Indexer
+}
+
+func (ts *IndexerTestSuite) SetupTest() {
+	ts.dbSource = &mockDBSource{}
+	ts.es = &fakeES{indexed: make(map[string]map[string]indexer.Document), aliases: make(map[string][]string)}
+	ts.server = httptest.NewServer(ts.es)
+
+	client, err := elastic.NewClient(elastic.SetURL(ts.server.URL), elastic.SetSniff(false), elastic.SetHealthcheck(false))
+	ts.NoError(err)
+	ts.indexer = indexer.New(ts.dbSource, client, testAlias, 2)
+}
+
+func (ts *IndexerTestSuite) TearDownTest() {
+	ts.server.Close()
+}
+
+var _ dbcontentcampaign.DBSource = &mockDBSource{}
+
+type mockDBSource struct {
+	mock.Mock
+}
+
+func (s *mockDBSource) GetContentCampaignByID(campaignID int64) (*dbcontentcampaign.ContentCampaign, error) {
+	ret := s.Called(campaignID)
+	return ret.Get(0).(*dbcontentcampaign.ContentCampaign), ret.Error(1)
+}
+
+func (s *mockDBSource) GetContentCampaignsByIDs(campaignIDs []int64) ([]dbcontentcampaign.ContentCampaign, error) {
+	ret := s.Called(campaignIDs)
+	return ret.Get(0).([]dbcontentcampaign.ContentCampaign), ret.Error(1)
+}
+
+func (s *mockDBSource) GetContentCampaignsUpdatedSince(since time.Time, afterID int64, limit int) ([]dbcontentcampaign.ContentCampaign, error) {
+	ret := s.Called(since, afterID, limit)
+	return ret.Get(0).([]dbcontentcampaign.ContentCampaign), ret.Error(1)
+}
+
+func (s *mockDBSource) GetContentChannelsByIDs(channelIDs []int64) ([]dbcontentcampaign.ContentChannel, error) {
+	ret := s.Called(channelIDs)
+	return ret.Get(0).([]dbcontentcampaign.ContentChannel), ret.Error(1)
+}
+
+// fakeES serves the elasticsearch apis used by the indexer
+type fakeES struct {
+	mu           sync.Mutex
+	indexed      map[string]map[string]indexer.Document
+	aliases      map[string][]string // index -> aliases
+	failedIDs    map[string]bool
+	missingIDs   map[string]bool
+	created      []string
+	deleted      []string
+	aliasActions []string
+}
+
+func (es *fakeES) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case r.Method == http.MethodPost && path == "_bulk":
+		es.serveBulk(w, r)
+	case r.Method == http.MethodGet && path == "_aliases":
+		result := make(map[string]interface{})
+		for index, aliases := range es.aliases {
+			aliasMap := make(map[string]interface{})
+			for _, alias := range aliases {
+				aliasMap[alias] = map[string]interface{}{}
+			}
+			result[index] = map[string]interface{}{"aliases": aliasMap}
+		}
+		writeJSON(w, result)
+	case r.Method == http.MethodPost && path == "_aliases":
+		var body struct {
+			Actions []map[string]map[string]string `json:"actions"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for _, action := range body.Actions {
+			for name, params := range action {
+				es.aliasActions = append(es.aliasActions, fmt.Sprintf("%s %s %s", name, params["index"], params["alias"]))
+			}
+		}
+		writeJSON(w, map[string]interface{}{"acknowledged": true})
+	case r.Method == http.MethodPut:
+		es.created = append(es.created, path)
+		writeJSON(w, map[string]interface{}{"acknowledged": true})
+	case r.Method == http.MethodDelete && strings.Count(path, "/") == 2:
+		if es.missingIDs[path[strings.LastIndex(path, "/")+1:]] {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(w, map[string]interface{}{"found": false, "result": "not_found"})
+			return
+		}
+		es.deleted = append(es.deleted, path)
+		writeJSON(w, map[string]interface{}{"found": true, "result": "deleted"})
+	case r.Method == http.MethodDelete:
+		es.deleted = append(es.deleted, strings.Split(path, ",")...)
+		writeJSON(w, map[string]interface{}{"acknowledged": true})
+	case strings.HasSuffix(path, "/_refresh"):
+		writeJSON(w, map[string]interface{}{"_shards": map[string]int{"total": 1, "successful": 1}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (es *fakeES) serveBulk(w http.ResponseWriter, r *http.Request) {
+	items := make([]map[string]interface{}, 0)
+	hasErrors := false
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var action map[string]map[string]string
+		_ = json.Unmarshal(scanner.Bytes(), &action)
+		scanner.Scan()
+		var doc indexer.Document
+		_ = json.Unmarshal(scanner.Bytes(), &doc)
+
+		meta := action["index"]
+		status := http.StatusCreated
+		if es.failedIDs[meta["_id"]] {
+			status = http.StatusBadRequest
+			hasErrors = true
+		} else {
+			if es.indexed[meta["_index"]] == nil {
+				es.indexed[meta["_index"]] = make(map[string]indexer.Document)
+			}
+			es.indexed[meta["_index"]][meta["_id"]] = doc
+		}
+		items = append(items, map[string]interface{}{"index": map[string]interface{}{
+			"_index": meta["_index"], "_type": meta["_type"], "_id": meta["_id"], "status": status,
+		}})
+	}
+	writeJSON(w, map[string]interface{}{"took": 1, "errors": hasErrors, "items": items})
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,6 @@
+package contentcampaign
+
+// StructuredLogger interface
+type StructuredLogger interface {
+	Log(m map[string]interface{})
+}
@@ -83,6 +83,25 @@ func (r *Repository) IncreaseClick(campaignID int64, unitID int64) error {
 	return r.redisContentCampaign.IncreaseClick(campaignID, unitID)
 }
 
+// GetStats func definition
+func (r *Repository) GetStats(from time.Time, to time.Time) (map[int64]contentcampaign.Stat, error) {
+	stats := make(map[int64]contentcampaign.Stat)
+	for dateHour := from.Truncate(time.Hour); !dateHour.After(to); dateHour = dateHour.Add(time.Hour) {
+		counts, err := r.redisContentCampaign.GetCampaignCounts(dateHour)
+		if err != nil {
+			return nil, err
+		}
+
+		for campaignID, c := range counts {
+			stat := stats[campaignID]
+			stat.Impressions += c.Impressions
+			stat.Clicks += c.Clicks
+			stats[campaignID] = stat
+		}
+	}
+	return stats, nil
+}
+
 // New func definition
 func New(dbContentCampaign dbcontentcampaign.DBSource, redisCache rediscache.RedisSource, redisContentCampaign rediscontentcampaign.RedisSource) *Repository {
 	return &Repository{
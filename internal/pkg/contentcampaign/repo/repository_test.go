@@ -11,6 +11,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbcontentcampaign"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscontentcampaign"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -35,25 +36,42 @@ func (ts *RepoTestSuite) TestRepo_GetContentCampaign() {
 	ts.dbSource.AssertExpectations(ts.T())
 }
 
+func (ts *RepoTestSuite) TestRepo_GetStats() {
+	to := time.Date(2019, 5, 1, 10, 30, 0, 0, time.UTC)
+	from := to.Add(-time.Hour * 2)
+	ts.redisContentCampaign.On("GetCampaignCounts", from.Truncate(time.Hour)).Return(map[int64]rediscontentcampaign.Counts{1: {Impressions: 100, Clicks: 1}}, nil).Once()
+	ts.redisContentCampaign.On("GetCampaignCounts", from.Truncate(time.Hour).Add(time.Hour)).Return(map[int64]rediscontentcampaign.Counts{1: {Impressions: 50, Clicks: 2}, 2: {Impressions: 10}}, nil).Once()
+	ts.redisContentCampaign.On("GetCampaignCounts", to.Truncate(time.Hour)).Return(map[int64]rediscontentcampaign.Counts{}, nil).Once()
+
+	stats, err := ts.repo.GetStats(from, to)
+
+	ts.NoError(err)
+	ts.Equal(map[int64]contentcampaign.Stat{1: {Impressions: 150, Clicks: 3}, 2: {Impressions: 10}}, stats)
+	ts.redisContentCampaign.AssertExpectations(ts.T())
+}
+
 func TestRepoSuite(t *testing.T) {
 	suite.Run(t, new(RepoTestSuite))
 }
 
 type RepoTestSuite struct {
 	suite.Suite
-	dbSource   *MockDBSource
-	redisCache *MockRedisCache
-	repo       contentcampaign.Repository
+	dbSource             *MockDBSource
+	redisCache           *MockRedisCache
+	redisContentCampaign *MockRedisContentCampaign
+	repo                 contentcampaign.Repository
 }
 
 func (ts *RepoTestSuite) SetupTest() {
 	ts.dbSource = &MockDBSource{}
 	ts.redisCache = &MockRedisCache{}
-	ts.repo = repo.New(ts.dbSource, ts.redisCache, nil)
+	ts.redisContentCampaign = &MockRedisContentCampaign{}
+	ts.repo = repo.New(ts.dbSource, ts.redisCache, ts.redisContentCampaign)
 }
 
 var _ dbcontentcampaign.DBSource = &MockDBSource{}
 var __ rediscache.RedisSource = &MockRedisCache{}
+var _ rediscontentcampaign.RedisSource = &MockRedisContentCampaign{}
 
 type MockDBSource struct {
 	mock.Mock
@@ -64,6 +82,21 @@ func (ds *MockDBSource) GetContentCampaignByID(contentCampaignID int64) (*dbcont
 	return ret.Get(0).(*dbcontentcampaign.ContentCampaign), ret.Error(1)
 }
 
+func (ds *MockDBSource) GetContentCampaignsByIDs(campaignIDs []int64) ([]dbcontentcampaign.ContentCampaign, error) {
+	ret := ds.Called(campaignIDs)
+	return ret.Get(0).([]dbcontentcampaign.ContentCampaign), ret.Error(1)
+}
+
+func (ds *MockDBSource) GetContentCampaignsUpdatedSince(since time.Time, afterID int64, limit int) ([]dbcontentcampaign.ContentCampaign, error) {
+	ret := ds.Called(since, afterID, limit)
+	return ret.Get(0).([]dbcontentcampaign.ContentCampaign), ret.Error(1)
+}
+
+func (ds *MockDBSource) GetContentChannelsByIDs(channelIDs []int64) ([]dbcontentcampaign.ContentChannel, error) {
+	ret := ds.Called(channelIDs)
+	return ret.Get(0).([]dbcontentcampaign.ContentChannel), ret.Error(1)
+}
+
 type MockRedisCache struct {
 	mock.Mock
 }
@@ -86,3 +119,47 @@ func (mrc *MockRedisCache) DeleteCache(key string) error {
 	ret := mrc.Called(key)
 	return ret.Error(0)
 }
+
+type MockRedisContentCampaign struct {
+	mock.Mock
+}
+
+func (m *MockRedisContentCampaign) IncreaseImpression(campaignID int64, unitID int64) error {
+	return m.Called(campaignID, unitID).Error(0)
+}
+
+func (m *MockRedisContentCampaign) IncreaseClick(campaignID int64, unitID int64) error {
+	return m.Called(campaignID, unitID).Error(0)
+}
+
+func (m *MockRedisContentCampaign) IncreaseVariantImpression(campaignID int64, variantID int64) error {
+	return m.Called(campaignID, variantID).Error(0)
+}
+
+func (m *MockRedisContentCampaign) IncreaseVariantClick(campaignID int64, variantID int64) error {
+	return m.Called(campaignID, variantID).Error(0)
+}
+
+func (m *MockRedisContentCampaign) GetVariantCounts(campaignIDs ...int64) (map[int64]rediscontentcampaign.Counts, error) {
+	ret := m.Called(campaignIDs)
+	return ret.Get(0).(map[int64]rediscontentcampaign.Counts), ret.Error(1)
+}
+
+func (m *MockRedisContentCampaign) GetCampaignCounts(dateHour time.Time) (map[int64]rediscontentcampaign.Counts, error) {
+	ret := m.Called(dateHour)
+	return ret.Get(0).(map[int64]rediscontentcampaign.Counts), ret.Error(1)
+}
+
+func (m *MockRedisContentCampaign) GetCampaignUnitCounts(dateHour time.Time) (map[int64]map[int64]rediscontentcampaign.Counts, error) {
+	ret := m.Called(dateHour)
+	return ret.Get(0).(map[int64]map[int64]rediscontentcampaign.Counts), ret.Error(1)
+}
+
+func (m *MockRedisContentCampaign) SaveTrendingScores(key string, scores map[int64]float64, ttl time.Duration) error {
+	return m.Called(key, scores, ttl).Error(0)
+}
+
+func (m *MockRedisContentCampaign) GetTrendingScores(key string, size int) ([]rediscontentcampaign.TrendingScore, error) {
+	ret := m.Called(key, size)
+	return ret.Get(0).([]rediscontentcampaign.TrendingScore), ret.Error(1)
+}
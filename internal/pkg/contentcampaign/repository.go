@@ -1,8 +1,21 @@
 package contentcampaign
 
+import "time"
+
 // Repository type definition
 type Repository interface {
 	GetContentCampaignByID(campaignID int64) (*ContentCampaign, error)
 	IncreaseClick(campaignID int64, unitID int64) error
 	IncreaseImpression(campaignID int64, unitID int64) error
+	// GetStats returns the stats of campaigns aggregated over the hours from "from" to "to" inclusive
+	GetStats(from time.Time, to time.Time) (map[int64]Stat, error)
+}
+
+// SearchRepository type definition
+type SearchRepository interface {
+	Search(req SearchRequest) (*SearchResult, error)
+	SearchByIDs(campaignIDs ...int64) (*SearchResult, error)
+	// Explain returns the targeting clauses of the query the campaign passes or fails with the campaign scored by the ranking.
+	// CampaignNotIndexedError is returned if the campaign is not indexed.
+	Explain(campaignID int64, req SearchRequest) (*Explanation, error)
 }
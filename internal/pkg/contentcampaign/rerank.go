@@ -0,0 +1,245 @@
+package contentcampaign
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultRerankTopK is the number of candidates retrieved from the search repository for reranking
+	DefaultRerankTopK = 200
+	// DefaultRetrievalModelArtifact is the script model used to retrieve candidates for reranking
+	DefaultRetrievalModelArtifact = "v4_a"
+)
+
+// sourceTimeLayouts are the time layouts of the indexed documents
+var sourceTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// Candidate is the searched campaign scored by rankers
+type Candidate struct {
+	ID        int64
+	Seen      bool
+	Demoted   bool      // demoted by CtrThrottle
+	CreatedAt time.Time // published_at or start_date of the campaign. zero if unknown
+
+	CategoryScores map[string]float64 // watson category -> relevance of the campaign
+	EntityScores   map[string]float64 // watson entity -> relevance of the campaign
+}
+
+// candidateSource is the fields of SearchHit.Source used for reranking
+type candidateSource struct {
+	PublishedAt          *string   `json:"published_at"`
+	StartDate            *string   `json:"start_date"`
+	WatsonCategoryTexts  []string  `json:"watson_category_texts"`
+	WatsonCategoryScores []float64 `json:"watson_category_scores"`
+	WatsonEntityTexts    []string  `json:"watson_entity_texts"`
+	WatsonEntityScores   []float64 `json:"watson_entity_scores"`
+}
+
+// NewCandidate parses the candidate of the hit
+func NewCandidate(hit SearchHit, ranking SearchRanking) (Candidate, error) {
+	var source candidateSource
+	if err := json.Unmarshal(hit.Source, &source); err != nil {
+		return Candidate{}, err
+	}
+
+	candidate := Candidate{
+		ID:             hit.ID,
+		Seen:           ranking.SeenIDs[strconv.FormatInt(hit.ID, 10)],
+		Demoted:        ranking.DemotedIDs[strconv.FormatInt(hit.ID, 10)],
+		CategoryScores: zipScores(source.WatsonCategoryTexts, source.WatsonCategoryScores),
+		EntityScores:   zipScores(source.WatsonEntityTexts, source.WatsonEntityScores),
+	}
+
+	if source.PublishedAt != nil {
+		candidate.CreatedAt = parseSourceTime(*source.PublishedAt)
+	}
+	if candidate.CreatedAt.IsZero() && source.StartDate != nil {
+		candidate.CreatedAt = parseSourceTime(*source.StartDate)
+	}
+	return candidate, nil
+}
+
+// Ranker scores a candidate. Scores of the rankers of a Reranker are added up.
+type Ranker interface {
+	Name() string
+	Score(candidate Candidate, ranking SearchRanking, rankedAt time.Time) float64
+}
+
+// LinearRanker scores the relevance of the candidate to the category and entity profiles of the device
+type LinearRanker struct {
+	CategoryWeight float64
+	EntityWeight   float64
+}
+
+// Name func definition
+func (r LinearRanker) Name() string {
+	return "linear"
+}
+
+// Score func definition
+func (r LinearRanker) Score(candidate Candidate, ranking SearchRanking, rankedAt time.Time) float64 {
+	return r.CategoryWeight*dotScores(ranking.CategoryProfile, candidate.CategoryScores) +
+		r.EntityWeight*dotScores(ranking.EntityProfile, candidate.EntityScores)
+}
+
+// FreshnessRanker scores the candidate by its age. The score is halved every HalfLife.
+type FreshnessRanker struct {
+	Weight   float64
+	HalfLife time.Duration
+}
+
+// Name func definition
+func (r FreshnessRanker) Name() string {
+	return "freshness"
+}
+
+// Score func definition
+func (r FreshnessRanker) Score(candidate Candidate, ranking SearchRanking, rankedAt time.Time) float64 {
+	if candidate.CreatedAt.IsZero() || r.HalfLife <= 0 {
+		return 0
+	}
+
+	age := rankedAt.Sub(candidate.CreatedAt)
+	if age < 0 {
+		age = 0
+	}
+	return r.Weight * math.Pow(0.5, float64(age)/float64(r.HalfLife))
+}
+
+// SeenPenaltyRanker lowers the candidate the device has already seen
+type SeenPenaltyRanker struct {
+	Penalty float64
+}
+
+// Name func definition
+func (r SeenPenaltyRanker) Name() string {
+	return "seen_penalty"
+}
+
+// Score func definition
+func (r SeenPenaltyRanker) Score(candidate Candidate, ranking SearchRanking, rankedAt time.Time) float64 {
+	if candidate.Seen {
+		return -r.Penalty
+	}
+	return 0
+}
+
+// CtrThrottleRanker lowers the candidate demoted by CtrThrottle
+type CtrThrottleRanker struct {
+	Penalty float64
+}
+
+// Name func definition
+func (r CtrThrottleRanker) Name() string {
+	return "ctr_throttle"
+}
+
+// Score func definition
+func (r CtrThrottleRanker) Score(candidate Candidate, ranking SearchRanking, rankedAt time.Time) float64 {
+	if candidate.Demoted {
+		return -r.Penalty
+	}
+	return 0
+}
+
+// Reranker scores the top-K candidates retrieved by RetrievalModelArtifact with the rankers
+type Reranker struct {
+	RetrievalModelArtifact string
+	TopK                   int
+	Rankers                []Ranker
+}
+
+// Rerank returns the hits ordered by the sum of ranker scores, then id descending.
+// Score of the hits are replaced and the ranker scores are set into ScoreFactors if ranking.Debug is set.
+// Hits failed to be parsed are dropped.
+func (r Reranker) Rerank(hits []SearchHit, ranking SearchRanking) []SearchHit {
+	rankedAt := ranking.RankedAt
+	if rankedAt.IsZero() {
+		rankedAt = time.Now()
+	}
+
+	reranked := make([]SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		candidate, err := NewCandidate(hit, ranking)
+		if err != nil {
+			continue
+		}
+
+		score := 0.0
+		factors := make(map[string]float64)
+		for _, ranker := range r.Rankers {
+			factor := ranker.Score(candidate, ranking, rankedAt)
+			factors[ranker.Name()] = factor
+			score += factor
+		}
+
+		hit.Score = &score
+		hit.ScoreFactors = make(map[string]float64)
+		if ranking.Debug {
+			hit.ScoreFactors = factors
+		}
+		reranked = append(reranked, hit)
+	}
+
+	sort.SliceStable(reranked, func(i, j int) bool {
+		if *reranked[i].Score != *reranked[j].Score {
+			return *reranked[i].Score > *reranked[j].Score
+		}
+		return reranked[i].ID > reranked[j].ID
+	})
+	return reranked
+}
+
+// NewDefaultRerankers returns the rerankers keyed by the model artifacts ranked in process
+func NewDefaultRerankers() map[string]Reranker {
+	return map[string]Reranker{
+		"r1": {
+			RetrievalModelArtifact: DefaultRetrievalModelArtifact,
+			TopK:                   DefaultRerankTopK,
+			Rankers: []Ranker{
+				LinearRanker{CategoryWeight: 2, EntityWeight: 1},
+				FreshnessRanker{Weight: 3, HalfLife: time.Hour * 12},
+				SeenPenaltyRanker{Penalty: 6},
+				CtrThrottleRanker{Penalty: 6},
+			},
+		},
+	}
+}
+
+func zipScores(texts []string, scores []float64) map[string]float64 {
+	zipped := make(map[string]float64, len(texts))
+	for i, text := range texts {
+		if i < len(scores) {
+			zipped[text] = scores[i]
+		}
+	}
+	return zipped
+}
+
+// dotScores adds up in the order of keys so that the score is deterministic
+func dotScores(profile map[string]float64, scores map[string]float64) float64 {
+	keys := make([]string, 0, len(scores))
+	for key := range scores {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	dot := 0.0
+	for _, key := range keys {
+		dot += profile[key] * scores[key]
+	}
+	return dot
+}
+
+func parseSourceTime(value string) time.Time {
+	for _, layout := range sourceTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,106 @@
+package contentcampaign_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/stretchr/testify/assert"
+)
+
+var rankedAt = time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestLinearRanker(t *testing.T) {
+	ranker := contentcampaign.LinearRanker{CategoryWeight: 2, EntityWeight: 1}
+	candidate := contentcampaign.Candidate{
+		CategoryScores: map[string]float64{"/sports": 0.5, "/news": 1},
+		EntityScores:   map[string]float64{"baseball": 0.8},
+	}
+	ranking := contentcampaign.SearchRanking{
+		CategoryProfile: map[string]float64{"/sports": 3},
+		EntityProfile:   map[string]float64{"baseball": 2, "soccer": 5},
+	}
+
+	assert.InDelta(t, 2*3*0.5+1*2*0.8, ranker.Score(candidate, ranking, rankedAt), 1e-9)
+	assert.Equal(t, 0.0, ranker.Score(candidate, contentcampaign.SearchRanking{}, rankedAt))
+}
+
+func TestFreshnessRanker(t *testing.T) {
+	ranker := contentcampaign.FreshnessRanker{Weight: 4, HalfLife: time.Hour * 12}
+
+	assert.Equal(t, 4.0, ranker.Score(contentcampaign.Candidate{CreatedAt: rankedAt}, contentcampaign.SearchRanking{}, rankedAt))
+	assert.Equal(t, 2.0, ranker.Score(contentcampaign.Candidate{CreatedAt: rankedAt.Add(-time.Hour * 12)}, contentcampaign.SearchRanking{}, rankedAt))
+	assert.Equal(t, 4.0, ranker.Score(contentcampaign.Candidate{CreatedAt: rankedAt.Add(time.Hour)}, contentcampaign.SearchRanking{}, rankedAt))
+	assert.Equal(t, 0.0, ranker.Score(contentcampaign.Candidate{}, contentcampaign.SearchRanking{}, rankedAt))
+}
+
+func TestSeenPenaltyRanker(t *testing.T) {
+	ranker := contentcampaign.SeenPenaltyRanker{Penalty: 6}
+
+	assert.Equal(t, -6.0, ranker.Score(contentcampaign.Candidate{Seen: true}, contentcampaign.SearchRanking{}, rankedAt))
+	assert.Equal(t, 0.0, ranker.Score(contentcampaign.Candidate{}, contentcampaign.SearchRanking{}, rankedAt))
+}
+
+func TestNewCandidate(t *testing.T) {
+	hit := newRerankHit(7, rankedAt.Add(-time.Hour), `"watson_category_texts": ["/sports"], "watson_category_scores": [0.5]`)
+
+	candidate, err := contentcampaign.NewCandidate(hit, contentcampaign.SearchRanking{SeenIDs: map[string]bool{"7": true}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), candidate.ID)
+	assert.True(t, candidate.Seen)
+	assert.True(t, rankedAt.Add(-time.Hour).Equal(candidate.CreatedAt))
+	assert.Equal(t, map[string]float64{"/sports": 0.5}, candidate.CategoryScores)
+	assert.Empty(t, candidate.EntityScores)
+}
+
+func TestReranker_Rerank(t *testing.T) {
+	hits := []contentcampaign.SearchHit{
+		newRerankHit(1, rankedAt.Add(-time.Hour*24), `"watson_category_texts": ["/sports"], "watson_category_scores": [1]`),
+		newRerankHit(2, rankedAt, ``),
+		newRerankHit(3, rankedAt, ``),
+		newRerankHit(4, rankedAt, ``),
+		{ID: 5, Source: []byte(`{broken`)},
+	}
+	ranking := contentcampaign.SearchRanking{
+		CategoryProfile: map[string]float64{"/sports": 2},
+		SeenIDs:         map[string]bool{"4": true},
+		RankedAt:        rankedAt,
+		Debug:           true,
+	}
+
+	reranked := testReranker.Rerank(hits, ranking)
+
+	// 1: 2*2*1 + 4*0.25 = 5, 2,3: 4, 4: 4-6 = -2. 같은 점수는 id 역순
+	ids := make([]int64, 0)
+	for _, hit := range reranked {
+		ids = append(ids, hit.ID)
+	}
+	assert.Equal(t, []int64{1, 3, 2, 4}, ids)
+	assert.Equal(t, 5.0, *reranked[0].Score)
+	assert.Equal(t, map[string]float64{"linear": 4, "freshness": 1, "seen_penalty": 0}, reranked[0].ScoreFactors)
+
+	// 같은 입력이면 항상 같은 순서로 정렬된다
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, reranked, testReranker.Rerank(hits, ranking))
+	}
+}
+
+var testReranker = contentcampaign.Reranker{
+	RetrievalModelArtifact: "v4_a",
+	TopK:                   100,
+	Rankers: []contentcampaign.Ranker{
+		contentcampaign.LinearRanker{CategoryWeight: 2, EntityWeight: 1},
+		contentcampaign.FreshnessRanker{Weight: 4, HalfLife: time.Hour * 12},
+		contentcampaign.SeenPenaltyRanker{Penalty: 6},
+	},
+}
+
+func newRerankHit(id int64, publishedAt time.Time, fields string) contentcampaign.SearchHit {
+	if fields != "" {
+		fields = ", " + fields
+	}
+	source := fmt.Sprintf(`{"id": %d, "published_at": "%s"%s}`, id, publishedAt.Format(time.RFC3339), fields)
+	return contentcampaign.SearchHit{ID: id, Source: []byte(source), ScoreFactors: map[string]float64{}}
+}
@@ -0,0 +1,152 @@
+package contentcampaign
+
+import (
+	"strconv"
+	"time"
+)
+
+// SearchQuery holds the targeting filters of a content campaign search.
+// Zero values of optional filters mean the filter is not applied unless noted.
+type SearchQuery struct {
+	StartsBy  time.Time // start_date <= StartsBy
+	EndsAfter time.Time // end_date > EndsAfter
+
+	UnitID          int64
+	IncludeGlobUnit bool // campaigns targeting all units are included
+	AppID           int64
+	OrganizationID  int64
+	Country         string
+	Gender          string
+	Carrier         *string
+	Region          *string
+	Languages       []string // languages the campaigns must target
+	TargetLanguages []string // languages of the device. campaigns targeting other languages are excluded. nil: not applied
+
+	Age            int  // 0: matches campaigns without age targeting only
+	AnyDevice      bool // gender and age are not applied so that the campaigns of every device are searched. refer to contentsnapshot
+	SdkVersion     int  // 0: matches campaigns without sdk targeting only
+	RegisteredDays int  // 0: matches campaigns without registered days targeting only
+	OsVersion      int
+	LocalTime      *time.Time
+	CustomTargets  [3]string
+
+	InBatteryOptimization bool // false: matches campaigns not targeting battery optimized devices only
+	CreativeType          string
+	MinImageRatio         *float64
+	LandingTypes          []int
+	UpdatedBefore         *time.Time
+
+	Statuses        []Status
+	RelatedStatuses []Status // statuses allowed only for campaigns having related campaigns
+
+	Packages            []string // installed packages. nil: not applied
+	Categories          []string
+	ExcludedCategories  []string
+	ChannelIDs          []int64
+	ExcludedChannelIDs  []int64
+	ExcludedProviderIDs []int64
+	ExcludedIDs         []int64 // campaigns paused by CtrThrottle or hidden by the device
+
+	FrequencyCap *FrequencyCap
+	ColdStart    *ColdStartFilter
+
+	// Keyword is matched against title, description and tags. Campaigns are ranked by relevance instead of SearchRanking if set
+	Keyword string
+}
+
+// FrequencyCap holds the campaign impression counts of a device keyed by campaign id.
+// Campaigns seen more than their ipu(hourly), dipu(daily) are filtered out by the search.
+// Campaigns seen more than their tipu(lifetime) are removed after retrieval. refer to FrequencyCap.Check
+type FrequencyCap struct {
+	CountsForHour     map[string]int
+	CountsForDay      map[string]int
+	CountsForLifetime map[string]int
+}
+
+// ColdStartFilter matches the campaigns without enough engagement history to be ranked by the score script.
+// A campaign created at or after CreatedAfter or having less impressions than MaxImpressions is matched.
+type ColdStartFilter struct {
+	CreatedAfter   time.Time
+	MaxImpressions int
+}
+
+// SearchRanking holds the parameters to rank searched campaigns
+type SearchRanking struct {
+	ModelArtifact   string
+	MultiplyFactors bool // score factors are multiplied instead of added
+	CategoryProfile map[string]float64
+	EntityProfile   map[string]float64
+	SeenIDs         map[string]bool
+	DemotedIDs      map[string]bool // campaigns demoted by CtrThrottle
+	CampaignScores  map[int64]int   // recommendation scores of the active content score version
+	RankedAt        time.Time       // time scores depending on time are computed at. time.Now() if zero
+	Debug           bool            // score factors are returned in SearchHit
+
+	// FollowedChannelIDs and CategoryWeights are the content preference of the device.
+	// Campaigns of the followed channels are boosted and campaigns of the categories are demoted by the weights in (0, 1)
+	FollowedChannelIDs []int64
+	CategoryWeights    map[string]float64
+
+	// TrendingScores ranks the campaigns by the scores keyed by campaign id instead of the model artifact.
+	// Campaigns without score are not searched. nil: not applied
+	TrendingScores map[int64]float64
+}
+
+// SearchCursor is the sort values of the last campaign of the previous page.
+// Campaigns are sorted by score and id descending so that the order is stable for the same score.
+type SearchCursor struct {
+	Score float64
+	ID    int64
+}
+
+// NewSearchCursor returns the cursor to search the campaigns after the hit. nil is returned if the hit has no score.
+func NewSearchCursor(hit SearchHit) *SearchCursor {
+	if hit.Score == nil {
+		return nil
+	}
+	return &SearchCursor{Score: *hit.Score, ID: hit.ID}
+}
+
+// IsBefore returns true if the campaign of the score and id is sorted before the cursor
+func (c SearchCursor) IsBefore(score float64, id int64) bool {
+	return score > c.Score || (score == c.Score && id >= c.ID)
+}
+
+// SearchRequest type definition
+type SearchRequest struct {
+	DeviceID int64 // device requesting the campaigns for logging
+	Query    SearchQuery
+	Ranking  SearchRanking
+	After    *SearchCursor // From is ignored if After is set
+	From     int
+	Size     int
+
+	Highlight bool // fragments matching SearchQuery.Keyword are returned in SearchHit.Highlights
+}
+
+// rankedByModel returns true if the campaigns are ranked by the score script of the model artifact
+func (r SearchRequest) rankedByModel() bool {
+	return r.Query.Keyword == "" && r.Ranking.TrendingScores == nil
+}
+
+// SearchHit is a searched campaign. Source is the indexed document of the campaign in json.
+type SearchHit struct {
+	ID            int64
+	Source        []byte
+	Score         *float64
+	ScoreFactors  map[string]float64
+	ModelArtifact string
+	Highlights    map[string][]string // highlighted fragments keyed by field. nil if not requested
+}
+
+// SearchResult type definition
+type SearchResult struct {
+	Hits  []SearchHit
+	Total int
+}
+
+// WeekSlot returns the hour slot of the week starts from monday 0:00. e.g. tuesday 1:00 -> "25"
+func WeekSlot(localTime time.Time) string {
+	weekday := (int(localTime.Weekday()) + 6) % 7
+	return strconv.Itoa(weekday*24 + localTime.Hour())
+}
@@ -0,0 +1,359 @@
+package searchrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/Buzzvil/buzzscreen-api/buzzscreen/service/es"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"gopkg.in/olivere/elastic.v5"
+)
+
+const esDocType = "content_campaign"
+
+// trendingScoreScript returns the trending score of the campaign from the params keyed by campaign id
+const trendingScoreScript = "params.trendingScores.getOrDefault(String.valueOf(doc['id'].value), 0.0)"
+
+// tags wrapping the fragments matching the keyword
+const (
+	highlightPreTag  = "<em>"
+	highlightPostTag = "</em>"
+)
+
+// ScriptLoader provides painless scripts used for filtering and ranking
+type ScriptLoader interface {
+	GetFilterScript() string
+	GetScoreScript(modelArtifact string, jop es.JoinOp) string
+	GetScriptFields(modelArtifact string, params map[string]interface{}) []*elastic.ScriptField
+}
+
+// ESRepository is the search repository backed by elasticsearch
+type ESRepository struct {
+	client    *elastic.Client
+	indexName string
+	scripts   ScriptLoader
+}
+
+// Search func definition
+func (r *ESRepository) Search(req contentcampaign.SearchRequest) (*contentcampaign.SearchResult, error) {
+	searchSource, scriptSort := r.buildSearchSourceAndScriptSort(req.Ranking)
+	query := elastic.NewBoolQuery().Filter(r.buildFilterQueries(req.Query)...)
+	var sorter elastic.Sorter = scriptSort
+
+	if req.Ranking.TrendingScores != nil {
+		query.Filter(elastic.NewTermsQuery("id", trendingIDs(req.Ranking.TrendingScores)...))
+		sorter = buildTrendingSort(req.Ranking.TrendingScores)
+	}
+
+	// 키워드 검색은 관련도 순으로 정렬한다
+	if req.Query.Keyword != "" {
+		query.Must(newClauseBuilder().withKeyword(req.Query.Keyword).build()...)
+		sorter = elastic.NewScoreSort().Desc()
+	}
+	// title, description은 그대로 html에 노출되므로 태그 외의 문자는 escape한다
+	if req.Highlight && req.Query.Keyword != "" {
+		searchSource.Highlight(elastic.NewHighlight().Encoder("html").Fields(
+			elastic.NewHighlighterField("title").NumOfFragments(0),
+			elastic.NewHighlighterField("description"),
+		).PreTags(highlightPreTag).PostTags(highlightPostTag))
+	}
+
+	searchService := r.client.Search().Index(r.indexName).Type(esDocType).FetchSource(true).
+		SearchSource(searchSource).Query(query).
+		SortBy(sorter, elastic.NewFieldSort("id").Desc()).TimeoutInMillis(1000).Preference("_local").Size(req.Size)
+	if req.After != nil {
+		searchService = searchService.SearchAfter(req.After.Score, req.After.ID)
+	} else if req.From > 0 {
+		searchService = searchService.From(req.From)
+	}
+
+	searchResult, err := searchService.Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.parseSearchResult(searchResult)
+}
+
+// SearchByIDs func definition
+func (r *ESRepository) SearchByIDs(campaignIDs ...int64) (*contentcampaign.SearchResult, error) {
+	searchResult, err := r.client.Search().Index(r.indexName).Type(esDocType).TimeoutInMillis(1000).Preference("_local").
+		Query(elastic.NewBoolQuery().Filter(elastic.NewBoolQuery().Must(elastic.NewTermsQuery("id", int64sToInterfaces(campaignIDs)...)))).
+		Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.parseSearchResult(searchResult)
+}
+
+// Explain func definition. Each clause is a named query so that the clauses the campaign passes are returned as matched queries.
+func (r *ESRepository) Explain(campaignID int64, req contentcampaign.SearchRequest) (*contentcampaign.Explanation, error) {
+	clauses := r.buildClauseQueries(req.Query)
+	query := elastic.NewBoolQuery().Filter(elastic.NewTermQuery("id", campaignID))
+	for _, c := range clauses {
+		query.Should(elastic.NewBoolQuery().Filter(c.queries...).QueryName(string(c.clause)))
+	}
+
+	searchSource, scriptSort := r.buildSearchSourceAndScriptSort(req.Ranking)
+	searchResult, err := r.client.Search().Index(r.indexName).Type(esDocType).FetchSource(true).
+		SearchSource(searchSource).Query(query).SortBy(scriptSort).TimeoutInMillis(1000).Preference("_local").Size(1).
+		Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.parseSearchResult(searchResult)
+	if err != nil {
+		return nil, err
+	} else if len(result.Hits) == 0 {
+		return nil, contentcampaign.CampaignNotIndexedError{CampaignID: campaignID}
+	}
+
+	matched := make(map[string]bool)
+	for _, name := range searchResult.Hits.Hits[0].MatchedQueries {
+		matched[name] = true
+	}
+
+	explanation := &contentcampaign.Explanation{CampaignID: campaignID, Hit: result.Hits[0]}
+	for _, c := range clauses {
+		explanation.Clauses = append(explanation.Clauses, contentcampaign.ClauseResult{Clause: c.clause, Passed: matched[string(c.clause)]})
+	}
+	return explanation, nil
+}
+
+// buildFilterQueries returns the filter queries of the clauses except the keyword which is queried for scoring by Search
+func (r *ESRepository) buildFilterQueries(query contentcampaign.SearchQuery) []elastic.Query {
+	queries := make([]elastic.Query, 0)
+	for _, c := range r.buildClauseQueries(query) {
+		if c.clause == contentcampaign.ClauseKeyword {
+			continue
+		}
+		queries = append(queries, c.queries...)
+	}
+	return queries
+}
+
+// clauseQuery is the filter queries of a targeting clause
+type clauseQuery struct {
+	clause  contentcampaign.TargetingClause
+	queries []elastic.Query
+}
+
+// buildClauseQueries returns the filter queries of the clauses applied by the query in the order of contentcampaign.TargetingClause
+func (r *ESRepository) buildClauseQueries(query contentcampaign.SearchQuery) []clauseQuery {
+	clauses := make([]clauseQuery, 0)
+	add := func(clause contentcampaign.TargetingClause, qb *queryBuilder) {
+		if queries := qb.build(); len(queries) > 0 {
+			clauses = append(clauses, clauseQuery{clause: clause, queries: queries})
+		}
+	}
+
+	add(contentcampaign.ClauseEnabled, newQueryBuilder())
+	add(contentcampaign.ClauseStartDate, newClauseBuilder().withStartTime(query.StartsBy))
+	add(contentcampaign.ClauseEndDate, newClauseBuilder().withEndTime(query.EndsAfter))
+	add(contentcampaign.ClauseCountry, newClauseBuilder().withCountry(query.Country))
+	if !query.AnyDevice {
+		add(contentcampaign.ClauseGender, newClauseBuilder().withGender(query.Gender))
+	}
+	add(contentcampaign.ClauseUnit, newClauseBuilder().withUnit(query.UnitID, query.IncludeGlobUnit))
+	add(contentcampaign.ClauseApp, newClauseBuilder().withAppID(query.AppID))
+	add(contentcampaign.ClauseOrganization, newClauseBuilder().withOrgID(query.OrganizationID))
+	if !query.AnyDevice {
+		add(contentcampaign.ClauseAge, newClauseBuilder().withAge(query.Age))
+	}
+	add(contentcampaign.ClauseSdk, newClauseBuilder().withSdk(query.SdkVersion))
+	add(contentcampaign.ClauseRegisteredDays, newClauseBuilder().withRegisteredDays(query.RegisteredDays))
+	add(contentcampaign.ClauseWeekSlot, newClauseBuilder().withWeekSlot(query.LocalTime))
+	add(contentcampaign.ClauseCreativeType, newClauseBuilder().withCreativeTypes(query.CreativeType))
+	add(contentcampaign.ClauseCustomTargets, newClauseBuilder().withCustomTargets(query.CustomTargets))
+	add(contentcampaign.ClauseOs, newClauseBuilder().withOsVersion(query.OsVersion))
+	add(contentcampaign.ClauseBatteryOptimization, newClauseBuilder().withBatteryOptimization(query.InBatteryOptimization))
+	add(contentcampaign.ClauseLanguage, newClauseBuilder().withLanguage(query.Languages...))
+	add(contentcampaign.ClauseCategories, newClauseBuilder().withCategories(true, query.Categories...))
+	add(contentcampaign.ClauseExcludedCategories, newClauseBuilder().withCategories(false, query.ExcludedCategories...))
+	add(contentcampaign.ClauseChannels, newClauseBuilder().withChannels(true, query.ChannelIDs...))
+	add(contentcampaign.ClauseExcludedChannels, newClauseBuilder().withChannels(false, query.ExcludedChannelIDs...))
+	add(contentcampaign.ClauseExcludedProviders, newClauseBuilder().withFilteredProviders(query.ExcludedProviderIDs...))
+	add(contentcampaign.ClauseExcludedIDs, newClauseBuilder().withExcludedIDs(query.ExcludedIDs...))
+	add(contentcampaign.ClauseUpdatedAt, newClauseBuilder().withUpdatedTime(query.UpdatedBefore))
+
+	if query.TargetLanguages != nil {
+		add(contentcampaign.ClauseTargetLanguage, newClauseBuilder().withTargetLanguage(query.TargetLanguages...))
+	}
+
+	if query.ColdStart != nil {
+		add(contentcampaign.ClauseColdStart, newClauseBuilder().withColdStart(*query.ColdStart))
+	}
+
+	if query.Carrier != nil {
+		add(contentcampaign.ClauseCarrier, newClauseBuilder().withCarrier(*query.Carrier))
+	}
+
+	if query.Region != nil {
+		add(contentcampaign.ClauseRegion, newClauseBuilder().withRegion(*query.Region))
+	}
+
+	if query.MinImageRatio != nil {
+		add(contentcampaign.ClauseImageRatio, newClauseBuilder().withImageRatio(*query.MinImageRatio))
+	}
+
+	if query.Packages != nil {
+		add(contentcampaign.ClausePackages, newClauseBuilder().withPackages(query.Packages))
+	}
+
+	if len(query.LandingTypes) > 0 {
+		add(contentcampaign.ClauseLandingTypes, newClauseBuilder().withLandingTypes(query.LandingTypes...))
+	}
+
+	if query.FrequencyCap != nil {
+		add(contentcampaign.ClauseFrequencyCap, newClauseBuilder().withFrequencyCapping(r.scripts.GetFilterScript(), *query.FrequencyCap))
+	}
+
+	add(contentcampaign.ClauseStatus, newClauseBuilder().withStatus(query.Statuses, query.RelatedStatuses))
+	add(contentcampaign.ClauseKeyword, newClauseBuilder().withKeyword(query.Keyword))
+	return clauses
+}
+
+func (r *ESRepository) buildSearchSourceAndScriptSort(ranking contentcampaign.SearchRanking) (*elastic.SearchSource, *elastic.ScriptSort) {
+	// 1. Build score script for ranking
+	joinOp := es.JoinOpAdd
+	if ranking.MultiplyFactors {
+		joinOp = es.JoinOpMultiply
+	}
+	script := elastic.NewScript(r.scripts.GetScoreScript(ranking.ModelArtifact, joinOp))
+	params := make(map[string]interface{})
+
+	// Add preferred categories information for personalization
+	if ranking.CategoryProfile != nil {
+		params["categoryProfile"] = ranking.CategoryProfile
+	}
+
+	// Add entity profiles for personalization
+	if ranking.EntityProfile != nil {
+		params["entityProfile"] = ranking.EntityProfile
+	}
+
+	// Add seen content ids
+	if len(ranking.SeenIDs) != 0 {
+		params["seenIDs"] = ranking.SeenIDs
+	}
+
+	// Add campaigns demoted by CTR
+	if len(ranking.DemotedIDs) != 0 {
+		params["demotedIDs"] = ranking.DemotedIDs
+	}
+
+	// Add recommendation scores of the device target
+	if len(ranking.CampaignScores) != 0 {
+		scoredCamps := make(map[string]int, len(ranking.CampaignScores))
+		for campaignID, score := range ranking.CampaignScores {
+			scoredCamps[strconv.FormatInt(campaignID, 10)] = score
+		}
+		params["scoredCamps"] = scoredCamps
+	}
+
+	// Add content preference of the device
+	if len(ranking.FollowedChannelIDs) != 0 {
+		followedChannels := make(map[string]bool, len(ranking.FollowedChannelIDs))
+		for _, channelID := range ranking.FollowedChannelIDs {
+			followedChannels[strconv.FormatInt(channelID, 10)] = true
+		}
+		params["followedChannels"] = followedChannels
+	}
+	if len(ranking.CategoryWeights) != 0 {
+		params["categoryWeights"] = ranking.CategoryWeights
+	}
+	script.Params(params)
+
+	// 2. Set searchSource for debug scoring if needed
+	artifactScript := elastic.NewScript(fmt.Sprintf("'%s'", ranking.ModelArtifact))
+	searchSource := elastic.NewSearchSource().FetchSource(true).ScriptField(elastic.NewScriptField("modelArtifact", artifactScript))
+	if ranking.Debug {
+		searchSource.ScriptFields(r.scripts.GetScriptFields(ranking.ModelArtifact, params)...)
+	}
+
+	return searchSource, elastic.NewScriptSort(script, "number").Desc()
+}
+
+// buildTrendingSort sorts the campaigns by the trending scores
+func buildTrendingSort(scores map[int64]float64) *elastic.ScriptSort {
+	params := make(map[string]interface{}, len(scores))
+	for campaignID, score := range scores {
+		params[strconv.FormatInt(campaignID, 10)] = score
+	}
+	script := elastic.NewScript(trendingScoreScript).Params(map[string]interface{}{"trendingScores": params})
+	return elastic.NewScriptSort(script, "number").Desc()
+}
+
+func trendingIDs(scores map[int64]float64) []interface{} {
+	ids := make([]interface{}, 0, len(scores))
+	for campaignID := range scores {
+		ids = append(ids, campaignID)
+	}
+	return ids
+}
+
+func (r *ESRepository) parseSearchResult(searchResult *elastic.SearchResult) (*contentcampaign.SearchResult, error) {
+	if searchResult.Hits == nil {
+		return nil, contentcampaign.RemoteESError{Err: errors.New("es search failed")}
+	}
+
+	result := &contentcampaign.SearchResult{
+		Hits:  make([]contentcampaign.SearchHit, 0, len(searchResult.Hits.Hits)),
+		Total: int(searchResult.Hits.TotalHits),
+	}
+
+	for _, hit := range searchResult.Hits.Hits {
+		if hit.Source == nil {
+			continue
+		}
+		id, _ := strconv.ParseInt(hit.Id, 10, 64)
+		searchHit := contentcampaign.SearchHit{
+			ID:           id,
+			Source:       *hit.Source,
+			ScoreFactors: make(map[string]float64),
+		}
+
+		// Add score factors & model artifact
+		for k, v := range hit.Fields {
+			varray := reflect.ValueOf(v)
+			if varray.Kind() != reflect.Slice || varray.Len() == 0 {
+				continue
+			}
+			if k == "modelArtifact" {
+				searchHit.ModelArtifact, _ = varray.Index(0).Interface().(string)
+			} else if weight, ok := varray.Index(0).Interface().(float64); ok {
+				searchHit.ScoreFactors[k] = weight
+			}
+		}
+
+		if len(hit.Highlight) > 0 {
+			searchHit.Highlights = hit.Highlight
+		}
+
+		// Add final score
+		if len(hit.Sort) > 0 {
+			if score, ok := hit.Sort[0].(float64); ok {
+				searchHit.Score = &score
+			}
+		}
+		result.Hits = append(result.Hits, searchHit)
+	}
+
+	return result, nil
+}
+
+// NewES returns the search repository using elasticsearch index
+func NewES(client *elastic.Client, indexName string, scripts ScriptLoader) *ESRepository {
+	return &ESRepository{
+		client:    client,
+		indexName: indexName,
+		scripts:   scripts,
+	}
+}
+
+var _ contentcampaign.SearchRepository = &ESRepository{}
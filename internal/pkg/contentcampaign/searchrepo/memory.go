@@ -0,0 +1,622 @@
+package searchrepo
+
+import (
+	"encoding/json"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+)
+
+const (
+	// defaultSearchSize is the size of SearchByIDs same as elasticsearch default
+	defaultSearchSize = 10
+	memoryScore       = 0.0
+)
+
+// dateLayouts are the layouts of date_optional_time format of the index mapping
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// document holds the fields of indexed document used for filtering
+type document struct {
+	ID        int64   `json:"id"`
+	IsEnabled bool    `json:"is_enabled"`
+	StartDate *string `json:"start_date"`
+	EndDate   *string `json:"end_date"`
+	UpdatedAt *string `json:"updated_at"`
+	CreatedAt *string `json:"created_at"`
+
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Tags        string `json:"tags"`
+
+	Country        *string `json:"country"`
+	TargetGender   *string `json:"target_gender"`
+	TargetLanguage *string `json:"target_language"`
+
+	Categories    string `json:"categories"`
+	CreativeTypes string `json:"creative_types"`
+	WeekSlot      string `json:"week_slot"`
+	TargetCarrier string `json:"target_carrier"`
+	TargetRegion  string `json:"target_region"`
+	CustomTarget1 string `json:"custom_target_1"`
+	CustomTarget2 string `json:"custom_target_2"`
+	CustomTarget3 string `json:"custom_target_3"`
+	TargetApp     string `json:"target_app"`
+	TargetUnit    string `json:"target_unit"`
+	TargetAppID   string `json:"target_app_id"`
+	TargetOrg     string `json:"target_org"`
+	DetargetApp   string `json:"detarget_app"`
+	DetargetUnit  string `json:"detarget_unit"`
+	DetargetAppID string `json:"detarget_app_id"`
+	DetargetOrg   string `json:"detarget_org"`
+
+	TargetAgeMin      *int `json:"target_age_min"`
+	TargetAgeMax      *int `json:"target_age_max"`
+	TargetSdkMin      *int `json:"target_sdk_min"`
+	TargetSdkMax      *int `json:"target_sdk_max"`
+	RegisteredDaysMin *int `json:"registered_days_min"`
+	RegisteredDaysMax *int `json:"registered_days_max"`
+	TargetOsMin       *int `json:"target_os_min"`
+	TargetOsMax       *int `json:"target_os_max"`
+
+	TargetBatteryOptimization *bool    `json:"target_battery_optimization"`
+	Status                    *int     `json:"status"`
+	LandingType               *int     `json:"landing_type"`
+	ChannelID                 *int64   `json:"channel_id"`
+	ProviderID                *int64   `json:"provider_id"`
+	ImageRatio                *float64 `json:"image_ratio"`
+	Related                   *int64   `json:"related"`
+	Ipu                       *int     `json:"ipu"`
+	Dipu                      *int     `json:"dipu"`
+	Impressions               *int     `json:"impressions"`
+}
+
+type memoryDocument struct {
+	source []byte
+	doc    document
+}
+
+// MemoryRepository is the search repository keeping documents in memory with the same filter semantics as elasticsearch.
+// It's used for tests and local runs without elasticsearch. Every campaign is scored 0 so that campaigns are ranked by id descending.
+type MemoryRepository struct {
+	mu        sync.RWMutex
+	documents map[int64]memoryDocument
+}
+
+// Index stores the document of a campaign. doc is marshaled into json as indexed into elasticsearch
+func (r *MemoryRepository) Index(doc interface{}) error {
+	source, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	var d document
+	if err := json.Unmarshal(source, &d); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.documents[d.ID] = memoryDocument{source: source, doc: d}
+	return nil
+}
+
+// Delete removes the document of the campaign
+func (r *MemoryRepository) Delete(campaignID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.documents, campaignID)
+}
+
+// Search func definition
+func (r *MemoryRepository) Search(req contentcampaign.SearchRequest) (*contentcampaign.SearchResult, error) {
+	trending := req.Ranking.TrendingScores
+	matched := r.filter(func(d *document) bool {
+		if _, ok := trending[d.ID]; trending != nil && !ok {
+			return false
+		}
+		return matchQuery(d, req.Query)
+	})
+
+	// 트렌딩 점수가 있으면 점수 순으로 정렬한다
+	score := func(id int64) float64 {
+		if s, ok := trending[id]; ok {
+			return s
+		}
+		return memoryScore
+	}
+	if trending != nil {
+		sort.SliceStable(matched, func(i, j int) bool {
+			return score(matched[i].doc.ID) > score(matched[j].doc.ID)
+		})
+	}
+
+	from := req.From
+	if req.After != nil {
+		from = 0
+		for from < len(matched) && req.After.IsBefore(score(matched[from].doc.ID), matched[from].doc.ID) {
+			from++
+		}
+	}
+
+	result := r.paginate(matched, from, req.Size)
+	for i := range result.Hits {
+		hitScore := score(result.Hits[i].ID)
+		result.Hits[i].Score = &hitScore
+		result.Hits[i].ModelArtifact = req.Ranking.ModelArtifact
+		if req.Highlight && req.Query.Keyword != "" {
+			result.Hits[i].Highlights = r.highlight(result.Hits[i].ID, req.Query.Keyword)
+		}
+	}
+	return result, nil
+}
+
+// SearchByIDs func definition
+func (r *MemoryRepository) SearchByIDs(campaignIDs ...int64) (*contentcampaign.SearchResult, error) {
+	ids := make(map[int64]bool)
+	for _, id := range campaignIDs {
+		ids[id] = true
+	}
+
+	matched := r.filter(func(d *document) bool {
+		return ids[d.ID]
+	})
+	return r.paginate(matched, 0, defaultSearchSize), nil
+}
+
+// highlight returns title and description of the campaign with the terms of the keyword wrapped as elasticsearch does
+func (r *MemoryRepository) highlight(campaignID int64, keyword string) map[string][]string {
+	r.mu.RLock()
+	d := r.documents[campaignID].doc
+	r.mu.RUnlock()
+
+	highlights := make(map[string][]string)
+	for field, value := range map[string]string{"title": d.Title, "description": d.Description} {
+		if highlighted, ok := highlightTerms(value, keywordTerms(keyword)); ok {
+			highlights[field] = []string{highlighted}
+		}
+	}
+	return highlights
+}
+
+func (r *MemoryRepository) filter(match func(d *document) bool) []memoryDocument {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]memoryDocument, 0)
+	for _, md := range r.documents {
+		if match(&md.doc) {
+			matched = append(matched, md)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].doc.ID > matched[j].doc.ID
+	})
+	return matched
+}
+
+func (r *MemoryRepository) paginate(matched []memoryDocument, from int, size int) *contentcampaign.SearchResult {
+	result := &contentcampaign.SearchResult{
+		Hits:  make([]contentcampaign.SearchHit, 0),
+		Total: len(matched),
+	}
+
+	for i := from; i < len(matched) && i < from+size; i++ {
+		result.Hits = append(result.Hits, contentcampaign.SearchHit{
+			ID:           matched[i].doc.ID,
+			Source:       matched[i].source,
+			ScoreFactors: make(map[string]float64),
+		})
+	}
+	return result
+}
+
+// Explain func definition
+func (r *MemoryRepository) Explain(campaignID int64, req contentcampaign.SearchRequest) (*contentcampaign.Explanation, error) {
+	r.mu.RLock()
+	md, ok := r.documents[campaignID]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, contentcampaign.CampaignNotIndexedError{CampaignID: campaignID}
+	}
+
+	score := memoryScore
+	explanation := &contentcampaign.Explanation{
+		CampaignID: campaignID,
+		Hit: contentcampaign.SearchHit{
+			ID:            campaignID,
+			Source:        md.source,
+			Score:         &score,
+			ScoreFactors:  make(map[string]float64),
+			ModelArtifact: req.Ranking.ModelArtifact,
+		},
+	}
+	for _, c := range matchClauses(&md.doc, req.Query) {
+		explanation.Clauses = append(explanation.Clauses, contentcampaign.ClauseResult{Clause: c.clause, Passed: c.match()})
+	}
+	return explanation, nil
+}
+
+func matchQuery(d *document, q contentcampaign.SearchQuery) bool {
+	for _, c := range matchClauses(d, q) {
+		if !c.match() {
+			return false
+		}
+	}
+	return true
+}
+
+type clauseMatch struct {
+	clause contentcampaign.TargetingClause
+	match  func() bool
+}
+
+// matchClauses returns the clauses applied by the query as ESRepository.buildClauseQueries
+func matchClauses(d *document, q contentcampaign.SearchQuery) []clauseMatch {
+	unitID := strconv.FormatInt(q.UnitID, 10)
+	appID := strconv.FormatInt(q.AppID, 10)
+	orgID := strconv.FormatInt(q.OrganizationID, 10)
+
+	clauses := []clauseMatch{
+		{contentcampaign.ClauseEnabled, func() bool { return d.IsEnabled }},
+		{contentcampaign.ClauseStartDate, func() bool {
+			return dateMatches(d.StartDate, func(t time.Time) bool { return !t.After(q.StartsBy) })
+		}},
+		{contentcampaign.ClauseEndDate, func() bool {
+			return dateMatches(d.EndDate, func(t time.Time) bool { return t.After(q.EndsAfter) })
+		}},
+		{contentcampaign.ClauseCountry, func() bool { return keywordIn(d.Country, globString, q.Country) }},
+		{contentcampaign.ClauseGender, func() bool { return keywordIn(d.TargetGender, globString, q.Gender) }},
+		{contentcampaign.ClauseUnit, func() bool {
+			return (hasToken(d.TargetUnit, unitID) || (q.IncludeGlobUnit && hasToken(d.TargetUnit, globString))) && !hasToken(d.DetargetUnit, unitID)
+		}},
+		{contentcampaign.ClauseApp, func() bool { return hasToken(d.TargetAppID, appID, globString) && !hasToken(d.DetargetAppID, appID) }},
+		{contentcampaign.ClauseOrganization, func() bool { return hasToken(d.TargetOrg, orgID, globString) && !hasToken(d.DetargetOrg, orgID) }},
+		{contentcampaign.ClauseAge, func() bool {
+			return rangeMatches(d.TargetAgeMin, d.TargetAgeMax, nullShortMin, nullShortMax, q.Age)
+		}},
+		{contentcampaign.ClauseSdk, func() bool {
+			return rangeMatches(d.TargetSdkMin, d.TargetSdkMax, nullIntMin, nullIntMax, q.SdkVersion)
+		}},
+		{contentcampaign.ClauseRegisteredDays, func() bool {
+			return rangeMatches(d.RegisteredDaysMin, d.RegisteredDaysMax, nullShortMin, nullShortMax, q.RegisteredDays)
+		}},
+		{contentcampaign.ClauseWeekSlot, func() bool { return matchWeekSlot(d, q.LocalTime) }},
+		{contentcampaign.ClauseCreativeType, func() bool { return hasToken(d.CreativeTypes, q.CreativeType) }},
+		{contentcampaign.ClauseCustomTargets, func() bool { return matchCustomTargets(d, q.CustomTargets) }},
+	}
+	add := func(clause contentcampaign.TargetingClause, match func() bool) {
+		clauses = append(clauses, clauseMatch{clause: clause, match: match})
+	}
+
+	if q.OsVersion != 0 {
+		add(contentcampaign.ClauseOs, func() bool { return matchOsVersion(d, q.OsVersion) })
+	}
+	if !q.InBatteryOptimization {
+		add(contentcampaign.ClauseBatteryOptimization, func() bool {
+			return d.TargetBatteryOptimization != nil && !*d.TargetBatteryOptimization
+		})
+	}
+	if len(q.Languages) > 0 {
+		add(contentcampaign.ClauseLanguage, func() bool { return keywordIn(d.TargetLanguage, q.Languages...) })
+	}
+	if q.TargetLanguages != nil {
+		add(contentcampaign.ClauseTargetLanguage, func() bool {
+			return keywordIn(d.TargetLanguage, append([]string{globString}, q.TargetLanguages...)...)
+		})
+	}
+	if len(q.Categories) > 0 {
+		add(contentcampaign.ClauseCategories, func() bool { return hasToken(d.Categories, q.Categories...) })
+	}
+	if len(q.ExcludedCategories) > 0 {
+		add(contentcampaign.ClauseExcludedCategories, func() bool { return !hasToken(d.Categories, q.ExcludedCategories...) })
+	}
+	if len(q.ChannelIDs) > 0 {
+		add(contentcampaign.ClauseChannels, func() bool { return int64In(d.ChannelID, q.ChannelIDs) })
+	}
+	if len(q.ExcludedChannelIDs) > 0 {
+		add(contentcampaign.ClauseExcludedChannels, func() bool { return !int64In(d.ChannelID, q.ExcludedChannelIDs) })
+	}
+	if len(q.ExcludedProviderIDs) > 0 {
+		add(contentcampaign.ClauseExcludedProviders, func() bool { return !int64In(d.ProviderID, q.ExcludedProviderIDs) })
+	}
+	if len(q.ExcludedIDs) > 0 {
+		add(contentcampaign.ClauseExcludedIDs, func() bool { return !int64In(&d.ID, q.ExcludedIDs) })
+	}
+	if q.UpdatedBefore != nil {
+		add(contentcampaign.ClauseUpdatedAt, func() bool {
+			return dateMatches(d.UpdatedAt, func(t time.Time) bool { return !t.After(*q.UpdatedBefore) })
+		})
+	}
+	if q.ColdStart != nil {
+		add(contentcampaign.ClauseColdStart, func() bool { return matchColdStart(d, *q.ColdStart) })
+	}
+	if q.Carrier != nil {
+		add(contentcampaign.ClauseCarrier, func() bool { return hasToken(d.TargetCarrier, globString, *q.Carrier) })
+	}
+	if q.Region != nil {
+		add(contentcampaign.ClauseRegion, func() bool { return matchRegion(d, *q.Region) })
+	}
+	if q.MinImageRatio != nil {
+		add(contentcampaign.ClauseImageRatio, func() bool { return d.ImageRatio == nil || *d.ImageRatio >= *q.MinImageRatio })
+	}
+	if q.Packages != nil {
+		add(contentcampaign.ClausePackages, func() bool { return matchPackages(d, q.Packages) })
+	}
+	if len(q.LandingTypes) > 0 {
+		add(contentcampaign.ClauseLandingTypes, func() bool { return intIn(d.LandingType, q.LandingTypes) })
+	}
+	if q.FrequencyCap != nil {
+		add(contentcampaign.ClauseFrequencyCap, func() bool { return matchFrequencyCap(d, *q.FrequencyCap) })
+	}
+	if len(q.Statuses) > 0 || len(q.RelatedStatuses) > 0 {
+		add(contentcampaign.ClauseStatus, func() bool { return matchStatus(d, q.Statuses, q.RelatedStatuses) })
+	}
+	if q.Keyword != "" {
+		add(contentcampaign.ClauseKeyword, func() bool { return matchKeyword(d, q.Keyword) })
+	}
+	if q.AnyDevice {
+		clauses = withoutClauses(clauses, contentcampaign.ClauseGender, contentcampaign.ClauseAge)
+	}
+	return clauses
+}
+
+// withoutClauses returns the clauses except the excluded ones keeping the order
+func withoutClauses(clauses []clauseMatch, excluded ...contentcampaign.TargetingClause) []clauseMatch {
+	result := make([]clauseMatch, 0, len(clauses))
+	for _, c := range clauses {
+		isExcluded := false
+		for _, clause := range excluded {
+			isExcluded = isExcluded || c.clause == clause
+		}
+		if !isExcluded {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// tokens splits the value as the comma analyzer of the index mapping
+func tokens(value string) []string {
+	result := make([]string, 0)
+	for _, token := range strings.Split(value, ",") {
+		if token != "" {
+			result = append(result, token)
+		}
+	}
+	return result
+}
+
+func hasToken(value string, terms ...string) bool {
+	for _, token := range tokens(value) {
+		for _, term := range terms {
+			if token == term {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func keywordIn(value *string, terms ...string) bool {
+	if value == nil {
+		return false
+	}
+	for _, term := range terms {
+		if *value == term {
+			return true
+		}
+	}
+	return false
+}
+
+func int64In(value *int64, items []int64) bool {
+	if value == nil {
+		return false
+	}
+	for _, item := range items {
+		if *value == item {
+			return true
+		}
+	}
+	return false
+}
+
+func intIn(value *int, items []int) bool {
+	if value == nil {
+		return false
+	}
+	for _, item := range items {
+		if *value == item {
+			return true
+		}
+	}
+	return false
+}
+
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func dateMatches(value *string, match func(t time.Time) bool) bool {
+	if value == nil {
+		return false
+	}
+	t, ok := parseDate(*value)
+	return ok && match(t)
+}
+
+func valueOrNull(value *int, null int) int {
+	if value == nil {
+		return null
+	}
+	return *value
+}
+
+// rangeMatches matches campaigns without targeting only if value is 0
+func rangeMatches(min *int, max *int, nullMin int, nullMax int, value int) bool {
+	minValue, maxValue := valueOrNull(min, nullMin), valueOrNull(max, nullMax)
+	if value == 0 {
+		return minValue == nullMin && maxValue == nullMax
+	}
+	return minValue <= value && value <= maxValue
+}
+
+func matchWeekSlot(d *document, localTime *time.Time) bool {
+	if localTime != nil {
+		return hasToken(d.WeekSlot, globString, contentcampaign.WeekSlot(*localTime))
+	}
+	return hasToken(d.WeekSlot, globString)
+}
+
+func matchCustomTargets(d *document, targets [3]string) bool {
+	values := [3]string{d.CustomTarget1, d.CustomTarget2, d.CustomTarget3}
+	for i, target := range targets {
+		if !hasToken(values[i], append(tokens(target), globString)...) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchOsVersion(d *document, osVersion int) bool {
+	if osVersion == 0 {
+		return true
+	}
+	return valueOrNull(d.TargetOsMin, nullIntMax) <= osVersion && osVersion <= valueOrNull(d.TargetOsMax, nullIntMax)
+}
+
+func matchPackages(d *document, packages []string) bool {
+	targets := append([]string{globString}, packages...)
+	return hasToken(d.TargetApp, targets...) && !hasToken(d.DetargetApp, packages...)
+}
+
+func matchRegion(d *document, region string) bool {
+	regionState := ""
+	if region != "" {
+		regionState = strings.Fields(region)[0]
+	}
+	return hasToken(d.TargetRegion, globString, regionState, region)
+}
+
+// matchFrequencyCap has the same semantics as es/script/filter/filter.painless
+func matchFrequencyCap(d *document, frequencyCap contentcampaign.FrequencyCap) bool {
+	id := strconv.FormatInt(d.ID, 10)
+	notExceeded := func(limit *int, counts map[string]int) bool {
+		if limit == nil || *limit == 0 {
+			return true
+		}
+		return counts[id] < *limit
+	}
+	return notExceeded(d.Ipu, frequencyCap.CountsForHour) && notExceeded(d.Dipu, frequencyCap.CountsForDay)
+}
+
+func matchColdStart(d *document, filter contentcampaign.ColdStartFilter) bool {
+	return d.Impressions != nil && *d.Impressions < filter.MaxImpressions ||
+		dateMatches(d.CreatedAt, func(t time.Time) bool { return !t.Before(filter.CreatedAfter) })
+}
+
+func matchStatus(d *document, statuses []contentcampaign.Status, relatedStatuses []contentcampaign.Status) bool {
+	if len(statuses) == 0 && len(relatedStatuses) == 0 {
+		return true
+	}
+	if d.Status == nil {
+		return false
+	}
+	for _, status := range statuses {
+		if *d.Status == int(status) {
+			return true
+		}
+	}
+	if d.Related != nil {
+		for _, status := range relatedStatuses {
+			if *d.Status == int(status) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keywordTerms splits the keyword into lower cased terms
+func keywordTerms(keyword string) []string {
+	return strings.Fields(strings.ToLower(keyword))
+}
+
+// matchKeyword returns true if one of title, description and tags contains every term of the keyword
+func matchKeyword(d *document, keyword string) bool {
+	terms := keywordTerms(keyword)
+	for _, value := range []string{d.Title, d.Description, d.Tags} {
+		value = strings.ToLower(value)
+		matched := len(terms) > 0
+		for _, term := range terms {
+			if !strings.Contains(value, term) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// highlightTerms wraps the occurrences of the terms in the value and escapes the rest as the html encoder of elasticsearch.
+// false is returned if none of them occurs
+func highlightTerms(value string, terms []string) (string, bool) {
+	lower := strings.ToLower(value)
+	if len(lower) != len(value) {
+		// 소문자 변환으로 길이가 바뀌면 위치가 맞지 않으므로 대소문자를 구분한다
+		lower = value
+	}
+	marked := make([]bool, len(value))
+	for _, term := range terms {
+		for from := 0; from < len(lower); {
+			i := strings.Index(lower[from:], term)
+			if i < 0 {
+				break
+			}
+			for j := from + i; j < from+i+len(term); j++ {
+				marked[j] = true
+			}
+			from += i + len(term)
+		}
+	}
+
+	var b strings.Builder
+	highlighted := false
+	for i := 0; i < len(value); i++ {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			b.WriteString(highlightPreTag)
+			highlighted = true
+		}
+		b.WriteString(html.EscapeString(value[i : i+1]))
+		if marked[i] && (i == len(value)-1 || !marked[i+1]) {
+			b.WriteString(highlightPostTag)
+		}
+	}
+	return b.String(), highlighted
+}
+
+// NewMemory returns the search repository keeping documents in memory
+func NewMemory() *MemoryRepository {
+	return &MemoryRepository{documents: make(map[int64]memoryDocument)}
+}
+
+var _ contentcampaign.SearchRepository = &MemoryRepository{}
@@ -0,0 +1,438 @@
+package searchrepo_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/searchrepo"
+	"github.com/stretchr/testify/suite"
+)
+
+func (ts *MemoryRepoTestSuite) Test_Search() {
+	ts.index(1, nil)
+	ts.index(2, nil)
+	ts.index(3, map[string]interface{}{"is_enabled": false})
+
+	result, err := ts.repo.Search(ts.request(ts.query()))
+
+	ts.NoError(err)
+	ts.Equal(2, result.Total)
+	ts.Equal([]int64{2, 1}, ts.hitIDs(result))
+	ts.Equal("v1", result.Hits[0].ModelArtifact)
+
+	var source map[string]interface{}
+	ts.NoError(json.Unmarshal(result.Hits[0].Source, &source))
+	ts.Equal(float64(2), source["id"])
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_Pagination() {
+	for id := int64(1); id <= 5; id++ {
+		ts.index(id, nil)
+	}
+
+	req := ts.request(ts.query())
+	req.From = 2
+	req.Size = 2
+	result, err := ts.repo.Search(req)
+
+	ts.NoError(err)
+	ts.Equal(5, result.Total)
+	ts.Equal([]int64{3, 2}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_After() {
+	for id := int64(1); id <= 5; id++ {
+		ts.index(id, nil)
+	}
+
+	req := ts.request(ts.query())
+	req.Size = 2
+	first, err := ts.repo.Search(req)
+	ts.NoError(err)
+	ts.Equal([]int64{5, 4}, ts.hitIDs(first))
+
+	req.After = contentcampaign.NewSearchCursor(first.Hits[len(first.Hits)-1])
+	req.From = 100 // After가 있으면 무시된다
+	second, err := ts.repo.Search(req)
+
+	ts.NoError(err)
+	ts.Equal(5, second.Total)
+	ts.Equal([]int64{3, 2}, ts.hitIDs(second))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_Period() {
+	ts.index(1, map[string]interface{}{"start_date": ts.now.Add(time.Hour).Format(time.RFC3339)})
+	ts.index(2, map[string]interface{}{"end_date": ts.now.Format(time.RFC3339)})
+	ts.index(3, nil)
+
+	result, err := ts.repo.Search(ts.request(ts.query()))
+
+	ts.NoError(err)
+	ts.Equal([]int64{3}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_Unit() {
+	ts.index(1, map[string]interface{}{"target_unit": "100,200"})
+	ts.index(2, map[string]interface{}{"target_unit": "200"})
+	ts.index(3, map[string]interface{}{"detarget_unit": "100"})
+	ts.index(4, nil)
+
+	query := ts.query()
+	result, err := ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{4, 1}, ts.hitIDs(result))
+
+	query.IncludeGlobUnit = false
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{1}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_Age() {
+	ts.index(1, map[string]interface{}{"target_age_min": 20, "target_age_max": 29})
+	ts.index(2, nil)
+
+	query := ts.query()
+	result, err := ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{2}, ts.hitIDs(result))
+
+	query.Age = 25
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{2, 1}, ts.hitIDs(result))
+
+	query.Age = 30
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{2}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_Packages() {
+	ts.index(1, map[string]interface{}{"target_app": "com.buzzvil.a"})
+	ts.index(2, map[string]interface{}{"detarget_app": "com.buzzvil.b"})
+	ts.index(3, nil)
+
+	query := ts.query()
+	result, err := ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3, 2, 1}, ts.hitIDs(result))
+
+	query.Packages = []string{"com.buzzvil.b"}
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3}, ts.hitIDs(result))
+
+	query.Packages = []string{"com.buzzvil.a"}
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3, 2, 1}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_FrequencyCap() {
+	ts.index(1, map[string]interface{}{"ipu": 2})
+	ts.index(2, map[string]interface{}{"dipu": 3})
+	ts.index(3, map[string]interface{}{"ipu": 0})
+
+	query := ts.query()
+	query.FrequencyCap = &contentcampaign.FrequencyCap{
+		CountsForHour: map[string]int{"1": 2, "3": 10},
+		CountsForDay:  map[string]int{"2": 2},
+	}
+	result, err := ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3, 2}, ts.hitIDs(result))
+
+	query.FrequencyCap.CountsForDay["2"] = 3
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_Trending() {
+	for id := int64(1); id <= 4; id++ {
+		ts.index(id, nil)
+	}
+	ts.index(5, map[string]interface{}{"is_enabled": false})
+
+	req := ts.request(ts.query())
+	req.Ranking.TrendingScores = map[int64]float64{1: 3.5, 2: 0.5, 3: 1.5, 5: 9}
+	req.Size = 2
+	first, err := ts.repo.Search(req)
+
+	// 점수가 없는 4번과 타게팅되지 않는 5번은 조회되지 않는다
+	ts.NoError(err)
+	ts.Equal(3, first.Total)
+	ts.Equal([]int64{1, 3}, ts.hitIDs(first))
+	ts.Equal(3.5, *first.Hits[0].Score)
+
+	req.After = contentcampaign.NewSearchCursor(first.Hits[1])
+	second, err := ts.repo.Search(req)
+	ts.NoError(err)
+	ts.Equal([]int64{2}, ts.hitIDs(second))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_Keyword() {
+	ts.index(1, map[string]interface{}{"title": "Baseball Season Opens", "description": "The new season of baseball"})
+	ts.index(2, map[string]interface{}{"title": "Weather", "tags": "baseball,sports"})
+	ts.index(3, map[string]interface{}{"title": "Season greetings", "description": "baseball"})
+	ts.index(4, map[string]interface{}{"title": "Basketball"})
+
+	query := ts.query()
+	query.Keyword = "baseball season"
+	req := ts.request(query)
+	req.Highlight = true
+	result, err := ts.repo.Search(req)
+
+	// 한 필드에 모든 단어가 있어야 한다
+	ts.NoError(err)
+	ts.Equal([]int64{1}, ts.hitIDs(result))
+	ts.Equal(map[string][]string{
+		"title":       {"<em>Baseball</em> <em>Season</em> Opens"},
+		"description": {"The new <em>season</em> of <em>baseball</em>"},
+	}, result.Hits[0].Highlights)
+
+	query.Keyword = "baseball"
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3, 2, 1}, ts.hitIDs(result))
+	ts.Nil(result.Hits[0].Highlights)
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_KeywordHighlightEscaped() {
+	ts.index(1, map[string]interface{}{"title": "<b>야구</b> & baseball"})
+
+	query := ts.query()
+	query.Keyword = "baseball"
+	req := ts.request(query)
+	req.Highlight = true
+	result, err := ts.repo.Search(req)
+
+	ts.NoError(err)
+	ts.Equal(map[string][]string{
+		"title": {"&lt;b&gt;야구&lt;/b&gt; &amp; <em>baseball</em>"},
+	}, result.Hits[0].Highlights)
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_Status() {
+	ts.index(1, map[string]interface{}{"status": int(contentcampaign.StatusFeedOnly)})
+	ts.index(2, map[string]interface{}{"status": int(contentcampaign.StatusFeedOnly), "related": 1})
+	ts.index(3, nil)
+
+	query := ts.query()
+	query.Statuses = contentcampaign.StatusesForLockscreen
+	result, err := ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3}, ts.hitIDs(result))
+
+	query.RelatedStatuses = contentcampaign.StatusesForFeed
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3, 2}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_Categories() {
+	ts.index(1, map[string]interface{}{"categories": "news,sports", "channel_id": 10})
+	ts.index(2, map[string]interface{}{"categories": "fun", "channel_id": 20})
+	ts.index(3, map[string]interface{}{"categories": "news", "channel_id": 30})
+
+	query := ts.query()
+	query.Categories = []string{"news"}
+	query.ExcludedCategories = []string{"sports"}
+	result, err := ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3}, ts.hitIDs(result))
+
+	query = ts.query()
+	query.ExcludedChannelIDs = []int64{20}
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3, 1}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_TargetLanguages() {
+	ts.index(1, map[string]interface{}{"target_language": "__GLOB__"})
+	ts.index(2, map[string]interface{}{"target_language": "ko"})
+	ts.index(3, map[string]interface{}{"target_language": "zh"})
+
+	query := ts.query()
+	query.TargetLanguages = []string{"zh-TW", "zh"}
+	result, err := ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3, 1}, ts.hitIDs(result))
+
+	query.TargetLanguages = nil
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3, 2, 1}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_AnyDevice() {
+	ts.index(1, nil)
+	ts.index(2, map[string]interface{}{"target_gender": "F"})
+	ts.index(3, map[string]interface{}{"target_age_min": 20, "target_age_max": 29})
+
+	query := ts.query()
+	query.Gender = "M"
+	result, err := ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{1}, ts.hitIDs(result))
+
+	query.AnyDevice = true
+	result, err = ts.repo.Search(ts.request(query))
+	ts.NoError(err)
+	ts.Equal([]int64{3, 2, 1}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_ExcludedIDs() {
+	ts.index(1, nil)
+	ts.index(2, nil)
+
+	query := ts.query()
+	query.ExcludedIDs = []int64{2}
+	result, err := ts.repo.Search(ts.request(query))
+
+	ts.NoError(err)
+	ts.Equal([]int64{1}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Search_ColdStart() {
+	ts.index(1, map[string]interface{}{"created_at": ts.now.Add(-48 * time.Hour).Format(time.RFC3339), "impressions": 5000})
+	ts.index(2, map[string]interface{}{"created_at": ts.now.Add(-time.Hour).Format(time.RFC3339), "impressions": 5000})
+	ts.index(3, map[string]interface{}{"created_at": ts.now.Add(-48 * time.Hour).Format(time.RFC3339), "impressions": 10})
+
+	query := ts.query()
+	query.ColdStart = &contentcampaign.ColdStartFilter{CreatedAfter: ts.now.Add(-24 * time.Hour), MaxImpressions: 1000}
+	result, err := ts.repo.Search(ts.request(query))
+
+	ts.NoError(err)
+	ts.Equal([]int64{3, 2}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_SearchByIDs() {
+	ts.index(1, nil)
+	ts.index(2, map[string]interface{}{"is_enabled": false})
+	ts.index(3, nil)
+
+	result, err := ts.repo.SearchByIDs(1, 2, 4)
+
+	ts.NoError(err)
+	ts.Equal(2, result.Total)
+	ts.Equal([]int64{2, 1}, ts.hitIDs(result))
+}
+
+func (ts *MemoryRepoTestSuite) Test_Delete() {
+	ts.index(1, nil)
+	ts.repo.Delete(1)
+
+	result, err := ts.repo.SearchByIDs(1)
+
+	ts.NoError(err)
+	ts.Equal(0, result.Total)
+}
+
+func (ts *MemoryRepoTestSuite) Test_Explain() {
+	ts.index(1, map[string]interface{}{"country": "JP", "detarget_unit": "100", "target_age_min": 20, "target_age_max": 29})
+
+	query := ts.query()
+	query.Age = 30
+	query.Categories = []string{"news"}
+	explanation, err := ts.repo.Explain(1, ts.request(query))
+
+	ts.NoError(err)
+	ts.False(explanation.Matched())
+	ts.Equal(int64(1), explanation.Hit.ID)
+	ts.NotNil(explanation.Hit.Score)
+
+	failed := make([]contentcampaign.TargetingClause, 0)
+	for _, result := range explanation.Clauses {
+		if !result.Passed {
+			failed = append(failed, result.Clause)
+		}
+	}
+	ts.Equal([]contentcampaign.TargetingClause{contentcampaign.ClauseCountry, contentcampaign.ClauseUnit, contentcampaign.ClauseAge}, failed)
+	ts.Contains(explanation.Clauses, contentcampaign.ClauseResult{Clause: contentcampaign.ClauseCategories, Passed: true})
+	ts.NotContains(explanation.Clauses, contentcampaign.ClauseResult{Clause: contentcampaign.ClausePackages, Passed: true})
+}
+
+func (ts *MemoryRepoTestSuite) Test_Explain_NotIndexed() {
+	_, err := ts.repo.Explain(1, ts.request(ts.query()))
+
+	ts.Equal(contentcampaign.CampaignNotIndexedError{CampaignID: 1}, err)
+}
+
+func TestMemoryRepoSuite(t *testing.T) {
+	suite.Run(t, new(MemoryRepoTestSuite))
+}
+
+type MemoryRepoTestSuite struct {
+	suite.Suite
+	repo *searchrepo.MemoryRepository
+	now  time.Time
+}
+
+func (ts *MemoryRepoTestSuite) SetupTest() {
+	ts.repo = searchrepo.NewMemory()
+	ts.now = time.Now().Truncate(time.Hour)
+}
+
+func (ts *MemoryRepoTestSuite) query() contentcampaign.SearchQuery {
+	return contentcampaign.SearchQuery{
+		StartsBy:              ts.now,
+		EndsAfter:             ts.now,
+		UnitID:                100,
+		IncludeGlobUnit:       true,
+		AppID:                 1000,
+		OrganizationID:        1,
+		Country:               "KR",
+		Gender:                "M",
+		SdkVersion:            3000,
+		CreativeType:          "R",
+		InBatteryOptimization: true,
+	}
+}
+
+func (ts *MemoryRepoTestSuite) request(query contentcampaign.SearchQuery) contentcampaign.SearchRequest {
+	return contentcampaign.SearchRequest{
+		Query:   query,
+		Ranking: contentcampaign.SearchRanking{ModelArtifact: "v1"},
+		Size:    10,
+	}
+}
+
+// index indexes a document targeting all with the fields overridden
+func (ts *MemoryRepoTestSuite) index(id int64, fields map[string]interface{}) {
+	doc := map[string]interface{}{
+		"id":             id,
+		"is_enabled":     true,
+		"start_date":     ts.now.Add(-time.Hour).Format(time.RFC3339),
+		"end_date":       ts.now.Add(time.Hour).Format(time.RFC3339),
+		"country":        "KR",
+		"target_gender":  "__GLOB__",
+		"categories":     "news",
+		"creative_types": "R,A",
+		"week_slot":      "__GLOB__",
+		"target_unit":    "__GLOB__",
+		"target_app_id":  "__GLOB__",
+		"target_org":     "__GLOB__",
+		"target_app":     "__GLOB__",
+		"status":         int(contentcampaign.StatusManual),
+	}
+	for _, key := range []string{"custom_target_1", "custom_target_2", "custom_target_3"} {
+		doc[key] = "__GLOB__"
+	}
+	for key, value := range fields {
+		doc[key] = value
+	}
+	ts.NoError(ts.repo.Index(doc))
+}
+
+func (ts *MemoryRepoTestSuite) hitIDs(result *contentcampaign.SearchResult) []int64 {
+	ids := make([]int64, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
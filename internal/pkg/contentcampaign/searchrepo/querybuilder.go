@@ -0,0 +1,359 @@
+package searchrepo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"gopkg.in/olivere/elastic.v5"
+)
+
+const (
+	esTimeFormat = "2006-01-02T15:04:05-07:00"
+
+	// globString is indexed for the targeting fields which targets all
+	globString = "__GLOB__"
+	// null values of the ranged targeting fields. refer to the index mapping
+	nullShortMin = -32768
+	nullShortMax = 32767
+	nullIntMin   = -2147483648
+	nullIntMax   = 2147483647
+)
+
+// keywordFields are the fields matched by SearchQuery.Keyword with their boosts
+var keywordFields = []string{"title^3", "description", "tags"}
+
+type queryBuilder struct {
+	queries []elastic.Query
+}
+
+func newQueryBuilder() *queryBuilder {
+	qb := queryBuilder{
+		queries: make([]elastic.Query, 0),
+	}
+	qb.queries = append(qb.queries, elastic.NewTermQuery("is_enabled", true))
+	return &qb
+}
+
+// newClauseBuilder returns the query builder without the is_enabled filter to build the queries of a targeting clause
+func newClauseBuilder() *queryBuilder {
+	return &queryBuilder{queries: make([]elastic.Query, 0)}
+}
+
+func (qb *queryBuilder) build() []elastic.Query {
+	return qb.queries
+}
+
+func (qb *queryBuilder) withStartTime(lte time.Time) *queryBuilder {
+	qb.queries = append(qb.queries, elastic.NewRangeQuery("start_date").Lte(lte.Format(esTimeFormat)))
+	return qb
+}
+
+func (qb *queryBuilder) withEndTime(gt time.Time) *queryBuilder {
+	qb.queries = append(qb.queries, elastic.NewRangeQuery("end_date").Gt(gt.Format(esTimeFormat)))
+	return qb
+}
+
+func (qb *queryBuilder) withCountry(country string) *queryBuilder {
+	qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(
+		elastic.NewTermQuery("country", globString),
+		elastic.NewTermQuery("country", country),
+	))
+	return qb
+}
+
+func (qb *queryBuilder) withGender(gender string) *queryBuilder {
+	qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(
+		elastic.NewTermQuery("target_gender", globString),
+		elastic.NewTermQuery("target_gender", gender),
+	))
+	return qb
+}
+
+func (qb *queryBuilder) withCarrier(carrier string) *queryBuilder {
+	qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(
+		elastic.NewTermQuery("target_carrier", globString),
+		elastic.NewTermQuery("target_carrier", carrier),
+	))
+	return qb
+}
+
+func (qb *queryBuilder) withUnit(unitID int64, withGlob bool) *queryBuilder {
+	unitQuery := elastic.NewBoolQuery().
+		Should(elastic.NewTermQuery("target_unit", strconv.FormatInt(unitID, 10))).
+		MustNot(elastic.NewTermQuery("detarget_unit", strconv.FormatInt(unitID, 10)))
+	if withGlob {
+		unitQuery.Should(elastic.NewTermQuery("target_unit", globString))
+	}
+	qb.queries = append(qb.queries, unitQuery)
+	return qb
+}
+
+func (qb *queryBuilder) withAppID(appID int64) *queryBuilder {
+	appIDQuery := elastic.NewBoolQuery().
+		Should(elastic.NewTermQuery("target_app_id", strconv.FormatInt(appID, 10))).
+		Should(elastic.NewTermQuery("target_app_id", globString)).
+		MustNot(elastic.NewTermQuery("detarget_app_id", strconv.FormatInt(appID, 10)))
+
+	qb.queries = append(qb.queries, appIDQuery)
+	return qb
+}
+
+func (qb *queryBuilder) withOrgID(orgID int64) *queryBuilder {
+	orgQuery := elastic.NewBoolQuery().
+		Should(elastic.NewTermQuery("target_org", strconv.FormatInt(orgID, 10))).
+		Should(elastic.NewTermQuery("target_org", globString)).
+		MustNot(elastic.NewTermQuery("detarget_org", strconv.FormatInt(orgID, 10)))
+
+	qb.queries = append(qb.queries, orgQuery)
+	return qb
+}
+
+func (qb *queryBuilder) withPackages(packages []string) *queryBuilder {
+	packageQuery := elastic.NewBoolQuery().
+		Should(elastic.NewTermsQuery("target_app", append(stringsToInterfaces(packages), globString)...)).
+		MustNot(elastic.NewTermsQuery("detarget_app", stringsToInterfaces(packages)...))
+	qb.queries = append(qb.queries, packageQuery)
+	return qb
+}
+
+// withStatus adds the status filter. relatedStatuses are allowed only for the campaigns having related campaigns
+func (qb *queryBuilder) withStatus(statuses []contentcampaign.Status, relatedStatuses []contentcampaign.Status) *queryBuilder {
+	statusQueries := make([]elastic.Query, 0)
+	for _, status := range statuses {
+		statusQueries = append(statusQueries, elastic.NewTermQuery("status", status))
+	}
+	for _, status := range relatedStatuses {
+		statusQueries = append(statusQueries, elastic.NewBoolQuery().Must(elastic.NewTermQuery("status", status), elastic.NewExistsQuery("related")))
+	}
+	if len(statusQueries) > 0 {
+		qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(statusQueries...))
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withAge(age int) *queryBuilder {
+	if age == 0 {
+		qb.queries = append(qb.queries, elastic.NewTermQuery("target_age_min", nullShortMin), elastic.NewTermQuery("target_age_max", nullShortMax))
+	} else {
+		qb.queries = append(qb.queries, elastic.NewRangeQuery("target_age_min").Lte(age), elastic.NewRangeQuery("target_age_max").Gte(age))
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withSdk(sdkVersion int) *queryBuilder {
+	if sdkVersion == 0 {
+		qb.queries = append(qb.queries, elastic.NewTermQuery("target_sdk_min", nullIntMin), elastic.NewTermQuery("target_sdk_max", nullIntMax))
+	} else {
+		qb.queries = append(qb.queries, elastic.NewRangeQuery("target_sdk_min").Lte(sdkVersion), elastic.NewRangeQuery("target_sdk_max").Gte(sdkVersion))
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withLanguage(languages ...string) *queryBuilder {
+	if len(languages) > 0 {
+		languageQueries := make([]elastic.Query, 0)
+		for _, lang := range languages {
+			languageQueries = append(languageQueries, elastic.NewTermQuery("target_language", lang))
+		}
+		qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(languageQueries...))
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withTargetLanguage(languages ...string) *queryBuilder {
+	languageQueries := []elastic.Query{elastic.NewTermQuery("target_language", globString)}
+	for _, lang := range languages {
+		languageQueries = append(languageQueries, elastic.NewTermQuery("target_language", lang))
+	}
+	qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(languageQueries...))
+	return qb
+}
+
+func (qb *queryBuilder) withKeyAndFilteredItems(key string, positive bool, items ...interface{}) *queryBuilder {
+	if len(items) > 0 {
+		var query elastic.Query
+		if positive {
+			positiveQueries := make([]elastic.Query, 0)
+			for _, item := range items {
+				positiveQueries = append(positiveQueries, elastic.NewTermQuery(key, item))
+			}
+			query = elastic.NewBoolQuery().Should(positiveQueries...)
+		} else {
+			query = elastic.NewBoolQuery().MustNot(elastic.NewTermsQuery(key, items...))
+		}
+
+		qb.queries = append(qb.queries, query)
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withCategories(positive bool, categories ...string) *queryBuilder {
+	return qb.withKeyAndFilteredItems("categories", positive, stringsToInterfaces(categories)...)
+}
+
+func (qb *queryBuilder) withChannels(positive bool, channelIDs ...int64) *queryBuilder {
+	return qb.withKeyAndFilteredItems("channel_id", positive, int64sToInterfaces(channelIDs)...)
+}
+
+func (qb *queryBuilder) withRegisteredDays(registeredDays int) *queryBuilder {
+	if registeredDays > 0 {
+		qb.queries = append(qb.queries, elastic.NewRangeQuery("registered_days_min").Lte(registeredDays), elastic.NewRangeQuery("registered_days_max").Gte(registeredDays))
+	} else {
+		qb.queries = append(qb.queries, elastic.NewTermQuery("registered_days_min", nullShortMin), elastic.NewTermQuery("registered_days_max", nullShortMax))
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withWeekSlot(localTime *time.Time) *queryBuilder {
+	weekSlotQueries := elastic.NewBoolQuery().Should(elastic.NewTermQuery("week_slot", globString))
+	if localTime != nil {
+		weekSlotQueries.Should(elastic.NewTermQuery("week_slot", contentcampaign.WeekSlot(*localTime)))
+	}
+	qb.queries = append(qb.queries, weekSlotQueries)
+	return qb
+}
+
+// TODO: Remove BoolMustNotExistsConstraint after 2018-08-01
+func (qb *queryBuilder) withImageRatio(lowerBound float64) *queryBuilder {
+	qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery("image_ratio")), elastic.NewRangeQuery("image_ratio").Gte(lowerBound)))
+	return qb
+}
+
+func (qb *queryBuilder) withCreativeTypes(creativeTypes string) *queryBuilder {
+	qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(elastic.NewTermQuery("creative_types", creativeTypes)))
+	return qb
+}
+
+func (qb *queryBuilder) withRegion(region string) *queryBuilder {
+	regionState := ""
+	if region != "" {
+		regionState = strings.Fields(region)[0]
+	}
+
+	qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(
+		elastic.NewTermQuery("target_region", globString),
+		elastic.NewTermQuery("target_region", regionState),
+		elastic.NewTermQuery("target_region", region),
+	))
+	return qb
+}
+
+func (qb *queryBuilder) withFilteredProviders(providerIDs ...int64) *queryBuilder {
+	if len(providerIDs) > 0 {
+		qb.queries = append(qb.queries, elastic.NewBoolQuery().MustNot(
+			elastic.NewTermsQuery("provider_id", int64sToInterfaces(providerIDs)...),
+		))
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withExcludedIDs(campaignIDs ...int64) *queryBuilder {
+	if len(campaignIDs) > 0 {
+		qb.queries = append(qb.queries, elastic.NewBoolQuery().MustNot(
+			elastic.NewTermsQuery("id", int64sToInterfaces(campaignIDs)...),
+		))
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withCustomTargets(targets [3]string) *queryBuilder {
+	for i, target := range targets {
+		key := customTargetKey(i)
+		switch len(target) {
+		case 0:
+			qb.queries = append(qb.queries, elastic.NewTermQuery(key, globString))
+		default:
+			customTargetQueries := []elastic.Query{elastic.NewTermQuery(key, globString)}
+			for _, t := range strings.Split(target, ",") {
+				if t != "" {
+					customTargetQueries = append(customTargetQueries, elastic.NewTermQuery(key, t))
+				}
+			}
+			qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(customTargetQueries...))
+		}
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withUpdatedTime(lteTime *time.Time) *queryBuilder {
+	if lteTime != nil {
+		qb.queries = append(qb.queries, elastic.NewRangeQuery("updated_at").Lte(lteTime.Format(esTimeFormat)))
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withColdStart(filter contentcampaign.ColdStartFilter) *queryBuilder {
+	qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(
+		elastic.NewRangeQuery("created_at").Gte(filter.CreatedAfter.Format(esTimeFormat)),
+		elastic.NewRangeQuery("impressions").Lt(filter.MaxImpressions),
+	))
+	return qb
+}
+
+func (qb *queryBuilder) withLandingTypes(landingTypes ...int) *queryBuilder {
+	landingTypeQueries := make([]elastic.Query, 0)
+	for _, landingType := range landingTypes {
+		landingTypeQueries = append(landingTypeQueries, elastic.NewTermQuery("landing_type", landingType))
+	}
+	qb.queries = append(qb.queries, elastic.NewBoolQuery().Should(landingTypeQueries...))
+	return qb
+}
+
+func (qb *queryBuilder) withOsVersion(osVersion int) *queryBuilder {
+	if osVersion != 0 {
+		qb.queries = append(qb.queries, elastic.NewRangeQuery("target_os_min").Lte(osVersion), elastic.NewRangeQuery("target_os_max").Gte(osVersion))
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withBatteryOptimization(isInBatteryOpts bool) *queryBuilder {
+	if !isInBatteryOpts {
+		qb.queries = append(qb.queries, elastic.NewTermQuery("target_battery_optimization", false))
+	}
+	return qb
+}
+
+func (qb *queryBuilder) withFrequencyCapping(filterScript string, frequencyCap contentcampaign.FrequencyCap) *queryBuilder {
+	params := map[string]interface{}{
+		"seenCampaignCountForDay":  frequencyCap.CountsForDay,
+		"seenCampaignCountForHour": frequencyCap.CountsForHour,
+	}
+
+	script := elastic.NewScript(filterScript)
+	script.Params(params)
+	qb.queries = append(qb.queries, elastic.NewScriptQuery(script))
+
+	return qb
+}
+
+// withKeyword matches the campaigns having every term of the keyword in one of title, description and tags.
+// The keyword is analyzed by the standard analyzer so that it isn't split into ngrams as title is indexed.
+func (qb *queryBuilder) withKeyword(keyword string) *queryBuilder {
+	if keyword != "" {
+		qb.queries = append(qb.queries, elastic.NewMultiMatchQuery(keyword, keywordFields...).
+			Type("best_fields").Operator("and").Analyzer("standard"))
+	}
+	return qb
+}
+
+func customTargetKey(index int) string {
+	return "custom_target_" + strconv.Itoa(index+1)
+}
+
+func stringsToInterfaces(strs []string) []interface{} {
+	inters := make([]interface{}, 0)
+	for _, str := range strs {
+		inters = append(inters, str)
+	}
+	return inters
+}
+
+func int64sToInterfaces(ints []int64) []interface{} {
+	inters := make([]interface{}, 0)
+	for _, i := range ints {
+		inters = append(inters, i)
+	}
+	return inters
+}
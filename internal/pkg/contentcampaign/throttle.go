@@ -0,0 +1,194 @@
+package contentcampaign
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+)
+
+// ThrottleAction type definition
+type ThrottleAction string
+
+// ThrottleAction constants
+const (
+	ThrottleNone   ThrottleAction = ""
+	ThrottleDemote ThrottleAction = "demote" // ranked lower by the score scripts and rerankers
+	ThrottlePause  ThrottleAction = "pause"  // excluded from searches
+)
+
+// CtrThrottlePolicy throttles the campaigns whose CTR over the window is below the thresholds once they have MinImpressions
+type CtrThrottlePolicy struct {
+	Window          time.Duration
+	MinImpressions  int64
+	DemoteCtr       float64 // 0: campaigns are not demoted
+	PauseCtr        float64 // 0: campaigns are not paused
+	RefreshInterval time.Duration
+}
+
+// DefaultCtrThrottlePolicy var definition
+var DefaultCtrThrottlePolicy = CtrThrottlePolicy{
+	Window:          time.Hour * 24,
+	MinImpressions:  1000,
+	DemoteCtr:       0.005,
+	PauseCtr:        0.001,
+	RefreshInterval: time.Minute * 10,
+}
+
+// Decide returns the action for the stat. Campaigns with IsCtrFilterOff are never throttled
+func (p CtrThrottlePolicy) Decide(stat Stat, isCtrFilterOff bool) ThrottleAction {
+	if isCtrFilterOff || stat.Impressions < p.MinImpressions {
+		return ThrottleNone
+	}
+
+	ctr := stat.CTR()
+	if ctr < p.PauseCtr {
+		return ThrottlePause
+	} else if ctr < p.DemoteCtr {
+		return ThrottleDemote
+	}
+	return ThrottleNone
+}
+
+// minThrottleRetryInterval is the first interval to retry a failed refresh. it is doubled on every failure up to RefreshInterval
+const minThrottleRetryInterval = time.Second * 30
+
+// CtrThrottle keeps the throttle actions of campaigns decided from the hourly stats.
+// Actions are refreshed in background every RefreshInterval so that searches don't wait for the stats.
+type CtrThrottle struct {
+	policy CtrThrottlePolicy
+	repo   Repository
+	logger StructuredLogger
+
+	mu          sync.RWMutex
+	actions     map[int64]ThrottleAction
+	refreshedAt time.Time
+	refreshing  bool
+	failedAt    time.Time
+	failures    int
+}
+
+// Apply excludes the paused campaigns from the query and sets the demoted campaigns into the ranking.
+// Actions are refreshed in background if they are older than RefreshInterval.
+func (t *CtrThrottle) Apply(req *SearchRequest) {
+	t.mu.Lock()
+	if !t.refreshing && t.shouldRefresh(time.Now()) {
+		t.refreshing = true
+		go t.refreshInBackground()
+	}
+	t.mu.Unlock()
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if len(t.actions) == 0 {
+		return
+	}
+
+	// 요청의 ExcludedIDs는 캐시된 slice일 수 있으므로 복사한 뒤 추가한다
+	excludedIDs := make([]int64, len(req.Query.ExcludedIDs), len(req.Query.ExcludedIDs)+len(t.actions))
+	copy(excludedIDs, req.Query.ExcludedIDs)
+	demotedIDs := make(map[string]bool, len(req.Ranking.DemotedIDs)+len(t.actions))
+	for id := range req.Ranking.DemotedIDs {
+		demotedIDs[id] = true
+	}
+	for campaignID, action := range t.actions {
+		switch action {
+		case ThrottlePause:
+			excludedIDs = append(excludedIDs, campaignID)
+		case ThrottleDemote:
+			demotedIDs[strconv.FormatInt(campaignID, 10)] = true
+		}
+	}
+	req.Query.ExcludedIDs = excludedIDs
+	req.Ranking.DemotedIDs = demotedIDs
+}
+
+// shouldRefresh returns true if the actions are older than RefreshInterval.
+// After failures, the refresh is retried with backoff so that a failing repository isn't queried on every search.
+func (t *CtrThrottle) shouldRefresh(now time.Time) bool {
+	if t.failures > 0 {
+		return now.Sub(t.failedAt) >= t.retryInterval()
+	}
+	return now.Sub(t.refreshedAt) >= t.policy.RefreshInterval
+}
+
+func (t *CtrThrottle) retryInterval() time.Duration {
+	interval := minThrottleRetryInterval
+	for i := 1; i < t.failures && interval < t.policy.RefreshInterval; i++ {
+		interval *= 2
+	}
+	if interval > t.policy.RefreshInterval {
+		return t.policy.RefreshInterval
+	}
+	return interval
+}
+
+func (t *CtrThrottle) refreshInBackground() {
+	if err := t.Refresh(time.Now()); err != nil {
+		core.Logger.WithError(err).Warn("CtrThrottle - failed to refresh")
+	}
+}
+
+// Refresh decides the actions from the stats over the window until now
+func (t *CtrThrottle) Refresh(now time.Time) error {
+	defer func() {
+		t.mu.Lock()
+		t.refreshing = false
+		t.mu.Unlock()
+	}()
+
+	stats, err := t.repo.GetStats(now.Add(-t.policy.Window), now)
+	if err != nil {
+		t.mu.Lock()
+		t.failedAt = now
+		t.failures++
+		t.mu.Unlock()
+		return err
+	}
+
+	actions := make(map[int64]ThrottleAction)
+	for campaignID, stat := range stats {
+		// CTR 필터를 끈 캠페인인지는 조절 대상일 때만 조회한다
+		if t.policy.Decide(stat, false) == ThrottleNone {
+			continue
+		}
+		campaign, err := t.repo.GetContentCampaignByID(campaignID)
+		if err != nil || campaign == nil {
+			continue
+		}
+
+		if action := t.policy.Decide(stat, campaign.IsCtrFilterOff); action != ThrottleNone {
+			actions[campaignID] = action
+			t.log(campaignID, action, stat)
+		}
+	}
+
+	t.mu.Lock()
+	t.actions = actions
+	t.refreshedAt = now
+	t.failures = 0
+	t.mu.Unlock()
+	return nil
+}
+
+func (t *CtrThrottle) log(campaignID int64, action ThrottleAction, stat Stat) {
+	if t.logger == nil {
+		return
+	}
+
+	t.logger.Log(map[string]interface{}{
+		"type":        "content_ctr_throttle",
+		"campaign_id": campaignID,
+		"action":      string(action),
+		"impressions": stat.Impressions,
+		"clicks":      stat.Clicks,
+		"ctr":         stat.CTR(),
+		"event_at":    time.Now().Unix(),
+	})
+}
+
+// NewCtrThrottle returns the throttle whose actions are refreshed on the first Apply
+func NewCtrThrottle(policy CtrThrottlePolicy, repo Repository, logger StructuredLogger) *CtrThrottle {
+	return &CtrThrottle{policy: policy, repo: repo, logger: logger, actions: make(map[int64]ThrottleAction)}
+}
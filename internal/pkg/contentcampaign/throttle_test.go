@@ -0,0 +1,88 @@
+package contentcampaign_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testThrottlePolicy = contentcampaign.CtrThrottlePolicy{
+	Window:          time.Hour * 6,
+	MinImpressions:  100,
+	DemoteCtr:       0.01,
+	PauseCtr:        0.002,
+	RefreshInterval: time.Hour,
+}
+
+func TestCtrThrottlePolicy_Decide(t *testing.T) {
+	assert.Equal(t, contentcampaign.ThrottleNone, testThrottlePolicy.Decide(contentcampaign.Stat{Impressions: 99}, false))
+	assert.Equal(t, contentcampaign.ThrottlePause, testThrottlePolicy.Decide(contentcampaign.Stat{Impressions: 1000, Clicks: 1}, false))
+	assert.Equal(t, contentcampaign.ThrottleDemote, testThrottlePolicy.Decide(contentcampaign.Stat{Impressions: 1000, Clicks: 5}, false))
+	assert.Equal(t, contentcampaign.ThrottleNone, testThrottlePolicy.Decide(contentcampaign.Stat{Impressions: 1000, Clicks: 10}, false))
+	assert.Equal(t, contentcampaign.ThrottleNone, testThrottlePolicy.Decide(contentcampaign.Stat{Impressions: 1000}, true))
+}
+
+func TestCtrThrottle_Apply(t *testing.T) {
+	now := time.Now()
+	repo := new(mockRepo)
+	logger := new(mockLogger)
+	repo.On("GetStats", now.Add(-testThrottlePolicy.Window), now).Return(map[int64]contentcampaign.Stat{
+		1: {Impressions: 1000, Clicks: 1},  // pause
+		2: {Impressions: 1000, Clicks: 5},  // demote
+		3: {Impressions: 1000, Clicks: 1},  // CTR filter off
+		4: {Impressions: 1000, Clicks: 50}, // not throttled
+	}, nil).Once()
+	repo.On("GetContentCampaignByID", int64(1)).Return(&contentcampaign.ContentCampaign{ID: 1}, nil).Once()
+	repo.On("GetContentCampaignByID", int64(2)).Return(&contentcampaign.ContentCampaign{ID: 2}, nil).Once()
+	repo.On("GetContentCampaignByID", int64(3)).Return(&contentcampaign.ContentCampaign{ID: 3, IsCtrFilterOff: true}, nil).Once()
+	logger.On("Log", mock.MatchedBy(func(m map[string]interface{}) bool {
+		return m["type"] == "content_ctr_throttle" && m["campaign_id"] == int64(1) && m["action"] == "pause"
+	})).Once()
+	logger.On("Log", mock.MatchedBy(func(m map[string]interface{}) bool {
+		return m["campaign_id"] == int64(2) && m["action"] == "demote"
+	})).Once()
+
+	throttle := contentcampaign.NewCtrThrottle(testThrottlePolicy, repo, logger)
+	assert.NoError(t, throttle.Refresh(now))
+
+	cachedIDs := []int64{7, 8}
+	req := contentcampaign.SearchRequest{
+		Query:   contentcampaign.SearchQuery{ExcludedIDs: cachedIDs[:1]},
+		Ranking: contentcampaign.SearchRanking{DemotedIDs: map[string]bool{"9": true}},
+	}
+	throttle.Apply(&req)
+
+	assert.Equal(t, []int64{7, 1}, req.Query.ExcludedIDs)
+	assert.Equal(t, []int64{7, 8}, cachedIDs)
+	assert.Equal(t, map[string]bool{"2": true, "9": true}, req.Ranking.DemotedIDs)
+	repo.AssertExpectations(t)
+	logger.AssertExpectations(t)
+}
+
+func TestCtrThrottle_RefreshBackoff(t *testing.T) {
+	now := time.Now()
+	repo := new(mockRepo)
+	repo.On("GetStats", now.Add(-testThrottlePolicy.Window), now).Return(map[int64]contentcampaign.Stat(nil), errors.New("redis error")).Once()
+
+	throttle := contentcampaign.NewCtrThrottle(testThrottlePolicy, repo, nil)
+	assert.Error(t, throttle.Refresh(now))
+
+	// 실패 직후에는 다시 조회하지 않는다
+	req := contentcampaign.SearchRequest{}
+	throttle.Apply(&req)
+	time.Sleep(time.Millisecond * 10)
+
+	assert.Empty(t, req.Query.ExcludedIDs)
+	repo.AssertNumberOfCalls(t, "GetStats", 1)
+}
+
+func TestCtrThrottleRanker(t *testing.T) {
+	ranker := contentcampaign.CtrThrottleRanker{Penalty: 6}
+
+	assert.Equal(t, -6.0, ranker.Score(contentcampaign.Candidate{Demoted: true}, contentcampaign.SearchRanking{}, rankedAt))
+	assert.Equal(t, 0.0, ranker.Score(contentcampaign.Candidate{}, contentcampaign.SearchRanking{}, rankedAt))
+}
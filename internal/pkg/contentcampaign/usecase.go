@@ -12,10 +12,17 @@ type UseCase interface {
 	IncreaseClick(campaignID int64, unitID int64) error
 	IncreaseImpression(campaignID int64, unitID int64) error
 	IsContentCampaignExpired(contentCampaign *ContentCampaign) bool
+	Search(req SearchRequest) (*SearchResult, error)
+	SearchByIDs(campaignIDs ...int64) (*SearchResult, error)
+	Explain(campaignID int64, req SearchRequest) (*Explanation, error)
 }
 
 type useCase struct {
-	repo Repository
+	repo       Repository
+	searchRepo SearchRepository
+	rerankers  map[string]Reranker // keyed by model artifact
+	logger     StructuredLogger
+	throttle   *CtrThrottle // nil: campaigns are not throttled by CTR
 }
 
 // GetContentCampaignByID func definition
@@ -33,6 +40,105 @@ func (u *useCase) IncreaseImpression(campaignID int64, unitID int64) error {
 	return u.repo.IncreaseImpression(campaignID, unitID)
 }
 
+// Search returns the campaigns matching targeting filters of the request ranked by the request.
+// If a reranker is registered for the model artifact, top-K campaigns are reranked in process unless they are ranked by keyword or trending scores.
+// Campaigns the device has seen as many as their ipu or tipu are removed and the page is filled up from the next pages.
+// Campaigns throttled by CTR are excluded or demoted.
+func (u *useCase) Search(req SearchRequest) (*SearchResult, error) {
+	if u.throttle != nil {
+		u.throttle.Apply(&req)
+	}
+	if req.Query.FrequencyCap != nil {
+		return u.searchWithFrequencyCap(req, *req.Query.FrequencyCap)
+	}
+	return u.search(req)
+}
+
+func (u *useCase) search(req SearchRequest) (*SearchResult, error) {
+	// 키워드 검색과 트렌딩 정렬은 모델 점수로 정렬하지 않으므로 재정렬하지 않는다
+	reranker, ok := u.rerankers[req.Ranking.ModelArtifact]
+	if !ok || !req.rankedByModel() {
+		return u.searchRepo.Search(req)
+	}
+	return u.searchAndRerank(req, reranker)
+}
+
+func (u *useCase) searchAndRerank(req SearchRequest, reranker Reranker) (*SearchResult, error) {
+	retrievalReq := req
+	retrievalReq.Ranking.ModelArtifact = reranker.RetrievalModelArtifact
+	retrievalReq.Ranking.Debug = false
+	retrievalReq.After = nil
+	retrievalReq.From = 0
+	retrievalReq.Size = reranker.TopK
+
+	retrieved, err := u.searchRepo.Search(retrievalReq)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := reranker.Rerank(retrieved.Hits, req.Ranking)
+	for i := range hits {
+		hits[i].ModelArtifact = req.Ranking.ModelArtifact
+	}
+
+	// 재정렬된 후보 안에서 페이지를 나눈다. 후보 밖의 캠페인은 조회되지 않는다
+	from := req.From
+	if req.After != nil {
+		from = 0
+		for from < len(hits) && req.After.IsBefore(*hits[from].Score, hits[from].ID) {
+			from++
+		}
+	}
+	if from > len(hits) {
+		from = len(hits)
+	}
+	to := from + req.Size
+	if to > len(hits) {
+		to = len(hits)
+	}
+	// Total은 재정렬 후보 수가 아니라 검색된 전체 캠페인 수이다
+	return &SearchResult{Hits: hits[from:to], Total: retrieved.Total}, nil
+}
+
+// SearchByIDs returns the indexed campaigns of the ids
+func (u *useCase) SearchByIDs(campaignIDs ...int64) (*SearchResult, error) {
+	return u.searchRepo.SearchByIDs(campaignIDs...)
+}
+
+// Explain returns why the campaign is or isn't searched by the request with the ranking score of the campaign.
+// Score factors are always returned. The campaign is reranked if a reranker is registered for the model artifact.
+func (u *useCase) Explain(campaignID int64, req SearchRequest) (*Explanation, error) {
+	req.Ranking.Debug = true
+	if u.throttle != nil {
+		u.throttle.Apply(&req)
+	}
+	explainReq := req
+	reranker, rerank := u.rerankers[req.Ranking.ModelArtifact]
+	if rerank {
+		explainReq.Ranking.ModelArtifact = reranker.RetrievalModelArtifact
+	}
+
+	explanation, err := u.searchRepo.Explain(campaignID, explainReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if rerank {
+		if hits := reranker.Rerank([]SearchHit{explanation.Hit}, req.Ranking); len(hits) > 0 {
+			explanation.Hit = hits[0]
+			explanation.Hit.ModelArtifact = req.Ranking.ModelArtifact
+		}
+	}
+
+	if req.Query.FrequencyCap != nil {
+		explanation.Clauses = append(explanation.Clauses, ClauseResult{
+			Clause: ClauseHardFrequencyCap,
+			Passed: req.Query.FrequencyCap.Check(explanation.Hit) == nil,
+		})
+	}
+	return explanation, nil
+}
+
 // Period constants
 const (
 	DAY  int64 = 60 * 60 * 24
@@ -54,7 +160,7 @@ func (u *useCase) IsContentCampaignExpired(contentCampaign *ContentCampaign) boo
 	return false
 }
 
-// NewUseCase func definition
-func NewUseCase(repo Repository) UseCase {
-	return &useCase{repo: repo}
+// NewUseCase func definition. rerankers are keyed by the model artifact ranked in process
+func NewUseCase(repo Repository, searchRepo SearchRepository, rerankers map[string]Reranker, logger StructuredLogger, throttle *CtrThrottle) UseCase {
+	return &useCase{repo: repo, searchRepo: searchRepo, rerankers: rerankers, logger: logger, throttle: throttle}
 }
@@ -48,6 +48,147 @@ func (ts *UseCaseTestSuite) Test_ContentCamPaignAlived() {
 	ts.repo.AssertExpectations(ts.T())
 }
 
+func (ts *UseCaseTestSuite) Test_Search() {
+	req := contentcampaign.SearchRequest{Query: contentcampaign.SearchQuery{UnitID: rand.Int63n(1000)}, Size: 10}
+	result := &contentcampaign.SearchResult{Hits: []contentcampaign.SearchHit{{ID: 1}}, Total: 1}
+	ts.searchRepo.On("Search", req).Return(result, nil).Once()
+
+	actual, err := ts.useCase.Search(req)
+
+	ts.NoError(err)
+	ts.Equal(result, actual)
+	ts.searchRepo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_Search_Rerank() {
+	ranking := contentcampaign.SearchRanking{ModelArtifact: "r1", RankedAt: rankedAt}
+	retrievalRanking := ranking
+	retrievalRanking.ModelArtifact = testReranker.RetrievalModelArtifact
+	hits := []contentcampaign.SearchHit{
+		newRerankHit(1, rankedAt.Add(-time.Hour*24), ``),
+		newRerankHit(2, rankedAt.Add(-time.Hour*12), ``),
+		newRerankHit(3, rankedAt, ``),
+	}
+	ts.searchRepo.On("Search", contentcampaign.SearchRequest{Ranking: retrievalRanking, Size: testReranker.TopK}).
+		Return(&contentcampaign.SearchResult{Hits: hits, Total: 30}, nil).Twice()
+
+	first, err := ts.useCase.Search(contentcampaign.SearchRequest{Ranking: ranking, Size: 2})
+	ts.NoError(err)
+	ts.Equal(30, first.Total)
+	ts.Equal([]int64{3, 2}, []int64{first.Hits[0].ID, first.Hits[1].ID})
+	ts.Equal("r1", first.Hits[0].ModelArtifact)
+
+	second, err := ts.useCase.Search(contentcampaign.SearchRequest{
+		Ranking: ranking,
+		After:   contentcampaign.NewSearchCursor(first.Hits[1]),
+		Size:    2,
+	})
+	ts.NoError(err)
+	ts.Len(second.Hits, 1)
+	ts.Equal(int64(1), second.Hits[0].ID)
+	ts.searchRepo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_Search_NotReranked() {
+	keywordReq := contentcampaign.SearchRequest{
+		Query:   contentcampaign.SearchQuery{Keyword: "baseball"},
+		Ranking: contentcampaign.SearchRanking{ModelArtifact: "r1", RankedAt: rankedAt},
+		Size:    2,
+	}
+	trendingReq := contentcampaign.SearchRequest{
+		Ranking: contentcampaign.SearchRanking{ModelArtifact: "r1", RankedAt: rankedAt, TrendingScores: map[int64]float64{1: 0.5, 2: 1}},
+		Size:    2,
+	}
+	result := &contentcampaign.SearchResult{Hits: []contentcampaign.SearchHit{{ID: 2}, {ID: 1}}, Total: 2}
+	ts.searchRepo.On("Search", keywordReq).Return(result, nil).Once()
+	ts.searchRepo.On("Search", trendingReq).Return(result, nil).Once()
+
+	for _, req := range []contentcampaign.SearchRequest{keywordReq, trendingReq} {
+		actual, err := ts.useCase.Search(req)
+
+		ts.NoError(err)
+		ts.Equal(result, actual)
+	}
+	ts.searchRepo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_Search_FrequencyCap() {
+	frequencyCap := &contentcampaign.FrequencyCap{
+		CountsForHour:     map[string]int{"1": 2},
+		CountsForDay:      map[string]int{"2": 5},
+		CountsForLifetime: map[string]int{"1": 2, "2": 5, "3": 1},
+	}
+	req := contentcampaign.SearchRequest{DeviceID: 7, Query: contentcampaign.SearchQuery{UnitID: 9, FrequencyCap: frequencyCap}, Size: 3}
+	first := &contentcampaign.SearchResult{Hits: []contentcampaign.SearchHit{
+		newCapHit(1, `{"ipu": 2}`),
+		newCapHit(2, `{"tipu": 5}`),
+		newCapHit(3, `{"ipu": 0, "tipu": 3}`),
+	}, Total: 5}
+	next := req
+	next.From = 3
+	second := &contentcampaign.SearchResult{Hits: []contentcampaign.SearchHit{
+		newCapHit(4, `{}`),
+		newCapHit(5, `{"tipu": null}`),
+	}, Total: 5}
+	ts.searchRepo.On("Search", req).Return(first, nil).Once()
+	ts.searchRepo.On("Search", next).Return(second, nil).Once()
+	ts.logger.On("Log", mock.MatchedBy(func(m map[string]interface{}) bool {
+		return m["type"] == "content_frequency_cap" && m["campaign_id"] == int64(2) && m["cap_type"] == "tipu" &&
+			m["cap_limit"] == 5 && m["cap_count"] == 5 && m["device_id"] == int64(7)
+	})).Once()
+
+	actual, err := ts.useCase.Search(req)
+
+	ts.NoError(err)
+	ts.Equal(4, actual.Total)
+	ts.Equal([]int64{1, 3, 4}, []int64{actual.Hits[0].ID, actual.Hits[1].ID, actual.Hits[2].ID})
+	ts.searchRepo.AssertExpectations(ts.T())
+	ts.logger.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_Explain_Rerank() {
+	ranking := contentcampaign.SearchRanking{ModelArtifact: "r1", RankedAt: rankedAt}
+	retrievalRanking := ranking
+	retrievalRanking.ModelArtifact = testReranker.RetrievalModelArtifact
+	retrievalRanking.Debug = true
+	query := contentcampaign.SearchQuery{FrequencyCap: &contentcampaign.FrequencyCap{CountsForLifetime: map[string]int{"1": 1}}}
+	hit := newRerankHit(1, rankedAt, `"tipu": 1`)
+	ts.searchRepo.On("Explain", int64(1), contentcampaign.SearchRequest{Query: query, Ranking: retrievalRanking}).Return(&contentcampaign.Explanation{
+		CampaignID: 1,
+		Clauses:    []contentcampaign.ClauseResult{{Clause: contentcampaign.ClauseCountry, Passed: true}},
+		Hit:        hit,
+	}, nil).Once()
+
+	explanation, err := ts.useCase.Explain(1, contentcampaign.SearchRequest{Query: query, Ranking: ranking})
+
+	ts.NoError(err)
+	ts.False(explanation.Matched())
+	ts.Equal(contentcampaign.ClauseResult{Clause: contentcampaign.ClauseHardFrequencyCap, Passed: false}, explanation.Clauses[1])
+	ts.Equal("r1", explanation.Hit.ModelArtifact)
+	ts.Equal(4.0, *explanation.Hit.Score)
+	ts.Equal(4.0, explanation.Hit.ScoreFactors["freshness"])
+	ts.searchRepo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_Explain_NotIndexed() {
+	ts.searchRepo.On("Explain", int64(1), mock.Anything).Return(nil, contentcampaign.CampaignNotIndexedError{CampaignID: 1}).Once()
+
+	_, err := ts.useCase.Explain(1, contentcampaign.SearchRequest{})
+
+	ts.Equal(contentcampaign.CampaignNotIndexedError{CampaignID: 1}, err)
+}
+
+func (ts *UseCaseTestSuite) Test_SearchByIDs() {
+	result := &contentcampaign.SearchResult{Hits: []contentcampaign.SearchHit{{ID: 1}, {ID: 2}}, Total: 2}
+	ts.searchRepo.On("SearchByIDs", []int64{1, 2}).Return(result, nil).Once()
+
+	actual, err := ts.useCase.SearchByIDs(1, 2)
+
+	ts.NoError(err)
+	ts.Equal(result, actual)
+	ts.searchRepo.AssertExpectations(ts.T())
+}
+
 var (
 	_ suite.SetupTestSuite = &UseCaseTestSuite{}
 )
@@ -58,13 +199,21 @@ func TestUseCaseSuite(t *testing.T) {
 
 type UseCaseTestSuite struct {
 	suite.Suite
-	repo    *mockRepo
-	useCase contentcampaign.UseCase
+	repo       *mockRepo
+	searchRepo *mockSearchRepo
+	logger     *mockLogger
+	useCase    contentcampaign.UseCase
 }
 
 func (ts *UseCaseTestSuite) SetupTest() {
 	ts.repo = new(mockRepo)
-	ts.useCase = contentcampaign.NewUseCase(ts.repo)
+	ts.searchRepo = new(mockSearchRepo)
+	ts.logger = new(mockLogger)
+	ts.useCase = contentcampaign.NewUseCase(ts.repo, ts.searchRepo, map[string]contentcampaign.Reranker{"r1": testReranker}, ts.logger, nil)
+}
+
+func newCapHit(id int64, source string) contentcampaign.SearchHit {
+	return contentcampaign.SearchHit{ID: id, Source: []byte(source)}
 }
 
 var _ contentcampaign.Repository = &mockRepo{}
@@ -83,7 +232,44 @@ func (r *mockRepo) IncreaseImpression(campaignID int64, unitID int64) error {
 	return ret.Error(0)
 }
 
+func (r *mockRepo) GetStats(from time.Time, to time.Time) (map[int64]contentcampaign.Stat, error) {
+	ret := r.Called(from, to)
+	return ret.Get(0).(map[int64]contentcampaign.Stat), ret.Error(1)
+}
+
 func (r *mockRepo) IncreaseClick(campaignID int64, unitID int64) error {
 	ret := r.Called(campaignID, unitID)
 	return ret.Error(0)
 }
+
+var _ contentcampaign.SearchRepository = &mockSearchRepo{}
+
+type mockSearchRepo struct {
+	mock.Mock
+}
+
+func (r *mockSearchRepo) Search(req contentcampaign.SearchRequest) (*contentcampaign.SearchResult, error) {
+	ret := r.Called(req)
+	return ret.Get(0).(*contentcampaign.SearchResult), ret.Error(1)
+}
+
+func (r *mockSearchRepo) SearchByIDs(campaignIDs ...int64) (*contentcampaign.SearchResult, error) {
+	ret := r.Called(campaignIDs)
+	return ret.Get(0).(*contentcampaign.SearchResult), ret.Error(1)
+}
+
+func (r *mockSearchRepo) Explain(campaignID int64, req contentcampaign.SearchRequest) (*contentcampaign.Explanation, error) {
+	ret := r.Called(campaignID, req)
+	explanation, _ := ret.Get(0).(*contentcampaign.Explanation)
+	return explanation, ret.Error(1)
+}
+
+var _ contentcampaign.StructuredLogger = &mockLogger{}
+
+type mockLogger struct {
+	mock.Mock
+}
+
+func (l *mockLogger) Log(m map[string]interface{}) {
+	l.Called(m)
+}
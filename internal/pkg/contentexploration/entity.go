@@ -0,0 +1,76 @@
+package contentexploration
+
+import (
+	"math"
+	"time"
+)
+
+// Strategy is the bandit choosing the campaigns to explore among the cold-start candidates
+type Strategy string
+
+// Strategy constants
+const (
+	StrategyEpsilonGreedy Strategy = "epsilon_greedy"
+	StrategyUCB           Strategy = "ucb"
+)
+
+// Tags of a choice telling how the campaign was chosen. Tags are carried in the tracking data of the impressions and clicks
+const (
+	TagRandom = "random" // epsilon-greedy picked a candidate at random
+	TagGreedy = "greedy" // epsilon-greedy picked the candidate of the best CTR
+	TagUCB    = "ucb"
+)
+
+// Candidate is a cold-start campaign and its engagement so far
+type Candidate struct {
+	CampaignID  int64
+	Impressions int64
+	Clicks      int64
+}
+
+// Choice is a campaign chosen to explore
+type Choice struct {
+	CampaignID int64
+	Tag        string
+}
+
+// Policy reserves Fraction of the slots of a page for the campaigns younger than MaxAge or having less impressions than MaxImpressions
+type Policy struct {
+	Fraction       float64 // 0: exploration is disabled
+	MaxAge         time.Duration
+	MaxImpressions int
+	PoolSize       int // cold-start candidates searched to choose from
+
+	Strategy   Strategy
+	Epsilon    float64 // probability epsilon-greedy picks at random
+	Confidence float64 // weight of the exploration bonus of UCB
+}
+
+// DefaultPolicy var definition. Exploration is disabled until Fraction is configured
+var DefaultPolicy = Policy{
+	Fraction:       0,
+	MaxAge:         24 * time.Hour,
+	MaxImpressions: 1000,
+	PoolSize:       50,
+	Strategy:       StrategyUCB,
+	Epsilon:        0.1,
+	Confidence:     math.Sqrt2,
+}
+
+// IsValid returns true if the policy can be applied
+func (p Policy) IsValid() bool {
+	switch p.Strategy {
+	case StrategyEpsilonGreedy, StrategyUCB:
+	default:
+		return false
+	}
+	return p.Fraction >= 0 && p.Fraction < 1 && p.Epsilon >= 0 && p.Epsilon <= 1 && p.Confidence >= 0 && p.PoolSize >= 0
+}
+
+// Slots returns the number of slots reserved for exploration in a page of the size
+func (p Policy) Slots(pageSize int) int {
+	if pageSize <= 1 || p.Fraction <= 0 {
+		return 0
+	}
+	return int(float64(pageSize) * p.Fraction)
+}
@@ -0,0 +1,130 @@
+package contentexploration
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+)
+
+// UseCase interface definition
+type UseCase interface {
+	// Slots returns the number of slots reserved for exploration in a page of the size
+	Slots(pageSize int) int
+	// PoolSize returns the number of cold-start candidates to search
+	PoolSize() int
+	// ColdStartFilter returns the filter matching the cold-start campaigns at now
+	ColdStartFilter(now time.Time) contentcampaign.ColdStartFilter
+	// Choose returns up to slots candidates to explore by the strategy of the policy
+	Choose(candidates []Candidate, slots int) []Choice
+}
+
+type useCase struct {
+	policy Policy
+
+	mu     sync.Mutex // rand.Rand isn't safe for concurrent use
+	random *rand.Rand
+}
+
+// Slots func definition
+func (u *useCase) Slots(pageSize int) int {
+	return u.policy.Slots(pageSize)
+}
+
+// PoolSize func definition
+func (u *useCase) PoolSize() int {
+	return u.policy.PoolSize
+}
+
+// ColdStartFilter func definition
+func (u *useCase) ColdStartFilter(now time.Time) contentcampaign.ColdStartFilter {
+	return contentcampaign.ColdStartFilter{
+		CreatedAfter:   now.Add(-u.policy.MaxAge),
+		MaxImpressions: u.policy.MaxImpressions,
+	}
+}
+
+// Choose func definition
+func (u *useCase) Choose(candidates []Candidate, slots int) []Choice {
+	if slots <= 0 || len(candidates) == 0 {
+		return nil
+	}
+	if slots > len(candidates) {
+		slots = len(candidates)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.policy.Strategy == StrategyEpsilonGreedy {
+		return u.chooseEpsilonGreedy(candidates, slots)
+	}
+	return u.chooseUCB(candidates, slots)
+}
+
+// chooseEpsilonGreedy picks a candidate at random with the probability epsilon or the candidate of the best CTR for each slot
+func (u *useCase) chooseEpsilonGreedy(candidates []Candidate, slots int) []Choice {
+	remaining := u.shuffle(candidates)
+	sort.SliceStable(remaining, func(i, j int) bool { return ctr(remaining[i]) > ctr(remaining[j]) })
+
+	choices := make([]Choice, 0, slots)
+	for len(choices) < slots {
+		index, tag := 0, TagGreedy
+		if u.random.Float64() < u.policy.Epsilon {
+			index, tag = u.random.Intn(len(remaining)), TagRandom
+		}
+		choices = append(choices, Choice{CampaignID: remaining[index].CampaignID, Tag: tag})
+		remaining = append(remaining[:index], remaining[index+1:]...)
+	}
+	return choices
+}
+
+// chooseUCB picks the candidates of the highest upper confidence bound of CTR. Candidates without impression are picked first
+func (u *useCase) chooseUCB(candidates []Candidate, slots int) []Choice {
+	var total int64
+	for _, c := range candidates {
+		total += c.Impressions
+	}
+	logTotal := math.Log(float64(total + 1))
+
+	bounds := make(map[int64]float64, len(candidates))
+	for _, c := range candidates {
+		if c.Impressions <= 0 {
+			bounds[c.CampaignID] = math.Inf(1)
+			continue
+		}
+		bounds[c.CampaignID] = ctr(c) + u.policy.Confidence*math.Sqrt(logTotal/float64(c.Impressions))
+	}
+
+	// 동점인 후보들 중에서는 무작위로 고른다
+	ranked := u.shuffle(candidates)
+	sort.SliceStable(ranked, func(i, j int) bool { return bounds[ranked[i].CampaignID] > bounds[ranked[j].CampaignID] })
+
+	choices := make([]Choice, 0, slots)
+	for _, c := range ranked[:slots] {
+		choices = append(choices, Choice{CampaignID: c.CampaignID, Tag: TagUCB})
+	}
+	return choices
+}
+
+func (u *useCase) shuffle(candidates []Candidate) []Candidate {
+	shuffled := make([]Candidate, len(candidates))
+	for i, j := range u.random.Perm(len(candidates)) {
+		shuffled[i] = candidates[j]
+	}
+	return shuffled
+}
+
+func ctr(c Candidate) float64 {
+	if c.Impressions <= 0 {
+		return 0
+	}
+	return float64(c.Clicks) / float64(c.Impressions)
+}
+
+// NewUseCase returns content exploration use case
+func NewUseCase(policy Policy) UseCase {
+	return &useCase{policy: policy, random: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
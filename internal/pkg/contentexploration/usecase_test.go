@@ -0,0 +1,109 @@
+package contentexploration_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentexploration"
+	"github.com/stretchr/testify/suite"
+)
+
+var candidates = []contentexploration.Candidate{
+	{CampaignID: 1, Impressions: 500, Clicks: 5},
+	{CampaignID: 2, Impressions: 500, Clicks: 100},
+	{CampaignID: 3, Impressions: 0},
+	{CampaignID: 4, Impressions: 500, Clicks: 50},
+}
+
+func (ts *UseCaseTestSuite) Test_Slots() {
+	policy := contentexploration.DefaultPolicy
+	policy.Fraction = 0.1
+	useCase := contentexploration.NewUseCase(policy)
+
+	ts.Equal(3, useCase.Slots(30))
+	ts.Equal(0, useCase.Slots(9))
+	ts.Equal(0, useCase.Slots(1))
+}
+
+func (ts *UseCaseTestSuite) Test_Slots_Disabled() {
+	useCase := contentexploration.NewUseCase(contentexploration.DefaultPolicy)
+
+	ts.Equal(0, useCase.Slots(30))
+}
+
+func (ts *UseCaseTestSuite) Test_ColdStartFilter() {
+	now := time.Now()
+	useCase := contentexploration.NewUseCase(contentexploration.DefaultPolicy)
+
+	ts.Equal(contentcampaign.ColdStartFilter{CreatedAfter: now.Add(-24 * time.Hour), MaxImpressions: 1000}, useCase.ColdStartFilter(now))
+}
+
+func (ts *UseCaseTestSuite) Test_Choose_UCB() {
+	useCase := contentexploration.NewUseCase(contentexploration.DefaultPolicy)
+
+	choices := useCase.Choose(candidates, 2)
+
+	ts.Equal([]contentexploration.Choice{
+		{CampaignID: 3, Tag: contentexploration.TagUCB},
+		{CampaignID: 2, Tag: contentexploration.TagUCB},
+	}, choices)
+}
+
+func (ts *UseCaseTestSuite) Test_Choose_EpsilonGreedy() {
+	policy := contentexploration.DefaultPolicy
+	policy.Strategy = contentexploration.StrategyEpsilonGreedy
+	policy.Epsilon = 0
+	useCase := contentexploration.NewUseCase(policy)
+
+	choices := useCase.Choose(candidates, 2)
+
+	ts.Equal([]contentexploration.Choice{
+		{CampaignID: 2, Tag: contentexploration.TagGreedy},
+		{CampaignID: 4, Tag: contentexploration.TagGreedy},
+	}, choices)
+}
+
+func (ts *UseCaseTestSuite) Test_Choose_EpsilonGreedy_Random() {
+	policy := contentexploration.DefaultPolicy
+	policy.Strategy = contentexploration.StrategyEpsilonGreedy
+	policy.Epsilon = 1
+	useCase := contentexploration.NewUseCase(policy)
+
+	choices := useCase.Choose(candidates, 10)
+
+	ts.Len(choices, len(candidates))
+	chosen := make(map[int64]bool)
+	for _, choice := range choices {
+		ts.Equal(contentexploration.TagRandom, choice.Tag)
+		chosen[choice.CampaignID] = true
+	}
+	ts.Len(chosen, len(candidates))
+}
+
+func (ts *UseCaseTestSuite) Test_Choose_NoSlot() {
+	useCase := contentexploration.NewUseCase(contentexploration.DefaultPolicy)
+
+	ts.Nil(useCase.Choose(candidates, 0))
+	ts.Nil(useCase.Choose(nil, 3))
+}
+
+func (ts *UseCaseTestSuite) Test_PolicyIsValid() {
+	policy := contentexploration.DefaultPolicy
+	ts.True(policy.IsValid())
+
+	policy.Strategy = "thompson"
+	ts.False(policy.IsValid())
+
+	policy = contentexploration.DefaultPolicy
+	policy.Fraction = 1
+	ts.False(policy.IsValid())
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+}
@@ -0,0 +1,86 @@
+package contentfeed
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultPollInterval = 30 * time.Minute
+	defaultCampaignDays = 7
+)
+
+// Feed is the RSS or Atom feed of a channel and the default targeting of the campaigns created from its entries
+type Feed struct {
+	ID           int64
+	ChannelID    int64
+	URL          string
+	IsEnabled    bool
+	PollInterval time.Duration
+	LastPolledAt *time.Time
+
+	Categories     string
+	Country        string
+	TargetLanguage string
+	OrganizationID int64
+	OwnerID        int64
+	ProviderID     *int64
+	LandingType    int
+	Timezone       string
+	CampaignDays   int // days a created campaign runs from its published time
+}
+
+// IsDue returns true if the feed should be polled at now
+func (f Feed) IsDue(now time.Time) bool {
+	if !f.IsEnabled {
+		return false
+	} else if f.LastPolledAt == nil {
+		return true
+	}
+
+	interval := f.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return !f.LastPolledAt.Add(interval).After(now)
+}
+
+// actor returns the actor of the audit logs of the campaigns created from the feed
+func (f Feed) actor() string {
+	return "feed:" + strconv.FormatInt(f.ID, 10)
+}
+
+// Entry is an item of RSS or an entry of Atom
+type Entry struct {
+	GUID        string // link is used if the feed doesn't provide it
+	Link        string
+	Title       string
+	Description string
+	ImageURL    string // from enclosures or media elements. empty if the entry has no image
+	PublishedAt *time.Time
+}
+
+// Item is the entry ingested into a campaign. Entries are deduplicated by GUID and Link of items
+type Item struct {
+	ID         int64
+	FeedID     int64
+	GUID       string
+	Link       string
+	CampaignID int64
+}
+
+// PollResult is the result of polling feeds
+type PollResult struct {
+	Polled  int
+	Created int
+	Skipped int // entries already ingested or without http(s) link
+	Failed  int // feeds failed to poll and entries failed to ingest
+}
+
+// isWebURL returns true if the url is an absolute http(s) url.
+// Links and images of entries are fetched by the poller and served to devices, so other schemes such as file: and javascript: are dropped
+func isWebURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -0,0 +1,29 @@
+package contentfeed
+
+import "fmt"
+
+var (
+	_ error = UnsupportedFeedError{}
+	_ error = DisallowedAddressError{}
+)
+
+// UnsupportedFeedError will be returned when the document is neither RSS 2.0 nor Atom 1.0
+type UnsupportedFeedError struct {
+	Root string
+}
+
+// Error func definition
+func (e UnsupportedFeedError) Error() string {
+	return fmt.Sprintf("unsupported feed document of root element %q", e.Root)
+}
+
+// DisallowedAddressError will be returned when the host of a fetched url resolves to a loopback, private or link-local address
+type DisallowedAddressError struct {
+	Host string
+	IP   string
+}
+
+// Error func definition
+func (e DisallowedAddressError) Error() string {
+	return fmt.Sprintf("host %s resolves to disallowed address %s", e.Host, e.IP)
+}
@@ -0,0 +1,290 @@
+package contentfeed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	mediaNamespace       = "http://search.yahoo.com/mrss/"
+	maxDescriptionLength = 300
+)
+
+var (
+	dateLayouts = []string{
+		time.RFC1123Z,
+		time.RFC1123,
+		"Mon, 2 Jan 2006 15:04:05 -0700",
+		"Mon, 2 Jan 2006 15:04:05 MST",
+		"2 Jan 2006 15:04:05 -0700",
+		time.RFC822Z,
+		time.RFC822,
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+	}
+	imageExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+
+	tagPattern       = regexp.MustCompile(`<[^>]*>`)
+	spacePattern     = regexp.MustCompile(`\s+`)
+	metaTagPattern   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attributePattern = regexp.MustCompile(`(?s)([\w:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+type rssDocument struct {
+	Items []rssItem `xml:"channel>item"`
+}
+
+type rssItem struct {
+	MediaContents   []mediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []mediaContent `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaGroups     []mediaGroup   `xml:"http://search.yahoo.com/mrss/ group"`
+	GUID            string         `xml:"guid"`
+	Link            string         `xml:"link"`
+	Title           string         `xml:"title"`
+	Description     string         `xml:"description"`
+	PubDate         string         `xml:"pubDate"`
+	Date            string         `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Enclosures      []enclosure    `xml:"enclosure"`
+}
+
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	MediaContents   []mediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []mediaContent `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaGroups     []mediaGroup   `xml:"http://search.yahoo.com/mrss/ group"`
+	ID              string         `xml:"id"`
+	Title           string         `xml:"title"`
+	Links           []atomLink     `xml:"link"`
+	Summary         string         `xml:"summary"`
+	Content         string         `xml:"content"`
+	Published       string         `xml:"published"`
+	Updated         string         `xml:"updated"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type enclosure struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type mediaContent struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+type mediaGroup struct {
+	Contents   []mediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails []mediaContent `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// ParseFeed parses entries of RSS 2.0 or Atom 1.0 document. UnsupportedFeedError is returned for the other documents
+func ParseFeed(data []byte) ([]Entry, error) {
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root {
+	case "rss":
+		var doc rssDocument
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		entries := make([]Entry, 0, len(doc.Items))
+		for _, item := range doc.Items {
+			entries = append(entries, item.toEntry())
+		}
+		return entries, nil
+	case "feed":
+		var feed atomFeed
+		if err := xml.Unmarshal(data, &feed); err != nil {
+			return nil, err
+		}
+		entries := make([]Entry, 0, len(feed.Entries))
+		for _, atomEntry := range feed.Entries {
+			entries = append(entries, atomEntry.toEntry())
+		}
+		return entries, nil
+	default:
+		return nil, UnsupportedFeedError{Root: root}
+	}
+}
+
+func rootElement(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return "", UnsupportedFeedError{}
+		} else if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func (item rssItem) toEntry() Entry {
+	entry := Entry{
+		GUID:        strings.TrimSpace(item.GUID),
+		Link:        strings.TrimSpace(item.Link),
+		Title:       plainText(item.Title),
+		Description: truncate(plainText(item.Description), maxDescriptionLength),
+		PublishedAt: parseDate(item.PubDate, item.Date),
+	}
+
+	for _, e := range item.Enclosures {
+		if entry.ImageURL == "" && isImage(e.Type, "", e.URL) {
+			entry.ImageURL = e.URL
+		}
+	}
+	if entry.ImageURL == "" {
+		entry.ImageURL = mediaImage(item.MediaContents, item.MediaThumbnails, item.MediaGroups)
+	}
+	if entry.GUID == "" {
+		entry.GUID = entry.Link
+	}
+	return entry
+}
+
+func (e atomEntry) toEntry() Entry {
+	description := e.Summary
+	if strings.TrimSpace(description) == "" {
+		description = e.Content
+	}
+
+	entry := Entry{
+		GUID:        strings.TrimSpace(e.ID),
+		Title:       plainText(e.Title),
+		Description: truncate(plainText(description), maxDescriptionLength),
+		PublishedAt: parseDate(e.Published, e.Updated),
+	}
+
+	for _, link := range e.Links {
+		switch link.Rel {
+		case "", "alternate":
+			if entry.Link == "" {
+				entry.Link = strings.TrimSpace(link.Href)
+			}
+		case "enclosure":
+			if entry.ImageURL == "" && isImage(link.Type, "", link.Href) {
+				entry.ImageURL = link.Href
+			}
+		}
+	}
+	if entry.ImageURL == "" {
+		entry.ImageURL = mediaImage(e.MediaContents, e.MediaThumbnails, e.MediaGroups)
+	}
+	if entry.GUID == "" {
+		entry.GUID = entry.Link
+	}
+	return entry
+}
+
+// mediaImage returns the first image of media:content, media:thumbnail and the ones in media:group in order
+func mediaImage(contents []mediaContent, thumbnails []mediaContent, groups []mediaGroup) string {
+	for _, group := range groups {
+		contents = append(contents, group.Contents...)
+		thumbnails = append(thumbnails, group.Thumbnails...)
+	}
+
+	for _, content := range contents {
+		if isImage(content.Type, content.Medium, content.URL) {
+			return content.URL
+		}
+	}
+	for _, thumbnail := range thumbnails {
+		if thumbnail.URL != "" {
+			return thumbnail.URL
+		}
+	}
+	return ""
+}
+
+func isImage(mimeType string, medium string, url string) bool {
+	if url == "" {
+		return false
+	} else if mimeType != "" {
+		return strings.HasPrefix(mimeType, "image/")
+	} else if medium != "" {
+		return medium == "image"
+	}
+
+	path := strings.ToLower(strings.SplitN(url, "?", 2)[0])
+	for _, ext := range imageExtensions {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDate returns the first parsable date. nil is returned if none of them is parsable
+func parseDate(values ...string) *time.Time {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return &t
+			}
+		}
+	}
+	return nil
+}
+
+// ExtractOgImage returns the og:image of the html document. empty string is returned if it doesn't exist
+func ExtractOgImage(document []byte) string {
+	for _, tag := range metaTagPattern.FindAll(document, -1) {
+		attributes := make(map[string]string)
+		for _, match := range attributePattern.FindAllSubmatch(tag, -1) {
+			value := match[2]
+			if len(value) == 0 {
+				value = match[3]
+			}
+			attributes[strings.ToLower(string(match[1]))] = string(value)
+		}
+
+		property := attributes["property"]
+		if property == "" {
+			property = attributes["name"]
+		}
+		if (property == "og:image" || property == "og:image:url") && attributes["content"] != "" {
+			return html.UnescapeString(strings.TrimSpace(attributes["content"]))
+		}
+	}
+	return ""
+}
+
+// plainText strips tags and entities of the html text
+func plainText(value string) string {
+	value = html.UnescapeString(tagPattern.ReplaceAllString(value, " "))
+	return strings.TrimSpace(spacePattern.ReplaceAllString(value, " "))
+}
+
+func truncate(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+	runes := []rune(value)
+	return strings.TrimSpace(string(runes[:length]))
+}
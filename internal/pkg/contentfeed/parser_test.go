@@ -0,0 +1,68 @@
+package contentfeed_test
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentfeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFeed_RSS(t *testing.T) {
+	entries, err := contentfeed.ParseFeed(readFixture(t, "rss.xml"))
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	assert.Equal(t, "article-3", entries[0].GUID)
+	assert.Equal(t, "Third article & more", entries[0].Title)
+	assert.Equal(t, "The third article", entries[0].Description)
+	assert.Equal(t, "", entries[0].ImageURL)
+	assert.True(t, time.Date(2019, 1, 2, 1, 0, 0, 0, time.UTC).Equal(*entries[0].PublishedAt))
+
+	// media:content가 이미지가 아니면 썸네일을 사용한다
+	assert.Equal(t, "https://news.buzzvil.com/images/2-thumb.jpg", entries[1].ImageURL)
+	assert.True(t, time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC).Equal(*entries[1].PublishedAt))
+
+	// guid가 없으면 link를 사용한다
+	assert.Equal(t, "https://news.buzzvil.com/articles/1", entries[2].GUID)
+	assert.Equal(t, "https://news.buzzvil.com/images/1.jpg", entries[2].ImageURL)
+	assert.True(t, time.Date(2018, 12, 31, 23, 0, 0, 0, time.UTC).Equal(*entries[2].PublishedAt))
+}
+
+func TestParseFeed_Atom(t *testing.T) {
+	entries, err := contentfeed.ParseFeed(readFixture(t, "atom.xml"))
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	assert.Equal(t, "urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a", entries[0].GUID)
+	assert.Equal(t, "https://blog.buzzvil.com/posts/1", entries[0].Link)
+	assert.Equal(t, "https://blog.buzzvil.com/images/1.png", entries[0].ImageURL)
+	assert.Equal(t, "Summary of the entry", entries[0].Description)
+	assert.True(t, time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC).Equal(*entries[0].PublishedAt))
+
+	assert.Equal(t, "https://blog.buzzvil.com/posts/2", entries[1].Link)
+	assert.Equal(t, "Content of the entry", entries[1].Description)
+	assert.True(t, time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC).Equal(*entries[1].PublishedAt))
+}
+
+func TestParseFeed_Unsupported(t *testing.T) {
+	_, err := contentfeed.ParseFeed(readFixture(t, "article.html"))
+
+	assert.IsType(t, contentfeed.UnsupportedFeedError{}, err)
+}
+
+func TestExtractOgImage(t *testing.T) {
+	assert.Equal(t, "https://news.buzzvil.com/images/3.jpg?w=720&h=360", contentfeed.ExtractOgImage(readFixture(t, "article.html")))
+	assert.Equal(t, "", contentfeed.ExtractOgImage([]byte(`<html><head><meta property="og:title" content="title"></head></html>`)))
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	data, err := ioutil.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
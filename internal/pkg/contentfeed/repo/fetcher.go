@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentfeed"
+)
+
+const (
+	userAgent       = "BuzzScreen-FeedFetcher/1.0"
+	maxDocumentSize = 5 << 20
+)
+
+// privateNetworks are the ranges not reachable from the internet in addition to loopback and link-local addresses
+var privateNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"fc00::/7",
+)
+
+// Fetcher fetches documents over http
+type Fetcher struct {
+	client *http.Client
+}
+
+// Fetch returns the body of the url. An error is returned for non 2xx responses and bodies larger than maxDocumentSize
+func (f *Fetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	res, err := f.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("fetching %s responded %d", url, res.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDocumentSize {
+		return nil, fmt.Errorf("fetching %s responded more than %d bytes", url, maxDocumentSize)
+	}
+	return data, nil
+}
+
+// dialPublic connects to the resolved address of the host only if every address of it is public.
+// The resolved address is dialed so that the host can't be resolved to another address after the check.
+func dialPublic(ctx context.Context, network string, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ipAddrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	for _, ipAddr := range ipAddrs {
+		if !isPublicIP(ipAddr.IP) {
+			return nil, contentfeed.DisallowedAddressError{Host: host, IP: ipAddr.IP.String()}
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	err = fmt.Errorf("no address of host %s", host)
+	for _, ipAddr := range ipAddrs {
+		var conn net.Conn
+		if conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ipAddr.IP.String(), port)); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// NewFetcher returns the fetcher of the timeout.
+// Feeds and linked pages are fetched without proxy, so that the addresses they resolve to are checked by dialPublic.
+func NewFetcher(timeout time.Duration) *Fetcher {
+	transport := &http.Transport{DialContext: dialPublic}
+	return &Fetcher{client: &http.Client{Transport: transport, Timeout: timeout}}
+}
+
+var _ contentfeed.Fetcher = &Fetcher{}
@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentfeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetcher_FetchFixture(t *testing.T) {
+	path, err := filepath.Abs("../testdata/rss.xml")
+	assert.NoError(t, err)
+
+	// file:// urls are served only to the fetcher of tests
+	transport := &http.Transport{}
+	transport.RegisterProtocol("file", http.NewFileTransport(http.Dir("/")))
+	fetcher := &Fetcher{client: &http.Client{Transport: transport, Timeout: time.Second}}
+
+	data, err := fetcher.Fetch(context.Background(), "file://"+filepath.ToSlash(path))
+
+	assert.NoError(t, err)
+	entries, err := contentfeed.ParseFeed(data)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestFetcher_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, userAgent, r.Header.Get("User-Agent"))
+		if r.URL.Path != "/rss" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("<rss></rss>"))
+	}))
+	defer server.Close()
+	// the test server listens on loopback which NewFetcher doesn't connect to
+	fetcher := &Fetcher{client: &http.Client{Timeout: time.Second}}
+
+	data, err := fetcher.Fetch(context.Background(), server.URL+"/rss")
+	assert.NoError(t, err)
+	assert.Equal(t, "<rss></rss>", string(data))
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/missing")
+	assert.Error(t, err)
+
+	_, err = NewFetcher(time.Second).Fetch(context.Background(), "file:///etc/hosts")
+	assert.Error(t, err)
+}
+
+func TestFetcher_FetchDisallowedAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("loopback server should not be requested")
+	}))
+	defer server.Close()
+
+	for _, rawURL := range []string{server.URL + "/rss", "http://localhost:9200/_cat/health", "http://169.254.169.254/latest/meta-data"} {
+		_, err := NewFetcher(time.Second).Fetch(context.Background(), rawURL)
+		assert.Error(t, err, rawURL)
+		assert.Contains(t, err.Error(), "disallowed address", rawURL)
+	}
+}
+
+func TestFetcher_FetchTooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(make([]byte, maxDocumentSize+1))
+	}))
+	defer server.Close()
+	fetcher := &Fetcher{client: &http.Client{Timeout: time.Second}}
+
+	_, err := fetcher.Fetch(context.Background(), server.URL)
+
+	assert.Error(t, err)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for ip, expected := range map[string]bool{
+		"8.8.8.8":         true,
+		"2001:4860::8888": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.1.2.3":        false,
+		"172.31.0.1":      false,
+		"192.168.0.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+	} {
+		assert.Equal(t, expected, isPublicIP(net.ParseIP(ip)), ip)
+	}
+}
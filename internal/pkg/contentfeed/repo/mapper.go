@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentfeed"
+)
+
+type entityMapper struct {
+}
+
+func (m *entityMapper) dbFeedToFeed(dbFeed DBFeed) contentfeed.Feed {
+	return contentfeed.Feed{
+		ID:             dbFeed.ID,
+		ChannelID:      dbFeed.ChannelID,
+		URL:            dbFeed.URL,
+		IsEnabled:      dbFeed.IsEnabled,
+		PollInterval:   time.Duration(dbFeed.PollIntervalMinutes) * time.Minute,
+		LastPolledAt:   dbFeed.LastPolledAt,
+		Categories:     dbFeed.Categories,
+		Country:        dbFeed.Country,
+		TargetLanguage: dbFeed.TargetLanguage,
+		OrganizationID: dbFeed.OrganizationID,
+		OwnerID:        dbFeed.OwnerID,
+		ProviderID:     dbFeed.ProviderID,
+		LandingType:    dbFeed.LandingType,
+		Timezone:       dbFeed.Timezone,
+		CampaignDays:   dbFeed.CampaignDays,
+	}
+}
+
+func (m *entityMapper) dbItemToItem(dbItem DBItem) contentfeed.Item {
+	return contentfeed.Item{
+		ID:         dbItem.ID,
+		FeedID:     dbItem.FeedID,
+		GUID:       dbItem.GUID,
+		Link:       dbItem.Link,
+		CampaignID: dbItem.CampaignID,
+	}
+}
+
+func (m *entityMapper) itemToDBItem(item contentfeed.Item) DBItem {
+	return DBItem{
+		ID:         item.ID,
+		FeedID:     item.FeedID,
+		GUID:       item.GUID,
+		Link:       item.Link,
+		CampaignID: item.CampaignID,
+	}
+}
@@ -0,0 +1,47 @@
+package repo
+
+import "time"
+
+// DBFeed struct definition
+type DBFeed struct {
+	ID                  int64 `gorm:"primary_key"`
+	ChannelID           int64
+	URL                 string
+	IsEnabled           bool
+	PollIntervalMinutes int
+	LastPolledAt        *time.Time
+
+	Categories     string
+	Country        string
+	TargetLanguage string
+	OrganizationID int64
+	OwnerID        int64
+	ProviderID     *int64
+	LandingType    int
+	Timezone       string
+	CampaignDays   int
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName func definition
+func (DBFeed) TableName() string {
+	return "content_feeds"
+}
+
+// DBItem struct definition
+type DBItem struct {
+	ID         int64 `gorm:"primary_key"`
+	FeedID     int64
+	GUID       string `gorm:"column:guid"`
+	Link       string
+	CampaignID int64
+
+	CreatedAt time.Time
+}
+
+// TableName func definition
+func (DBItem) TableName() string {
+	return "content_feed_items"
+}
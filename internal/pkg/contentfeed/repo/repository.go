@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentfeed"
+	"github.com/jinzhu/gorm"
+)
+
+// Repository struct definition
+type Repository struct {
+	db     *gorm.DB
+	mapper *entityMapper
+}
+
+// GetEnabledFeeds returns the enabled feeds
+func (r *Repository) GetEnabledFeeds() ([]contentfeed.Feed, error) {
+	var dbFeeds []DBFeed
+	if err := r.db.Where("is_enabled = ?", true).Order("id").Find(&dbFeeds).Error; err != nil {
+		return nil, err
+	}
+
+	feeds := make([]contentfeed.Feed, 0, len(dbFeeds))
+	for _, dbFeed := range dbFeeds {
+		feeds = append(feeds, r.mapper.dbFeedToFeed(dbFeed))
+	}
+	return feeds, nil
+}
+
+// UpdatePolledAt saves the time the feed is polled at
+func (r *Repository) UpdatePolledAt(feedID int64, polledAt time.Time) error {
+	return r.db.Model(&DBFeed{}).Where("id = ?", feedID).UpdateColumn("last_polled_at", polledAt).Error
+}
+
+// FindItems returns the items of any of the guids or links
+func (r *Repository) FindItems(guids []string, links []string) ([]contentfeed.Item, error) {
+	var dbItems []DBItem
+	if err := r.db.Where("guid IN (?) OR link IN (?)", guids, links).Find(&dbItems).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]contentfeed.Item, 0, len(dbItems))
+	for _, dbItem := range dbItems {
+		items = append(items, r.mapper.dbItemToItem(dbItem))
+	}
+	return items, nil
+}
+
+// CreateItem saves the item and returns it with the id
+func (r *Repository) CreateItem(item contentfeed.Item) (*contentfeed.Item, error) {
+	dbItem := r.mapper.itemToDBItem(item)
+	if err := r.db.Create(&dbItem).Error; err != nil {
+		return nil, err
+	}
+
+	created := r.mapper.dbItemToItem(dbItem)
+	return &created, nil
+}
+
+// LinkCampaign saves the campaign created from the item
+func (r *Repository) LinkCampaign(itemID int64, campaignID int64) error {
+	return r.db.Model(&DBItem{}).Where("id = ?", itemID).UpdateColumn("campaign_id", campaignID).Error
+}
+
+// DeleteItem deletes the item so that the entry is ingested again on the next poll
+func (r *Repository) DeleteItem(itemID int64) error {
+	return r.db.Where("id = ?", itemID).Delete(DBItem{}).Error
+}
+
+// New returns content feed repository
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db:     db,
+		mapper: &entityMapper{},
+	}
+}
+
+var _ contentfeed.Repository = &Repository{}
@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentfeed"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	db   *gorm.DB
+	repo contentfeed.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	ts.NoError(err)
+	ts.mock = mock
+	ts.db, err = gorm.Open("mysql", db)
+	ts.NoError(err)
+	ts.repo = New(ts.db)
+}
+
+func (ts *RepoTestSuite) AfterTest() {
+	_ = ts.db.Close()
+}
+
+func (ts *RepoTestSuite) Test_GetEnabledFeeds() {
+	req := "SELECT * FROM `content_feeds` WHERE (is_enabled = ?) ORDER BY `id`"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "channel_id", "url", "is_enabled", "poll_interval_minutes"}).
+			AddRow(1, 7, "https://news.buzzvil.com/rss", true, 15))
+
+	feeds, err := ts.repo.GetEnabledFeeds()
+
+	ts.NoError(err)
+	ts.Equal([]contentfeed.Feed{{ID: 1, ChannelID: 7, URL: "https://news.buzzvil.com/rss", IsEnabled: true, PollInterval: 15 * time.Minute}}, feeds)
+}
+
+func (ts *RepoTestSuite) Test_UpdatePolledAt() {
+	polledAt := time.Now()
+	ts.mock.ExpectExec(ts.fixedFullRe("UPDATE `content_feeds` SET `last_polled_at` = ? WHERE (id = ?)")).
+		WithArgs(polledAt, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := ts.repo.UpdatePolledAt(1, polledAt)
+
+	ts.NoError(err)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_FindItems() {
+	req := "SELECT * FROM `content_feed_items` WHERE (guid IN (?,?) OR link IN (?,?))"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs("a", "b", "http://a", "http://b").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "feed_id", "guid", "link", "campaign_id"}).AddRow(1, 1, "a", "http://a", 10))
+
+	items, err := ts.repo.FindItems([]string{"a", "b"}, []string{"http://a", "http://b"})
+
+	ts.NoError(err)
+	ts.Equal([]contentfeed.Item{{ID: 1, FeedID: 1, GUID: "a", Link: "http://a", CampaignID: 10}}, items)
+}
+
+func (ts *RepoTestSuite) Test_CreateItem() {
+	ts.mock.ExpectBegin()
+	ts.mock.ExpectExec(ts.fixedFullRe("INSERT INTO `content_feed_items` (`feed_id`,`guid`,`link`,`campaign_id`,`created_at`) VALUES (?,?,?,?,?)")).
+		WithArgs(1, "a", "http://a", 0, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(5, 1))
+	ts.mock.ExpectCommit()
+
+	item, err := ts.repo.CreateItem(contentfeed.Item{FeedID: 1, GUID: "a", Link: "http://a"})
+
+	ts.NoError(err)
+	ts.Equal(&contentfeed.Item{ID: 5, FeedID: 1, GUID: "a", Link: "http://a"}, item)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_LinkCampaign() {
+	ts.mock.ExpectExec(ts.fixedFullRe("UPDATE `content_feed_items` SET `campaign_id` = ? WHERE (id = ?)")).
+		WithArgs(10, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := ts.repo.LinkCampaign(5, 10)
+
+	ts.NoError(err)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) fixedFullRe(s string) string {
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(s))
+}
@@ -0,0 +1,30 @@
+package contentfeed
+
+import (
+	"context"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+)
+
+// Repository interface definition
+type Repository interface {
+	GetEnabledFeeds() ([]Feed, error)
+	UpdatePolledAt(feedID int64, polledAt time.Time) error
+	// FindItems returns the items of any of the guids or links
+	FindItems(guids []string, links []string) ([]Item, error)
+	// CreateItem saves the item before its campaign is created so that the entry isn't ingested twice
+	CreateItem(item Item) (*Item, error)
+	LinkCampaign(itemID int64, campaignID int64) error
+	DeleteItem(itemID int64) error
+}
+
+// Fetcher fetches the feed documents and the linked pages
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// CampaignCreator creates the campaigns of the entries. contentadmin.UseCase validates, audits and indexes them
+type CampaignCreator interface {
+	CreateCampaign(actor string, campaign contentadmin.Campaign) (*contentadmin.Campaign, error)
+}
@@ -0,0 +1,201 @@
+package contentfeed
+
+import (
+	"context"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+)
+
+// UseCase interface definition
+type UseCase interface {
+	// PollFeeds polls the feeds due at now. Failures of each feed are counted in the result
+	PollFeeds(ctx context.Context, now time.Time) (*PollResult, error)
+	PollFeed(ctx context.Context, feed Feed, now time.Time) (*PollResult, error)
+}
+
+type useCase struct {
+	repo    Repository
+	fetcher Fetcher
+	creator CampaignCreator
+}
+
+// PollFeeds polls the feeds due at now
+func (u *useCase) PollFeeds(ctx context.Context, now time.Time) (*PollResult, error) {
+	feeds, err := u.repo.GetEnabledFeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	total := &PollResult{}
+	for _, feed := range feeds {
+		if !feed.IsDue(now) {
+			continue
+		}
+
+		result, err := u.PollFeed(ctx, feed, now)
+		if err != nil {
+			core.Logger.WithError(err).Warnf("PollFeeds() - failed to poll feed %d %s", feed.ID, feed.URL)
+			total.Failed++
+			continue
+		}
+		total.Polled += result.Polled
+		total.Created += result.Created
+		total.Skipped += result.Skipped
+		total.Failed += result.Failed
+	}
+	return total, nil
+}
+
+// PollFeed creates campaigns of the new entries of the feed. Entries are created from the oldest one
+func (u *useCase) PollFeed(ctx context.Context, feed Feed, now time.Time) (*PollResult, error) {
+	data, err := u.fetcher.Fetch(ctx, feed.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ParseFeed(data)
+	if err != nil {
+		return nil, err
+	}
+
+	seen, err := u.getIngested(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PollResult{Polled: 1}
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if !isWebURL(entry.Link) || seen[entry.GUID] || seen[entry.Link] {
+			result.Skipped++
+			continue
+		}
+		seen[entry.GUID], seen[entry.Link] = true, true
+
+		campaign := newCampaign(feed, entry, now)
+		if campaign.EndDate.Before(now) {
+			result.Skipped++
+			continue
+		}
+		campaign.Image = u.getImage(ctx, entry)
+
+		// 캠페인 생성 전에 항목을 먼저 저장해서 같은 항목의 캠페인이 중복으로 생성되지 않게 한다
+		item, err := u.repo.CreateItem(Item{FeedID: feed.ID, GUID: entry.GUID, Link: entry.Link})
+		if err != nil {
+			core.Logger.WithError(err).Warnf("PollFeed() - failed to save item of %s", entry.Link)
+			result.Failed++
+			continue
+		}
+
+		created, err := u.creator.CreateCampaign(feed.actor(), campaign)
+		if err != nil {
+			core.Logger.WithError(err).Warnf("PollFeed() - failed to create campaign of %s", entry.Link)
+			if err := u.repo.DeleteItem(item.ID); err != nil {
+				core.Logger.WithError(err).Errorf("PollFeed() - failed to delete item %d", item.ID)
+			}
+			result.Failed++
+			continue
+		}
+
+		if err := u.repo.LinkCampaign(item.ID, created.ID); err != nil {
+			core.Logger.WithError(err).Errorf("PollFeed() - failed to link item %d to campaign %d", item.ID, created.ID)
+		}
+		result.Created++
+	}
+
+	if err := u.repo.UpdatePolledAt(feed.ID, now); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// getIngested returns the set of guids and links already ingested
+func (u *useCase) getIngested(entries []Entry) (map[string]bool, error) {
+	guids := make([]string, 0, len(entries))
+	links := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		guids = append(guids, entry.GUID)
+		links = append(links, entry.Link)
+	}
+
+	seen := make(map[string]bool)
+	if len(entries) == 0 {
+		return seen, nil
+	}
+
+	items, err := u.repo.FindItems(guids, links)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		seen[item.GUID], seen[item.Link] = true, true
+	}
+	return seen, nil
+}
+
+// newCampaign returns the curatable campaign of the entry with the default targeting of the feed. Image is set by the caller
+func newCampaign(feed Feed, entry Entry, now time.Time) contentadmin.Campaign {
+	publishedAt := now
+	if entry.PublishedAt != nil {
+		publishedAt = *entry.PublishedAt
+	}
+
+	campaignDays := feed.CampaignDays
+	if campaignDays <= 0 {
+		campaignDays = defaultCampaignDays
+	}
+
+	landingType := feed.LandingType
+	if landingType == 0 {
+		landingType = int(contentcampaign.LandingTypeBrowser)
+	}
+
+	channelID := feed.ChannelID
+	return contentadmin.Campaign{
+		Name:           entry.Title,
+		Title:          entry.Title,
+		Description:    entry.Description,
+		ClickURL:       entry.Link,
+		CleanLink:      entry.Link,
+		ChannelID:      &channelID,
+		PublishedAt:    &publishedAt,
+		StartDate:      publishedAt,
+		EndDate:        publishedAt.AddDate(0, 0, campaignDays),
+		Status:         int(contentcampaign.StatusCuratable),
+		IsEnabled:      true,
+		Categories:     feed.Categories,
+		Country:        feed.Country,
+		TargetLanguage: feed.TargetLanguage,
+		OrganizationID: feed.OrganizationID,
+		OwnerID:        feed.OwnerID,
+		ProviderID:     feed.ProviderID,
+		LandingType:    landingType,
+		Timezone:       feed.Timezone,
+	}
+}
+
+// getImage returns the image of the enclosures or og:image of the linked page. Images other than http(s) urls are dropped
+func (u *useCase) getImage(ctx context.Context, entry Entry) string {
+	if isWebURL(entry.ImageURL) {
+		return entry.ImageURL
+	}
+
+	page, err := u.fetcher.Fetch(ctx, entry.Link)
+	if err != nil {
+		core.Logger.WithError(err).Debugf("getImage() - failed to fetch %s", entry.Link)
+		return ""
+	}
+
+	if image := ExtractOgImage(page); isWebURL(image) {
+		return image
+	}
+	return ""
+}
+
+// NewUseCase returns content feed use case
+func NewUseCase(repo Repository, fetcher Fetcher, creator CampaignCreator) UseCase {
+	return &useCase{repo: repo, fetcher: fetcher, creator: creator}
+}
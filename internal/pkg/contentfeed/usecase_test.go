@@ -0,0 +1,214 @@
+package contentfeed_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentadmin"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentfeed"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var now = time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC)
+
+func (ts *UseCaseTestSuite) Test_PollFeed() {
+	feed := contentfeed.Feed{ID: 1, ChannelID: 7, URL: "https://news.buzzvil.com/rss", IsEnabled: true, Categories: "news", Country: "KR"}
+	ts.repo.On("FindItems", []string{"article-3", "article-2", "https://news.buzzvil.com/articles/1"}, mock.Anything).
+		Return([]contentfeed.Item{{FeedID: 1, GUID: "article-2", Link: "https://news.buzzvil.com/articles/2", CampaignID: 20}}, nil).Once()
+	ts.creator.On("CreateCampaign", "feed:1", mock.AnythingOfType("contentadmin.Campaign")).
+		Return(&contentadmin.Campaign{ID: 100}, nil).Twice()
+	ts.repo.On("CreateItem", mock.AnythingOfType("contentfeed.Item")).Return(&contentfeed.Item{ID: 50}, nil).Twice()
+	ts.repo.On("LinkCampaign", int64(50), int64(100)).Return(nil).Twice()
+	ts.repo.On("UpdatePolledAt", int64(1), now).Return(nil).Once()
+
+	result, err := ts.useCase.PollFeed(context.Background(), feed, now)
+
+	ts.NoError(err)
+	ts.Equal(contentfeed.PollResult{Polled: 1, Created: 2, Skipped: 1}, *result)
+	ts.repo.AssertExpectations(ts.T())
+
+	// 오래된 항목부터 생성된다
+	first := ts.creator.Calls[0].Arguments.Get(1).(contentadmin.Campaign)
+	ts.Equal("First article", first.Title)
+	ts.Equal("https://news.buzzvil.com/images/1.jpg", first.Image)
+	ts.Equal(int64(7), *first.ChannelID)
+	ts.Equal(int(contentcampaign.StatusCuratable), first.Status)
+	ts.Equal(int(contentcampaign.LandingTypeBrowser), first.LandingType)
+	ts.Equal("news", first.Categories)
+	ts.Equal("KR", first.Country)
+	ts.True(first.StartDate.Equal(*first.PublishedAt))
+	ts.True(first.EndDate.Equal(first.StartDate.AddDate(0, 0, 7)))
+
+	// 이미지가 없는 항목은 링크의 og:image를 사용한다
+	third := ts.creator.Calls[1].Arguments.Get(1).(contentadmin.Campaign)
+	ts.Equal("https://news.buzzvil.com/images/3.jpg?w=720&h=360", third.Image)
+	ts.Equal(contentfeed.Item{FeedID: 1, GUID: "article-3", Link: "https://news.buzzvil.com/articles/3"},
+		ts.repo.Calls[3].Arguments.Get(0))
+}
+
+func (ts *UseCaseTestSuite) Test_PollFeed_SkipsExpiredEntries() {
+	feed := contentfeed.Feed{ID: 2, ChannelID: 7, URL: "https://blog.buzzvil.com/atom", IsEnabled: true, CampaignDays: 1}
+	ts.repo.On("FindItems", mock.Anything, mock.Anything).Return([]contentfeed.Item{}, nil).Once()
+	ts.creator.On("CreateCampaign", "feed:2", mock.AnythingOfType("contentadmin.Campaign")).
+		Return(nil, contentadmin.ValidationError{Field: "timezone", Reason: "is unknown"}).Once()
+	ts.repo.On("CreateItem", mock.AnythingOfType("contentfeed.Item")).Return(&contentfeed.Item{ID: 51}, nil).Once()
+	ts.repo.On("DeleteItem", int64(51)).Return(nil).Once()
+	ts.repo.On("UpdatePolledAt", int64(2), now).Return(nil).Once()
+
+	result, err := ts.useCase.PollFeed(context.Background(), feed, now)
+
+	// 1일 전에 게시된 항목만 생성을 시도하고, 생성에 실패한 항목은 다음 polling에 다시 시도하도록 지운다
+	ts.NoError(err)
+	ts.Equal(contentfeed.PollResult{Polled: 1, Skipped: 1, Failed: 1}, *result)
+	ts.creator.AssertNumberOfCalls(ts.T(), "CreateCampaign", 1)
+	ts.repo.AssertExpectations(ts.T())
+	ts.repo.AssertNotCalled(ts.T(), "LinkCampaign", mock.Anything, mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_PollFeed_ItemSaveError() {
+	feed := contentfeed.Feed{ID: 2, ChannelID: 7, URL: "https://blog.buzzvil.com/atom", IsEnabled: true, CampaignDays: 1}
+	ts.repo.On("FindItems", mock.Anything, mock.Anything).Return([]contentfeed.Item{}, nil).Once()
+	ts.repo.On("CreateItem", mock.AnythingOfType("contentfeed.Item")).Return(nil, errors.New("duplicate entry")).Once()
+	ts.repo.On("UpdatePolledAt", int64(2), now).Return(nil).Once()
+
+	result, err := ts.useCase.PollFeed(context.Background(), feed, now)
+
+	// 항목을 저장하지 못하면 캠페인을 만들지 않는다
+	ts.NoError(err)
+	ts.Equal(contentfeed.PollResult{Polled: 1, Skipped: 1, Failed: 1}, *result)
+	ts.creator.AssertNotCalled(ts.T(), "CreateCampaign", mock.Anything, mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_PollFeed_DropsNonWebURLs() {
+	feed := contentfeed.Feed{ID: 4, ChannelID: 7, URL: "https://news.buzzvil.com/unsafe", IsEnabled: true}
+	ts.repo.On("FindItems", mock.Anything, mock.Anything).Return([]contentfeed.Item{}, nil).Once()
+	ts.creator.On("CreateCampaign", "feed:4", mock.AnythingOfType("contentadmin.Campaign")).
+		Return(&contentadmin.Campaign{ID: 100}, nil).Once()
+	ts.repo.On("CreateItem", mock.AnythingOfType("contentfeed.Item")).Return(&contentfeed.Item{ID: 52}, nil).Once()
+	ts.repo.On("LinkCampaign", int64(52), int64(100)).Return(nil).Once()
+	ts.repo.On("UpdatePolledAt", int64(4), now).Return(nil).Once()
+
+	result, err := ts.useCase.PollFeed(context.Background(), feed, now)
+
+	// javascript:, file: 링크의 항목은 건너뛰고 file: 이미지는 사용하지 않는다
+	ts.NoError(err)
+	ts.Equal(contentfeed.PollResult{Polled: 1, Created: 1, Skipped: 2}, *result)
+	created := ts.creator.Calls[0].Arguments.Get(1).(contentadmin.Campaign)
+	ts.Equal("https://news.buzzvil.com/articles/4", created.ClickURL)
+	ts.Equal("", created.Image)
+}
+
+func (ts *UseCaseTestSuite) Test_PollFeeds() {
+	polledAt := now.Add(-10 * time.Minute)
+	ts.repo.On("GetEnabledFeeds").Return([]contentfeed.Feed{
+		{ID: 1, URL: "https://news.buzzvil.com/rss", IsEnabled: true, PollInterval: time.Hour, LastPolledAt: &polledAt},
+		{ID: 3, URL: "https://news.buzzvil.com/missing", IsEnabled: true},
+	}, nil).Once()
+
+	result, err := ts.useCase.PollFeeds(context.Background(), now)
+
+	ts.NoError(err)
+	ts.Equal(contentfeed.PollResult{Failed: 1}, *result)
+	ts.repo.AssertNotCalled(ts.T(), "UpdatePolledAt", mock.Anything, mock.Anything)
+}
+
+func TestFeed_IsDue(t *testing.T) {
+	polledAt := now.Add(-30 * time.Minute)
+	feed := contentfeed.Feed{IsEnabled: true, LastPolledAt: &polledAt}
+
+	if !feed.IsDue(now) {
+		t.Error("feed of the default interval should be due")
+	}
+	feed.PollInterval = time.Hour
+	if feed.IsDue(now) {
+		t.Error("feed polled within the interval should not be due")
+	}
+	feed.LastPolledAt, feed.IsEnabled = nil, false
+	if feed.IsDue(now) {
+		t.Error("disabled feed should not be due")
+	}
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	creator *mockCreator
+	useCase contentfeed.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.creator = new(mockCreator)
+	ts.useCase = contentfeed.NewUseCase(ts.repo, fixtureFetcher{
+		"https://news.buzzvil.com/rss":        "rss.xml",
+		"https://blog.buzzvil.com/atom":       "atom.xml",
+		"https://news.buzzvil.com/articles/3": "article.html",
+		"https://news.buzzvil.com/unsafe":     "unsafe.xml",
+	}, ts.creator)
+}
+
+// fixtureFetcher serves the fixture files of testdata by url
+type fixtureFetcher map[string]string
+
+func (f fixtureFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	name, ok := f[url]
+	if !ok {
+		return nil, errors.New("fetching " + url + " responded 404")
+	}
+	return ioutil.ReadFile("testdata/" + name)
+}
+
+var _ contentfeed.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) GetEnabledFeeds() ([]contentfeed.Feed, error) {
+	ret := r.Called()
+	return ret.Get(0).([]contentfeed.Feed), ret.Error(1)
+}
+
+func (r *mockRepo) UpdatePolledAt(feedID int64, polledAt time.Time) error {
+	return r.Called(feedID, polledAt).Error(0)
+}
+
+func (r *mockRepo) FindItems(guids []string, links []string) ([]contentfeed.Item, error) {
+	ret := r.Called(guids, links)
+	return ret.Get(0).([]contentfeed.Item), ret.Error(1)
+}
+
+func (r *mockRepo) CreateItem(item contentfeed.Item) (*contentfeed.Item, error) {
+	ret := r.Called(item)
+	created, _ := ret.Get(0).(*contentfeed.Item)
+	return created, ret.Error(1)
+}
+
+func (r *mockRepo) LinkCampaign(itemID int64, campaignID int64) error {
+	return r.Called(itemID, campaignID).Error(0)
+}
+
+func (r *mockRepo) DeleteItem(itemID int64) error {
+	return r.Called(itemID).Error(0)
+}
+
+var _ contentfeed.CampaignCreator = &mockCreator{}
+
+type mockCreator struct {
+	mock.Mock
+}
+
+func (c *mockCreator) CreateCampaign(actor string, campaign contentadmin.Campaign) (*contentadmin.Campaign, error) {
+	ret := c.Called(actor, campaign)
+	created, _ := ret.Get(0).(*contentadmin.Campaign)
+	return created, ret.Error(1)
+}
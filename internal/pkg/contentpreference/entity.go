@@ -0,0 +1,66 @@
+package contentpreference
+
+import "time"
+
+// Limits of the preference of a device
+const (
+	MaxFollowedChannels = 500
+	MaxMutedChannels    = 500
+	MaxLanguages        = 10
+	MaxCategoryWeights  = 100
+)
+
+// Preference is the content preference a device set explicitly
+type Preference struct {
+	DeviceID           int64
+	FollowedChannelIDs []int64 // from the oldest
+	MutedChannelIDs    []int64 // from the oldest
+	Languages          []string
+	// CategoryWeights는 "이 카테고리 덜 보기" 가중치로 (0, 1) 사이면 덜 노출하고 0이면 노출하지 않는다. 없는 카테고리는 1
+	CategoryWeights map[string]float64
+	UpdatedAt       time.Time
+}
+
+// IsFollowing returns true if the device follows the channel
+func (p *Preference) IsFollowing(channelID int64) bool {
+	return containsID(p.FollowedChannelIDs, channelID)
+}
+
+// IsMuting returns true if the device muted the channel
+func (p *Preference) IsMuting(channelID int64) bool {
+	return containsID(p.MutedChannelIDs, channelID)
+}
+
+// ExcludedCategories returns the categories of weight 0
+func (p *Preference) ExcludedCategories() []string {
+	var categories []string
+	for category, weight := range p.CategoryWeights {
+		if weight <= 0 {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// DemotedCategories returns the weights of the categories shown less but not excluded
+func (p *Preference) DemotedCategories() map[string]float64 {
+	var weights map[string]float64
+	for category, weight := range p.CategoryWeights {
+		if weight > 0 && weight < 1 {
+			if weights == nil {
+				weights = make(map[string]float64)
+			}
+			weights[category] = weight
+		}
+	}
+	return weights
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
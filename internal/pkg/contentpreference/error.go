@@ -0,0 +1,29 @@
+package contentpreference
+
+import "fmt"
+
+var (
+	_ error = InvalidPreferenceError{}
+	_ error = TooManyChannelsError{}
+)
+
+// InvalidPreferenceError will be returned when the preference to update is out of range
+type InvalidPreferenceError struct {
+	Reason string
+}
+
+// Error func definition
+func (e InvalidPreferenceError) Error() string {
+	return fmt.Sprintf("invalid content preference: %s", e.Reason)
+}
+
+// TooManyChannelsError will be returned when a device follows or mutes more channels than the limit
+type TooManyChannelsError struct {
+	DeviceID int64
+	Limit    int
+}
+
+// Error func definition
+func (e TooManyChannelsError) Error() string {
+	return fmt.Sprintf("device %d can't have more than %d channels", e.DeviceID, e.Limit)
+}
@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"encoding/json"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference"
+)
+
+type entityMapper struct {
+}
+
+func (m *entityMapper) dbPreferenceToPreference(dbPreference DBPreference) (contentpreference.Preference, error) {
+	preference := contentpreference.Preference{
+		DeviceID:  dbPreference.DeviceID,
+		UpdatedAt: dbPreference.UpdatedAt,
+	}
+	if err := unmarshalIfNotEmpty(dbPreference.FollowedChannelIDs, &preference.FollowedChannelIDs); err != nil {
+		return contentpreference.Preference{}, err
+	}
+	if err := unmarshalIfNotEmpty(dbPreference.MutedChannelIDs, &preference.MutedChannelIDs); err != nil {
+		return contentpreference.Preference{}, err
+	}
+	if err := unmarshalIfNotEmpty(dbPreference.Languages, &preference.Languages); err != nil {
+		return contentpreference.Preference{}, err
+	}
+	if err := unmarshalIfNotEmpty(dbPreference.CategoryWeights, &preference.CategoryWeights); err != nil {
+		return contentpreference.Preference{}, err
+	}
+	return preference, nil
+}
+
+func (m *entityMapper) preferenceToDBPreference(preference contentpreference.Preference) (DBPreference, error) {
+	dbPreference := DBPreference{
+		DeviceID:  preference.DeviceID,
+		UpdatedAt: preference.UpdatedAt,
+	}
+	var err error
+	if dbPreference.FollowedChannelIDs, err = marshalIfNotEmpty(preference.FollowedChannelIDs, len(preference.FollowedChannelIDs)); err != nil {
+		return DBPreference{}, err
+	}
+	if dbPreference.MutedChannelIDs, err = marshalIfNotEmpty(preference.MutedChannelIDs, len(preference.MutedChannelIDs)); err != nil {
+		return DBPreference{}, err
+	}
+	if dbPreference.Languages, err = marshalIfNotEmpty(preference.Languages, len(preference.Languages)); err != nil {
+		return DBPreference{}, err
+	}
+	if dbPreference.CategoryWeights, err = marshalIfNotEmpty(preference.CategoryWeights, len(preference.CategoryWeights)); err != nil {
+		return DBPreference{}, err
+	}
+	return dbPreference, nil
+}
+
+func unmarshalIfNotEmpty(data string, v interface{}) error {
+	if data == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(data), v)
+}
+
+// marshalIfNotEmpty returns "" for the empty value so that the column is cleared
+func marshalIfNotEmpty(v interface{}, length int) (string, error) {
+	if length == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
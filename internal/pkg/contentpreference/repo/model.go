@@ -0,0 +1,19 @@
+package repo
+
+import "time"
+
+// DBPreference struct definition
+type DBPreference struct {
+	DeviceID           int64  `gorm:"primary_key;auto_increment:false"`
+	FollowedChannelIDs string // JSON array
+	MutedChannelIDs    string // JSON array
+	Languages          string // JSON array
+	CategoryWeights    string // JSON object
+
+	UpdatedAt time.Time
+}
+
+// TableName func definition
+func (DBPreference) TableName() string {
+	return "device_content_preferences"
+}
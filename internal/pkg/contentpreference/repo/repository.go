@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference"
+	"github.com/jinzhu/gorm"
+)
+
+// Repository struct definition
+type Repository struct {
+	db     *gorm.DB
+	mapper *entityMapper
+}
+
+// GetPreference returns the preference of the device. nil is returned if it doesn't exist
+func (r *Repository) GetPreference(deviceID int64) (*contentpreference.Preference, error) {
+	var dbPreference DBPreference
+	err := r.db.Where("device_id = ?", deviceID).First(&dbPreference).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	preference, err := r.mapper.dbPreferenceToPreference(dbPreference)
+	if err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// SavePreference updates the preference of the device or inserts it if there's none
+func (r *Repository) SavePreference(preference contentpreference.Preference) error {
+	dbPreference, err := r.mapper.preferenceToDBPreference(preference)
+	if err != nil {
+		return err
+	}
+	return r.db.Save(&dbPreference).Error
+}
+
+// New returns content preference repository
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db:     db,
+		mapper: &entityMapper{},
+	}
+}
+
+var _ contentpreference.Repository = &Repository{}
@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	db   *gorm.DB
+	repo contentpreference.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	ts.NoError(err)
+	ts.mock = mock
+	ts.db, err = gorm.Open("mysql", db)
+	ts.NoError(err)
+	ts.repo = New(ts.db)
+}
+
+func (ts *RepoTestSuite) AfterTest() {
+	_ = ts.db.Close()
+}
+
+func (ts *RepoTestSuite) Test_GetPreference() {
+	updatedAt := time.Now()
+	req := "SELECT * FROM `device_content_preferences` WHERE (device_id = ?) ORDER BY `device_content_preferences`.`device_id` ASC LIMIT 1"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"device_id", "followed_channel_ids", "muted_channel_ids", "languages", "category_weights", "updated_at"}).
+			AddRow(1, "[2,3]", "", `["ko"]`, `{"sports":0.5}`, updatedAt))
+
+	preference, err := ts.repo.GetPreference(1)
+
+	ts.NoError(err)
+	ts.Equal(&contentpreference.Preference{
+		DeviceID:           1,
+		FollowedChannelIDs: []int64{2, 3},
+		Languages:          []string{"ko"},
+		CategoryWeights:    map[string]float64{"sports": 0.5},
+		UpdatedAt:          updatedAt,
+	}, preference)
+}
+
+func (ts *RepoTestSuite) Test_GetPreference_NotFound() {
+	req := "SELECT * FROM `device_content_preferences` WHERE (device_id = ?) ORDER BY `device_content_preferences`.`device_id` ASC LIMIT 1"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"device_id"}))
+
+	preference, err := ts.repo.GetPreference(1)
+
+	ts.NoError(err)
+	ts.Nil(preference)
+}
+
+func (ts *RepoTestSuite) Test_SavePreference() {
+	ts.mock.ExpectBegin()
+	ts.mock.ExpectExec(ts.fixedFullRe("UPDATE `device_content_preferences` SET `followed_channel_ids` = ?, `muted_channel_ids` = ?, `languages` = ?, `category_weights` = ?, `updated_at` = ? WHERE `device_content_preferences`.`device_id` = ?")).
+		WithArgs("", "[3]", "", "", sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	ts.mock.ExpectCommit()
+
+	err := ts.repo.SavePreference(contentpreference.Preference{DeviceID: 1, MutedChannelIDs: []int64{3}})
+
+	ts.NoError(err)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) fixedFullRe(s string) string {
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(s))
+}
@@ -0,0 +1,8 @@
+package contentpreference
+
+// Repository interface definition
+type Repository interface {
+	// GetPreference returns nil if the device hasn't set any preference
+	GetPreference(deviceID int64) (*Preference, error)
+	SavePreference(preference Preference) error
+}
@@ -0,0 +1,182 @@
+package contentpreference
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UseCase interface definition
+type UseCase interface {
+	// GetPreference returns the empty preference if the device hasn't set any
+	GetPreference(deviceID int64) (*Preference, error)
+	// FollowChannel follows the channel. The channel is unmuted if it's muted
+	FollowChannel(deviceID int64, channelID int64) (*Preference, error)
+	UnfollowChannel(deviceID int64, channelID int64) (*Preference, error)
+	// MuteChannel mutes the channel. The channel is unfollowed if it's followed
+	MuteChannel(deviceID int64, channelID int64) (*Preference, error)
+	UnmuteChannel(deviceID int64, channelID int64) (*Preference, error)
+	// UpdateSettings replaces the preferred languages and the category weights. nil keeps the current one
+	UpdateSettings(deviceID int64, languages []string, categoryWeights map[string]float64) (*Preference, error)
+}
+
+type useCase struct {
+	repo Repository
+}
+
+// GetPreference func definition
+func (u *useCase) GetPreference(deviceID int64) (*Preference, error) {
+	preference, err := u.repo.GetPreference(deviceID)
+	if err != nil {
+		return nil, err
+	} else if preference == nil {
+		preference = &Preference{DeviceID: deviceID}
+	}
+	return preference, nil
+}
+
+// FollowChannel func definition
+func (u *useCase) FollowChannel(deviceID int64, channelID int64) (*Preference, error) {
+	return u.update(deviceID, func(p *Preference) error {
+		if p.IsFollowing(channelID) {
+			return nil
+		} else if len(p.FollowedChannelIDs) >= MaxFollowedChannels {
+			return TooManyChannelsError{DeviceID: deviceID, Limit: MaxFollowedChannels}
+		}
+		p.FollowedChannelIDs = append(p.FollowedChannelIDs, channelID)
+		p.MutedChannelIDs = removeID(p.MutedChannelIDs, channelID)
+		return nil
+	})
+}
+
+// UnfollowChannel func definition
+func (u *useCase) UnfollowChannel(deviceID int64, channelID int64) (*Preference, error) {
+	return u.update(deviceID, func(p *Preference) error {
+		p.FollowedChannelIDs = removeID(p.FollowedChannelIDs, channelID)
+		return nil
+	})
+}
+
+// MuteChannel func definition
+func (u *useCase) MuteChannel(deviceID int64, channelID int64) (*Preference, error) {
+	return u.update(deviceID, func(p *Preference) error {
+		if p.IsMuting(channelID) {
+			return nil
+		} else if len(p.MutedChannelIDs) >= MaxMutedChannels {
+			return TooManyChannelsError{DeviceID: deviceID, Limit: MaxMutedChannels}
+		}
+		p.MutedChannelIDs = append(p.MutedChannelIDs, channelID)
+		p.FollowedChannelIDs = removeID(p.FollowedChannelIDs, channelID)
+		return nil
+	})
+}
+
+// UnmuteChannel func definition
+func (u *useCase) UnmuteChannel(deviceID int64, channelID int64) (*Preference, error) {
+	return u.update(deviceID, func(p *Preference) error {
+		p.MutedChannelIDs = removeID(p.MutedChannelIDs, channelID)
+		return nil
+	})
+}
+
+// UpdateSettings func definition
+func (u *useCase) UpdateSettings(deviceID int64, languages []string, categoryWeights map[string]float64) (*Preference, error) {
+	if languages != nil {
+		normalized, err := normalizeLanguages(languages)
+		if err != nil {
+			return nil, err
+		}
+		languages = normalized
+	}
+	if categoryWeights != nil {
+		normalized, err := normalizeCategoryWeights(categoryWeights)
+		if err != nil {
+			return nil, err
+		}
+		categoryWeights = normalized
+	}
+
+	return u.update(deviceID, func(p *Preference) error {
+		if languages != nil {
+			p.Languages = languages
+		}
+		if categoryWeights != nil {
+			p.CategoryWeights = categoryWeights
+		}
+		return nil
+	})
+}
+
+func (u *useCase) update(deviceID int64, modify func(p *Preference) error) (*Preference, error) {
+	preference, err := u.GetPreference(deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := modify(preference); err != nil {
+		return nil, err
+	}
+
+	preference.UpdatedAt = time.Now()
+	if err := u.repo.SavePreference(*preference); err != nil {
+		return nil, err
+	}
+	return preference, nil
+}
+
+func normalizeLanguages(languages []string) ([]string, error) {
+	normalized := make([]string, 0, len(languages))
+	for _, language := range languages {
+		language = strings.TrimSpace(language)
+		if language == "" {
+			continue
+		} else if len(language) > 8 {
+			return nil, InvalidPreferenceError{Reason: fmt.Sprintf("unknown language %q", language)}
+		} else if !containsString(normalized, language) {
+			normalized = append(normalized, language)
+		}
+	}
+	if len(normalized) > MaxLanguages {
+		return nil, InvalidPreferenceError{Reason: fmt.Sprintf("more than %d languages", MaxLanguages)}
+	}
+	return normalized, nil
+}
+
+// normalizeCategoryWeights drops the categories of weight 1 which is the default
+func normalizeCategoryWeights(categoryWeights map[string]float64) (map[string]float64, error) {
+	normalized := make(map[string]float64, len(categoryWeights))
+	for category, weight := range categoryWeights {
+		category = strings.TrimSpace(category)
+		if category == "" || weight < 0 || weight > 1 {
+			return nil, InvalidPreferenceError{Reason: fmt.Sprintf("weight of category %q should be in [0, 1]", category)}
+		} else if weight < 1 {
+			normalized[category] = weight
+		}
+	}
+	if len(normalized) > MaxCategoryWeights {
+		return nil, InvalidPreferenceError{Reason: fmt.Sprintf("more than %d category weights", MaxCategoryWeights)}
+	}
+	return normalized, nil
+}
+
+func removeID(ids []int64, id int64) []int64 {
+	for i := range ids {
+		if ids[i] == id {
+			return append(ids[:i:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// NewUseCase returns content preference use case
+func NewUseCase(repo Repository) UseCase {
+	return &useCase{repo: repo}
+}
@@ -0,0 +1,113 @@
+package contentpreference_test
+
+import (
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func (ts *UseCaseTestSuite) Test_GetPreference_NotSet() {
+	ts.repo.On("GetPreference", int64(1)).Return(nil, nil).Once()
+
+	preference, err := ts.useCase.GetPreference(1)
+
+	ts.NoError(err)
+	ts.Equal(&contentpreference.Preference{DeviceID: 1}, preference)
+}
+
+func (ts *UseCaseTestSuite) Test_FollowChannel() {
+	ts.repo.On("GetPreference", int64(1)).Return(&contentpreference.Preference{DeviceID: 1, FollowedChannelIDs: []int64{2}, MutedChannelIDs: []int64{3, 4}}, nil).Once()
+	ts.repo.On("SavePreference", mock.AnythingOfType("contentpreference.Preference")).Return(nil).Once()
+
+	preference, err := ts.useCase.FollowChannel(1, 3)
+
+	ts.NoError(err)
+	ts.Equal([]int64{2, 3}, preference.FollowedChannelIDs)
+	ts.Equal([]int64{4}, preference.MutedChannelIDs)
+	ts.False(preference.UpdatedAt.IsZero())
+}
+
+func (ts *UseCaseTestSuite) Test_FollowChannel_TooMany() {
+	followed := make([]int64, contentpreference.MaxFollowedChannels)
+	for i := range followed {
+		followed[i] = int64(i + 100)
+	}
+	ts.repo.On("GetPreference", int64(1)).Return(&contentpreference.Preference{DeviceID: 1, FollowedChannelIDs: followed}, nil).Once()
+
+	preference, err := ts.useCase.FollowChannel(1, 3)
+
+	ts.IsType(contentpreference.TooManyChannelsError{}, err)
+	ts.Nil(preference)
+	ts.repo.AssertNotCalled(ts.T(), "SavePreference", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_MuteChannel() {
+	ts.repo.On("GetPreference", int64(1)).Return(nil, nil).Once()
+	ts.repo.On("SavePreference", mock.AnythingOfType("contentpreference.Preference")).Return(nil).Once()
+
+	preference, err := ts.useCase.MuteChannel(1, 3)
+
+	ts.NoError(err)
+	ts.Equal([]int64{3}, preference.MutedChannelIDs)
+	ts.True(preference.IsMuting(3))
+}
+
+func (ts *UseCaseTestSuite) Test_UpdateSettings() {
+	ts.Run("success", func() {
+		ts.repo.On("GetPreference", int64(1)).Return(&contentpreference.Preference{DeviceID: 1, Languages: []string{"en"}}, nil).Once()
+		ts.repo.On("SavePreference", mock.AnythingOfType("contentpreference.Preference")).Return(nil).Once()
+
+		preference, err := ts.useCase.UpdateSettings(1, []string{" ko", "ko", "zh_Hant"}, map[string]float64{"sports": 0, "news": 0.5, "fun": 1})
+
+		ts.NoError(err)
+		ts.Equal([]string{"ko", "zh_Hant"}, preference.Languages)
+		ts.Equal(map[string]float64{"sports": 0, "news": 0.5}, preference.CategoryWeights)
+		ts.Equal([]string{"sports"}, preference.ExcludedCategories())
+		ts.Equal(map[string]float64{"news": 0.5}, preference.DemotedCategories())
+	})
+	ts.Run("out of range", func() {
+		preference, err := ts.useCase.UpdateSettings(1, nil, map[string]float64{"sports": 1.5})
+
+		ts.IsType(contentpreference.InvalidPreferenceError{}, err)
+		ts.Nil(preference)
+	})
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	useCase contentpreference.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.useCase = contentpreference.NewUseCase(ts.repo)
+}
+
+func (ts *UseCaseTestSuite) TearDownTest() {
+	ts.repo.AssertExpectations(ts.T())
+}
+
+var _ contentpreference.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) GetPreference(deviceID int64) (*contentpreference.Preference, error) {
+	ret := r.Called(deviceID)
+	if preference := ret.Get(0); preference != nil {
+		return preference.(*contentpreference.Preference), ret.Error(1)
+	}
+	return nil, ret.Error(1)
+}
+
+func (r *mockRepo) SavePreference(preference contentpreference.Preference) error {
+	return r.Called(preference).Error(0)
+}
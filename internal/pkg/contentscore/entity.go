@@ -0,0 +1,51 @@
+package contentscore
+
+import (
+	"fmt"
+	"time"
+)
+
+// VersionStatus type definition
+type VersionStatus string
+
+// VersionStatus constants
+const (
+	StatusStaged     VersionStatus = "staged"      // scores are being saved. not used for allocation
+	StatusPublished  VersionStatus = "published"   // the latest published version is active
+	StatusRolledBack VersionStatus = "rolled_back" // excluded from the active version by rollback
+)
+
+const (
+	// DefaultRetainCount is the number of published versions kept for rollback
+	DefaultRetainCount = 5
+
+	activeVersionTTL = time.Minute
+)
+
+// Target is the device segment which campaign scores are computed for
+type Target struct {
+	Country string
+	Gender  string
+	Age     int
+}
+
+// String func definition
+func (t Target) String() string {
+	return fmt.Sprintf("%s_%s_%d", t.Country, t.Gender, t.Age)
+}
+
+// Version is the model version of a full score set
+type Version struct {
+	ID          int64
+	Name        string
+	Status      VersionStatus
+	CreatedAt   time.Time
+	PublishedAt *time.Time
+}
+
+// ScoreSet holds campaign scores of the target keyed by campaign id
+type ScoreSet struct {
+	Version Version
+	Target  Target
+	Scores  map[int64]int
+}
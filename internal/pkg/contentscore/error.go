@@ -0,0 +1,60 @@
+package contentscore
+
+import "fmt"
+
+var (
+	_ error = VersionNotFoundError{}
+	_ error = VersionPublishedError{}
+	_ error = EmptyVersionError{}
+	_ error = NoActiveVersionError{}
+	_ error = NoPreviousVersionError{}
+)
+
+// VersionNotFoundError will be returned when the version doesn't exist
+type VersionNotFoundError struct {
+	Name string
+}
+
+// Error func definition
+func (e VersionNotFoundError) Error() string {
+	return fmt.Sprintf("content score version %s is not found", e.Name)
+}
+
+// VersionPublishedError will be returned when scores are saved into the published version.
+// Published score sets are immutable so that allocation sees a full score set of a version.
+type VersionPublishedError struct {
+	Name string
+}
+
+// Error func definition
+func (e VersionPublishedError) Error() string {
+	return fmt.Sprintf("content score version %s is already published", e.Name)
+}
+
+// EmptyVersionError will be returned when the version without scores is published
+type EmptyVersionError struct {
+	Name string
+}
+
+// Error func definition
+func (e EmptyVersionError) Error() string {
+	return fmt.Sprintf("content score version %s has no scores", e.Name)
+}
+
+// NoActiveVersionError will be returned when no version is published
+type NoActiveVersionError struct{}
+
+// Error func definition
+func (e NoActiveVersionError) Error() string {
+	return "no content score version is published"
+}
+
+// NoPreviousVersionError will be returned when there's no published version to roll back to
+type NoPreviousVersionError struct {
+	Active string
+}
+
+// Error func definition
+func (e NoPreviousVersionError) Error() string {
+	return fmt.Sprintf("no published version to roll back to from %s", e.Active)
+}
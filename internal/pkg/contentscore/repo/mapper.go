@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+)
+
+type entityMapper struct {
+}
+
+func (m *entityMapper) dbVersionToVersion(dbVersion DBVersion) contentscore.Version {
+	return contentscore.Version{
+		ID:          dbVersion.ID,
+		Name:        dbVersion.Name,
+		Status:      contentscore.VersionStatus(dbVersion.Status),
+		CreatedAt:   dbVersion.CreatedAt,
+		PublishedAt: dbVersion.PublishedAt,
+	}
+}
+
+func (m *entityMapper) scoresToDBScores(scores map[int64]int) (string, error) {
+	dbScores := make(map[string]int, len(scores))
+	for campaignID, score := range scores {
+		dbScores[strconv.FormatInt(campaignID, 10)] = score
+	}
+
+	encoded, err := json.Marshal(dbScores)
+	return string(encoded), err
+}
+
+func (m *entityMapper) dbScoresToScores(dbScores string) (map[int64]int, error) {
+	decoded := make(map[string]int)
+	if err := json.Unmarshal([]byte(dbScores), &decoded); err != nil {
+		return nil, err
+	}
+
+	scores := make(map[int64]int, len(decoded))
+	for key, score := range decoded {
+		campaignID, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		scores[campaignID] = score
+	}
+	return scores, nil
+}
@@ -0,0 +1,37 @@
+package repo
+
+import "time"
+
+// DBVersion struct definition
+type DBVersion struct {
+	ID          int64 `gorm:"primary_key"`
+	Name        string
+	Status      string
+	PublishedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName func definition
+func (DBVersion) TableName() string {
+	return "content_score_versions"
+}
+
+// DBScoreSet struct definition
+type DBScoreSet struct {
+	ID        int64 `gorm:"primary_key"`
+	VersionID int64
+	Country   string
+	Gender    string
+	Age       int
+	Scores    string // json of campaign id to score
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName func definition
+func (DBScoreSet) TableName() string {
+	return "content_score_sets"
+}
@@ -0,0 +1,158 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+	"github.com/jinzhu/gorm"
+)
+
+// Repository struct definition
+type Repository struct {
+	db     *gorm.DB
+	mapper *entityMapper
+}
+
+// GetVersion returns the version of the name. nil is returned if it doesn't exist
+func (r *Repository) GetVersion(name string) (*contentscore.Version, error) {
+	var dbVersion DBVersion
+	err := r.db.Where("name = ?", name).First(&dbVersion).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	version := r.mapper.dbVersionToVersion(dbVersion)
+	return &version, nil
+}
+
+// CreateVersion creates the staged version of the name
+func (r *Repository) CreateVersion(name string) (*contentscore.Version, error) {
+	dbVersion := DBVersion{Name: name, Status: string(contentscore.StatusStaged)}
+	if err := r.db.Create(&dbVersion).Error; err != nil {
+		return nil, err
+	}
+
+	version := r.mapper.dbVersionToVersion(dbVersion)
+	return &version, nil
+}
+
+// GetPublishedVersions returns the published versions from the latest one
+func (r *Repository) GetPublishedVersions(limit int) ([]contentscore.Version, error) {
+	var dbVersions []DBVersion
+	err := r.db.Where("status = ?", string(contentscore.StatusPublished)).
+		Order("published_at DESC, id DESC").Limit(limit).Find(&dbVersions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]contentscore.Version, 0, len(dbVersions))
+	for _, dbVersion := range dbVersions {
+		versions = append(versions, r.mapper.dbVersionToVersion(dbVersion))
+	}
+	return versions, nil
+}
+
+// Publish marks the version published and deletes the published or rolled back versions except the latest retainCount ones in a transaction
+func (r *Repository) Publish(versionID int64, publishedAt time.Time, retainCount int) error {
+	tx := r.db.Begin()
+	if err := r.publish(tx, versionID, publishedAt, retainCount); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (r *Repository) publish(tx *gorm.DB, versionID int64, publishedAt time.Time, retainCount int) error {
+	err := tx.Model(&DBVersion{}).Where("id = ?", versionID).Updates(map[string]interface{}{
+		"status":       string(contentscore.StatusPublished),
+		"published_at": publishedAt,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	var retainedIDs []int64
+	err = tx.Model(&DBVersion{}).Where("status = ?", string(contentscore.StatusPublished)).
+		Order("published_at DESC, id DESC").Limit(retainCount).Pluck("id", &retainedIDs).Error
+	if err != nil {
+		return err
+	}
+
+	var expiredIDs []int64
+	err = tx.Model(&DBVersion{}).Where("status IN (?) AND id NOT IN (?)",
+		[]string{string(contentscore.StatusPublished), string(contentscore.StatusRolledBack)}, retainedIDs).
+		Pluck("id", &expiredIDs).Error
+	if err != nil || len(expiredIDs) == 0 {
+		return err
+	}
+
+	if err := tx.Where("version_id IN (?)", expiredIDs).Delete(DBScoreSet{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("id IN (?)", expiredIDs).Delete(DBVersion{}).Error
+}
+
+// RollBack marks the version rolled back so that the previously published version becomes active
+func (r *Repository) RollBack(versionID int64) error {
+	return r.db.Model(&DBVersion{}).Where("id = ?", versionID).
+		Update("status", string(contentscore.StatusRolledBack)).Error
+}
+
+// SaveScores replaces the scores of the target in the version
+func (r *Repository) SaveScores(versionID int64, target contentscore.Target, scores map[int64]int) error {
+	dbScores, err := r.mapper.scoresToDBScores(scores)
+	if err != nil {
+		return err
+	}
+
+	tx := r.db.Begin()
+	err = tx.Where("version_id = ? AND country = ? AND gender = ? AND age = ?", versionID, target.Country, target.Gender, target.Age).
+		Delete(DBScoreSet{}).Error
+	if err == nil {
+		err = tx.Create(&DBScoreSet{
+			VersionID: versionID,
+			Country:   target.Country,
+			Gender:    target.Gender,
+			Age:       target.Age,
+			Scores:    dbScores,
+		}).Error
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// GetScores returns the scores of the target in the version. nil is returned if the target has no scores
+func (r *Repository) GetScores(versionID int64, target contentscore.Target) (map[int64]int, error) {
+	var dbScoreSet DBScoreSet
+	err := r.db.Where("version_id = ? AND country = ? AND gender = ? AND age = ?", versionID, target.Country, target.Gender, target.Age).
+		First(&dbScoreSet).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return r.mapper.dbScoresToScores(dbScoreSet.Scores)
+}
+
+// CountScoreSets returns the number of targets which have scores in the version
+func (r *Repository) CountScoreSets(versionID int64) (int, error) {
+	var count int
+	err := r.db.Model(&DBScoreSet{}).Where("version_id = ?", versionID).Count(&count).Error
+	return count, err
+}
+
+// New returns content score repository
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db:     db,
+		mapper: &entityMapper{},
+	}
+}
+
+var _ contentscore.Repository = &Repository{}
@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	db   *gorm.DB
+	repo contentscore.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	ts.NoError(err)
+	ts.mock = mock
+	ts.db, err = gorm.Open("mysql", db)
+	ts.NoError(err)
+	ts.repo = New(ts.db)
+}
+
+func (ts *RepoTestSuite) AfterTest() {
+	_ = ts.db.Close()
+}
+
+func (ts *RepoTestSuite) Test_GetVersion() {
+	req := "SELECT * FROM `content_score_versions` WHERE (name = ?) ORDER BY `content_score_versions`.`id` ASC LIMIT 1"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs("v2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status"}).AddRow(3, "v2", "published"))
+
+	version, err := ts.repo.GetVersion("v2")
+
+	ts.NoError(err)
+	ts.Equal(contentscore.Version{ID: 3, Name: "v2", Status: contentscore.StatusPublished}, *version)
+}
+
+func (ts *RepoTestSuite) Test_GetVersion_NotFound() {
+	ts.mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	version, err := ts.repo.GetVersion("v2")
+
+	ts.NoError(err)
+	ts.Nil(version)
+}
+
+func (ts *RepoTestSuite) Test_Publish() {
+	publishedAt := time.Now()
+	ts.mock.ExpectBegin()
+	ts.mock.ExpectExec(ts.fixedFullRe("UPDATE `content_score_versions` SET `published_at` = ?, `status` = ?, `updated_at` = ? WHERE (id = ?)")).
+		WithArgs(publishedAt, "published", sqlmock.AnyArg(), 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	ts.mock.ExpectQuery(ts.fixedFullRe("SELECT id FROM `content_score_versions` WHERE (status = ?) ORDER BY published_at DESC, id DESC LIMIT 2")).
+		WithArgs("published").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5).AddRow(4))
+	ts.mock.ExpectQuery(ts.fixedFullRe("SELECT id FROM `content_score_versions` WHERE (status IN (?,?) AND id NOT IN (?,?))")).
+		WithArgs("published", "rolled_back", 5, 4).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+	ts.mock.ExpectExec(ts.fixedFullRe("DELETE FROM `content_score_sets` WHERE (version_id IN (?,?))")).
+		WithArgs(2, 3).WillReturnResult(sqlmock.NewResult(0, 10))
+	ts.mock.ExpectExec(ts.fixedFullRe("DELETE FROM `content_score_versions` WHERE (id IN (?,?))")).
+		WithArgs(2, 3).WillReturnResult(sqlmock.NewResult(0, 2))
+	ts.mock.ExpectCommit()
+
+	err := ts.repo.Publish(5, publishedAt, 2)
+
+	ts.NoError(err)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_Publish_RollbackOnError() {
+	ts.mock.ExpectBegin()
+	ts.mock.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("db error"))
+	ts.mock.ExpectRollback()
+
+	err := ts.repo.Publish(5, time.Now(), 2)
+
+	ts.Error(err)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_GetScores() {
+	target := contentscore.Target{Country: "KR", Gender: "M", Age: 30}
+	req := "SELECT * FROM `content_score_sets` WHERE (version_id = ? AND country = ? AND gender = ? AND age = ?) ORDER BY `content_score_sets`.`id` ASC LIMIT 1"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs(5, "KR", "M", 30).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version_id", "scores"}).AddRow(1, 5, `{"1111":10,"2222":20}`))
+
+	scores, err := ts.repo.GetScores(5, target)
+
+	ts.NoError(err)
+	ts.Equal(map[int64]int{1111: 10, 2222: 20}, scores)
+}
+
+func (ts *RepoTestSuite) fixedFullRe(s string) string {
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(s))
+}
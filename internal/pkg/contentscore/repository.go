@@ -0,0 +1,17 @@
+package contentscore
+
+import "time"
+
+// Repository interface definition
+type Repository interface {
+	GetVersion(name string) (*Version, error)
+	CreateVersion(name string) (*Version, error)
+	GetPublishedVersions(limit int) ([]Version, error)
+	// Publish marks the version published and deletes the published or rolled back versions except the latest retainCount ones in a transaction
+	Publish(versionID int64, publishedAt time.Time, retainCount int) error
+	RollBack(versionID int64) error
+
+	SaveScores(versionID int64, target Target, scores map[int64]int) error
+	GetScores(versionID int64, target Target) (map[int64]int, error)
+	CountScoreSets(versionID int64) (int, error)
+}
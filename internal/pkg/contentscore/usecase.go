@@ -0,0 +1,174 @@
+package contentscore
+
+import (
+	"sync"
+	"time"
+)
+
+// UseCase interface definition
+type UseCase interface {
+	SaveScores(versionName string, target Target, scores map[int64]int) error
+	Publish(versionName string) (*Version, error)
+	RollBack() (*Version, error)
+	GetActiveScoreSet(target Target) (*ScoreSet, error)
+}
+
+type useCase struct {
+	repo        Repository
+	retainCount int
+
+	mu       sync.RWMutex
+	active   *Version
+	loadedAt time.Time
+	scores   map[Target]map[int64]int // scores of the active version
+}
+
+// SaveScores stages the scores of the target into the version. The version is created if it doesn't exist.
+func (u *useCase) SaveScores(versionName string, target Target, scores map[int64]int) error {
+	version, err := u.repo.GetVersion(versionName)
+	if err != nil {
+		return err
+	}
+
+	if version == nil {
+		if version, err = u.repo.CreateVersion(versionName); err != nil {
+			return err
+		}
+	} else if version.Status == StatusPublished {
+		return VersionPublishedError{Name: versionName}
+	}
+
+	return u.repo.SaveScores(version.ID, target, scores)
+}
+
+// Publish activates the full score set of the version at once.
+// Published versions older than the latest retainCount ones are deleted.
+func (u *useCase) Publish(versionName string) (*Version, error) {
+	version, err := u.getVersion(versionName)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.Status == StatusPublished {
+		return nil, VersionPublishedError{Name: versionName}
+	}
+
+	count, err := u.repo.CountScoreSets(version.ID)
+	if err != nil {
+		return nil, err
+	} else if count == 0 {
+		return nil, EmptyVersionError{Name: versionName}
+	}
+
+	publishedAt := time.Now()
+	if err := u.repo.Publish(version.ID, publishedAt, u.retainCount); err != nil {
+		return nil, err
+	}
+
+	version.Status = StatusPublished
+	version.PublishedAt = &publishedAt
+	u.setActive(version)
+	return version, nil
+}
+
+// RollBack deactivates the active version and returns the previously published one which becomes active
+func (u *useCase) RollBack() (*Version, error) {
+	versions, err := u.repo.GetPublishedVersions(2)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, NoActiveVersionError{}
+	} else if len(versions) == 1 {
+		return nil, NoPreviousVersionError{Active: versions[0].Name}
+	}
+
+	if err := u.repo.RollBack(versions[0].ID); err != nil {
+		return nil, err
+	}
+
+	u.setActive(&versions[1])
+	return &versions[1], nil
+}
+
+// GetActiveScoreSet returns the scores of the target in the active version.
+// The active version is reloaded every activeVersionTTL so that publish of other servers is applied.
+func (u *useCase) GetActiveScoreSet(target Target) (*ScoreSet, error) {
+	active, err := u.getActiveVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	u.mu.RLock()
+	scores, ok := u.scores[target]
+	u.mu.RUnlock()
+
+	if !ok {
+		if scores, err = u.repo.GetScores(active.ID, target); err != nil {
+			return nil, err
+		}
+
+		// 게시된 버전의 점수는 변경되지 않으므로 버전이 바뀔 때까지 캐시한다
+		u.mu.Lock()
+		if u.active != nil && u.active.ID == active.ID {
+			u.scores[target] = scores
+		}
+		u.mu.Unlock()
+	}
+
+	return &ScoreSet{Version: *active, Target: target, Scores: scores}, nil
+}
+
+func (u *useCase) getVersion(versionName string) (*Version, error) {
+	version, err := u.repo.GetVersion(versionName)
+	if err != nil {
+		return nil, err
+	} else if version == nil {
+		return nil, VersionNotFoundError{Name: versionName}
+	}
+	return version, nil
+}
+
+func (u *useCase) getActiveVersion() (*Version, error) {
+	u.mu.RLock()
+	active, loadedAt := u.active, u.loadedAt
+	u.mu.RUnlock()
+
+	if active != nil && time.Since(loadedAt) < activeVersionTTL {
+		return active, nil
+	}
+
+	versions, err := u.repo.GetPublishedVersions(1)
+	if err != nil {
+		return nil, err
+	} else if len(versions) == 0 {
+		return nil, NoActiveVersionError{}
+	}
+
+	u.setActive(&versions[0])
+	return &versions[0], nil
+}
+
+func (u *useCase) setActive(version *Version) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.active == nil || u.active.ID != version.ID {
+		u.scores = make(map[Target]map[int64]int)
+	}
+	u.active = version
+	u.loadedAt = time.Now()
+}
+
+// NewUseCase returns UseCase interface. retainCount is the number of published versions kept for rollback.
+func NewUseCase(repo Repository, retainCount int) UseCase {
+	if retainCount <= 0 {
+		retainCount = DefaultRetainCount
+	}
+	return &useCase{
+		repo:        repo,
+		retainCount: retainCount,
+		scores:      make(map[Target]map[int64]int),
+	}
+}
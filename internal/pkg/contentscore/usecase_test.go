@@ -0,0 +1,170 @@
+package contentscore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentscore"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var target = contentscore.Target{Country: "KR", Gender: "M", Age: 30}
+
+func (ts *UseCaseTestSuite) Test_SaveScores_CreatesVersion() {
+	scores := map[int64]int{1111: 10}
+	ts.repo.On("GetVersion", "v2").Return(nil, nil).Once()
+	ts.repo.On("CreateVersion", "v2").Return(&contentscore.Version{ID: 2, Name: "v2", Status: contentscore.StatusStaged}, nil).Once()
+	ts.repo.On("SaveScores", int64(2), target, scores).Return(nil).Once()
+
+	err := ts.useCase.SaveScores("v2", target, scores)
+
+	ts.NoError(err)
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_SaveScores_Published() {
+	ts.repo.On("GetVersion", "v1").Return(&contentscore.Version{ID: 1, Name: "v1", Status: contentscore.StatusPublished}, nil).Once()
+
+	err := ts.useCase.SaveScores("v1", target, map[int64]int{1111: 10})
+
+	ts.Equal(contentscore.VersionPublishedError{Name: "v1"}, err)
+	ts.repo.AssertNotCalled(ts.T(), "SaveScores", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_Publish() {
+	ts.repo.On("GetVersion", "v2").Return(&contentscore.Version{ID: 2, Name: "v2", Status: contentscore.StatusStaged}, nil).Once()
+	ts.repo.On("CountScoreSets", int64(2)).Return(3, nil).Once()
+	ts.repo.On("Publish", int64(2), mock.AnythingOfType("time.Time"), 3).Return(nil).Once()
+	ts.repo.On("GetScores", int64(2), target).Return(map[int64]int{1111: 10}, nil).Once()
+
+	version, err := ts.useCase.Publish("v2")
+
+	ts.NoError(err)
+	ts.Equal(contentscore.StatusPublished, version.Status)
+	ts.NotNil(version.PublishedAt)
+
+	// 게시한 버전이 바로 활성화되고 점수는 캐시된다
+	for i := 0; i < 2; i++ {
+		scoreSet, err := ts.useCase.GetActiveScoreSet(target)
+		ts.NoError(err)
+		ts.Equal("v2", scoreSet.Version.Name)
+		ts.Equal(map[int64]int{1111: 10}, scoreSet.Scores)
+	}
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_Publish_Empty() {
+	ts.repo.On("GetVersion", "v2").Return(&contentscore.Version{ID: 2, Name: "v2", Status: contentscore.StatusStaged}, nil).Once()
+	ts.repo.On("CountScoreSets", int64(2)).Return(0, nil).Once()
+
+	_, err := ts.useCase.Publish("v2")
+
+	ts.Equal(contentscore.EmptyVersionError{Name: "v2"}, err)
+}
+
+func (ts *UseCaseTestSuite) Test_Publish_NotFound() {
+	ts.repo.On("GetVersion", "v2").Return(nil, nil).Once()
+
+	_, err := ts.useCase.Publish("v2")
+
+	ts.Equal(contentscore.VersionNotFoundError{Name: "v2"}, err)
+}
+
+func (ts *UseCaseTestSuite) Test_RollBack() {
+	now := time.Now()
+	versions := []contentscore.Version{
+		{ID: 3, Name: "v3", Status: contentscore.StatusPublished, PublishedAt: &now},
+		{ID: 2, Name: "v2", Status: contentscore.StatusPublished, PublishedAt: &now},
+	}
+	ts.repo.On("GetPublishedVersions", 2).Return(versions, nil).Once()
+	ts.repo.On("RollBack", int64(3)).Return(nil).Once()
+	ts.repo.On("GetScores", int64(2), target).Return(map[int64]int{1111: 5}, nil).Once()
+
+	active, err := ts.useCase.RollBack()
+
+	ts.NoError(err)
+	ts.Equal("v2", active.Name)
+
+	scoreSet, err := ts.useCase.GetActiveScoreSet(target)
+	ts.NoError(err)
+	ts.Equal("v2", scoreSet.Version.Name)
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_RollBack_NoPrevious() {
+	ts.repo.On("GetPublishedVersions", 2).Return([]contentscore.Version{{ID: 3, Name: "v3"}}, nil).Once()
+
+	_, err := ts.useCase.RollBack()
+
+	ts.Equal(contentscore.NoPreviousVersionError{Active: "v3"}, err)
+	ts.repo.AssertNotCalled(ts.T(), "RollBack", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_GetActiveScoreSet_NoActiveVersion() {
+	ts.repo.On("GetPublishedVersions", 1).Return([]contentscore.Version{}, nil).Once()
+
+	scoreSet, err := ts.useCase.GetActiveScoreSet(target)
+
+	ts.Nil(scoreSet)
+	ts.Equal(contentscore.NoActiveVersionError{}, err)
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	useCase contentscore.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.useCase = contentscore.NewUseCase(ts.repo, 3)
+}
+
+var _ contentscore.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) GetVersion(name string) (*contentscore.Version, error) {
+	ret := r.Called(name)
+	version, _ := ret.Get(0).(*contentscore.Version)
+	return version, ret.Error(1)
+}
+
+func (r *mockRepo) CreateVersion(name string) (*contentscore.Version, error) {
+	ret := r.Called(name)
+	return ret.Get(0).(*contentscore.Version), ret.Error(1)
+}
+
+func (r *mockRepo) GetPublishedVersions(limit int) ([]contentscore.Version, error) {
+	ret := r.Called(limit)
+	return ret.Get(0).([]contentscore.Version), ret.Error(1)
+}
+
+func (r *mockRepo) Publish(versionID int64, publishedAt time.Time, retainCount int) error {
+	return r.Called(versionID, publishedAt, retainCount).Error(0)
+}
+
+func (r *mockRepo) RollBack(versionID int64) error {
+	return r.Called(versionID).Error(0)
+}
+
+func (r *mockRepo) SaveScores(versionID int64, target contentscore.Target, scores map[int64]int) error {
+	return r.Called(versionID, target, scores).Error(0)
+}
+
+func (r *mockRepo) GetScores(versionID int64, target contentscore.Target) (map[int64]int, error) {
+	ret := r.Called(versionID, target)
+	return ret.Get(0).(map[int64]int), ret.Error(1)
+}
+
+func (r *mockRepo) CountScoreSets(versionID int64) (int, error) {
+	ret := r.Called(versionID)
+	return ret.Int(0), ret.Error(1)
+}
@@ -0,0 +1,39 @@
+package contentsnapshot
+
+import (
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+)
+
+// Snapshot is the top ranked campaigns of the last successful search of a unit and country.
+// It's served instead when the search isn't available
+type Snapshot struct {
+	UnitID    int64
+	Country   string
+	Hits      []contentcampaign.SearchHit
+	CreatedAt time.Time
+}
+
+// DeviceFilter removes the campaigns of a snapshot the device can't see since the snapshot is shared by every device of the unit
+type DeviceFilter struct {
+	SeenIDs     map[string]bool // keyed by campaign id as device.Activity.SeenCampaignIDs
+	ExcludedIDs []int64         // campaigns hidden by the device
+	Gender      string
+	Age         int // 0: matches campaigns without age targeting only
+	Now         time.Time
+}
+
+// Policy type definition
+type Policy struct {
+	Size            int           // campaigns kept in a snapshot
+	RefreshInterval time.Duration // a snapshot is refreshed at most once in the interval by each instance
+	TTL             time.Duration // a snapshot older than TTL isn't served
+}
+
+// DefaultPolicy keeps top 100 campaigns refreshed every 10 minutes and serves them for a day at most
+var DefaultPolicy = Policy{
+	Size:            100,
+	RefreshInterval: 10 * time.Minute,
+	TTL:             24 * time.Hour,
+}
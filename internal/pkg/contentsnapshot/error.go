@@ -0,0 +1,18 @@
+package contentsnapshot
+
+import "fmt"
+
+var (
+	_ error = NotFoundError{}
+)
+
+// NotFoundError will be returned when the unit and country have no snapshot or it's expired
+type NotFoundError struct {
+	UnitID  int64
+	Country string
+}
+
+// Error func definition
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("content snapshot of unit %d in %s is not found", e.UnitID, e.Country)
+}
@@ -0,0 +1,75 @@
+package contentsnapshot
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+)
+
+// null values and the glob of the indexed document. refer to contentcampaign/indexer
+const (
+	globString   = "GLOB"
+	nullShortMin = -32768
+	nullShortMax = 32767
+)
+
+// targeting is the device targeting of the indexed document of a campaign
+type targeting struct {
+	TargetGender *string `json:"target_gender"`
+	TargetAgeMin *int    `json:"target_age_min"`
+	TargetAgeMax *int    `json:"target_age_max"`
+	EndDate      *string `json:"end_date"`
+}
+
+// Matches returns true if the device can see the campaign of the hit.
+// The campaign doesn't match if its document can't be parsed
+func (f DeviceFilter) Matches(hit contentcampaign.SearchHit) bool {
+	if f.SeenIDs[strconv.FormatInt(hit.ID, 10)] || int64In(hit.ID, f.ExcludedIDs) {
+		return false
+	}
+
+	var t targeting
+	if err := json.Unmarshal(hit.Source, &t); err != nil {
+		return false
+	}
+	return t.matchesGender(f.Gender) && t.matchesAge(f.Age) && t.endsAfter(f.Now)
+}
+
+func (t targeting) matchesGender(gender string) bool {
+	return t.TargetGender == nil || *t.TargetGender == globString || *t.TargetGender == gender
+}
+
+func (t targeting) matchesAge(age int) bool {
+	min, max := nullShortMin, nullShortMax
+	if t.TargetAgeMin != nil {
+		min = *t.TargetAgeMin
+	}
+	if t.TargetAgeMax != nil {
+		max = *t.TargetAgeMax
+	}
+
+	if age == 0 {
+		return min == nullShortMin && max == nullShortMax
+	}
+	return min <= age && age <= max
+}
+
+// endsAfter returns true if the campaign hasn't ended at now. Campaigns ending while the snapshot is served are removed by it
+func (t targeting) endsAfter(now time.Time) bool {
+	if t.EndDate == nil {
+		return true
+	}
+	endDate, err := time.Parse(time.RFC3339, *t.EndDate)
+	return err != nil || endDate.After(now)
+}
+
+func int64In(value int64, items []int64) bool {
+	for _, item := range items {
+		if value == item {
+			return true
+		}
+	}
+	return false
+}
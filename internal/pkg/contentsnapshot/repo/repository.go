@@ -0,0 +1,41 @@
+package repo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentsnapshot"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/go-redis/cache"
+)
+
+const snapshotCacheKeyFormat = "CACHE_GO_CONTENT_SNAPSHOT-%d-%s"
+
+// Repository struct definition
+type Repository struct {
+	redisCache rediscache.RedisSource
+}
+
+// GetSnapshot returns the snapshot stored under the unit and country
+func (r *Repository) GetSnapshot(unitID int64, country string) (*contentsnapshot.Snapshot, error) {
+	var snapshot contentsnapshot.Snapshot
+	err := r.redisCache.GetCache(fmt.Sprintf(snapshotCacheKeyFormat, unitID, country), &snapshot)
+	if err == cache.ErrCacheMiss {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// SaveSnapshot stores the snapshot under the unit and country of it
+func (r *Repository) SaveSnapshot(snapshot contentsnapshot.Snapshot, ttl time.Duration) error {
+	return r.redisCache.SetCache(fmt.Sprintf(snapshotCacheKeyFormat, snapshot.UnitID, snapshot.Country), snapshot, ttl)
+}
+
+// New returns content snapshot repository
+func New(redisCache rediscache.RedisSource) *Repository {
+	return &Repository{redisCache: redisCache}
+}
+
+var _ contentsnapshot.Repository = &Repository{}
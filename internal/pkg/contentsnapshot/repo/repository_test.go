@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentsnapshot"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/go-redis/cache"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	redisCache *mockRedisCache
+	repo       contentsnapshot.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	ts.redisCache = new(mockRedisCache)
+	ts.repo = New(ts.redisCache)
+}
+
+func (ts *RepoTestSuite) AfterTest(_, _ string) {
+	ts.redisCache.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) Test_SaveSnapshot() {
+	snapshot := contentsnapshot.Snapshot{
+		UnitID:    100,
+		Country:   "KR",
+		Hits:      []contentcampaign.SearchHit{{ID: 1, Source: []byte(`{}`)}},
+		CreatedAt: time.Now(),
+	}
+	ts.redisCache.On("SetCache", "CACHE_GO_CONTENT_SNAPSHOT-100-KR", snapshot, time.Hour).Return(nil).Once()
+
+	ts.NoError(ts.repo.SaveSnapshot(snapshot, time.Hour))
+}
+
+func (ts *RepoTestSuite) Test_GetSnapshot() {
+	ts.redisCache.On("GetCache", "CACHE_GO_CONTENT_SNAPSHOT-100-KR", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*(args.Get(1).(*contentsnapshot.Snapshot)) = contentsnapshot.Snapshot{UnitID: 100, Country: "KR"}
+	}).Once()
+
+	snapshot, err := ts.repo.GetSnapshot(100, "KR")
+
+	ts.NoError(err)
+	ts.Equal(&contentsnapshot.Snapshot{UnitID: 100, Country: "KR"}, snapshot)
+}
+
+func (ts *RepoTestSuite) Test_GetSnapshot_Miss() {
+	ts.redisCache.On("GetCache", "CACHE_GO_CONTENT_SNAPSHOT-100-KR", mock.Anything).Return(cache.ErrCacheMiss).Once()
+
+	snapshot, err := ts.repo.GetSnapshot(100, "KR")
+
+	ts.NoError(err)
+	ts.Nil(snapshot)
+}
+
+func (ts *RepoTestSuite) Test_GetSnapshot_Error() {
+	redisErr := errors.New("redis is unavailable")
+	ts.redisCache.On("GetCache", "CACHE_GO_CONTENT_SNAPSHOT-100-KR", mock.Anything).Return(redisErr).Once()
+
+	_, err := ts.repo.GetSnapshot(100, "KR")
+
+	ts.Equal(redisErr, err)
+}
+
+var _ rediscache.RedisSource = &mockRedisCache{}
+
+type mockRedisCache struct {
+	mock.Mock
+}
+
+func (r *mockRedisCache) GetCache(key string, obj interface{}) error {
+	ret := r.Called(key, obj)
+	return ret.Error(0)
+}
+
+func (r *mockRedisCache) SetCacheAsync(key string, obj interface{}, expiration time.Duration) {
+	r.Called(key, obj, expiration)
+}
+
+func (r *mockRedisCache) SetCache(key string, obj interface{}, expiration time.Duration) error {
+	ret := r.Called(key, obj, expiration)
+	return ret.Error(0)
+}
+
+func (r *mockRedisCache) DeleteCache(key string) error {
+	ret := r.Called(key)
+	return ret.Error(0)
+}
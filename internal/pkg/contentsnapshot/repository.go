@@ -0,0 +1,20 @@
+package contentsnapshot
+
+import (
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+)
+
+// Repository interface definition
+type Repository interface {
+	// GetSnapshot returns nil if the unit and country have no snapshot
+	GetSnapshot(unitID int64, country string) (*Snapshot, error)
+	// SaveSnapshot replaces the snapshot of the unit and country. It expires after ttl unless it's saved again
+	SaveSnapshot(snapshot Snapshot, ttl time.Duration) error
+}
+
+// Searcher searches the campaigns of a snapshot. contentcampaign.UseCase satisfies it
+type Searcher interface {
+	Search(req contentcampaign.SearchRequest) (*contentcampaign.SearchResult, error)
+}
@@ -0,0 +1,99 @@
+package contentsnapshot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+)
+
+// UseCase interface definition
+type UseCase interface {
+	// GetCampaigns returns up to size campaigns of the snapshot of the unit and country matching the filter in the order of the snapshot.
+	// NotFoundError is returned if there's no snapshot or it's older than the TTL of the policy
+	GetCampaigns(unitID int64, country string, filter DeviceFilter, size int) ([]contentcampaign.SearchHit, error)
+	// Refresh searches the top campaigns by the request and saves them as the snapshot of the unit and country.
+	// The last snapshot is kept if nothing is searched
+	Refresh(unitID int64, country string, req contentcampaign.SearchRequest) error
+	// RefreshIfStale refreshes the snapshot in the background unless this instance has refreshed it within the refresh interval of the policy
+	RefreshIfStale(unitID int64, country string, req contentcampaign.SearchRequest)
+}
+
+type useCase struct {
+	repo     Repository
+	searcher Searcher
+	policy   Policy
+
+	mutex       sync.Mutex
+	refreshedAt map[string]time.Time // keyed by unit and country
+}
+
+// GetCampaigns func definition
+func (u *useCase) GetCampaigns(unitID int64, country string, filter DeviceFilter, size int) ([]contentcampaign.SearchHit, error) {
+	snapshot, err := u.repo.GetSnapshot(unitID, country)
+	if err != nil {
+		return nil, err
+	} else if snapshot == nil || time.Since(snapshot.CreatedAt) > u.policy.TTL {
+		return nil, NotFoundError{UnitID: unitID, Country: country}
+	}
+
+	hits := make([]contentcampaign.SearchHit, 0, size)
+	for _, hit := range snapshot.Hits {
+		if len(hits) >= size {
+			break
+		}
+		if filter.Matches(hit) {
+			hits = append(hits, hit)
+		}
+	}
+	return hits, nil
+}
+
+// Refresh func definition
+func (u *useCase) Refresh(unitID int64, country string, req contentcampaign.SearchRequest) error {
+	req.From, req.After, req.Size = 0, nil, u.policy.Size
+	req.Highlight = false
+
+	result, err := u.searcher.Search(req)
+	if err != nil {
+		return err
+	} else if len(result.Hits) == 0 {
+		return nil
+	}
+
+	snapshot := Snapshot{UnitID: unitID, Country: country, Hits: result.Hits, CreatedAt: time.Now()}
+	return u.repo.SaveSnapshot(snapshot, u.policy.TTL)
+}
+
+// RefreshIfStale func definition
+func (u *useCase) RefreshIfStale(unitID int64, country string, req contentcampaign.SearchRequest) {
+	if !u.markRefreshed(unitID, country, time.Now()) {
+		return
+	}
+
+	go func() {
+		if err := u.Refresh(unitID, country, req); err != nil {
+			core.Logger.WithError(err).Warnf("RefreshIfStale() - failed to refresh snapshot of unit %d in %s", unitID, country)
+		}
+	}()
+}
+
+// markRefreshed records the refresh of the unit and country at now. false is returned if it has been refreshed within the refresh interval
+func (u *useCase) markRefreshed(unitID int64, country string, now time.Time) bool {
+	key := fmt.Sprintf("%d-%s", unitID, country)
+
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	if refreshedAt, ok := u.refreshedAt[key]; ok && now.Sub(refreshedAt) < u.policy.RefreshInterval {
+		return false
+	}
+	u.refreshedAt[key] = now
+	return true
+}
+
+// NewUseCase returns content snapshot use case
+func NewUseCase(repo Repository, searcher Searcher, policy Policy) UseCase {
+	return &useCase{repo: repo, searcher: searcher, policy: policy, refreshedAt: make(map[string]time.Time)}
+}
@@ -0,0 +1,192 @@
+package contentsnapshot_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentsnapshot"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var policy = contentsnapshot.Policy{
+	Size:            3,
+	RefreshInterval: time.Hour,
+	TTL:             time.Hour,
+}
+
+func (ts *UseCaseTestSuite) Test_GetCampaigns() {
+	now := time.Now()
+	ts.repo.On("GetSnapshot", int64(100), "KR").Return(&contentsnapshot.Snapshot{
+		UnitID:  100,
+		Country: "KR",
+		Hits: []contentcampaign.SearchHit{
+			hit(1, `{"target_gender": "GLOB", "target_age_min": -32768, "target_age_max": 32767}`),
+			hit(2, `{"target_gender": "GLOB"}`), // seen
+			hit(3, `{"target_gender": "F"}`),
+			hit(4, `{"target_gender": "GLOB"}`), // hidden
+			hit(5, `{"target_age_min": 20, "target_age_max": 29}`),
+			hit(6, `{"target_age_min": 30, "target_age_max": 39}`),
+			hit(7, `{"end_date": "`+now.Add(-time.Minute).Format(time.RFC3339)+`"}`),
+			hit(8, `{"end_date": "`+now.Add(time.Hour).Format(time.RFC3339)+`"}`),
+			hit(9, `{}`),
+		},
+		CreatedAt: now.Add(-time.Minute),
+	}, nil)
+
+	filter := contentsnapshot.DeviceFilter{
+		SeenIDs:     map[string]bool{"2": true},
+		ExcludedIDs: []int64{4},
+		Gender:      "M",
+		Age:         25,
+		Now:         now,
+	}
+	hits, err := ts.useCase.GetCampaigns(100, "KR", filter, 3)
+
+	ts.NoError(err)
+	ts.Equal([]int64{1, 5, 8}, hitIDs(hits))
+}
+
+func (ts *UseCaseTestSuite) Test_GetCampaigns_UnknownAge() {
+	ts.repo.On("GetSnapshot", int64(100), "KR").Return(&contentsnapshot.Snapshot{
+		Hits: []contentcampaign.SearchHit{
+			hit(1, `{"target_age_min": 20, "target_age_max": 29}`),
+			hit(2, `{"target_age_min": -32768, "target_age_max": 32767}`),
+			hit(3, `invalid`),
+		},
+		CreatedAt: time.Now(),
+	}, nil)
+
+	hits, err := ts.useCase.GetCampaigns(100, "KR", contentsnapshot.DeviceFilter{Now: time.Now()}, 3)
+
+	ts.NoError(err)
+	ts.Equal([]int64{2}, hitIDs(hits))
+}
+
+func (ts *UseCaseTestSuite) Test_GetCampaigns_NotFound() {
+	ts.repo.On("GetSnapshot", int64(100), "KR").Return(nil, nil).Once()
+	ts.repo.On("GetSnapshot", int64(100), "JP").Return(&contentsnapshot.Snapshot{
+		Hits:      []contentcampaign.SearchHit{hit(1, `{}`)},
+		CreatedAt: time.Now().Add(-policy.TTL - time.Minute),
+	}, nil).Once()
+
+	_, err := ts.useCase.GetCampaigns(100, "KR", contentsnapshot.DeviceFilter{}, 3)
+	ts.IsType(contentsnapshot.NotFoundError{}, err)
+
+	_, err = ts.useCase.GetCampaigns(100, "JP", contentsnapshot.DeviceFilter{}, 3)
+	ts.IsType(contentsnapshot.NotFoundError{}, err)
+}
+
+func (ts *UseCaseTestSuite) Test_Refresh() {
+	req := contentcampaign.SearchRequest{Query: contentcampaign.SearchQuery{UnitID: 100}, From: 10, Size: 30, Highlight: true}
+	expectedReq := contentcampaign.SearchRequest{Query: contentcampaign.SearchQuery{UnitID: 100}, Size: policy.Size}
+	hits := []contentcampaign.SearchHit{hit(1, `{}`), hit(2, `{}`)}
+	ts.searcher.On("Search", expectedReq).Return(&contentcampaign.SearchResult{Hits: hits}, nil).Once()
+	ts.repo.On("SaveSnapshot", mock.Anything, policy.TTL).Return(nil).Run(func(args mock.Arguments) {
+		snapshot := args.Get(0).(contentsnapshot.Snapshot)
+		ts.Equal(int64(100), snapshot.UnitID)
+		ts.Equal("KR", snapshot.Country)
+		ts.Equal(hits, snapshot.Hits)
+		ts.False(snapshot.CreatedAt.IsZero())
+	}).Once()
+
+	ts.NoError(ts.useCase.Refresh(100, "KR", req))
+}
+
+func (ts *UseCaseTestSuite) Test_Refresh_KeepsLastSnapshot() {
+	ts.searcher.On("Search", mock.Anything).Return(&contentcampaign.SearchResult{Hits: []contentcampaign.SearchHit{}}, nil).Once()
+	ts.NoError(ts.useCase.Refresh(100, "KR", contentcampaign.SearchRequest{}))
+
+	searchErr := errors.New("search failed")
+	ts.searcher.On("Search", mock.Anything).Return(nil, searchErr).Once()
+	ts.Equal(searchErr, ts.useCase.Refresh(100, "KR", contentcampaign.SearchRequest{}))
+
+	ts.repo.AssertNotCalled(ts.T(), "SaveSnapshot", mock.Anything, mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_RefreshIfStale() {
+	saved := make(chan contentsnapshot.Snapshot, 2)
+	ts.searcher.On("Search", mock.Anything).Return(&contentcampaign.SearchResult{Hits: []contentcampaign.SearchHit{hit(1, `{}`)}}, nil).Twice()
+	ts.repo.On("SaveSnapshot", mock.Anything, policy.TTL).Return(nil).Run(func(args mock.Arguments) {
+		saved <- args.Get(0).(contentsnapshot.Snapshot)
+	}).Twice()
+
+	ts.useCase.RefreshIfStale(100, "KR", contentcampaign.SearchRequest{})
+	ts.useCase.RefreshIfStale(100, "KR", contentcampaign.SearchRequest{})
+	ts.useCase.RefreshIfStale(100, "JP", contentcampaign.SearchRequest{})
+
+	countries := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case snapshot := <-saved:
+			countries[snapshot.Country] = true
+		case <-time.After(time.Second):
+			ts.FailNow("snapshot isn't refreshed")
+		}
+	}
+	ts.Equal(map[string]bool{"KR": true, "JP": true}, countries)
+}
+
+func hit(id int64, source string) contentcampaign.SearchHit {
+	return contentcampaign.SearchHit{ID: id, Source: []byte(source)}
+}
+
+func hitIDs(hits []contentcampaign.SearchHit) []int64 {
+	ids := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo     *mockRepo
+	searcher *mockSearcher
+	useCase  contentsnapshot.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.searcher = new(mockSearcher)
+	ts.useCase = contentsnapshot.NewUseCase(ts.repo, ts.searcher, policy)
+}
+
+func (ts *UseCaseTestSuite) TearDownTest() {
+	ts.repo.AssertExpectations(ts.T())
+	ts.searcher.AssertExpectations(ts.T())
+}
+
+var _ contentsnapshot.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) GetSnapshot(unitID int64, country string) (*contentsnapshot.Snapshot, error) {
+	ret := r.Called(unitID, country)
+	snapshot, _ := ret.Get(0).(*contentsnapshot.Snapshot)
+	return snapshot, ret.Error(1)
+}
+
+func (r *mockRepo) SaveSnapshot(snapshot contentsnapshot.Snapshot, ttl time.Duration) error {
+	return r.Called(snapshot, ttl).Error(0)
+}
+
+var _ contentsnapshot.Searcher = &mockSearcher{}
+
+type mockSearcher struct {
+	mock.Mock
+}
+
+func (s *mockSearcher) Search(req contentcampaign.SearchRequest) (*contentcampaign.SearchResult, error) {
+	ret := s.Called(req)
+	result, _ := ret.Get(0).(*contentcampaign.SearchResult)
+	return result, ret.Error(1)
+}
@@ -0,0 +1,111 @@
+package contenttrending
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// ScopeType type definition
+type ScopeType string
+
+// ScopeType constants
+const (
+	ScopeGlobal  ScopeType = "global"
+	ScopeCountry ScopeType = "country"
+	ScopeUnit    ScopeType = "unit"
+)
+
+// Scope is the audience a ranked list of trending campaigns is computed for
+type Scope struct {
+	Type  ScopeType
+	Value string // country or unit id. empty for ScopeGlobal
+}
+
+// GlobalScope returns the scope of every unit
+func GlobalScope() Scope {
+	return Scope{Type: ScopeGlobal}
+}
+
+// CountryScope returns the scope of the units of the country
+func CountryScope(country string) Scope {
+	return Scope{Type: ScopeCountry, Value: country}
+}
+
+// UnitScope returns the scope of the unit
+func UnitScope(unitID int64) Scope {
+	return Scope{Type: ScopeUnit, Value: strconv.FormatInt(unitID, 10)}
+}
+
+// Key returns the key of the ranked list of the scope. e.g. "global", "country:KR", "unit:100"
+func (s Scope) Key() string {
+	if s.Value == "" {
+		return string(s.Type)
+	}
+	return string(s.Type) + ":" + s.Value
+}
+
+// Counts is the impression and click counts of a campaign
+type Counts struct {
+	Impressions int64
+	Clicks      int64
+}
+
+// HourlyCounts is the counts of the campaigns in an hour keyed by campaign id and unit id
+type HourlyCounts struct {
+	DateHour time.Time
+	Units    map[int64]map[int64]Counts
+}
+
+// Trend is the trending score of a campaign
+type Trend struct {
+	CampaignID int64
+	Score      float64
+}
+
+// Policy computes the trending score of a campaign as the time-decayed engagement per hour over the window.
+// Engagement of an hour is weighted by 0.5^(age/HalfLife) where age is the time from the end of the hour to now.
+type Policy struct {
+	Window           time.Duration
+	HalfLife         time.Duration
+	ClickWeight      float64
+	ImpressionWeight float64
+	MinImpressions   int64 // campaigns with fewer impressions over the window are not ranked
+	Size             int   // max campaigns of a ranked list
+	TTL              time.Duration
+}
+
+// DefaultPolicy var definition
+var DefaultPolicy = Policy{
+	Window:           time.Hour * 6,
+	HalfLife:         time.Hour * 2,
+	ClickWeight:      1,
+	ImpressionWeight: 0.01,
+	MinImpressions:   100,
+	Size:             300,
+	TTL:              time.Hour * 2,
+}
+
+// DateHours returns the hours of the window until now from the oldest one
+func (p Policy) DateHours(now time.Time) []time.Time {
+	dateHours := make([]time.Time, 0)
+	for dateHour := now.Add(-p.Window).Truncate(time.Hour); !dateHour.After(now); dateHour = dateHour.Add(time.Hour) {
+		dateHours = append(dateHours, dateHour)
+	}
+	return dateHours
+}
+
+// decay returns the weight of the hour and the duration of the hour counted until now in hours
+func (p Policy) decay(dateHour time.Time, now time.Time) (float64, float64) {
+	end := dateHour.Add(time.Hour)
+	if end.After(now) {
+		// 진행 중인 시간은 경과한 만큼만 반영한다
+		return 1, now.Sub(dateHour).Hours()
+	}
+	return math.Pow(0.5, now.Sub(end).Hours()/p.HalfLife.Hours()), 1
+}
+
+// engagement returns the weighted engagement of the counts
+func (p Policy) engagement(c Counts) float64 {
+	return float64(c.Clicks)*p.ClickWeight + float64(c.Impressions)*p.ImpressionWeight
+}
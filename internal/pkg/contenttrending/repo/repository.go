@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contenttrending"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscontentcampaign"
+)
+
+// Repository reads the hourly counts and keeps the ranked lists in the stat redis
+type Repository struct {
+	redisContentCampaign rediscontentcampaign.RedisSource
+}
+
+// GetHourlyCounts func definition
+func (r *Repository) GetHourlyCounts(dateHour time.Time) (*contenttrending.HourlyCounts, error) {
+	counts, err := r.redisContentCampaign.GetCampaignUnitCounts(dateHour)
+	if err != nil {
+		return nil, err
+	}
+
+	hourlyCounts := &contenttrending.HourlyCounts{DateHour: dateHour, Units: make(map[int64]map[int64]contenttrending.Counts, len(counts))}
+	for campaignID, units := range counts {
+		hourlyCounts.Units[campaignID] = make(map[int64]contenttrending.Counts, len(units))
+		for unitID, c := range units {
+			hourlyCounts.Units[campaignID][unitID] = contenttrending.Counts{Impressions: c.Impressions, Clicks: c.Clicks}
+		}
+	}
+	return hourlyCounts, nil
+}
+
+// SaveTrends func definition
+func (r *Repository) SaveTrends(scope contenttrending.Scope, trends []contenttrending.Trend, ttl time.Duration) error {
+	scores := make(map[int64]float64, len(trends))
+	for _, trend := range trends {
+		scores[trend.CampaignID] = trend.Score
+	}
+	return r.redisContentCampaign.SaveTrendingScores(scope.Key(), scores, ttl)
+}
+
+// GetTrends func definition
+func (r *Repository) GetTrends(scope contenttrending.Scope, size int) ([]contenttrending.Trend, error) {
+	scores, err := r.redisContentCampaign.GetTrendingScores(scope.Key(), size)
+	if err != nil {
+		return nil, err
+	}
+
+	trends := make([]contenttrending.Trend, 0, len(scores))
+	for _, score := range scores {
+		trends = append(trends, contenttrending.Trend{CampaignID: score.CampaignID, Score: score.Score})
+	}
+	return trends, nil
+}
+
+// New func definition
+func New(redisContentCampaign rediscontentcampaign.RedisSource) *Repository {
+	return &Repository{redisContentCampaign: redisContentCampaign}
+}
+
+var _ contenttrending.Repository = &Repository{}
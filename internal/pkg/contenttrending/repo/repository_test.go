@@ -0,0 +1,106 @@
+package repo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contenttrending"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contenttrending/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscontentcampaign"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func (ts *RepoTestSuite) Test_GetHourlyCounts() {
+	dateHour := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	ts.source.On("GetCampaignUnitCounts", dateHour).Return(map[int64]map[int64]rediscontentcampaign.Counts{
+		1: {100: {Impressions: 10, Clicks: 1}, 200: {Impressions: 5}},
+	}, nil).Once()
+
+	counts, err := ts.repo.GetHourlyCounts(dateHour)
+
+	ts.NoError(err)
+	ts.Equal(dateHour, counts.DateHour)
+	ts.Equal(map[int64]map[int64]contenttrending.Counts{
+		1: {100: {Impressions: 10, Clicks: 1}, 200: {Impressions: 5}},
+	}, counts.Units)
+}
+
+func (ts *RepoTestSuite) Test_SaveTrends() {
+	ts.source.On("SaveTrendingScores", "country:KR", map[int64]float64{1: 2.5, 2: 1}, time.Hour).Return(nil).Once()
+
+	err := ts.repo.SaveTrends(contenttrending.CountryScope("KR"), []contenttrending.Trend{{CampaignID: 1, Score: 2.5}, {CampaignID: 2, Score: 1}}, time.Hour)
+
+	ts.NoError(err)
+	ts.source.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) Test_GetTrends() {
+	ts.source.On("GetTrendingScores", "unit:100", 10).Return([]rediscontentcampaign.TrendingScore{{CampaignID: 2, Score: 3}, {CampaignID: 1, Score: 1}}, nil).Once()
+
+	trends, err := ts.repo.GetTrends(contenttrending.UnitScope(100), 10)
+
+	ts.NoError(err)
+	ts.Equal([]contenttrending.Trend{{CampaignID: 2, Score: 3}, {CampaignID: 1, Score: 1}}, trends)
+}
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	source *mockRedisContentCampaign
+	repo   *repo.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	ts.source = new(mockRedisContentCampaign)
+	ts.repo = repo.New(ts.source)
+}
+
+var _ rediscontentcampaign.RedisSource = &mockRedisContentCampaign{}
+
+type mockRedisContentCampaign struct {
+	mock.Mock
+}
+
+func (m *mockRedisContentCampaign) IncreaseImpression(campaignID int64, unitID int64) error {
+	return m.Called(campaignID, unitID).Error(0)
+}
+
+func (m *mockRedisContentCampaign) IncreaseClick(campaignID int64, unitID int64) error {
+	return m.Called(campaignID, unitID).Error(0)
+}
+
+func (m *mockRedisContentCampaign) IncreaseVariantImpression(campaignID int64, variantID int64) error {
+	return m.Called(campaignID, variantID).Error(0)
+}
+
+func (m *mockRedisContentCampaign) IncreaseVariantClick(campaignID int64, variantID int64) error {
+	return m.Called(campaignID, variantID).Error(0)
+}
+
+func (m *mockRedisContentCampaign) GetVariantCounts(campaignIDs ...int64) (map[int64]rediscontentcampaign.Counts, error) {
+	ret := m.Called(campaignIDs)
+	return ret.Get(0).(map[int64]rediscontentcampaign.Counts), ret.Error(1)
+}
+
+func (m *mockRedisContentCampaign) GetCampaignCounts(dateHour time.Time) (map[int64]rediscontentcampaign.Counts, error) {
+	ret := m.Called(dateHour)
+	return ret.Get(0).(map[int64]rediscontentcampaign.Counts), ret.Error(1)
+}
+
+func (m *mockRedisContentCampaign) GetCampaignUnitCounts(dateHour time.Time) (map[int64]map[int64]rediscontentcampaign.Counts, error) {
+	ret := m.Called(dateHour)
+	return ret.Get(0).(map[int64]map[int64]rediscontentcampaign.Counts), ret.Error(1)
+}
+
+func (m *mockRedisContentCampaign) SaveTrendingScores(key string, scores map[int64]float64, ttl time.Duration) error {
+	return m.Called(key, scores, ttl).Error(0)
+}
+
+func (m *mockRedisContentCampaign) GetTrendingScores(key string, size int) ([]rediscontentcampaign.TrendingScore, error) {
+	ret := m.Called(key, size)
+	return ret.Get(0).([]rediscontentcampaign.TrendingScore), ret.Error(1)
+}
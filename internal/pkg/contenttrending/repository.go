@@ -0,0 +1,21 @@
+package contenttrending
+
+import (
+	"context"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
+)
+
+// Repository interface definition
+type Repository interface {
+	GetHourlyCounts(dateHour time.Time) (*HourlyCounts, error)
+	// SaveTrends replaces the ranked list of the scope. The list expires after ttl unless it's saved again
+	SaveTrends(scope Scope, trends []Trend, ttl time.Duration) error
+	GetTrends(scope Scope, size int) ([]Trend, error)
+}
+
+// UnitGetter provides the units to find the countries of them. app.UseCase satisfies it
+type UnitGetter interface {
+	GetUnitByID(ctx context.Context, unitID int64) (*app.Unit, error)
+}
@@ -0,0 +1,115 @@
+package contenttrending
+
+import (
+	"context"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+)
+
+// UseCase interface definition
+type UseCase interface {
+	// Compute ranks the campaigns of each unit, country and global from the counts over the window until now and saves them
+	Compute(ctx context.Context, now time.Time) (*ComputeResult, error)
+	// GetTrendingScores returns the trending scores of the unit keyed by campaign id.
+	// The scores of the country and global are returned in order if the unit has none. nil is returned if none of them exists.
+	GetTrendingScores(ctx context.Context, unitID int64, country string) (map[int64]float64, error)
+}
+
+// ComputeResult type definition
+type ComputeResult struct {
+	Scopes    int
+	Campaigns int // campaigns ranked in any scope
+}
+
+type useCase struct {
+	repo   Repository
+	units  UnitGetter
+	policy Policy
+}
+
+// Compute func definition
+func (u *useCase) Compute(ctx context.Context, now time.Time) (*ComputeResult, error) {
+	v := newVelocity(u.policy)
+	countries := make(map[int64]string)
+	for _, dateHour := range u.policy.DateHours(now) {
+		counts, err := u.repo.GetHourlyCounts(dateHour)
+		if err != nil {
+			return nil, err
+		}
+
+		weight := v.addHour(dateHour, now)
+		for campaignID, units := range counts.Units {
+			for unitID, c := range units {
+				v.add(UnitScope(unitID), campaignID, c, weight)
+				v.add(GlobalScope(), campaignID, c, weight)
+				if country := u.getCountry(ctx, unitID, countries); country != "" {
+					v.add(CountryScope(country), campaignID, c, weight)
+				}
+			}
+		}
+	}
+
+	result := &ComputeResult{}
+	campaigns := make(map[int64]bool)
+	for scope, trends := range v.rank() {
+		if err := u.repo.SaveTrends(scope, trends, u.policy.TTL); err != nil {
+			return nil, err
+		}
+		result.Scopes++
+		for _, trend := range trends {
+			campaigns[trend.CampaignID] = true
+		}
+	}
+	result.Campaigns = len(campaigns)
+	return result, nil
+}
+
+// getCountry returns the country of the unit cached in countries. empty string is returned if the unit isn't found
+func (u *useCase) getCountry(ctx context.Context, unitID int64, countries map[int64]string) string {
+	if country, ok := countries[unitID]; ok {
+		return country
+	}
+
+	unit, err := u.units.GetUnitByID(ctx, unitID)
+	if err != nil {
+		core.Logger.WithError(err).Warnf("Compute() - failed to get unit %d", unitID)
+	}
+	country := ""
+	if unit != nil {
+		country = unit.Country
+	}
+	countries[unitID] = country
+	return country
+}
+
+// GetTrendingScores func definition
+func (u *useCase) GetTrendingScores(ctx context.Context, unitID int64, country string) (map[int64]float64, error) {
+	scopes := []Scope{UnitScope(unitID)}
+	if country != "" {
+		scopes = append(scopes, CountryScope(country))
+	}
+	scopes = append(scopes, GlobalScope())
+
+	for _, scope := range scopes {
+		trends, err := u.repo.GetTrends(scope, u.policy.Size)
+		if err != nil {
+			return nil, err
+		}
+		if len(trends) == 0 {
+			continue
+		}
+
+		scores := make(map[int64]float64, len(trends))
+		for _, trend := range trends {
+			scores[trend.CampaignID] = trend.Score
+		}
+		return scores, nil
+	}
+	return nil, nil
+}
+
+// NewUseCase returns content trending use case
+func NewUseCase(repo Repository, units UnitGetter, policy Policy) UseCase {
+	return &useCase{repo: repo, units: units, policy: policy}
+}
@@ -0,0 +1,140 @@
+package contenttrending_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contenttrending"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	now    = time.Date(2019, 5, 1, 10, 30, 0, 0, time.UTC)
+	policy = contenttrending.Policy{
+		Window:         time.Hour * 2,
+		HalfLife:       time.Hour,
+		ClickWeight:    1,
+		MinImpressions: 10,
+		Size:           10,
+		TTL:            time.Hour,
+	}
+)
+
+func (ts *UseCaseTestSuite) Test_Compute() {
+	ts.repo.On("GetHourlyCounts", time.Date(2019, 5, 1, 8, 0, 0, 0, time.UTC)).Return(&contenttrending.HourlyCounts{}, nil).Once()
+	ts.repo.On("GetHourlyCounts", time.Date(2019, 5, 1, 9, 0, 0, 0, time.UTC)).Return(&contenttrending.HourlyCounts{
+		Units: map[int64]map[int64]contenttrending.Counts{
+			1: {100: {Impressions: 10, Clicks: 2}},
+			2: {200: {Impressions: 10, Clicks: 4}},
+			3: {100: {Impressions: 5, Clicks: 5}},
+		},
+	}, nil).Once()
+	ts.repo.On("GetHourlyCounts", time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)).Return(&contenttrending.HourlyCounts{
+		Units: map[int64]map[int64]contenttrending.Counts{
+			1: {100: {Impressions: 5, Clicks: 2}},
+		},
+	}, nil).Once()
+	ts.units.On("GetUnitByID", mock.Anything, int64(100)).Return(&app.Unit{ID: 100, Country: "KR"}, nil).Once()
+	ts.units.On("GetUnitByID", mock.Anything, int64(200)).Return(nil, errors.New("unit not found")).Once()
+
+	saved := make(map[string][]contenttrending.Trend)
+	ts.repo.On("SaveTrends", mock.Anything, mock.Anything, time.Hour).Return(nil).Run(func(args mock.Arguments) {
+		saved[args.Get(0).(contenttrending.Scope).Key()] = args.Get(1).([]contenttrending.Trend)
+	})
+
+	result, err := ts.useCase.Compute(context.Background(), now)
+
+	ts.NoError(err)
+	ts.Equal(contenttrending.ComputeResult{Scopes: 4, Campaigns: 2}, *result)
+	ts.units.AssertExpectations(ts.T())
+
+	// 9시는 0.5시간 전에 끝나 0.5^0.5, 10시는 0.5시간만 경과해 1로 반영된다. 노출이 적은 3번은 제외된다
+	hours := 0.5*0.5*1.4142135623730951 + 0.5*1.4142135623730951 + 0.5
+	ts.Len(saved["unit:100"], 1)
+	ts.InDelta((2*0.7071067811865476+2)/hours, saved["unit:100"][0].Score, 1e-9)
+	ts.Equal([]int64{1, 2}, trendIDs(saved["global"]))
+	ts.Equal([]int64{1}, trendIDs(saved["country:KR"]))
+	ts.Equal([]int64{2}, trendIDs(saved["unit:200"]))
+}
+
+func (ts *UseCaseTestSuite) Test_GetTrendingScores_FallsBack() {
+	ts.repo.On("GetTrends", contenttrending.UnitScope(100), 10).Return([]contenttrending.Trend{}, nil).Once()
+	ts.repo.On("GetTrends", contenttrending.CountryScope("KR"), 10).Return([]contenttrending.Trend{{CampaignID: 1, Score: 2}}, nil).Once()
+
+	scores, err := ts.useCase.GetTrendingScores(context.Background(), 100, "KR")
+
+	ts.NoError(err)
+	ts.Equal(map[int64]float64{1: 2}, scores)
+	ts.repo.AssertNotCalled(ts.T(), "GetTrends", contenttrending.GlobalScope(), 10)
+}
+
+func (ts *UseCaseTestSuite) Test_GetTrendingScores_Empty() {
+	ts.repo.On("GetTrends", mock.Anything, 10).Return([]contenttrending.Trend{}, nil).Twice()
+
+	scores, err := ts.useCase.GetTrendingScores(context.Background(), 100, "")
+
+	ts.NoError(err)
+	ts.Nil(scores)
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func trendIDs(trends []contenttrending.Trend) []int64 {
+	ids := make([]int64, 0, len(trends))
+	for _, trend := range trends {
+		ids = append(ids, trend.CampaignID)
+	}
+	return ids
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	units   *mockUnitGetter
+	useCase contenttrending.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.units = new(mockUnitGetter)
+	ts.useCase = contenttrending.NewUseCase(ts.repo, ts.units, policy)
+}
+
+var _ contenttrending.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) GetHourlyCounts(dateHour time.Time) (*contenttrending.HourlyCounts, error) {
+	ret := r.Called(dateHour)
+	return ret.Get(0).(*contenttrending.HourlyCounts), ret.Error(1)
+}
+
+func (r *mockRepo) SaveTrends(scope contenttrending.Scope, trends []contenttrending.Trend, ttl time.Duration) error {
+	return r.Called(scope, trends, ttl).Error(0)
+}
+
+func (r *mockRepo) GetTrends(scope contenttrending.Scope, size int) ([]contenttrending.Trend, error) {
+	ret := r.Called(scope, size)
+	return ret.Get(0).([]contenttrending.Trend), ret.Error(1)
+}
+
+var _ contenttrending.UnitGetter = &mockUnitGetter{}
+
+type mockUnitGetter struct {
+	mock.Mock
+}
+
+func (g *mockUnitGetter) GetUnitByID(ctx context.Context, unitID int64) (*app.Unit, error) {
+	ret := g.Called(ctx, unitID)
+	unit, _ := ret.Get(0).(*app.Unit)
+	return unit, ret.Error(1)
+}
@@ -0,0 +1,68 @@
+package contenttrending
+
+import (
+	"sort"
+	"time"
+)
+
+// velocity accumulates the decayed engagement of the campaigns of each scope over the hours of the window
+type velocity struct {
+	policy      Policy
+	hours       float64 // decayed duration of the window in hours
+	engagements map[Scope]map[int64]float64
+	impressions map[Scope]map[int64]int64
+}
+
+func newVelocity(policy Policy) *velocity {
+	return &velocity{
+		policy:      policy,
+		engagements: make(map[Scope]map[int64]float64),
+		impressions: make(map[Scope]map[int64]int64),
+	}
+}
+
+// addHour adds the decayed duration of the hour. It should be called once for each hour of the window even without counts
+func (v *velocity) addHour(dateHour time.Time, now time.Time) float64 {
+	weight, duration := v.policy.decay(dateHour, now)
+	v.hours += weight * duration
+	return weight
+}
+
+func (v *velocity) add(scope Scope, campaignID int64, counts Counts, weight float64) {
+	if v.engagements[scope] == nil {
+		v.engagements[scope] = make(map[int64]float64)
+		v.impressions[scope] = make(map[int64]int64)
+	}
+	v.engagements[scope][campaignID] += v.policy.engagement(counts) * weight
+	v.impressions[scope][campaignID] += counts.Impressions
+}
+
+// rank returns the campaigns of each scope ordered by the decayed engagement per hour
+func (v *velocity) rank() map[Scope][]Trend {
+	ranked := make(map[Scope][]Trend, len(v.engagements))
+	if v.hours <= 0 {
+		return ranked
+	}
+
+	for scope, engagements := range v.engagements {
+		trends := make([]Trend, 0, len(engagements))
+		for campaignID, engagement := range engagements {
+			if v.impressions[scope][campaignID] < v.policy.MinImpressions || engagement <= 0 {
+				continue
+			}
+			trends = append(trends, Trend{CampaignID: campaignID, Score: engagement / v.hours})
+		}
+
+		sort.Slice(trends, func(i, j int) bool {
+			if trends[i].Score != trends[j].Score {
+				return trends[i].Score > trends[j].Score
+			}
+			return trends[i].CampaignID > trends[j].CampaignID
+		})
+		if v.policy.Size > 0 && len(trends) > v.policy.Size {
+			trends = trends[:v.policy.Size]
+		}
+		ranked[scope] = trends
+	}
+	return ranked
+}
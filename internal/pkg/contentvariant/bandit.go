@@ -0,0 +1,111 @@
+package contentvariant
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Policy is the parameters of the Thompson sampling bandit.
+// CTR of a variant is modeled as Beta(PriorClicks + clicks, PriorNonClicks + impressions - clicks)
+type Policy struct {
+	PriorClicks    float64
+	PriorNonClicks float64
+	Simulations    int // draws to estimate the win probabilities of a report
+	MaxVariants    int
+
+	// The winner is conclusive if every variant has MinImpressions and the winner has ConclusiveProbability
+	MinImpressions        int64
+	ConclusiveProbability float64
+}
+
+// DefaultPolicy var definition
+var DefaultPolicy = Policy{
+	PriorClicks:           1,
+	PriorNonClicks:        1,
+	Simulations:           10000,
+	MaxVariants:           5,
+	MinImpressions:        1000,
+	ConclusiveProbability: 0.95,
+}
+
+// sampler draws from the posterior of the variants. rand.Rand isn't safe for concurrent use
+type sampler struct {
+	mu     sync.Mutex
+	random *rand.Rand
+	policy Policy
+}
+
+func newSampler(policy Policy) *sampler {
+	return &sampler{random: rand.New(rand.NewSource(time.Now().UnixNano())), policy: policy}
+}
+
+// choose returns the index of the variant of the highest CTR sampled from the posteriors
+func (s *sampler) choose(counts []Counts) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chooseWith(s.random, counts)
+}
+
+// winProbabilities estimates the probability each variant has the highest CTR.
+// Simulations are drawn from a rand.Rand of the call so that choose of the allocation isn't blocked by reports
+func (s *sampler) winProbabilities(counts []Counts) []float64 {
+	probabilities := make([]float64, len(counts))
+	if len(counts) == 0 || s.policy.Simulations <= 0 {
+		return probabilities
+	}
+
+	s.mu.Lock()
+	random := rand.New(rand.NewSource(s.random.Int63()))
+	s.mu.Unlock()
+
+	for i := 0; i < s.policy.Simulations; i++ {
+		probabilities[s.chooseWith(random, counts)]++
+	}
+	for i := range probabilities {
+		probabilities[i] /= float64(s.policy.Simulations)
+	}
+	return probabilities
+}
+
+func (s *sampler) chooseWith(random *rand.Rand, counts []Counts) int {
+	best, bestSample := 0, -1.0
+	for i, c := range counts {
+		// 노출 없이 클릭만 기록된 경우에도 분포가 유효하도록 0 미만은 0으로 본다
+		nonClicks := math.Max(float64(c.Impressions-c.Clicks), 0)
+		sample := sampleBeta(random, s.policy.PriorClicks+float64(c.Clicks), s.policy.PriorNonClicks+nonClicks)
+		if sample > bestSample {
+			best, bestSample = i, sample
+		}
+	}
+	return best
+}
+
+func sampleBeta(random *rand.Rand, alpha, beta float64) float64 {
+	x := sampleGamma(random, alpha)
+	y := sampleGamma(random, beta)
+	return x / (x + y)
+}
+
+// sampleGamma draws from Gamma(shape, 1) by Marsaglia and Tsang's method
+func sampleGamma(random *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		return sampleGamma(random, shape+1) * math.Pow(random.Float64(), 1/shape)
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := random.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := random.Float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package contentvariant
+
+import "time"
+
+// Variant is an alternative creative of a content campaign. Empty fields keep the creative of the campaign
+type Variant struct {
+	ID          int64
+	CampaignID  int64
+	Title       string
+	Description string
+	ImageURL    string
+	Enabled     bool // only enabled variants are allocated
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Counts is the total impressions and clicks of a variant
+type Counts struct {
+	Impressions int64
+	Clicks      int64
+}
+
+// CTR returns the click through rate. 0 if there's no impression
+func (c Counts) CTR() float64 {
+	if c.Impressions <= 0 {
+		return 0
+	}
+	return float64(c.Clicks) / float64(c.Impressions)
+}
+
+// Performance is the counts of a variant and the probability it has the best CTR
+type Performance struct {
+	Variant        Variant
+	Counts         Counts
+	WinProbability float64
+}
+
+// Report is the performance of the enabled variants of a campaign
+type Report struct {
+	CampaignID   int64
+	Performances []Performance
+	WinnerID     int64 // the variant of the highest win probability. 0 if there's no enabled variant
+	Conclusive   bool  // the winner has enough impressions and win probability by Policy
+}
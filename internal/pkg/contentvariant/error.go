@@ -0,0 +1,41 @@
+package contentvariant
+
+import "fmt"
+
+var (
+	_ error = ValidationError{}
+	_ error = VariantNotFoundError{}
+	_ error = TooManyVariantsError{}
+)
+
+// ValidationError will be returned when the variant to save is invalid
+type ValidationError struct {
+	Reason string
+}
+
+// Error func definition
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("invalid variant: %s", e.Reason)
+}
+
+// VariantNotFoundError will be returned when the variant doesn't exist in the campaign
+type VariantNotFoundError struct {
+	CampaignID int64
+	VariantID  int64
+}
+
+// Error func definition
+func (e VariantNotFoundError) Error() string {
+	return fmt.Sprintf("variant %d of campaign %d is not found", e.VariantID, e.CampaignID)
+}
+
+// TooManyVariantsError will be returned when a campaign has more variants than the limit
+type TooManyVariantsError struct {
+	CampaignID int64
+	Limit      int
+}
+
+// Error func definition
+func (e TooManyVariantsError) Error() string {
+	return fmt.Sprintf("campaign %d can't have more than %d variants", e.CampaignID, e.Limit)
+}
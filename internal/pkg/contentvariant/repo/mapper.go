@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscontentcampaign"
+)
+
+type entityMapper struct {
+}
+
+func (m *entityMapper) dbVariantToVariant(dbVariant DBVariant) contentvariant.Variant {
+	return contentvariant.Variant{
+		ID:          dbVariant.ID,
+		CampaignID:  dbVariant.CampaignID,
+		Title:       dbVariant.Title,
+		Description: dbVariant.Description,
+		ImageURL:    dbVariant.ImageURL,
+		Enabled:     dbVariant.Enabled,
+		CreatedAt:   dbVariant.CreatedAt,
+		UpdatedAt:   dbVariant.UpdatedAt,
+	}
+}
+
+func (m *entityMapper) variantToDBVariant(variant contentvariant.Variant) DBVariant {
+	return DBVariant{
+		ID:          variant.ID,
+		CampaignID:  variant.CampaignID,
+		Title:       variant.Title,
+		Description: variant.Description,
+		ImageURL:    variant.ImageURL,
+		Enabled:     variant.Enabled,
+		CreatedAt:   variant.CreatedAt,
+		UpdatedAt:   variant.UpdatedAt,
+	}
+}
+
+func (m *entityMapper) redisCountsToCounts(counts rediscontentcampaign.Counts) contentvariant.Counts {
+	return contentvariant.Counts{
+		Impressions: counts.Impressions,
+		Clicks:      counts.Clicks,
+	}
+}
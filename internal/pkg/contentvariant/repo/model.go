@@ -0,0 +1,21 @@
+package repo
+
+import "time"
+
+// DBVariant struct definition
+type DBVariant struct {
+	ID          int64 `gorm:"primary_key"`
+	CampaignID  int64
+	Title       string
+	Description string
+	ImageURL    string `gorm:"column:image_url"`
+	Enabled     bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName func definition
+func (DBVariant) TableName() string {
+	return "content_campaign_variants"
+}
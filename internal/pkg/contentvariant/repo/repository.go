@@ -0,0 +1,160 @@
+package repo
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscontentcampaign"
+	"github.com/jinzhu/gorm"
+)
+
+const variantsCacheKey = "CACHE_GO_CONTENTVARIANTS-%v"
+
+// Repository struct definition
+type Repository struct {
+	db                   *gorm.DB
+	redisCache           rediscache.RedisSource
+	redisContentCampaign rediscontentcampaign.RedisSource
+	mapper               *entityMapper
+}
+
+type variantsCache struct {
+	Variants []contentvariant.Variant
+	Created  time.Time
+}
+
+// GetVariants returns the variants of the campaigns ordered by id. Variants of a campaign are cached for a minute
+func (r *Repository) GetVariants(campaignIDs ...int64) ([]contentvariant.Variant, error) {
+	if len(campaignIDs) == 0 {
+		return nil, nil
+	}
+
+	variants := make([]contentvariant.Variant, 0)
+	missedIDs := make([]int64, 0, len(campaignIDs))
+	for _, campaignID := range campaignIDs {
+		if cached, ok := r.getVariantsFromCache(campaignID); ok {
+			variants = append(variants, cached...)
+		} else {
+			missedIDs = append(missedIDs, campaignID)
+		}
+	}
+	if len(missedIDs) == 0 {
+		return sortVariants(variants), nil
+	}
+
+	var dbVariants []DBVariant
+	if err := r.db.Where("campaign_id IN (?)", missedIDs).Order("id").Find(&dbVariants).Error; err != nil {
+		return nil, err
+	}
+
+	campaignVariants := make(map[int64][]contentvariant.Variant, len(missedIDs))
+	for _, dbVariant := range dbVariants {
+		variant := r.mapper.dbVariantToVariant(dbVariant)
+		campaignVariants[variant.CampaignID] = append(campaignVariants[variant.CampaignID], variant)
+		variants = append(variants, variant)
+	}
+	// 대부분의 캠페인은 변형이 없으므로 빈 결과도 캐시한다
+	for _, campaignID := range missedIDs {
+		r.setVariantsToCache(campaignID, campaignVariants[campaignID])
+	}
+	return sortVariants(variants), nil
+}
+
+func (r *Repository) getVariantsFromCache(campaignID int64) ([]contentvariant.Variant, bool) {
+	var cache variantsCache
+	if err := r.redisCache.GetCache(fmt.Sprintf(variantsCacheKey, campaignID), &cache); err != nil ||
+		time.Now().After(cache.Created.Add(time.Minute)) {
+		return nil, false
+	}
+	return cache.Variants, true
+}
+
+func (r *Repository) setVariantsToCache(campaignID int64, variants []contentvariant.Variant) {
+	cache := variantsCache{
+		Variants: variants,
+		Created:  time.Now(),
+	}
+	r.redisCache.SetCacheAsync(fmt.Sprintf(variantsCacheKey, campaignID), cache, time.Hour*24)
+}
+
+// deleteVariantsCache deletes the cached variants of the campaign. Failure is logged only since the cache expires in a minute
+func (r *Repository) deleteVariantsCache(campaignID int64) {
+	if err := r.redisCache.DeleteCache(fmt.Sprintf(variantsCacheKey, campaignID)); err != nil {
+		core.Logger.WithError(err).Warnf("deleteVariantsCache() - failed to delete variants cache of campaign %d", campaignID)
+	}
+}
+
+func sortVariants(variants []contentvariant.Variant) []contentvariant.Variant {
+	sort.Slice(variants, func(i, j int) bool {
+		return variants[i].ID < variants[j].ID
+	})
+	return variants
+}
+
+// SaveVariant inserts the variant if it has no id or updates it
+func (r *Repository) SaveVariant(variant contentvariant.Variant) (*contentvariant.Variant, error) {
+	dbVariant := r.mapper.variantToDBVariant(variant)
+	var err error
+	if dbVariant.ID == 0 {
+		err = r.db.Create(&dbVariant).Error
+	} else {
+		err = r.db.Save(&dbVariant).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.deleteVariantsCache(dbVariant.CampaignID)
+	saved := r.mapper.dbVariantToVariant(dbVariant)
+	return &saved, nil
+}
+
+// DeleteVariant func definition
+func (r *Repository) DeleteVariant(campaignID int64, variantID int64) error {
+	if err := r.db.Where("campaign_id = ? AND id = ?", campaignID, variantID).Delete(&DBVariant{}).Error; err != nil {
+		return err
+	}
+
+	r.deleteVariantsCache(campaignID)
+	return nil
+}
+
+// IncreaseImpression func definition
+func (r *Repository) IncreaseImpression(campaignID int64, variantID int64) error {
+	return r.redisContentCampaign.IncreaseVariantImpression(campaignID, variantID)
+}
+
+// IncreaseClick func definition
+func (r *Repository) IncreaseClick(campaignID int64, variantID int64) error {
+	return r.redisContentCampaign.IncreaseVariantClick(campaignID, variantID)
+}
+
+// GetCounts func definition
+func (r *Repository) GetCounts(campaignIDs ...int64) (map[int64]contentvariant.Counts, error) {
+	redisCounts, err := r.redisContentCampaign.GetVariantCounts(campaignIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]contentvariant.Counts, len(redisCounts))
+	for variantID, c := range redisCounts {
+		counts[variantID] = r.mapper.redisCountsToCounts(c)
+	}
+	return counts, nil
+}
+
+// New returns content variant repository
+func New(db *gorm.DB, redisCache rediscache.RedisSource, redisContentCampaign rediscontentcampaign.RedisSource) *Repository {
+	return &Repository{
+		db:                   db,
+		redisCache:           redisCache,
+		redisContentCampaign: redisContentCampaign,
+		mapper:               &entityMapper{},
+	}
+}
+
+var _ contentvariant.Repository = &Repository{}
@@ -0,0 +1,207 @@
+package repo
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscontentcampaign"
+	"github.com/go-redis/cache"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	mock  sqlmock.Sqlmock
+	db    *gorm.DB
+	cache *mockRedisCache
+	redis *mockRedisContentCampaign
+	repo  contentvariant.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	ts.NoError(err)
+	ts.mock = mock
+	ts.db, err = gorm.Open("mysql", db)
+	ts.NoError(err)
+	ts.cache = new(mockRedisCache)
+	ts.redis = new(mockRedisContentCampaign)
+	ts.repo = New(ts.db, ts.cache, ts.redis)
+}
+
+func (ts *RepoTestSuite) AfterTest() {
+	_ = ts.db.Close()
+}
+
+func (ts *RepoTestSuite) Test_GetVariants() {
+	createdAt := time.Now()
+	ts.cache.On("GetCache", mock.Anything, mock.Anything).Return(cache.ErrCacheMiss).Twice()
+	ts.cache.On("SetCacheAsync", "CACHE_GO_CONTENTVARIANTS-1", mock.Anything, time.Hour*24).Return().Once()
+	ts.cache.On("SetCacheAsync", "CACHE_GO_CONTENTVARIANTS-2", mock.Anything, time.Hour*24).Return().Once()
+	req := "SELECT * FROM `content_campaign_variants` WHERE (campaign_id IN (?,?)) ORDER BY `id`"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "title", "description", "image_url", "enabled", "created_at", "updated_at"}).
+			AddRow(3, 1, "title", "", "", true, createdAt, createdAt).
+			AddRow(4, 2, "", "", "https://image", false, createdAt, createdAt))
+
+	variants, err := ts.repo.GetVariants(1, 2)
+
+	ts.NoError(err)
+	ts.Equal([]contentvariant.Variant{
+		{ID: 3, CampaignID: 1, Title: "title", Enabled: true, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: 4, CampaignID: 2, ImageURL: "https://image", CreatedAt: createdAt, UpdatedAt: createdAt},
+	}, variants)
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) Test_GetVariants_Cached() {
+	createdAt := time.Now()
+	ts.cache.On("GetCache", "CACHE_GO_CONTENTVARIANTS-1", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*variantsCache) = variantsCache{
+			Variants: []contentvariant.Variant{{ID: 5, CampaignID: 1, Title: "cached", CreatedAt: createdAt, UpdatedAt: createdAt}},
+			Created:  time.Now(),
+		}
+	}).Once()
+	ts.cache.On("GetCache", "CACHE_GO_CONTENTVARIANTS-2", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*variantsCache) = variantsCache{Created: time.Now().Add(-time.Hour)}
+	}).Once()
+	ts.cache.On("SetCacheAsync", "CACHE_GO_CONTENTVARIANTS-2", mock.Anything, time.Hour*24).Return().Once()
+	req := "SELECT * FROM `content_campaign_variants` WHERE (campaign_id IN (?)) ORDER BY `id`"
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "title", "description", "image_url", "enabled", "created_at", "updated_at"}).
+			AddRow(3, 2, "title", "", "", true, createdAt, createdAt))
+
+	variants, err := ts.repo.GetVariants(1, 2)
+
+	// 1분이 지난 캐시는 DB에서 다시 읽는다
+	ts.NoError(err)
+	ts.Equal([]contentvariant.Variant{
+		{ID: 3, CampaignID: 2, Title: "title", Enabled: true, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: 5, CampaignID: 1, Title: "cached", CreatedAt: createdAt, UpdatedAt: createdAt},
+	}, variants)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) Test_SaveVariant_Create() {
+	ts.mock.ExpectBegin()
+	ts.mock.ExpectExec(ts.fixedFullRe("INSERT INTO `content_campaign_variants` (`campaign_id`,`title`,`description`,`image_url`,`enabled`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(1, "title", "", "", true, sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(5, 1))
+	ts.mock.ExpectCommit()
+	ts.cache.On("DeleteCache", "CACHE_GO_CONTENTVARIANTS-1").Return(nil).Once()
+
+	variant, err := ts.repo.SaveVariant(contentvariant.Variant{CampaignID: 1, Title: "title", Enabled: true})
+
+	ts.NoError(err)
+	ts.Equal(int64(5), variant.ID)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) Test_DeleteVariant() {
+	ts.mock.ExpectBegin()
+	ts.mock.ExpectExec(ts.fixedFullRe("DELETE FROM `content_campaign_variants` WHERE (campaign_id = ? AND id = ?)")).
+		WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	ts.mock.ExpectCommit()
+	ts.cache.On("DeleteCache", "CACHE_GO_CONTENTVARIANTS-1").Return(nil).Once()
+
+	err := ts.repo.DeleteVariant(1, 5)
+
+	ts.NoError(err)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) Test_GetCounts() {
+	ts.redis.On("GetVariantCounts", []int64{1}).Return(map[int64]rediscontentcampaign.Counts{5: {Impressions: 10, Clicks: 2}}, nil).Once()
+
+	counts, err := ts.repo.GetCounts(1)
+
+	ts.NoError(err)
+	ts.Equal(map[int64]contentvariant.Counts{5: {Impressions: 10, Clicks: 2}}, counts)
+	ts.redis.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) fixedFullRe(s string) string {
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(s))
+}
+
+var _ rediscache.RedisSource = &mockRedisCache{}
+
+type mockRedisCache struct {
+	mock.Mock
+}
+
+func (r *mockRedisCache) GetCache(key string, obj interface{}) error {
+	ret := r.Called(key, obj)
+	return ret.Error(0)
+}
+
+func (r *mockRedisCache) SetCacheAsync(key string, obj interface{}, expiration time.Duration) {
+	r.Called(key, obj, expiration)
+}
+
+func (r *mockRedisCache) SetCache(key string, obj interface{}, expiration time.Duration) error {
+	ret := r.Called(key, obj, expiration)
+	return ret.Error(0)
+}
+
+func (r *mockRedisCache) DeleteCache(key string) error {
+	ret := r.Called(key)
+	return ret.Error(0)
+}
+
+type mockRedisContentCampaign struct {
+	mock.Mock
+}
+
+func (m *mockRedisContentCampaign) IncreaseImpression(campaignID int64, unitID int64) error {
+	return m.Called(campaignID, unitID).Error(0)
+}
+
+func (m *mockRedisContentCampaign) IncreaseClick(campaignID int64, unitID int64) error {
+	return m.Called(campaignID, unitID).Error(0)
+}
+
+func (m *mockRedisContentCampaign) IncreaseVariantImpression(campaignID int64, variantID int64) error {
+	return m.Called(campaignID, variantID).Error(0)
+}
+
+func (m *mockRedisContentCampaign) IncreaseVariantClick(campaignID int64, variantID int64) error {
+	return m.Called(campaignID, variantID).Error(0)
+}
+
+func (m *mockRedisContentCampaign) GetVariantCounts(campaignIDs ...int64) (map[int64]rediscontentcampaign.Counts, error) {
+	ret := m.Called(campaignIDs)
+	return ret.Get(0).(map[int64]rediscontentcampaign.Counts), ret.Error(1)
+}
+
+func (m *mockRedisContentCampaign) GetCampaignCounts(dateHour time.Time) (map[int64]rediscontentcampaign.Counts, error) {
+	ret := m.Called(dateHour)
+	return ret.Get(0).(map[int64]rediscontentcampaign.Counts), ret.Error(1)
+}
+
+func (m *mockRedisContentCampaign) GetCampaignUnitCounts(dateHour time.Time) (map[int64]map[int64]rediscontentcampaign.Counts, error) {
+	ret := m.Called(dateHour)
+	return ret.Get(0).(map[int64]map[int64]rediscontentcampaign.Counts), ret.Error(1)
+}
+
+func (m *mockRedisContentCampaign) SaveTrendingScores(key string, scores map[int64]float64, ttl time.Duration) error {
+	return m.Called(key, scores, ttl).Error(0)
+}
+
+func (m *mockRedisContentCampaign) GetTrendingScores(key string, size int) ([]rediscontentcampaign.TrendingScore, error) {
+	ret := m.Called(key, size)
+	return ret.Get(0).([]rediscontentcampaign.TrendingScore), ret.Error(1)
+}
@@ -0,0 +1,14 @@
+package contentvariant
+
+// Repository interface definition
+type Repository interface {
+	// GetVariants returns the variants of the campaigns ordered by id
+	GetVariants(campaignIDs ...int64) ([]Variant, error)
+	SaveVariant(variant Variant) (*Variant, error)
+	DeleteVariant(campaignID int64, variantID int64) error
+
+	IncreaseImpression(campaignID int64, variantID int64) error
+	IncreaseClick(campaignID int64, variantID int64) error
+	// GetCounts returns the counts of the variants of the campaigns keyed by variant id
+	GetCounts(campaignIDs ...int64) (map[int64]Counts, error)
+}
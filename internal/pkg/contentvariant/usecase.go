@@ -0,0 +1,227 @@
+package contentvariant
+
+import (
+	"fmt"
+	"time"
+)
+
+// Max length of the creative of a variant
+const (
+	MaxTitleLength       = 200
+	MaxDescriptionLength = 1000
+)
+
+// UseCase interface definition
+type UseCase interface {
+	// GetReport returns the performance of the enabled variants of the campaign and the current winner
+	GetReport(campaignID int64) (*Report, error)
+	GetVariants(campaignID int64) ([]Variant, error)
+	CreateVariant(variant Variant) (*Variant, error)
+	UpdateVariant(variant Variant) (*Variant, error)
+	DeleteVariant(campaignID int64, variantID int64) error
+
+	// SelectVariants picks an enabled variant of each campaign by Thompson sampling. Campaigns without variant aren't in the result
+	SelectVariants(campaignIDs []int64) (map[int64]Variant, error)
+	RecordImpression(campaignID int64, variantID int64) error
+	RecordClick(campaignID int64, variantID int64) error
+}
+
+type useCase struct {
+	repo    Repository
+	policy  Policy
+	sampler *sampler
+}
+
+// GetReport func definition
+func (u *useCase) GetReport(campaignID int64) (*Report, error) {
+	variants, err := u.getEnabledVariants(campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{CampaignID: campaignID, Performances: make([]Performance, 0, len(variants))}
+	if len(variants) == 0 {
+		return report, nil
+	}
+
+	counts, err := u.getCounts(variants, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	probabilities := u.sampler.winProbabilities(counts)
+	enoughImpressions := true
+	winner := 0
+	for i, variant := range variants {
+		report.Performances = append(report.Performances, Performance{Variant: variant, Counts: counts[i], WinProbability: probabilities[i]})
+		if probabilities[i] > probabilities[winner] {
+			winner = i
+		}
+		if counts[i].Impressions < u.policy.MinImpressions {
+			enoughImpressions = false
+		}
+	}
+	report.WinnerID = variants[winner].ID
+	report.Conclusive = enoughImpressions && probabilities[winner] >= u.policy.ConclusiveProbability
+	return report, nil
+}
+
+// GetVariants func definition
+func (u *useCase) GetVariants(campaignID int64) ([]Variant, error) {
+	return u.repo.GetVariants(campaignID)
+}
+
+// CreateVariant func definition
+func (u *useCase) CreateVariant(variant Variant) (*Variant, error) {
+	if err := validateVariant(variant); err != nil {
+		return nil, err
+	}
+
+	variants, err := u.repo.GetVariants(variant.CampaignID)
+	if err != nil {
+		return nil, err
+	} else if u.policy.MaxVariants > 0 && len(variants) >= u.policy.MaxVariants {
+		return nil, TooManyVariantsError{CampaignID: variant.CampaignID, Limit: u.policy.MaxVariants}
+	}
+
+	now := time.Now()
+	variant.ID = 0
+	variant.CreatedAt = now
+	variant.UpdatedAt = now
+	return u.repo.SaveVariant(variant)
+}
+
+// UpdateVariant func definition
+func (u *useCase) UpdateVariant(variant Variant) (*Variant, error) {
+	if err := validateVariant(variant); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.getVariant(variant.CampaignID, variant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant.CreatedAt = existing.CreatedAt
+	variant.UpdatedAt = time.Now()
+	return u.repo.SaveVariant(variant)
+}
+
+// DeleteVariant func definition
+func (u *useCase) DeleteVariant(campaignID int64, variantID int64) error {
+	if _, err := u.getVariant(campaignID, variantID); err != nil {
+		return err
+	}
+	return u.repo.DeleteVariant(campaignID, variantID)
+}
+
+// SelectVariants func definition
+func (u *useCase) SelectVariants(campaignIDs []int64) (map[int64]Variant, error) {
+	if len(campaignIDs) == 0 {
+		return nil, nil
+	}
+
+	variants, err := u.repo.GetVariants(campaignIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	variantsOfCampaigns := make(map[int64][]Variant)
+	for _, variant := range variants {
+		if variant.Enabled {
+			variantsOfCampaigns[variant.CampaignID] = append(variantsOfCampaigns[variant.CampaignID], variant)
+		}
+	}
+	if len(variantsOfCampaigns) == 0 {
+		return nil, nil
+	}
+
+	testedIDs := make([]int64, 0, len(variantsOfCampaigns))
+	for campaignID := range variantsOfCampaigns {
+		testedIDs = append(testedIDs, campaignID)
+	}
+	counts, err := u.repo.GetCounts(testedIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[int64]Variant, len(variantsOfCampaigns))
+	for campaignID, variants := range variantsOfCampaigns {
+		variantCounts := make([]Counts, len(variants))
+		for i, variant := range variants {
+			variantCounts[i] = counts[variant.ID]
+		}
+		selected[campaignID] = variants[u.sampler.choose(variantCounts)]
+	}
+	return selected, nil
+}
+
+// RecordImpression func definition
+func (u *useCase) RecordImpression(campaignID int64, variantID int64) error {
+	return u.repo.IncreaseImpression(campaignID, variantID)
+}
+
+// RecordClick func definition
+func (u *useCase) RecordClick(campaignID int64, variantID int64) error {
+	return u.repo.IncreaseClick(campaignID, variantID)
+}
+
+func (u *useCase) getVariant(campaignID int64, variantID int64) (*Variant, error) {
+	variants, err := u.repo.GetVariants(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range variants {
+		if variants[i].ID == variantID {
+			return &variants[i], nil
+		}
+	}
+	return nil, VariantNotFoundError{CampaignID: campaignID, VariantID: variantID}
+}
+
+func (u *useCase) getEnabledVariants(campaignID int64) ([]Variant, error) {
+	variants, err := u.repo.GetVariants(campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make([]Variant, 0, len(variants))
+	for _, variant := range variants {
+		if variant.Enabled {
+			enabled = append(enabled, variant)
+		}
+	}
+	return enabled, nil
+}
+
+func (u *useCase) getCounts(variants []Variant, campaignID int64) ([]Counts, error) {
+	countsByID, err := u.repo.GetCounts(campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]Counts, len(variants))
+	for i, variant := range variants {
+		counts[i] = countsByID[variant.ID]
+	}
+	return counts, nil
+}
+
+func validateVariant(variant Variant) error {
+	switch {
+	case variant.CampaignID <= 0:
+		return ValidationError{Reason: "campaign id is required"}
+	case variant.Title == "" && variant.Description == "" && variant.ImageURL == "":
+		return ValidationError{Reason: "at least one of title, description and image url is required"}
+	case len([]rune(variant.Title)) > MaxTitleLength:
+		return ValidationError{Reason: fmt.Sprintf("title is longer than %d", MaxTitleLength)}
+	case len([]rune(variant.Description)) > MaxDescriptionLength:
+		return ValidationError{Reason: fmt.Sprintf("description is longer than %d", MaxDescriptionLength)}
+	}
+	return nil
+}
+
+// NewUseCase returns content variant use case
+func NewUseCase(repo Repository, policy Policy) UseCase {
+	return &useCase{repo: repo, policy: policy, sampler: newSampler(policy)}
+}
@@ -0,0 +1,172 @@
+package contentvariant_test
+
+import (
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentvariant"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func (ts *UseCaseTestSuite) Test_GetReport() {
+	ts.repo.On("GetVariants", []int64{1}).Return([]contentvariant.Variant{
+		{ID: 10, CampaignID: 1, Title: "a", Enabled: true},
+		{ID: 11, CampaignID: 1, Title: "b", Enabled: true},
+		{ID: 12, CampaignID: 1, Title: "c"},
+	}, nil).Once()
+	ts.repo.On("GetCounts", []int64{1}).Return(map[int64]contentvariant.Counts{
+		10: {Impressions: 2000, Clicks: 40},
+		11: {Impressions: 2000, Clicks: 200},
+		12: {Impressions: 2000, Clicks: 1000},
+	}, nil).Once()
+
+	report, err := ts.useCase.GetReport(1)
+
+	ts.NoError(err)
+	ts.Len(report.Performances, 2)
+	ts.Equal(int64(11), report.WinnerID)
+	ts.True(report.Conclusive)
+	ts.InDelta(0.1, report.Performances[1].Counts.CTR(), 0.0001)
+	ts.True(report.Performances[1].WinProbability > 0.99)
+}
+
+func (ts *UseCaseTestSuite) Test_GetReport_NotEnoughImpressions() {
+	ts.repo.On("GetVariants", []int64{1}).Return([]contentvariant.Variant{
+		{ID: 10, CampaignID: 1, Title: "a", Enabled: true},
+		{ID: 11, CampaignID: 1, Title: "b", Enabled: true},
+	}, nil).Once()
+	ts.repo.On("GetCounts", []int64{1}).Return(map[int64]contentvariant.Counts{
+		10: {Impressions: 10},
+		11: {Impressions: 10, Clicks: 5},
+	}, nil).Once()
+
+	report, err := ts.useCase.GetReport(1)
+
+	ts.NoError(err)
+	ts.Equal(int64(11), report.WinnerID)
+	ts.False(report.Conclusive)
+}
+
+func (ts *UseCaseTestSuite) Test_CreateVariant() {
+	ts.repo.On("GetVariants", []int64{1}).Return([]contentvariant.Variant{}, nil).Once()
+	ts.repo.On("SaveVariant", mock.AnythingOfType("contentvariant.Variant")).Return(&contentvariant.Variant{ID: 10, CampaignID: 1, Title: "a"}, nil).Once()
+
+	variant, err := ts.useCase.CreateVariant(contentvariant.Variant{CampaignID: 1, Title: "a"})
+
+	ts.NoError(err)
+	ts.Equal(int64(10), variant.ID)
+}
+
+func (ts *UseCaseTestSuite) Test_CreateVariant_Invalid() {
+	variant, err := ts.useCase.CreateVariant(contentvariant.Variant{CampaignID: 1})
+
+	ts.IsType(contentvariant.ValidationError{}, err)
+	ts.Nil(variant)
+}
+
+func (ts *UseCaseTestSuite) Test_CreateVariant_TooMany() {
+	variants := make([]contentvariant.Variant, contentvariant.DefaultPolicy.MaxVariants)
+	ts.repo.On("GetVariants", []int64{1}).Return(variants, nil).Once()
+
+	variant, err := ts.useCase.CreateVariant(contentvariant.Variant{CampaignID: 1, Title: "a"})
+
+	ts.IsType(contentvariant.TooManyVariantsError{}, err)
+	ts.Nil(variant)
+	ts.repo.AssertNotCalled(ts.T(), "SaveVariant", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_UpdateVariant_NotFound() {
+	ts.repo.On("GetVariants", []int64{1}).Return([]contentvariant.Variant{{ID: 10, CampaignID: 1}}, nil).Once()
+
+	variant, err := ts.useCase.UpdateVariant(contentvariant.Variant{ID: 11, CampaignID: 1, Title: "a"})
+
+	ts.IsType(contentvariant.VariantNotFoundError{}, err)
+	ts.Nil(variant)
+}
+
+func (ts *UseCaseTestSuite) Test_DeleteVariant() {
+	ts.repo.On("GetVariants", []int64{1}).Return([]contentvariant.Variant{{ID: 10, CampaignID: 1}}, nil).Once()
+	ts.repo.On("DeleteVariant", int64(1), int64(10)).Return(nil).Once()
+
+	err := ts.useCase.DeleteVariant(1, 10)
+
+	ts.NoError(err)
+}
+
+func (ts *UseCaseTestSuite) Test_SelectVariants() {
+	ts.repo.On("GetVariants", []int64{1, 2}).Return([]contentvariant.Variant{
+		{ID: 10, CampaignID: 1, Title: "a", Enabled: true},
+		{ID: 11, CampaignID: 1, Title: "b", Enabled: true},
+		{ID: 20, CampaignID: 2, Title: "c"},
+	}, nil)
+	ts.repo.On("GetCounts", []int64{1}).Return(map[int64]contentvariant.Counts{
+		10: {Impressions: 1000, Clicks: 10},
+		11: {Impressions: 1000, Clicks: 300},
+	}, nil)
+
+	picked := 0
+	for i := 0; i < 100; i++ {
+		selected, err := ts.useCase.SelectVariants([]int64{1, 2})
+		ts.NoError(err)
+		ts.Len(selected, 1)
+		if selected[1].ID == 11 {
+			picked++
+		}
+	}
+	ts.True(picked > 95)
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	useCase contentvariant.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.useCase = contentvariant.NewUseCase(ts.repo, contentvariant.DefaultPolicy)
+}
+
+func (ts *UseCaseTestSuite) TearDownTest() {
+	ts.repo.AssertExpectations(ts.T())
+}
+
+var _ contentvariant.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) GetVariants(campaignIDs ...int64) ([]contentvariant.Variant, error) {
+	ret := r.Called(campaignIDs)
+	return ret.Get(0).([]contentvariant.Variant), ret.Error(1)
+}
+
+func (r *mockRepo) SaveVariant(variant contentvariant.Variant) (*contentvariant.Variant, error) {
+	ret := r.Called(variant)
+	if saved := ret.Get(0); saved != nil {
+		return saved.(*contentvariant.Variant), ret.Error(1)
+	}
+	return nil, ret.Error(1)
+}
+
+func (r *mockRepo) DeleteVariant(campaignID int64, variantID int64) error {
+	return r.Called(campaignID, variantID).Error(0)
+}
+
+func (r *mockRepo) IncreaseImpression(campaignID int64, variantID int64) error {
+	return r.Called(campaignID, variantID).Error(0)
+}
+
+func (r *mockRepo) IncreaseClick(campaignID int64, variantID int64) error {
+	return r.Called(campaignID, variantID).Error(0)
+}
+
+func (r *mockRepo) GetCounts(campaignIDs ...int64) (map[int64]contentvariant.Counts, error) {
+	ret := r.Called(campaignIDs)
+	return ret.Get(0).(map[int64]contentvariant.Counts), ret.Error(1)
+}
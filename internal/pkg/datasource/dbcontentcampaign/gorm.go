@@ -1,6 +1,8 @@
 package dbcontentcampaign
 
 import (
+	"time"
+
 	"github.com/jinzhu/gorm"
 )
 
@@ -18,6 +20,37 @@ func (r *GormDB) GetContentCampaignByID(campaignID int64) (*ContentCampaign, err
 	return &contentCampaign, err
 }
 
+// GetContentCampaignsByIDs returns content campaign records for the campaignIDs
+func (r *GormDB) GetContentCampaignsByIDs(campaignIDs []int64) ([]ContentCampaign, error) {
+	var contentCampaigns []ContentCampaign
+	if len(campaignIDs) == 0 {
+		return contentCampaigns, nil
+	}
+
+	err := r.db.Where("id IN (?)", campaignIDs).Find(&contentCampaigns).Error
+	return contentCampaigns, err
+}
+
+// GetContentCampaignsUpdatedSince returns content campaign records ordered by (updated_at, id) after the cursor.
+// Records updated at the same time of the cursor are returned only if its id is greater than afterID.
+func (r *GormDB) GetContentCampaignsUpdatedSince(since time.Time, afterID int64, limit int) ([]ContentCampaign, error) {
+	var contentCampaigns []ContentCampaign
+	err := r.db.Where("updated_at > ? OR (updated_at = ? AND id > ?)", since, since, afterID).
+		Order("updated_at, id").Limit(limit).Find(&contentCampaigns).Error
+	return contentCampaigns, err
+}
+
+// GetContentChannelsByIDs returns content channel records for the channelIDs
+func (r *GormDB) GetContentChannelsByIDs(channelIDs []int64) ([]ContentChannel, error) {
+	var contentChannels []ContentChannel
+	if len(channelIDs) == 0 {
+		return contentChannels, nil
+	}
+
+	err := r.db.Where("id IN (?)", channelIDs).Find(&contentChannels).Error
+	return contentChannels, err
+}
+
 // NewSource returns GormDB struct
 func NewSource(db *gorm.DB) *GormDB {
 	return &GormDB{db}
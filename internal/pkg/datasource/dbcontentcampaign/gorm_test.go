@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/dbcontentcampaign"
 
@@ -36,6 +37,65 @@ func (ts *RepoTestSuite) Test_GetContentCampaignByID() {
 	ts.Equal(dbContentCampaign.UpdatedAt, result.UpdatedAt)
 }
 
+func (ts *RepoTestSuite) Test_GetContentCampaignsByIDs() {
+	var dbContentCampaign dbcontentcampaign.ContentCampaign
+	ts.NoError(faker.FakeData(&dbContentCampaign))
+
+	req := "SELECT * FROM `content_campaigns` WHERE (id IN (?,?))"
+	ts.mock.ExpectQuery(fixedFullRe(req)).
+		WithArgs(1, 2).
+		WillReturnRows(getRowsForContentCampaigns(&dbContentCampaign))
+
+	result, err := ts.dbSource.GetContentCampaignsByIDs([]int64{1, 2})
+
+	ts.NoError(err)
+	ts.Len(result, 1)
+	ts.Equal(dbContentCampaign.ID, result[0].ID)
+
+	result, err = ts.dbSource.GetContentCampaignsByIDs(nil)
+
+	ts.NoError(err)
+	ts.Len(result, 0)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_GetContentCampaignsUpdatedSince() {
+	var dbContentCampaign dbcontentcampaign.ContentCampaign
+	ts.NoError(faker.FakeData(&dbContentCampaign))
+	since := time.Now().Add(-time.Hour)
+
+	req := "SELECT * FROM `content_campaigns` WHERE (updated_at > ? OR (updated_at = ? AND id > ?)) ORDER BY updated_at, id LIMIT 100"
+	ts.mock.ExpectQuery(fixedFullRe(req)).
+		WithArgs(since, since, 10).
+		WillReturnRows(getRowsForContentCampaigns(&dbContentCampaign))
+
+	result, err := ts.dbSource.GetContentCampaignsUpdatedSince(since, 10, 100)
+
+	ts.NoError(err)
+	ts.Len(result, 1)
+	ts.Equal(dbContentCampaign.ID, result[0].ID)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_GetContentChannelsByIDs() {
+	req := "SELECT * FROM `content_channels` WHERE (id IN (?,?))"
+	ts.mock.ExpectQuery(fixedFullRe(req)).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "logo"}).AddRow(1, "buzzvil", "http://logo.png"))
+
+	result, err := ts.dbSource.GetContentChannelsByIDs([]int64{1, 2})
+
+	ts.NoError(err)
+	ts.Len(result, 1)
+	ts.Equal("buzzvil", result[0].Name)
+
+	result, err = ts.dbSource.GetContentChannelsByIDs(nil)
+
+	ts.NoError(err)
+	ts.Len(result, 0)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
 func getRowsForContentCampaigns(cc *dbcontentcampaign.ContentCampaign) *sqlmock.Rows {
 	names, fields := getListFields(*cc)
 	rows := sqlmock.NewRows(names)
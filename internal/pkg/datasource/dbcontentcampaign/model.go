@@ -65,3 +65,17 @@ type ContentCampaign struct {
 func (ContentCampaign) TableName() string {
 	return "content_campaigns"
 }
+
+// ContentChannel model definition
+type ContentChannel struct {
+	ID        int64 `gorm:"primary_key"`
+	Category  string
+	Logo      string
+	Name      string
+	Publisher string
+}
+
+// TableName returns name of content channel table
+func (ContentChannel) TableName() string {
+	return "content_channels"
+}
@@ -1,6 +1,11 @@
 package dbcontentcampaign
 
+import "time"
+
 // DBSource interface definition
 type DBSource interface {
 	GetContentCampaignByID(campaignID int64) (*ContentCampaign, error)
+	GetContentCampaignsByIDs(campaignIDs []int64) ([]ContentCampaign, error)
+	GetContentCampaignsUpdatedSince(since time.Time, afterID int64, limit int) ([]ContentCampaign, error)
+	GetContentChannelsByIDs(channelIDs []int64) ([]ContentChannel, error)
 }
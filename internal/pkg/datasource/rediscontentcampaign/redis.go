@@ -2,6 +2,8 @@ package rediscontentcampaign
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis"
@@ -16,6 +18,7 @@ const (
 	dataTypeImpression = "imp"
 	dataTypeClick      = "clk"
 	dateHourLayout     = "2006-01-02:15"
+	scanCount          = 1000
 )
 
 // IncreaseImpression increase impression count
@@ -44,6 +47,218 @@ func (r *RedisContentCampaign) increase(campaignID int64, unitID int64, dataType
 	return nil
 }
 
+// IncreaseVariantImpression increase impression count of the creative variant
+func (r *RedisContentCampaign) IncreaseVariantImpression(campaignID int64, variantID int64) error {
+	return r.client.HIncrBy(getCampaignHashKey(campaignID), getKeyVariant(variantID, dataTypeImpression), 1).Err()
+}
+
+// IncreaseVariantClick increase click count of the creative variant
+func (r *RedisContentCampaign) IncreaseVariantClick(campaignID int64, variantID int64) error {
+	return r.client.HIncrBy(getCampaignHashKey(campaignID), getKeyVariant(variantID, dataTypeClick), 1).Err()
+}
+
+// GetVariantCounts returns the total counts of the creative variants of the campaigns keyed by variant id
+func (r *RedisContentCampaign) GetVariantCounts(campaignIDs ...int64) (map[int64]Counts, error) {
+	pipeline := r.client.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, 0, len(campaignIDs))
+	for _, campaignID := range campaignIDs {
+		cmds = append(cmds, pipeline.HGetAll(getCampaignHashKey(campaignID)))
+	}
+	if _, err := pipeline.Exec(); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]Counts)
+	for _, cmd := range cmds {
+		for key, valueStr := range cmd.Val() {
+			variantID, dataType, ok := parseKeyVariant(key)
+			value, err := strconv.ParseInt(valueStr, 10, 64)
+			if !ok || err != nil {
+				continue
+			}
+
+			c := counts[variantID]
+			switch dataType {
+			case dataTypeImpression:
+				c.Impressions += value
+			case dataTypeClick:
+				c.Clicks += value
+			}
+			counts[variantID] = c
+		}
+	}
+	return counts, nil
+}
+
+// GetCampaignCounts returns the counts of campaigns for all units in the hour. counts of each unit are not scanned
+func (r *RedisContentCampaign) GetCampaignCounts(dateHour time.Time) (map[int64]Counts, error) {
+	hashKey := getHashKey(dateHour)
+	counts := make(map[int64]Counts)
+
+	var cursor uint64
+	for {
+		fields, next, err := r.client.HScan(hashKey, cursor, "cam:*:all:*", scanCount).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		// fields are [key1, value1, key2, value2, ...]
+		for i := 0; i+1 < len(fields); i += 2 {
+			campaignID, dataType, ok := parseKeyCampaign(fields[i])
+			value, err := strconv.ParseInt(fields[i+1], 10, 64)
+			if !ok || err != nil {
+				continue
+			}
+
+			c := counts[campaignID]
+			switch dataType {
+			case dataTypeImpression:
+				c.Impressions += value
+			case dataTypeClick:
+				c.Clicks += value
+			}
+			counts[campaignID] = c
+		}
+
+		if next == 0 {
+			return counts, nil
+		}
+		cursor = next
+	}
+}
+
+// GetCampaignUnitCounts returns the counts of campaigns for each unit in the hour keyed by campaign id and unit id
+func (r *RedisContentCampaign) GetCampaignUnitCounts(dateHour time.Time) (map[int64]map[int64]Counts, error) {
+	hashKey := getHashKey(dateHour)
+	counts := make(map[int64]map[int64]Counts)
+
+	var cursor uint64
+	for {
+		fields, next, err := r.client.HScan(hashKey, cursor, "cam:*", scanCount).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i+1 < len(fields); i += 2 {
+			campaignID, unitID, dataType, ok := parseKeyCampaignUnit(fields[i])
+			value, err := strconv.ParseInt(fields[i+1], 10, 64)
+			if !ok || err != nil {
+				continue
+			}
+
+			if counts[campaignID] == nil {
+				counts[campaignID] = make(map[int64]Counts)
+			}
+			c := counts[campaignID][unitID]
+			switch dataType {
+			case dataTypeImpression:
+				c.Impressions += value
+			case dataTypeClick:
+				c.Clicks += value
+			}
+			counts[campaignID][unitID] = c
+		}
+
+		if next == 0 {
+			return counts, nil
+		}
+		cursor = next
+	}
+}
+
+// SaveTrendingScores replaces the ranked list of the key with the scores. The list is removed if scores is empty
+func (r *RedisContentCampaign) SaveTrendingScores(key string, scores map[int64]float64, ttl time.Duration) error {
+	redisKey := getTrendingKey(key)
+	if len(scores) == 0 {
+		return r.client.Del(redisKey).Err()
+	}
+
+	members := make([]redis.Z, 0, len(scores))
+	for campaignID, score := range scores {
+		members = append(members, redis.Z{Score: score, Member: campaignID})
+	}
+
+	// 임시 키에 쓰고 교체해서 조회 중에 일부만 보이지 않게 한다
+	tmpKey := redisKey + ":tmp"
+	pipeline := r.client.TxPipeline()
+	pipeline.Del(tmpKey)
+	pipeline.ZAdd(tmpKey, members...)
+	pipeline.Rename(tmpKey, redisKey)
+	pipeline.Expire(redisKey, ttl)
+	_, err := pipeline.Exec()
+	return err
+}
+
+// GetTrendingScores returns the top ranked scores of the key
+func (r *RedisContentCampaign) GetTrendingScores(key string, size int) ([]TrendingScore, error) {
+	members, err := r.client.ZRevRangeWithScores(getTrendingKey(key), 0, int64(size-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]TrendingScore, 0, len(members))
+	for _, member := range members {
+		memberStr, _ := member.Member.(string)
+		campaignID, err := strconv.ParseInt(memberStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		scores = append(scores, TrendingScore{CampaignID: campaignID, Score: member.Score})
+	}
+	return scores, nil
+}
+
+// parseKeyCampaign parses "cam:{campaignID}:all:{dataType}"
+func parseKeyCampaign(key string) (int64, string, bool) {
+	parts := strings.Split(key, ":")
+	if len(parts) != 4 || parts[0] != "cam" || parts[2] != "all" {
+		return 0, "", false
+	}
+	campaignID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return campaignID, parts[3], true
+}
+
+// parseKeyCampaignUnit parses "cam:{campaignID}:{unitID}:{dataType}". counts for all units are not parsed
+func parseKeyCampaignUnit(key string) (int64, int64, string, bool) {
+	parts := strings.Split(key, ":")
+	if len(parts) != 4 || parts[0] != "cam" {
+		return 0, 0, "", false
+	}
+	campaignID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, "", false
+	}
+	unitID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, 0, "", false
+	}
+	return campaignID, unitID, parts[3], true
+}
+
+// parseKeyVariant parses "var:{variantID}:{dataType}"
+func parseKeyVariant(key string) (int64, string, bool) {
+	parts := strings.Split(key, ":")
+	if len(parts) != 3 || parts[0] != "var" {
+		return 0, "", false
+	}
+	variantID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return variantID, parts[2], true
+}
+
+func getKeyVariant(variantID int64, dataType string) string {
+	return fmt.Sprintf("var:%v:%v", variantID, dataType)
+}
+
+func getTrendingKey(key string) string {
+	return fmt.Sprintf("trending:%v", key)
+}
+
 func getKeyTotal(dataType string) string {
 	return fmt.Sprintf("total:%v", dataType)
 }
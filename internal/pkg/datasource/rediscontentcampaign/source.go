@@ -1,7 +1,28 @@
 package rediscontentcampaign
 
+import "time"
+
 // RedisSource interface definition
 type RedisSource interface {
 	IncreaseImpression(campaignID int64, unitID int64) error
 	IncreaseClick(campaignID int64, unitID int64) error
+	IncreaseVariantImpression(campaignID int64, variantID int64) error
+	IncreaseVariantClick(campaignID int64, variantID int64) error
+	GetVariantCounts(campaignIDs ...int64) (map[int64]Counts, error)
+	GetCampaignCounts(dateHour time.Time) (map[int64]Counts, error)
+	GetCampaignUnitCounts(dateHour time.Time) (map[int64]map[int64]Counts, error)
+	SaveTrendingScores(key string, scores map[int64]float64, ttl time.Duration) error
+	GetTrendingScores(key string, size int) ([]TrendingScore, error)
+}
+
+// Counts is the impression and click counts of a campaign for all units
+type Counts struct {
+	Impressions int64
+	Clicks      int64
+}
+
+// TrendingScore is the trending score of a campaign in a ranked list
+type TrendingScore struct {
+	CampaignID int64
+	Score      float64
 }
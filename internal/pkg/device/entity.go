@@ -17,6 +17,10 @@ type Profile struct {
 	IsDebugScore                 bool
 	UnitRegisteredSeconds        *map[int64]int64
 	UnitRegisteredSecondsChanged *map[int64]bool
+	ContentFeedbacks             *[]ContentFeedback // from the oldest
+	ContentFeedbacksVersion      int64              // incremented on every save of the feedbacks to detect concurrent updates
+	HiddenCampaignIDs            *[]int64
+	HiddenChannelIDs             *[]int64
 }
 
 // ActivityType type definition
@@ -30,9 +34,10 @@ const (
 
 // Activity struct definition
 type Activity struct {
-	SeenCampaignIDs          map[string]bool
-	SeenCampaignCountForDay  map[string]int
-	SeenCampaignCountForHour map[string]int
+	SeenCampaignIDs              map[string]bool
+	SeenCampaignCountForDay      map[string]int
+	SeenCampaignCountForHour     map[string]int
+	SeenCampaignCountForLifetime map[string]int
 }
 
 // ChangePackageName returns true if package name is changed
@@ -112,3 +117,32 @@ type Params struct {
 	PubUserID string
 	IFA       string
 }
+
+// FeedbackType type definition
+type FeedbackType string
+
+// FeedbackType constants
+const (
+	FeedbackLike                   FeedbackType = "like"
+	FeedbackDislike                FeedbackType = "dislike"
+	FeedbackHide                   FeedbackType = "hide"
+	FeedbackNotInterestedInChannel FeedbackType = "not_interested_in_channel"
+	FeedbackBookmark               FeedbackType = "bookmark"
+)
+
+// ContentFeedback is an explicit feedback of a device on a content campaign
+type ContentFeedback struct {
+	Type       FeedbackType `json:"t"`
+	CampaignID int64        `json:"c"`
+	ChannelID  int64        `json:"ch,omitempty"`
+	CreatedAt  int64        `json:"ts"`
+	// 점수 범위로 잘린 뒤 실제로 반영된 변화량. feedback이 바뀔 때 그대로 되돌린다
+	CategoryDeltas map[string]float64 `json:"cd,omitempty"`
+	EntityDeltas   map[string]float64 `json:"ed,omitempty"`
+}
+
+// ContentRelevance is the relevance of a content campaign to watson categories and entities
+type ContentRelevance struct {
+	CategoryScores map[string]float64
+	EntityScores   map[string]float64
+}
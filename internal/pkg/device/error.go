@@ -11,6 +11,11 @@ type RemoteProfileError struct {
 	Err error
 }
 
+// ProfileConflictError is returned when the profile was updated by another request after it was read
+type ProfileConflictError struct {
+	DeviceID int64
+}
+
 // InvalidArgumentError struct definition
 type InvalidArgumentError struct {
 	ArgName  string
@@ -26,3 +31,8 @@ func (pre RemoteProfileError) Error() string {
 func (iae InvalidArgumentError) Error() string {
 	return fmt.Sprintf("Argument %v : %v is invalid", iae.ArgName, iae.ArgValue)
 }
+
+// Error func definition
+func (pce ProfileConflictError) Error() string {
+	return fmt.Sprintf("profile of device %v was updated concurrently", pce.DeviceID)
+}
@@ -0,0 +1,184 @@
+package device
+
+import "math"
+
+// FeedbackPolicy bounds the update of the category and entity scores of a profile by a feedback.
+// A score changes by Step * weight of the feedback type * relevance of the campaign and doesn't cross [MinScore, MaxScore] by the change.
+type FeedbackPolicy struct {
+	Step                float64
+	Weights             map[FeedbackType]float64
+	MinScore            float64
+	MaxScore            float64
+	MaxFeedbacks        int // the oldest feedbacks are dropped over the limit
+	MaxHiddenCampaigns  int
+	MaxHiddenChannels   int
+	MaxScoresPerProfile int // new categories or entities aren't added to the scores over the limit
+}
+
+// DefaultFeedbackPolicy var definition
+var DefaultFeedbackPolicy = FeedbackPolicy{
+	Step: 0.1,
+	Weights: map[FeedbackType]float64{
+		FeedbackLike:     1,
+		FeedbackDislike:  -1,
+		FeedbackHide:     -0.5,
+		FeedbackBookmark: 1,
+	},
+	MinScore:            0,
+	MaxScore:            1,
+	MaxFeedbacks:        300,
+	MaxHiddenCampaigns:  1000,
+	MaxHiddenChannels:   100,
+	MaxScoresPerProfile: 500,
+}
+
+// IsValid returns true if the feedback type is known
+func (t FeedbackType) IsValid() bool {
+	switch t {
+	case FeedbackLike, FeedbackDislike, FeedbackHide, FeedbackNotInterestedInChannel, FeedbackBookmark:
+		return true
+	}
+	return false
+}
+
+// ApplyContentFeedback records the feedback and updates the scores of the profile by the relevance of the campaign.
+// The previous feedback on the same campaign is reverted first so that repeating a feedback doesn't change the scores further.
+func (dp *Profile) ApplyContentFeedback(feedback ContentFeedback, relevance ContentRelevance, policy FeedbackPolicy) {
+	if prev := dp.popContentFeedback(feedback.CampaignID); prev != nil {
+		dp.revertContentFeedback(*prev, feedback.Type)
+	}
+	feedback.CategoryDeltas, feedback.EntityDeltas = dp.updateScores(relevance, policy.Step*policy.Weights[feedback.Type], policy)
+
+	feedbacks := append(dp.getContentFeedbacks(), feedback)
+	if policy.MaxFeedbacks > 0 && len(feedbacks) > policy.MaxFeedbacks {
+		feedbacks = feedbacks[len(feedbacks)-policy.MaxFeedbacks:]
+	}
+	dp.ContentFeedbacks = &feedbacks
+
+	switch feedback.Type {
+	case FeedbackHide:
+		dp.HiddenCampaignIDs = appendBounded(dp.HiddenCampaignIDs, feedback.CampaignID, policy.MaxHiddenCampaigns)
+	case FeedbackNotInterestedInChannel:
+		if feedback.ChannelID > 0 {
+			dp.HiddenChannelIDs = appendBounded(dp.HiddenChannelIDs, feedback.ChannelID, policy.MaxHiddenChannels)
+		}
+	}
+}
+
+// revertContentFeedback subtracts the deltas applied by the previous feedback and unhides the campaign if the feedback type changed from hide
+func (dp *Profile) revertContentFeedback(prev ContentFeedback, newType FeedbackType) {
+	revertScores(dp.CategoriesScores, prev.CategoryDeltas)
+	revertScores(dp.EntityScores, prev.EntityDeltas)
+	if prev.Type == FeedbackHide && newType != FeedbackHide {
+		dp.HiddenCampaignIDs = removeID(dp.HiddenCampaignIDs, prev.CampaignID)
+	}
+}
+
+func (dp *Profile) getContentFeedbacks() []ContentFeedback {
+	if dp.ContentFeedbacks == nil {
+		return make([]ContentFeedback, 0, 1)
+	}
+	return *dp.ContentFeedbacks
+}
+
+// popContentFeedback removes the feedback on the campaign and returns it. nil is returned if there's none
+func (dp *Profile) popContentFeedback(campaignID int64) *ContentFeedback {
+	feedbacks := dp.getContentFeedbacks()
+	for i := range feedbacks {
+		if feedbacks[i].CampaignID == campaignID {
+			prev := feedbacks[i]
+			feedbacks = append(feedbacks[:i:i], feedbacks[i+1:]...)
+			dp.ContentFeedbacks = &feedbacks
+			return &prev
+		}
+	}
+	return nil
+}
+
+// updateScores returns the deltas actually applied to the category and entity scores
+func (dp *Profile) updateScores(relevance ContentRelevance, delta float64, policy FeedbackPolicy) (map[string]float64, map[string]float64) {
+	if delta == 0 {
+		return nil, nil
+	}
+	var categoryDeltas, entityDeltas map[string]float64
+	if len(relevance.CategoryScores) > 0 {
+		dp.CategoriesScores, categoryDeltas = updateScores(dp.CategoriesScores, relevance.CategoryScores, delta, policy)
+	}
+	if len(relevance.EntityScores) > 0 {
+		dp.EntityScores, entityDeltas = updateScores(dp.EntityScores, relevance.EntityScores, delta, policy)
+	}
+	return categoryDeltas, entityDeltas
+}
+
+func updateScores(scores *map[string]float64, relevance map[string]float64, delta float64, policy FeedbackPolicy) (*map[string]float64, map[string]float64) {
+	if scores == nil {
+		newScores := make(map[string]float64, len(relevance))
+		scores = &newScores
+	}
+	var applied map[string]float64
+	for key, r := range relevance {
+		score, ok := (*scores)[key]
+		updated := score + delta*r
+		// 오프라인 잡이 범위 밖의 점수를 저장했더라도 feedback으로 범위 쪽으로 당기지는 않는다
+		if delta > 0 && updated > policy.MaxScore {
+			updated = math.Max(score, policy.MaxScore)
+		} else if delta < 0 && updated < policy.MinScore {
+			updated = math.Min(score, policy.MinScore)
+		}
+		if !ok && (updated <= policy.MinScore || policy.MaxScoresPerProfile > 0 && len(*scores) >= policy.MaxScoresPerProfile) {
+			continue
+		}
+		(*scores)[key] = updated
+		if updated != score {
+			if applied == nil {
+				applied = make(map[string]float64)
+			}
+			applied[key] = updated - score
+		}
+	}
+	return scores, applied
+}
+
+// revertScores subtracts the applied deltas. Keys removed from the scores since, e.g. by the offline job, are left out
+func revertScores(scores *map[string]float64, deltas map[string]float64) {
+	if scores == nil {
+		return
+	}
+	for key, delta := range deltas {
+		if score, ok := (*scores)[key]; ok {
+			(*scores)[key] = score - delta
+		}
+	}
+}
+
+// appendBounded appends the id if it's not in ids. The oldest ids are dropped over max
+func appendBounded(ids *[]int64, id int64, max int) *[]int64 {
+	var newIDs []int64
+	if ids != nil {
+		for _, i := range *ids {
+			if i == id {
+				return ids
+			}
+		}
+		newIDs = *ids
+	}
+	newIDs = append(newIDs, id)
+	if max > 0 && len(newIDs) > max {
+		newIDs = newIDs[len(newIDs)-max:]
+	}
+	return &newIDs
+}
+
+// removeID removes the id from ids without modifying the underlying array
+func removeID(ids *[]int64, id int64) *[]int64 {
+	if ids == nil {
+		return nil
+	}
+	newIDs := make([]int64, 0, len(*ids))
+	for _, i := range *ids {
+		if i != id {
+			newIDs = append(newIDs, i)
+		}
+	}
+	return &newIDs
+}
@@ -1,10 +1,12 @@
 package repo
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
+	"github.com/go-redis/redis"
 	"github.com/guregu/dynamo"
 )
 
@@ -14,6 +16,10 @@ const (
 	keyActivityType = "at"
 	keyCampaignID   = "cid"
 	keyTTL          = "t"
+
+	// activity는 이틀만 보관되므로 tipu 비교에 쓰이는 전체 노출 수는 redis에 따로 누적한다
+	lifetimeCountKeyFormat = "device:campaign_impressions:%d"
+	lifetimeCountTTL       = time.Hour * 24 * 90 // 마지막 노출 후 90일이 지나면 초기화된다
 )
 
 // Activity struct definition
@@ -28,6 +34,7 @@ type Activity struct {
 // ActivityRepo type definition
 type ActivityRepo struct {
 	dynamoTable *dynamo.Table
+	redisClient *redis.Client
 }
 
 // GetByID func definition
@@ -40,10 +47,17 @@ func (r *ActivityRepo) GetByID(deviceID int64) (*device.Activity, error) {
 		Range(keyCreatedAt, dynamo.GreaterOrEqual, float64(now.AddDate(0, 0, -2).Unix())).
 		Filter("$ >= ?", keyTTL, now.Unix()).
 		All(&activities)
+	if err != nil {
+		return nil, err
+	}
 
-	if err != nil || len(activities) == 0 {
+	seenCampaignCountForLifetime, err := r.getLifetimeCounts(deviceID)
+	if err != nil {
 		return nil, err
 	}
+	if len(activities) == 0 && len(seenCampaignCountForLifetime) == 0 {
+		return nil, nil
+	}
 
 	// Convert list of IDs to map
 	yesterday := float64(now.AddDate(0, 0, -1).Unix())
@@ -67,12 +81,28 @@ func (r *ActivityRepo) GetByID(deviceID int64) (*device.Activity, error) {
 	}
 
 	return &device.Activity{
-		SeenCampaignCountForDay:  seenCampaignCountForDay,
-		SeenCampaignCountForHour: seenCampaignCountForHour,
-		SeenCampaignIDs:          seenCampaignIDs,
+		SeenCampaignCountForDay:      seenCampaignCountForDay,
+		SeenCampaignCountForHour:     seenCampaignCountForHour,
+		SeenCampaignCountForLifetime: seenCampaignCountForLifetime,
+		SeenCampaignIDs:              seenCampaignIDs,
 	}, nil
 }
 
+func (r *ActivityRepo) getLifetimeCounts(deviceID int64) (map[string]int, error) {
+	values, err := r.redisClient.HGetAll(getLifetimeCountKey(deviceID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(values))
+	for cID, value := range values {
+		if count, err := strconv.Atoi(value); err == nil {
+			counts[cID] = count
+		}
+	}
+	return counts, nil
+}
+
 // Save func definition
 func (r *ActivityRepo) Save(deviceID int64, campaignID int64, activityType device.ActivityType) error {
 	now := time.Now()
@@ -84,10 +114,30 @@ func (r *ActivityRepo) Save(deviceID int64, campaignID int64, activityType devic
 		CampaignID: campaignID,
 		TTL:        time.Now().AddDate(0, 0, 2).Unix(),
 	}
-	return r.dynamoTable.Put(activity).Run()
+	if err := r.dynamoTable.Put(activity).Run(); err != nil {
+		return err
+	}
+
+	if activityType == device.ActivityImpression {
+		return r.increaseLifetimeCount(deviceID, campaignID)
+	}
+	return nil
+}
+
+func (r *ActivityRepo) increaseLifetimeCount(deviceID int64, campaignID int64) error {
+	key := getLifetimeCountKey(deviceID)
+	pipeline := r.redisClient.Pipeline()
+	pipeline.HIncrBy(key, strconv.FormatInt(campaignID, 10), 1)
+	pipeline.Expire(key, lifetimeCountTTL)
+	_, err := pipeline.Exec()
+	return err
+}
+
+func getLifetimeCountKey(deviceID int64) string {
+	return fmt.Sprintf(lifetimeCountKeyFormat, deviceID)
 }
 
 // NewActivityRepo returns new ActivityRepository implementation
-func NewActivityRepo(dynamoTable *dynamo.Table) *ActivityRepo {
-	return &ActivityRepo{dynamoTable}
+func NewActivityRepo(dynamoTable *dynamo.Table, redisClient *redis.Client) *ActivityRepo {
+	return &ActivityRepo{dynamoTable, redisClient}
 }
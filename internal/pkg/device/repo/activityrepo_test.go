@@ -1,6 +1,7 @@
 package repo_test
 
 import (
+	"fmt"
 	"math/rand"
 	"net/http/httptest"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/Buzzvil/buzzscreen-api/buzzscreen/env"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device/repo"
+	"github.com/go-redis/redis"
 	"github.com/guregu/dynamo"
 	"github.com/stretchr/testify/suite"
 )
@@ -31,9 +33,10 @@ func (ts *ActivityRepoTestSuite) TestRepo_GetByID() {
 	ts.createActivityRecord(deviceID, campaignID, now.Add(-(time.Hour*48 + time.Minute)).Unix()) // 48시간 1분 전
 
 	expectedActivity := &device.Activity{
-		SeenCampaignIDs:          map[string]bool{"100": true},
-		SeenCampaignCountForDay:  map[string]int{"100": 4},
-		SeenCampaignCountForHour: map[string]int{"100": 3},
+		SeenCampaignIDs:              map[string]bool{"100": true},
+		SeenCampaignCountForDay:      map[string]int{"100": 4},
+		SeenCampaignCountForHour:     map[string]int{"100": 3},
+		SeenCampaignCountForLifetime: map[string]int{},
 	}
 
 	resultActivity, err := ts.repo.GetByID(deviceID)
@@ -42,6 +45,21 @@ func (ts *ActivityRepoTestSuite) TestRepo_GetByID() {
 	ts.Equal(expectedActivity, resultActivity)
 }
 
+func (ts *ActivityRepoTestSuite) TestRepo_Save() {
+	deviceID := rand.Int63n(10000000) + 1
+	defer ts.redisClient.Del(fmt.Sprintf("device:campaign_impressions:%d", deviceID))
+
+	ts.NoError(ts.repo.Save(deviceID, 100, device.ActivityImpression))
+	ts.NoError(ts.repo.Save(deviceID, 100, device.ActivityImpression))
+	ts.NoError(ts.repo.Save(deviceID, 100, device.ActivityClick))
+
+	resultActivity, err := ts.repo.GetByID(deviceID)
+	ts.NoError(err)
+
+	ts.Equal(map[string]int{"100": 2}, resultActivity.SeenCampaignCountForDay)
+	ts.Equal(map[string]int{"100": 2}, resultActivity.SeenCampaignCountForLifetime)
+}
+
 func (ts *ActivityRepoTestSuite) createActivityRecord(deviceID int64, campaignID int64, createdAt int64) *repo.Activity {
 	activity := &repo.Activity{
 		DeviceID:   deviceID,
@@ -64,6 +82,7 @@ type ActivityRepoTestSuite struct {
 	suite.Suite
 	activityTable dynamo.Table
 	server        *httptest.Server
+	redisClient   *redis.Client
 	repo          device.ActivityRepository
 }
 
@@ -79,7 +98,8 @@ func (ts *ActivityRepoTestSuite) SetupTest() {
 	}
 
 	ts.activityTable = dyDB.Table(ActivityTableName)
-	ts.repo = repo.NewActivityRepo(&ts.activityTable)
+	ts.redisClient = env.GetRedis()
+	ts.repo = repo.NewActivityRepo(&ts.activityTable, ts.redisClient)
 }
 
 func (ts *ActivityRepoTestSuite) TearDownTest() {
@@ -2,10 +2,13 @@ package repo
 
 import (
 	"encoding/json"
+	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/guregu/dynamo"
 )
 
@@ -22,6 +25,10 @@ const (
 	keyDailyActiveUser             = "dau"
 	keyIsDebugScore                = "ids"
 	keyUnitRegisteredSecondsPrefix = "urd:"
+	keyContentFeedbacks            = "fb"
+	keyHiddenCampaignIDs           = "hc"
+	keyHiddenChannelIDs            = "hch"
+	keyVersion                     = "v"
 )
 
 // DynamoDP type definition
@@ -30,6 +37,7 @@ type DynamoDP struct {
 	Profile      string `dynamo:"profile,range"`
 	ProfileValue string `dynamo:"pv"`
 	Timestamp    int64  `dynamo:"ts"`
+	Version      int64  `dynamo:"v,omitempty"`
 }
 
 // ProfileRepo type definition
@@ -76,6 +84,19 @@ func (r *ProfileRepo) GetByID(deviceID int64) (*device.Profile, error) {
 			entScores := make(map[string]float64)
 			deviceProfile.EntityScores = &entScores
 			json.Unmarshal([]byte(profile.ProfileValue), deviceProfile.EntityScores)
+		case keyContentFeedbacks:
+			feedbacks := make([]device.ContentFeedback, 0)
+			deviceProfile.ContentFeedbacks = &feedbacks
+			json.Unmarshal([]byte(profile.ProfileValue), deviceProfile.ContentFeedbacks)
+			deviceProfile.ContentFeedbacksVersion = profile.Version
+		case keyHiddenCampaignIDs:
+			campaignIDs := make([]int64, 0)
+			deviceProfile.HiddenCampaignIDs = &campaignIDs
+			json.Unmarshal([]byte(profile.ProfileValue), deviceProfile.HiddenCampaignIDs)
+		case keyHiddenChannelIDs:
+			channelIDs := make([]int64, 0)
+			deviceProfile.HiddenChannelIDs = &channelIDs
+			json.Unmarshal([]byte(profile.ProfileValue), deviceProfile.HiddenChannelIDs)
 		case keyModelArtifact:
 			deviceProfile.ModelArtifact = &profile.ProfileValue
 		case keyPackageName:
@@ -195,6 +216,66 @@ func (r *ProfileRepo) SaveUnitRegisteredSeconds(dp device.Profile) error {
 	return err
 }
 
+// SaveContentFeedback saves the values updated by content feedbacks.
+// The feedbacks are saved first only if their version is still dp.ContentFeedbacksVersion, otherwise ProfileConflictError is returned and nothing is saved.
+func (r *ProfileRepo) SaveContentFeedback(dp device.Profile) error {
+	if err := r.saveContentFeedbacks(dp); err != nil {
+		return err
+	}
+
+	values := []struct {
+		key   string
+		value interface{}
+	}{
+		{keyHiddenCampaignIDs, dp.HiddenCampaignIDs},
+		{keyHiddenChannelIDs, dp.HiddenChannelIDs},
+		{keyCategoriesScores, dp.CategoriesScores},
+		{keyEntityScores, dp.EntityScores},
+	}
+
+	for _, v := range values {
+		if reflect.ValueOf(v.value).IsNil() {
+			continue
+		}
+		jsonBytes, err := json.Marshal(v.value)
+		if err != nil {
+			return err
+		}
+		err = r.saveDynamoDP(DynamoDP{
+			DeviceID:     dp.ID,
+			Profile:      v.key,
+			ProfileValue: string(jsonBytes),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ProfileRepo) saveContentFeedbacks(dp device.Profile) error {
+	feedbacks := dp.ContentFeedbacks
+	if feedbacks == nil {
+		feedbacks = &[]device.ContentFeedback{}
+	}
+	jsonBytes, err := json.Marshal(feedbacks)
+	if err != nil {
+		return err
+	}
+	err = r.dynamoTable.Put(&DynamoDP{
+		DeviceID:     dp.ID,
+		Profile:      keyContentFeedbacks,
+		ProfileValue: string(jsonBytes),
+		Version:      dp.ContentFeedbacksVersion + 1,
+	}).If("attribute_not_exists($) OR $ = ?", keyVersion, keyVersion, dp.ContentFeedbacksVersion).Run()
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		return device.ProfileConflictError{DeviceID: dp.ID}
+	} else if err != nil {
+		return device.RemoteProfileError{Err: err}
+	}
+	return nil
+}
+
 // Delete func definition
 func (r *ProfileRepo) Delete(dp device.Profile) error {
 	return r.dynamoTable.Delete(keyID, dp.ID).If("$ >= 0", keyRegisteredSeconds).Run()
@@ -6,6 +6,8 @@ type ProfileRepository interface {
 	Save(dp Profile) error
 	SavePackage(dp Profile) error
 	SaveUnitRegisteredSeconds(dp Profile) error
+	// SaveContentFeedback saves the content feedbacks, hidden campaigns and channels and the category and entity scores
+	SaveContentFeedback(dp Profile) error
 	Delete(dp Profile) error
 }
 
@@ -11,6 +11,8 @@ type UseCase interface {
 	SaveProfilePackage(dp Profile) error
 	SaveProfileUnitRegisteredSeconds(dp Profile) error
 	DeleteProfile(dp Profile) error
+	// ApplyContentFeedback records the feedback of the device and updates the category and entity scores of the profile immediately
+	ApplyContentFeedback(deviceID int64, feedback ContentFeedback, relevance ContentRelevance) (*Profile, error)
 	GetByID(deviceID int64) (*Device, error)
 	GetByParams(params Params) (*Device, error)
 	UpsertDevice(device Device) (*Device, error)
@@ -18,10 +20,14 @@ type UseCase interface {
 	ValidateUnitDeviceToken(unitDeviceToken string) (bool, error)
 }
 
+// maxContentFeedbackAttempts bounds the retries of ApplyContentFeedback on concurrent updates of the profile
+const maxContentFeedbackAttempts = 3
+
 type deviceUseCase struct {
-	repo    Repository
-	proRepo ProfileRepository
-	actRepo ActivityRepository
+	repo           Repository
+	proRepo        ProfileRepository
+	actRepo        ActivityRepository
+	feedbackPolicy FeedbackPolicy
 }
 
 // GetActivity func definition
@@ -59,6 +65,40 @@ func (u *deviceUseCase) DeleteProfile(dp Profile) error {
 	return u.proRepo.Delete(dp)
 }
 
+// ApplyContentFeedback func definition
+func (u *deviceUseCase) ApplyContentFeedback(deviceID int64, feedback ContentFeedback, relevance ContentRelevance) (*Profile, error) {
+	if deviceID == 0 {
+		return nil, InvalidArgumentError{ArgName: "deviceID", ArgValue: deviceID}
+	} else if !feedback.Type.IsValid() {
+		return nil, InvalidArgumentError{ArgName: "feedback.Type", ArgValue: feedback.Type}
+	} else if feedback.CampaignID == 0 {
+		return nil, InvalidArgumentError{ArgName: "feedback.CampaignID", ArgValue: feedback.CampaignID}
+	}
+
+	var err error
+	for attempt := 0; attempt < maxContentFeedbackAttempts; attempt++ {
+		var dp *Profile
+		dp, err = u.proRepo.GetByID(deviceID)
+		if err != nil {
+			return nil, err
+		} else if dp == nil {
+			dp = &Profile{ID: deviceID}
+		}
+
+		dp.ApplyContentFeedback(feedback, relevance, u.feedbackPolicy)
+		err = u.proRepo.SaveContentFeedback(*dp)
+		if _, ok := err.(ProfileConflictError); ok {
+			// 다른 요청이 먼저 저장했으면 새로 읽은 profile에 다시 반영한다
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		dp.ContentFeedbacksVersion++
+		return dp, nil
+	}
+	return nil, err
+}
+
 // GetByID func definition
 func (u *deviceUseCase) GetByID(deviceID int64) (*Device, error) {
 	if deviceID == 0 {
@@ -118,5 +158,5 @@ var _ UseCase = &deviceUseCase{}
 
 // NewUseCase returns new device usecase.
 func NewUseCase(repo Repository, profileRepo ProfileRepository, activityRepo ActivityRepository) UseCase {
-	return &deviceUseCase{repo, profileRepo, activityRepo}
+	return &deviceUseCase{repo: repo, proRepo: profileRepo, actRepo: activityRepo, feedbackPolicy: DefaultFeedbackPolicy}
 }
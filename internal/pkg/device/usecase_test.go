@@ -81,6 +81,148 @@ func (ts *UseCaseTestSuite) TestValidateUnitDeviceToken() {
 	})
 }
 
+func (ts *UseCaseTestSuite) Test_ApplyContentFeedback() {
+	deviceID := int64(rand.Int63n(1000000) + 1)
+	relevance := device.ContentRelevance{
+		CategoryScores: map[string]float64{"/sports": 1, "/news": 0.5},
+		EntityScores:   map[string]float64{"son": 1},
+	}
+
+	ts.Run("like", func() {
+		categoriesScores := map[string]float64{"/sports": 0.5, "/travel": 0.3}
+		dp := &device.Profile{ID: deviceID, CategoriesScores: &categoriesScores}
+		ts.profileRepo.On("GetByID", deviceID).Return(dp, nil).Once()
+		ts.profileRepo.On("SaveContentFeedback", mock.AnythingOfType("device.Profile")).Return(nil).Once()
+
+		res, err := ts.useCase.ApplyContentFeedback(deviceID, device.ContentFeedback{Type: device.FeedbackLike, CampaignID: 1}, relevance)
+
+		ts.NoError(err)
+		ts.InDelta(0.6, (*res.CategoriesScores)["/sports"], 1e-9)
+		ts.InDelta(0.05, (*res.CategoriesScores)["/news"], 1e-9)
+		ts.InDelta(0.3, (*res.CategoriesScores)["/travel"], 1e-9)
+		ts.InDelta(0.1, (*res.EntityScores)["son"], 1e-9)
+		ts.Len(*res.ContentFeedbacks, 1)
+		ts.Nil(res.HiddenCampaignIDs)
+	})
+
+	ts.Run("repeated and changed feedback", func() {
+		categoriesScores := map[string]float64{"/sports": 0.5}
+		dp := &device.Profile{ID: deviceID, CategoriesScores: &categoriesScores}
+		ts.profileRepo.On("GetByID", deviceID).Return(dp, nil).Times(3)
+		ts.profileRepo.On("SaveContentFeedback", mock.AnythingOfType("device.Profile")).Return(nil).Times(3)
+
+		for _, feedbackType := range []device.FeedbackType{device.FeedbackLike, device.FeedbackLike, device.FeedbackDislike} {
+			_, err := ts.useCase.ApplyContentFeedback(deviceID, device.ContentFeedback{Type: feedbackType, CampaignID: 1}, relevance)
+			ts.NoError(err)
+		}
+
+		ts.InDelta(0.4, (*dp.CategoriesScores)["/sports"], 1e-9)
+		ts.Len(*dp.ContentFeedbacks, 1)
+		ts.Equal(device.FeedbackDislike, (*dp.ContentFeedbacks)[0].Type)
+	})
+
+	ts.Run("bounded", func() {
+		categoriesScores := map[string]float64{"/sports": 0.95, "/news": 17.3}
+		dp := &device.Profile{ID: deviceID, CategoriesScores: &categoriesScores}
+		ts.profileRepo.On("GetByID", deviceID).Return(dp, nil).Once()
+		ts.profileRepo.On("SaveContentFeedback", mock.AnythingOfType("device.Profile")).Return(nil).Once()
+
+		res, err := ts.useCase.ApplyContentFeedback(deviceID, device.ContentFeedback{Type: device.FeedbackLike, CampaignID: 1}, relevance)
+
+		ts.NoError(err)
+		ts.Equal(1.0, (*res.CategoriesScores)["/sports"])
+		ts.Equal(17.3, (*res.CategoriesScores)["/news"])
+	})
+
+	ts.Run("clipped feedback reverted", func() {
+		categoriesScores := map[string]float64{"/sports": 0.95}
+		dp := &device.Profile{ID: deviceID, CategoriesScores: &categoriesScores}
+		ts.profileRepo.On("GetByID", deviceID).Return(dp, nil).Times(2)
+		ts.profileRepo.On("SaveContentFeedback", mock.AnythingOfType("device.Profile")).Return(nil).Times(2)
+
+		for _, feedbackType := range []device.FeedbackType{device.FeedbackLike, device.FeedbackDislike} {
+			_, err := ts.useCase.ApplyContentFeedback(deviceID, device.ContentFeedback{Type: feedbackType, CampaignID: 1}, relevance)
+			ts.NoError(err)
+		}
+
+		ts.InDelta(0.85, (*dp.CategoriesScores)["/sports"], 1e-9)
+		ts.InDelta(-0.1, (*dp.ContentFeedbacks)[0].CategoryDeltas["/sports"], 1e-9)
+	})
+
+	ts.Run("hide changed", func() {
+		hiddenCampaignIDs := []int64{5}
+		dp := &device.Profile{ID: deviceID, HiddenCampaignIDs: &hiddenCampaignIDs}
+		ts.profileRepo.On("GetByID", deviceID).Return(dp, nil).Times(2)
+		ts.profileRepo.On("SaveContentFeedback", mock.AnythingOfType("device.Profile")).Return(nil).Times(2)
+
+		for _, feedbackType := range []device.FeedbackType{device.FeedbackHide, device.FeedbackLike} {
+			_, err := ts.useCase.ApplyContentFeedback(deviceID, device.ContentFeedback{Type: feedbackType, CampaignID: 1}, relevance)
+			ts.NoError(err)
+		}
+
+		ts.Equal([]int64{5}, *dp.HiddenCampaignIDs)
+	})
+
+	ts.Run("concurrent update", func() {
+		ts.profileRepo.On("GetByID", deviceID).Return(&device.Profile{ID: deviceID}, nil).Once()
+		ts.profileRepo.On("SaveContentFeedback", mock.AnythingOfType("device.Profile")).Return(device.ProfileConflictError{DeviceID: deviceID}).Once()
+		feedbacks := []device.ContentFeedback{{Type: device.FeedbackLike, CampaignID: 2}}
+		ts.profileRepo.On("GetByID", deviceID).Return(&device.Profile{ID: deviceID, ContentFeedbacks: &feedbacks, ContentFeedbacksVersion: 1}, nil).Once()
+		ts.profileRepo.On("SaveContentFeedback", mock.MatchedBy(func(dp device.Profile) bool {
+			return dp.ContentFeedbacksVersion == 1 && len(*dp.ContentFeedbacks) == 2
+		})).Return(nil).Once()
+
+		res, err := ts.useCase.ApplyContentFeedback(deviceID, device.ContentFeedback{Type: device.FeedbackLike, CampaignID: 1}, relevance)
+
+		ts.NoError(err)
+		ts.Equal(int64(2), res.ContentFeedbacksVersion)
+		ts.profileRepo.AssertExpectations(ts.T())
+	})
+
+	ts.Run("conflict retries exhausted", func() {
+		ts.profileRepo.On("GetByID", deviceID).Return(&device.Profile{ID: deviceID}, nil).Times(3)
+		ts.profileRepo.On("SaveContentFeedback", mock.AnythingOfType("device.Profile")).Return(device.ProfileConflictError{DeviceID: deviceID}).Times(3)
+
+		res, err := ts.useCase.ApplyContentFeedback(deviceID, device.ContentFeedback{Type: device.FeedbackLike, CampaignID: 1}, relevance)
+
+		ts.IsType(device.ProfileConflictError{}, err)
+		ts.Nil(res)
+	})
+
+	ts.Run("hide", func() {
+		ts.profileRepo.On("GetByID", deviceID).Return((*device.Profile)(nil), nil).Once()
+		ts.profileRepo.On("SaveContentFeedback", mock.AnythingOfType("device.Profile")).Return(nil).Once()
+
+		res, err := ts.useCase.ApplyContentFeedback(deviceID, device.ContentFeedback{Type: device.FeedbackHide, CampaignID: 1, ChannelID: 2}, relevance)
+
+		ts.NoError(err)
+		ts.Equal([]int64{1}, *res.HiddenCampaignIDs)
+		ts.Nil(res.HiddenChannelIDs)
+		ts.Empty(*res.CategoriesScores) // 없던 카테고리는 negative feedback으로 추가되지 않는다
+	})
+
+	ts.Run("not interested in channel", func() {
+		hiddenChannelIDs := []int64{2}
+		dp := &device.Profile{ID: deviceID, HiddenChannelIDs: &hiddenChannelIDs}
+		ts.profileRepo.On("GetByID", deviceID).Return(dp, nil).Once()
+		ts.profileRepo.On("SaveContentFeedback", mock.AnythingOfType("device.Profile")).Return(nil).Once()
+
+		res, err := ts.useCase.ApplyContentFeedback(deviceID, device.ContentFeedback{Type: device.FeedbackNotInterestedInChannel, CampaignID: 1, ChannelID: 3}, relevance)
+
+		ts.NoError(err)
+		ts.Equal([]int64{2, 3}, *res.HiddenChannelIDs)
+		ts.Nil(res.CategoriesScores)
+	})
+
+	ts.Run("invalid type", func() {
+		res, err := ts.useCase.ApplyContentFeedback(deviceID, device.ContentFeedback{Type: "love", CampaignID: 1}, relevance)
+
+		_, ok := err.(device.InvalidArgumentError)
+		ts.True(ok)
+		ts.Nil(res)
+	})
+}
+
 type mockRepo struct {
 	mock.Mock
 }
@@ -129,6 +271,11 @@ func (r *mockProfileRepo) SaveUnitRegisteredSeconds(dp device.Profile) error {
 	return ret.Error(0)
 }
 
+func (r *mockProfileRepo) SaveContentFeedback(dp device.Profile) error {
+	ret := r.Called(dp)
+	return ret.Error(0)
+}
+
 func (r *mockProfileRepo) Delete(dp device.Profile) error {
 	ret := r.Called(dp)
 	return ret.Error(0)
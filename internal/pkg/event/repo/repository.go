@@ -146,6 +146,44 @@ func (r *Repository) DeleteTrackingURL(deviceID int64, resource event.Resource)
 	return nil
 }
 
+// SaveFailTrackers saves failTrackers of the trackingURL to cache
+func (r *Repository) SaveFailTrackers(deviceID int64, resource event.Resource, failTrackers []string) {
+	cacheKey := r.getCacheKeyFailTrackers(deviceID, resource)
+	r.redisCache.SetCacheAsync(cacheKey, failTrackers, trackingURLCacheExpiration)
+}
+
+// GetFailTrackers returns failTrackers from cache
+func (r *Repository) GetFailTrackers(deviceID int64, resource event.Resource) ([]string, error) {
+	cacheKey := r.getCacheKeyFailTrackers(deviceID, resource)
+
+	var failTrackers []string
+	err := r.redisCache.GetCache(cacheKey, &failTrackers)
+	if err == cache.ErrCacheMiss {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return failTrackers, nil
+}
+
+// DeleteFailTrackers deletes failTrackers in cache
+func (r *Repository) DeleteFailTrackers(deviceID int64, resource event.Resource) error {
+	cacheKey := r.getCacheKeyFailTrackers(deviceID, resource)
+
+	err := r.redisCache.DeleteCache(cacheKey)
+	if err != nil && err != cache.ErrCacheMiss {
+		return err
+	}
+
+	return nil
+}
+
+// getCacheKeyFailTrackers shares the prefix of trackingURL key so that both are found by device
+func (r *Repository) getCacheKeyFailTrackers(deviceID int64, resource event.Resource) string {
+	return fmt.Sprintf("%s-FAIL", r.getCacheKeyTrackingURL(deviceID, resource))
+}
+
 func (r *Repository) getCacheKeyTrackingURL(deviceID int64, resource event.Resource) string {
 	return fmt.Sprintf("CACHE_GO_TRACKINGURL-%v-%v-%v", deviceID, resource.ID, resource.Type)
 }
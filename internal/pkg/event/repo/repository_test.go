@@ -66,6 +66,30 @@ func (ts *RepoTestSuite) TestSaveAndDelteTrackingURL() {
 	ts.NoError(err)
 }
 
+func (ts *RepoTestSuite) TestSaveGetAndDeleteFailTrackers() {
+	deviceID := rand.Int63n(1000000) + 1
+	resource := event.Resource{
+		ID:   rand.Int63n(1000000) + 1,
+		Type: event.ResourceTypeAd,
+	}
+	failTrackers := []string{"https://fail.tracker/1", "https://fail.tracker/2"}
+	cacheExpiration := time.Minute * 5
+	cacheKey := fmt.Sprintf("CACHE_GO_TRACKINGURL-%v-%v-%v-FAIL", deviceID, resource.ID, resource.Type)
+
+	ts.cache.On("SetCacheAsync", cacheKey, failTrackers, cacheExpiration).Once()
+	ts.cache.On("GetCache", cacheKey, mock.AnythingOfType("*[]string")).Return(func(key string, obj interface{}) error {
+		*obj.(*[]string) = failTrackers
+		return nil
+	}).Once()
+	ts.cache.On("DeleteCache", cacheKey).Return(nil).Once()
+
+	ts.repo.SaveFailTrackers(deviceID, resource, failTrackers)
+	result, err := ts.repo.GetFailTrackers(deviceID, resource)
+	ts.NoError(err)
+	ts.Equal(failTrackers, result)
+	ts.NoError(ts.repo.DeleteFailTrackers(deviceID, resource))
+}
+
 func (ts *RepoTestSuite) TestGetRewardStatus() {
 	resource := ts.createResource(rand.Intn(100000) + 1)
 	unitID := rand.Int63n(1000000) + 1
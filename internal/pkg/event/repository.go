@@ -11,4 +11,7 @@ type Repository interface {
 	SaveTrackingURL(deviceID int64, resource Resource, trackURL string)
 	GetTrackingURL(deviceID int64, resource Resource) (string, error)
 	DeleteTrackingURL(deviceID int64, resource Resource) error
+	SaveFailTrackers(deviceID int64, resource Resource, failTrackers []string)
+	GetFailTrackers(deviceID int64, resource Resource) ([]string, error)
+	DeleteFailTrackers(deviceID int64, resource Resource) error
 }
@@ -16,6 +16,8 @@ type UseCase interface {
 	GetEventsMap(resources []Resource, unitID int64, a header.Auth) (map[int64]Events, error)
 	SaveTrackingURL(deviceID int64, resource Resource, trackingURL string)
 	GetTrackingURL(deviceID int64, resource Resource) (string, error)
+	SaveFailTrackers(deviceID int64, resource Resource, failTrackers []string)
+	GetFailTrackers(deviceID int64, resource Resource) ([]string, error)
 }
 
 type useCase struct {
@@ -67,6 +69,26 @@ func (u *useCase) GetTrackingURL(deviceID int64, resource Resource) (string, err
 	return trackingURL, nil
 }
 
+// SaveFailTrackers saves failTrackers fired when the trackingURL can't be delivered
+func (u *useCase) SaveFailTrackers(deviceID int64, resource Resource, failTrackers []string) {
+	u.repo.SaveFailTrackers(deviceID, resource, failTrackers)
+}
+
+// GetFailTrackers retrieves and deletes failTrackers
+func (u *useCase) GetFailTrackers(deviceID int64, resource Resource) ([]string, error) {
+	failTrackers, err := u.repo.GetFailTrackers(deviceID, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(failTrackers) > 0 {
+		if err := u.repo.DeleteFailTrackers(deviceID, resource); err != nil {
+			core.Logger.Warnf("Failed to delete fail trackers. err: %v", err)
+		}
+	}
+	return failTrackers, nil
+}
+
 func (u *useCase) logTrackingURLActivity(method string, deviceID int64, resource Resource, trackingURL string) {
 	m := map[string]interface{}{
 		"type":          "tracking_url_activity",
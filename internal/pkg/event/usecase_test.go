@@ -55,6 +55,23 @@ func (ts *UseCaseTestSuite) TestGetTrackURL() {
 	ts.Equal(trackingURL, expected)
 }
 
+func (ts *UseCaseTestSuite) TestGetFailTrackers() {
+	deviceID := rand.Int63n(1000000) + 1
+	resource := Resource{
+		ID:   rand.Int63n(1000000) + 1,
+		Type: ResourceTypeAd,
+	}
+	failTrackers := []string{"https://fail.tracker"}
+
+	ts.repo.On("GetFailTrackers", deviceID, resource).Return(failTrackers, nil).Once()
+	ts.repo.On("DeleteFailTrackers", deviceID, resource).Return(nil).Once()
+
+	expected, err := ts.useCase.GetFailTrackers(deviceID, resource)
+
+	ts.NoError(err)
+	ts.Equal(failTrackers, expected)
+}
+
 func (ts *UseCaseTestSuite) validateLogTrackURLActivity(method string, deviceID int64, resource Resource, trackURL string) func(map[string]interface{}) bool {
 	return func(m map[string]interface{}) bool {
 		ts.Equal(method, m["method"])
@@ -267,6 +284,20 @@ func (r *mockRepo) DeleteTrackingURL(deviceID int64, resource Resource) error {
 	return ret.Error(0)
 }
 
+func (r *mockRepo) SaveFailTrackers(deviceID int64, resource Resource, failTrackers []string) {
+	r.Called(deviceID, resource, failTrackers)
+}
+
+func (r *mockRepo) GetFailTrackers(deviceID int64, resource Resource) ([]string, error) {
+	ret := r.Called(deviceID, resource)
+	return ret.Get(0).([]string), ret.Error(1)
+}
+
+func (r *mockRepo) DeleteFailTrackers(deviceID int64, resource Resource) error {
+	ret := r.Called(deviceID, resource)
+	return ret.Error(0)
+}
+
 type mockMessageHandler struct {
 	mock.Mock
 }
@@ -0,0 +1,145 @@
+package identitygraph
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/common/ifa"
+)
+
+// IdentifierType type definition
+type IdentifierType string
+
+// IdentifierType constants
+const (
+	IdentifierIFA             IdentifierType = "ifa"
+	IdentifierIFV             IdentifierType = "ifv"
+	IdentifierUnitDeviceToken IdentifierType = "unit_device_token"
+	IdentifierCookie          IdentifierType = "cookie"
+)
+
+// patchedIFAPattern matches the IFA a device lost to another device. refer to dbdevice.GormDB.patchIFAForCollidedDeviceInTransaction
+var patchedIFAPattern = regexp.MustCompile(`_d_\d+_\d+$`)
+
+// Identifier is a value identifying a device. Unit device tokens are unique in the app only
+type Identifier struct {
+	Type  IdentifierType
+	AppID int64 // 0 unless Type is IdentifierUnitDeviceToken
+	Value string
+}
+
+// IFAIdentifier returns the identifier of the IFA of a device. The IFV identifier is returned if the IFA was replaced by the IFV
+func IFAIdentifier(value string) Identifier {
+	if strings.HasPrefix(value, ifa.IFVPrefix) {
+		return IFVIdentifier(strings.TrimPrefix(value, ifa.IFVPrefix))
+	}
+	return Identifier{Type: IdentifierIFA, Value: value}
+}
+
+// IFVIdentifier func definition
+func IFVIdentifier(value string) Identifier {
+	return Identifier{Type: IdentifierIFV, Value: value}
+}
+
+// UnitDeviceTokenIdentifier func definition
+func UnitDeviceTokenIdentifier(appID int64, value string) Identifier {
+	return Identifier{Type: IdentifierUnitDeviceToken, AppID: appID, Value: value}
+}
+
+// CookieIdentifier func definition
+func CookieIdentifier(value string) Identifier {
+	return Identifier{Type: IdentifierCookie, Value: value}
+}
+
+// IsValid returns false for the values shared by unrelated devices such as the empty IFA and the IFAs patched on collision
+func (i Identifier) IsValid() bool {
+	switch {
+	case i.Value == "":
+		return false
+	case i.Type == IdentifierIFA:
+		return i.Value != ifa.EmptyIFA && !patchedIFAPattern.MatchString(i.Value)
+	case i.Type == IdentifierUnitDeviceToken:
+		return i.AppID != 0
+	default:
+		return true
+	}
+}
+
+// String func definition
+func (i Identifier) String() string {
+	if i.AppID != 0 {
+		return fmt.Sprintf("%s:%d:%s", i.Type, i.AppID, i.Value)
+	}
+	return fmt.Sprintf("%s:%s", i.Type, i.Value)
+}
+
+// DeviceIdentifiers returns the valid identifiers of the IFA and unit device token of a device record
+func DeviceIdentifiers(appID int64, ifa string, unitDeviceToken string) []Identifier {
+	identifiers := make([]Identifier, 0, 2)
+	for _, identifier := range []Identifier{IFAIdentifier(ifa), UnitDeviceTokenIdentifier(appID, unitDeviceToken)} {
+		if identifier.IsValid() {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+	return identifiers
+}
+
+// Source is where a link is found
+type Source string
+
+// Source constants
+const (
+	SourceDevice        Source = "device"         // device registration
+	SourceDeviceHistory Source = "device_history" // device_update_history recorded before the graph
+	SourceClick         Source = "click"          // click redirect setting the cookie
+)
+
+// Link is an edge between a device and an identifier associated with it from FirstSeenAt to LastSeenAt
+type Link struct {
+	ID          int64
+	DeviceID    int64
+	Identifier  Identifier
+	Source      Source // where the link is found first
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
+
+// Account is the devices connected by sharing identifiers. The oldest device, the one of the smallest id, is canonical
+type Account struct {
+	CanonicalDeviceID int64
+	DeviceIDs         []int64
+	Links             []Link
+	Truncated         bool // true if the devices are cut by the policy
+}
+
+// DeviceHistory is a change of the IFA or unit device token of a device recorded by dbdevice.GormDB.UpsertDevice
+type DeviceHistory struct {
+	ID        int64
+	DeviceID  int64
+	AppID     int64
+	Field     IdentifierType // IdentifierIFA or IdentifierUnitDeviceToken
+	FromValue string
+	ToValue   string
+	CreatedAt time.Time
+}
+
+// BackfillResult type definition
+type BackfillResult struct {
+	LastHistoryID int64 // 0 if no history is left
+	Histories     int
+	Links         int
+}
+
+// Policy limits the traversal of the graph so that an identifier shared by many devices doesn't merge unrelated accounts
+type Policy struct {
+	MaxDevices              int // devices of an account
+	MaxDevicesPerIdentifier int // identifiers linked to more devices are not traversed
+}
+
+// DefaultPolicy stops at 50 devices and skips the identifiers of more than 10 devices
+var DefaultPolicy = Policy{
+	MaxDevices:              50,
+	MaxDevicesPerIdentifier: 10,
+}
@@ -0,0 +1,17 @@
+package identitygraph
+
+import "fmt"
+
+var (
+	_ error = NotFoundError{}
+)
+
+// NotFoundError will be returned when no device is linked to the identifier
+type NotFoundError struct {
+	Identifier string
+}
+
+// Error func definition
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("no device is linked to %s", e.Identifier)
+}
@@ -0,0 +1,120 @@
+package identitygraph
+
+import "sort"
+
+// graph walks the links alternately through devices and identifiers until no new device is found
+type graph struct {
+	policy      Policy
+	devices     map[int64]bool
+	identifiers map[Identifier]bool
+	links       map[int64]Link // keyed by link id
+	truncated   bool
+}
+
+func newGraph(policy Policy) *graph {
+	return &graph{
+		policy:      policy,
+		devices:     make(map[int64]bool),
+		identifiers: make(map[Identifier]bool),
+		links:       make(map[int64]Link),
+	}
+}
+
+func (g *graph) visitIdentifier(identifier Identifier) bool {
+	if g.identifiers[identifier] {
+		return false
+	}
+	g.identifiers[identifier] = true
+	return true
+}
+
+// addDevices returns the devices of the links not visited yet up to the max devices of the policy
+func (g *graph) addDevices(links []Link) []int64 {
+	deviceIDs := make([]int64, 0)
+	for _, link := range links {
+		if g.devices[link.DeviceID] {
+			continue
+		} else if len(g.devices) >= g.policy.MaxDevices {
+			g.truncated = true
+			break
+		}
+		g.devices[link.DeviceID] = true
+		deviceIDs = append(deviceIDs, link.DeviceID)
+	}
+	return deviceIDs
+}
+
+// addLinks records the links of the visited devices and returns the identifiers not visited yet
+func (g *graph) addLinks(links []Link) []Identifier {
+	identifiers := make([]Identifier, 0)
+	for _, link := range links {
+		g.links[link.ID] = link
+		if g.visitIdentifier(link.Identifier) {
+			identifiers = append(identifiers, link.Identifier)
+		}
+	}
+	return identifiers
+}
+
+// removeHubs drops the links of the identifiers linked to more devices than the policy allows
+func (g *graph) removeHubs(links []Link) []Link {
+	devices := make(map[Identifier]map[int64]bool)
+	for _, link := range links {
+		if devices[link.Identifier] == nil {
+			devices[link.Identifier] = make(map[int64]bool)
+		}
+		devices[link.Identifier][link.DeviceID] = true
+	}
+
+	result := make([]Link, 0, len(links))
+	for _, link := range links {
+		if len(devices[link.Identifier]) > g.policy.MaxDevicesPerIdentifier {
+			g.truncated = true
+			continue
+		}
+		result = append(result, link)
+	}
+	return result
+}
+
+func (g *graph) expand(repo Repository, deviceIDs []int64) (*Account, error) {
+	for len(deviceIDs) > 0 {
+		links, err := repo.GetLinksByDevices(deviceIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		identifiers := g.addLinks(links)
+		if len(identifiers) == 0 {
+			break
+		}
+
+		links, err = repo.GetLinksByIdentifiers(identifiers)
+		if err != nil {
+			return nil, err
+		}
+		deviceIDs = g.addDevices(g.removeHubs(links))
+	}
+	return g.account(), nil
+}
+
+func (g *graph) account() *Account {
+	account := &Account{
+		DeviceIDs: make([]int64, 0, len(g.devices)),
+		Links:     make([]Link, 0, len(g.links)),
+		Truncated: g.truncated,
+	}
+	for deviceID := range g.devices {
+		account.DeviceIDs = append(account.DeviceIDs, deviceID)
+	}
+	sort.Slice(account.DeviceIDs, func(i, j int) bool { return account.DeviceIDs[i] < account.DeviceIDs[j] })
+	if len(account.DeviceIDs) > 0 {
+		account.CanonicalDeviceID = account.DeviceIDs[0]
+	}
+
+	for _, link := range g.links {
+		account.Links = append(account.Links, link)
+	}
+	sortLinks(account.Links)
+	return account
+}
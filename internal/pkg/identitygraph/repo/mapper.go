@@ -0,0 +1,46 @@
+package repo
+
+import "github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
+
+type entityMapper struct {
+}
+
+func (m *entityMapper) dbLinkToLink(dbLink DBLink) identitygraph.Link {
+	return identitygraph.Link{
+		ID:       dbLink.ID,
+		DeviceID: dbLink.DeviceID,
+		Identifier: identitygraph.Identifier{
+			Type:  identitygraph.IdentifierType(dbLink.IdentifierType),
+			AppID: dbLink.IdentifierAppID,
+			Value: dbLink.IdentifierValue,
+		},
+		Source:      identitygraph.Source(dbLink.Source),
+		FirstSeenAt: dbLink.FirstSeenAt,
+		LastSeenAt:  dbLink.LastSeenAt,
+	}
+}
+
+func (m *entityMapper) linkToDBLink(link identitygraph.Link) DBLink {
+	return DBLink{
+		ID:              link.ID,
+		DeviceID:        link.DeviceID,
+		IdentifierType:  string(link.Identifier.Type),
+		IdentifierAppID: link.Identifier.AppID,
+		IdentifierValue: link.Identifier.Value,
+		Source:          string(link.Source),
+		FirstSeenAt:     link.FirstSeenAt,
+		LastSeenAt:      link.LastSeenAt,
+	}
+}
+
+func (m *entityMapper) dbDeviceHistoryToDeviceHistory(dbHistory dbDeviceHistory) identitygraph.DeviceHistory {
+	return identitygraph.DeviceHistory{
+		ID:        dbHistory.ID,
+		DeviceID:  dbHistory.DeviceID,
+		AppID:     dbHistory.AppID,
+		Field:     identitygraph.IdentifierType(dbHistory.UpdatedField),
+		FromValue: dbHistory.FromValue,
+		ToValue:   dbHistory.ToValue,
+		CreatedAt: dbHistory.CreatedAt,
+	}
+}
@@ -0,0 +1,31 @@
+package repo
+
+import "time"
+
+// DBLink struct definition
+type DBLink struct {
+	ID              int64  `gorm:"primary_key"`
+	DeviceID        int64  `gorm:"unique_index:index_device_identifier"`
+	IdentifierType  string `gorm:"type:varchar(32);unique_index:index_device_identifier;index:index_identifier"`
+	IdentifierAppID int64  `gorm:"unique_index:index_device_identifier;index:index_identifier"`
+	IdentifierValue string `gorm:"type:varchar(255);unique_index:index_device_identifier;index:index_identifier"`
+	Source          string `gorm:"type:varchar(32)"`
+	FirstSeenAt     time.Time
+	LastSeenAt      time.Time
+}
+
+// TableName func definition
+func (DBLink) TableName() string {
+	return "identity_links"
+}
+
+// dbDeviceHistory is a row of device_update_history joined with the app of the device
+type dbDeviceHistory struct {
+	ID           int64
+	DeviceID     int64
+	AppID        int64
+	UpdatedField string
+	FromValue    string
+	ToValue      string
+	CreatedAt    time.Time
+}
@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"strings"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
+	"github.com/jinzhu/gorm"
+)
+
+// historyFields are the fields of device_update_history linked to the devices. They are named as identitygraph.IdentifierType
+var historyFields = []string{string(identitygraph.IdentifierIFA), string(identitygraph.IdentifierUnitDeviceToken)}
+
+// Repository struct definition
+type Repository struct {
+	db     *gorm.DB
+	mapper *entityMapper
+}
+
+// SaveLinks inserts the links in a statement. The existing links are widened to cover the periods of the new ones
+func (r *Repository) SaveLinks(links []identitygraph.Link) error {
+	if len(links) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(links))
+	args := make([]interface{}, 0, len(links)*7)
+	for _, link := range links {
+		dbLink := r.mapper.linkToDBLink(link)
+		placeholders = append(placeholders, "(?,?,?,?,?,?,?)")
+		args = append(args, dbLink.DeviceID, dbLink.IdentifierType, dbLink.IdentifierAppID, dbLink.IdentifierValue, dbLink.Source, dbLink.FirstSeenAt, dbLink.LastSeenAt)
+	}
+
+	query := "INSERT INTO `identity_links` (`device_id`,`identifier_type`,`identifier_app_id`,`identifier_value`,`source`,`first_seen_at`,`last_seen_at`) VALUES " +
+		strings.Join(placeholders, ",") +
+		" ON DUPLICATE KEY UPDATE `first_seen_at` = LEAST(`first_seen_at`, VALUES(`first_seen_at`)), `last_seen_at` = GREATEST(`last_seen_at`, VALUES(`last_seen_at`))"
+	return r.db.Exec(query, args...).Error
+}
+
+// GetLinksByDevices func definition
+func (r *Repository) GetLinksByDevices(deviceIDs []int64) ([]identitygraph.Link, error) {
+	var dbLinks []DBLink
+	if err := r.db.Where("device_id IN (?)", deviceIDs).Find(&dbLinks).Error; err != nil {
+		return nil, err
+	}
+	return r.toLinks(dbLinks), nil
+}
+
+// GetLinksByIdentifiers func definition
+func (r *Repository) GetLinksByIdentifiers(identifiers []identitygraph.Identifier) ([]identitygraph.Link, error) {
+	if len(identifiers) == 0 {
+		return []identitygraph.Link{}, nil
+	}
+
+	conditions := make([]string, 0, len(identifiers))
+	args := make([]interface{}, 0, len(identifiers)*3)
+	for _, identifier := range identifiers {
+		conditions = append(conditions, "(identifier_type = ? AND identifier_app_id = ? AND identifier_value = ?)")
+		args = append(args, string(identifier.Type), identifier.AppID, identifier.Value)
+	}
+
+	var dbLinks []DBLink
+	if err := r.db.Where(strings.Join(conditions, " OR "), args...).Find(&dbLinks).Error; err != nil {
+		return nil, err
+	}
+	return r.toLinks(dbLinks), nil
+}
+
+// GetDeviceHistories func definition
+func (r *Repository) GetDeviceHistories(afterID int64, size int) ([]identitygraph.DeviceHistory, error) {
+	var dbHistories []dbDeviceHistory
+	err := r.db.Table("device_update_history").
+		Select("device_update_history.id, device_update_history.device_id, device.app_id, device_update_history.updated_field, device_update_history.from_value, device_update_history.to_value, device_update_history.created_at").
+		Joins("JOIN device ON device.id = device_update_history.device_id").
+		Where("device_update_history.id > ? AND device_update_history.updated_field IN (?)", afterID, historyFields).
+		Order("device_update_history.id").
+		Limit(size).
+		Scan(&dbHistories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make([]identitygraph.DeviceHistory, 0, len(dbHistories))
+	for _, dbHistory := range dbHistories {
+		histories = append(histories, r.mapper.dbDeviceHistoryToDeviceHistory(dbHistory))
+	}
+	return histories, nil
+}
+
+func (r *Repository) toLinks(dbLinks []DBLink) []identitygraph.Link {
+	links := make([]identitygraph.Link, 0, len(dbLinks))
+	for _, dbLink := range dbLinks {
+		links = append(links, r.mapper.dbLinkToLink(dbLink))
+	}
+	return links
+}
+
+// New returns identity graph repository
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db:     db,
+		mapper: &entityMapper{},
+	}
+}
+
+var _ identitygraph.Repository = &Repository{}
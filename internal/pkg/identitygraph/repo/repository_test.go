@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	db   *gorm.DB
+	repo identitygraph.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	ts.NoError(err)
+	ts.mock = mock
+	ts.db, err = gorm.Open("mysql", db)
+	ts.NoError(err)
+	ts.repo = New(ts.db)
+}
+
+func (ts *RepoTestSuite) AfterTest() {
+	_ = ts.db.Close()
+}
+
+func (ts *RepoTestSuite) Test_SaveLinks() {
+	seenAt := time.Now()
+	query := "INSERT INTO `identity_links` (`device_id`,`identifier_type`,`identifier_app_id`,`identifier_value`,`source`,`first_seen_at`,`last_seen_at`) VALUES (?,?,?,?,?,?,?),(?,?,?,?,?,?,?)" +
+		" ON DUPLICATE KEY UPDATE `first_seen_at` = LEAST(`first_seen_at`, VALUES(`first_seen_at`)), `last_seen_at` = GREATEST(`last_seen_at`, VALUES(`last_seen_at`))"
+	ts.mock.ExpectExec(ts.fixedFullRe(query)).
+		WithArgs(1, "ifa", 0, "ifa-a", "device", seenAt, seenAt, 1, "unit_device_token", 100, "token", "device", seenAt, seenAt).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := ts.repo.SaveLinks([]identitygraph.Link{
+		{DeviceID: 1, Identifier: identitygraph.IFAIdentifier("ifa-a"), Source: identitygraph.SourceDevice, FirstSeenAt: seenAt, LastSeenAt: seenAt},
+		{DeviceID: 1, Identifier: identitygraph.UnitDeviceTokenIdentifier(100, "token"), Source: identitygraph.SourceDevice, FirstSeenAt: seenAt, LastSeenAt: seenAt},
+	})
+
+	ts.NoError(err)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_GetLinksByDevices() {
+	seenAt := time.Now()
+	query := "SELECT * FROM `identity_links` WHERE (device_id IN (?,?))"
+	ts.mock.ExpectQuery(ts.fixedFullRe(query)).WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "device_id", "identifier_type", "identifier_app_id", "identifier_value", "source", "first_seen_at", "last_seen_at"}).
+			AddRow(10, 1, "cookie", 0, "cookie", "click", seenAt, seenAt))
+
+	links, err := ts.repo.GetLinksByDevices([]int64{1, 2})
+
+	ts.NoError(err)
+	ts.Equal([]identitygraph.Link{{
+		ID:          10,
+		DeviceID:    1,
+		Identifier:  identitygraph.CookieIdentifier("cookie"),
+		Source:      identitygraph.SourceClick,
+		FirstSeenAt: seenAt,
+		LastSeenAt:  seenAt,
+	}}, links)
+}
+
+func (ts *RepoTestSuite) Test_GetLinksByIdentifiers() {
+	query := "SELECT * FROM `identity_links` WHERE ((identifier_type = ? AND identifier_app_id = ? AND identifier_value = ?) OR (identifier_type = ? AND identifier_app_id = ? AND identifier_value = ?))"
+	ts.mock.ExpectQuery(ts.fixedFullRe(query)).WithArgs("ifa", 0, "ifa-a", "unit_device_token", 100, "token").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "device_id", "identifier_type", "identifier_app_id", "identifier_value"}).
+			AddRow(10, 1, "unit_device_token", 100, "token"))
+
+	links, err := ts.repo.GetLinksByIdentifiers([]identitygraph.Identifier{
+		identitygraph.IFAIdentifier("ifa-a"),
+		identitygraph.UnitDeviceTokenIdentifier(100, "token"),
+	})
+
+	ts.NoError(err)
+	ts.Len(links, 1)
+	ts.Equal(identitygraph.UnitDeviceTokenIdentifier(100, "token"), links[0].Identifier)
+}
+
+func (ts *RepoTestSuite) Test_GetDeviceHistories() {
+	createdAt := time.Now()
+	query := "SELECT device_update_history.id, device_update_history.device_id, device.app_id, device_update_history.updated_field, device_update_history.from_value, device_update_history.to_value, device_update_history.created_at" +
+		" FROM `device_update_history` JOIN device ON device.id = device_update_history.device_id" +
+		" WHERE (device_update_history.id > ? AND device_update_history.updated_field IN (?,?)) ORDER BY device_update_history.id LIMIT 100"
+	ts.mock.ExpectQuery(ts.fixedFullRe(query)).WithArgs(10, "ifa", "unit_device_token").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "device_id", "app_id", "updated_field", "from_value", "to_value", "created_at"}).
+			AddRow(11, 1, 100, "ifa", "ifa-a", "ifa-b", createdAt))
+
+	histories, err := ts.repo.GetDeviceHistories(10, 100)
+
+	ts.NoError(err)
+	ts.Equal([]identitygraph.DeviceHistory{{
+		ID:        11,
+		DeviceID:  1,
+		AppID:     100,
+		Field:     identitygraph.IdentifierIFA,
+		FromValue: "ifa-a",
+		ToValue:   "ifa-b",
+		CreatedAt: createdAt,
+	}}, histories)
+}
+
+func (ts *RepoTestSuite) fixedFullRe(s string) string {
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(s))
+}
@@ -0,0 +1,11 @@
+package identitygraph
+
+// Repository interface definition
+type Repository interface {
+	// SaveLinks inserts the links. The period of the existing link of the same device and identifier is extended instead
+	SaveLinks(links []Link) error
+	GetLinksByDevices(deviceIDs []int64) ([]Link, error)
+	GetLinksByIdentifiers(identifiers []Identifier) ([]Link, error)
+	// GetDeviceHistories returns the IFA and unit device token changes after the history id in id order
+	GetDeviceHistories(afterID int64, size int) ([]DeviceHistory, error)
+}
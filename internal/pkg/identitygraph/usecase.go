@@ -0,0 +1,124 @@
+package identitygraph
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// UseCase interface definition
+type UseCase interface {
+	// LinkDevice links the identifiers seen at seenAt to the device. Invalid identifiers are skipped
+	LinkDevice(deviceID int64, identifiers []Identifier, source Source, seenAt time.Time) error
+	// GetLinks returns every identifier ever associated with the device ordered by first seen
+	GetLinks(deviceID int64) ([]Link, error)
+	// Resolve returns the account the identifier belongs to. NotFoundError is returned if no device is linked to it
+	Resolve(identifier Identifier) (*Account, error)
+	// ResolveDevice returns the account the device belongs to. NotFoundError is returned if the device has no link
+	ResolveDevice(deviceID int64) (*Account, error)
+	// BackfillHistories links the identifiers of the device histories after the history id
+	BackfillHistories(afterID int64, size int) (*BackfillResult, error)
+}
+
+type useCase struct {
+	repo   Repository
+	policy Policy
+}
+
+// LinkDevice func definition
+func (u *useCase) LinkDevice(deviceID int64, identifiers []Identifier, source Source, seenAt time.Time) error {
+	links := make([]Link, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		if identifier.IsValid() {
+			links = append(links, Link{DeviceID: deviceID, Identifier: identifier, Source: source, FirstSeenAt: seenAt, LastSeenAt: seenAt})
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return u.repo.SaveLinks(links)
+}
+
+// GetLinks func definition
+func (u *useCase) GetLinks(deviceID int64) ([]Link, error) {
+	links, err := u.repo.GetLinksByDevices([]int64{deviceID})
+	if err != nil {
+		return nil, err
+	}
+	sortLinks(links)
+	return links, nil
+}
+
+// Resolve func definition
+func (u *useCase) Resolve(identifier Identifier) (*Account, error) {
+	links, err := u.repo.GetLinksByIdentifiers([]Identifier{identifier})
+	if err != nil {
+		return nil, err
+	} else if len(links) == 0 {
+		return nil, NotFoundError{Identifier: identifier.String()}
+	}
+
+	g := newGraph(u.policy)
+	g.visitIdentifier(identifier)
+	return g.expand(u.repo, g.addDevices(links))
+}
+
+// ResolveDevice func definition
+func (u *useCase) ResolveDevice(deviceID int64) (*Account, error) {
+	g := newGraph(u.policy)
+	account, err := g.expand(u.repo, g.addDevices([]Link{{DeviceID: deviceID}}))
+	if err != nil {
+		return nil, err
+	} else if len(account.Links) == 0 {
+		return nil, NotFoundError{Identifier: "device:" + strconv.FormatInt(deviceID, 10)}
+	}
+	return account, nil
+}
+
+// BackfillHistories func definition
+func (u *useCase) BackfillHistories(afterID int64, size int) (*BackfillResult, error) {
+	histories, err := u.repo.GetDeviceHistories(afterID, size)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BackfillResult{Histories: len(histories)}
+	for _, history := range histories {
+		identifiers := make([]Identifier, 0, 2)
+		for _, value := range []string{history.FromValue, history.ToValue} {
+			if history.Field == IdentifierUnitDeviceToken {
+				identifiers = append(identifiers, UnitDeviceTokenIdentifier(history.AppID, value))
+			} else {
+				identifiers = append(identifiers, IFAIdentifier(value))
+			}
+		}
+
+		if err := u.LinkDevice(history.DeviceID, identifiers, SourceDeviceHistory, history.CreatedAt); err != nil {
+			return nil, err
+		}
+		for _, identifier := range identifiers {
+			if identifier.IsValid() {
+				result.Links++
+			}
+		}
+		result.LastHistoryID = history.ID
+	}
+	return result, nil
+}
+
+// NewUseCase returns identity graph use case
+func NewUseCase(repo Repository, policy Policy) UseCase {
+	return &useCase{repo: repo, policy: policy}
+}
+
+// sortLinks orders the links by device, first seen and id
+func sortLinks(links []Link) {
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].DeviceID != links[j].DeviceID {
+			return links[i].DeviceID < links[j].DeviceID
+		} else if !links[i].FirstSeenAt.Equal(links[j].FirstSeenAt) {
+			return links[i].FirstSeenAt.Before(links[j].FirstSeenAt)
+		}
+		return links[i].ID < links[j].ID
+	})
+}
@@ -0,0 +1,191 @@
+package identitygraph_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/identitygraph"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	now    = time.Date(2019, 5, 1, 10, 30, 0, 0, time.UTC)
+	policy = identitygraph.Policy{MaxDevices: 3, MaxDevicesPerIdentifier: 2}
+
+	ifaA   = identitygraph.IFAIdentifier("ifa-a")
+	ifaJ   = identitygraph.IFAIdentifier("ifa-junk")
+	token  = identitygraph.UnitDeviceTokenIdentifier(100, "token")
+	cookie = identitygraph.CookieIdentifier("cookie")
+)
+
+func (ts *UseCaseTestSuite) Test_LinkDevice() {
+	identifiers := []identitygraph.Identifier{
+		identitygraph.IFAIdentifier("ifvXYZ"),
+		identitygraph.IFAIdentifier("00000000-0000-0000-0000-000000000000"),
+		identitygraph.IFAIdentifier("ifa-a_d_1_2"),
+		identitygraph.UnitDeviceTokenIdentifier(0, "token"),
+		token,
+	}
+	ts.repo.On("SaveLinks", []identitygraph.Link{
+		{DeviceID: 1, Identifier: identitygraph.IFVIdentifier("XYZ"), Source: identitygraph.SourceDevice, FirstSeenAt: now, LastSeenAt: now},
+		{DeviceID: 1, Identifier: token, Source: identitygraph.SourceDevice, FirstSeenAt: now, LastSeenAt: now},
+	}).Return(nil).Once()
+
+	ts.NoError(ts.useCase.LinkDevice(1, identifiers, identitygraph.SourceDevice, now))
+}
+
+func (ts *UseCaseTestSuite) Test_LinkDevice_NothingValid() {
+	ts.NoError(ts.useCase.LinkDevice(1, []identitygraph.Identifier{identitygraph.CookieIdentifier("")}, identitygraph.SourceClick, now))
+
+	ts.repo.AssertNotCalled(ts.T(), "SaveLinks", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_GetLinks() {
+	ts.repo.On("GetLinksByDevices", []int64{1}).Return([]identitygraph.Link{
+		link(2, 1, token, now),
+		link(1, 1, ifaA, now.Add(-time.Hour)),
+	}, nil).Once()
+
+	links, err := ts.useCase.GetLinks(1)
+
+	ts.NoError(err)
+	ts.Equal([]int64{1, 2}, linkIDs(links))
+}
+
+func (ts *UseCaseTestSuite) Test_Resolve() {
+	l1, l2 := link(1, 1, ifaA, now), link(2, 1, token, now)
+	l3, l4 := link(3, 2, ifaA, now), link(4, 2, cookie, now)
+	l5, l6 := link(5, 3, cookie, now), link(6, 3, ifaJ, now)
+	ts.repo.On("GetLinksByIdentifiers", []identitygraph.Identifier{token}).Return([]identitygraph.Link{l2}, nil).Once()
+	ts.repo.On("GetLinksByDevices", []int64{1}).Return([]identitygraph.Link{l1, l2}, nil).Once()
+	ts.repo.On("GetLinksByIdentifiers", []identitygraph.Identifier{ifaA}).Return([]identitygraph.Link{l1, l3}, nil).Once()
+	ts.repo.On("GetLinksByDevices", []int64{2}).Return([]identitygraph.Link{l3, l4}, nil).Once()
+	ts.repo.On("GetLinksByIdentifiers", []identitygraph.Identifier{cookie}).Return([]identitygraph.Link{l4, l5}, nil).Once()
+	ts.repo.On("GetLinksByDevices", []int64{3}).Return([]identitygraph.Link{l5, l6}, nil).Once()
+	// ifa-junk is shared by 3 devices, more than the policy allows
+	ts.repo.On("GetLinksByIdentifiers", []identitygraph.Identifier{ifaJ}).Return([]identitygraph.Link{l6, link(7, 4, ifaJ, now), link(8, 5, ifaJ, now)}, nil).Once()
+
+	account, err := ts.useCase.Resolve(token)
+
+	ts.NoError(err)
+	ts.Equal(int64(1), account.CanonicalDeviceID)
+	ts.Equal([]int64{1, 2, 3}, account.DeviceIDs)
+	ts.Equal([]int64{1, 2, 3, 4, 5, 6}, linkIDs(account.Links))
+	ts.True(account.Truncated)
+}
+
+func (ts *UseCaseTestSuite) Test_Resolve_MaxDevices() {
+	links := []identitygraph.Link{link(1, 4, ifaA, now), link(2, 2, ifaA, now), link(3, 3, ifaA, now), link(4, 1, ifaA, now)}
+	ts.repo.On("GetLinksByIdentifiers", []identitygraph.Identifier{ifaA}).Return(links, nil).Once()
+	ts.repo.On("GetLinksByDevices", []int64{4, 2, 3}).Return(links[:3], nil).Once()
+
+	account, err := ts.useCase.Resolve(ifaA)
+
+	ts.NoError(err)
+	ts.Equal(int64(2), account.CanonicalDeviceID)
+	ts.Equal([]int64{2, 3, 4}, account.DeviceIDs)
+	ts.True(account.Truncated)
+}
+
+func (ts *UseCaseTestSuite) Test_Resolve_NotFound() {
+	ts.repo.On("GetLinksByIdentifiers", []identitygraph.Identifier{cookie}).Return([]identitygraph.Link{}, nil).Once()
+
+	_, err := ts.useCase.Resolve(cookie)
+
+	ts.Equal(identitygraph.NotFoundError{Identifier: "cookie:cookie"}, err)
+}
+
+func (ts *UseCaseTestSuite) Test_ResolveDevice() {
+	l1 := link(1, 1, ifaA, now)
+	ts.repo.On("GetLinksByDevices", []int64{1}).Return([]identitygraph.Link{l1}, nil).Once()
+	ts.repo.On("GetLinksByIdentifiers", []identitygraph.Identifier{ifaA}).Return([]identitygraph.Link{l1}, nil).Once()
+
+	account, err := ts.useCase.ResolveDevice(1)
+
+	ts.NoError(err)
+	ts.Equal(&identitygraph.Account{CanonicalDeviceID: 1, DeviceIDs: []int64{1}, Links: []identitygraph.Link{l1}}, account)
+}
+
+func (ts *UseCaseTestSuite) Test_ResolveDevice_NotFound() {
+	ts.repo.On("GetLinksByDevices", []int64{1}).Return([]identitygraph.Link{}, nil).Once()
+
+	_, err := ts.useCase.ResolveDevice(1)
+
+	ts.Equal(identitygraph.NotFoundError{Identifier: "device:1"}, err)
+}
+
+func (ts *UseCaseTestSuite) Test_BackfillHistories() {
+	ts.repo.On("GetDeviceHistories", int64(10), 2).Return([]identitygraph.DeviceHistory{
+		{ID: 11, DeviceID: 1, AppID: 100, Field: identitygraph.IdentifierIFA, FromValue: "ifa-a", ToValue: "ifa-a_d_2_1", CreatedAt: now},
+		{ID: 12, DeviceID: 2, AppID: 100, Field: identitygraph.IdentifierUnitDeviceToken, FromValue: "old", ToValue: "token", CreatedAt: now},
+	}, nil).Once()
+	ts.repo.On("SaveLinks", []identitygraph.Link{
+		{DeviceID: 1, Identifier: ifaA, Source: identitygraph.SourceDeviceHistory, FirstSeenAt: now, LastSeenAt: now},
+	}).Return(nil).Once()
+	ts.repo.On("SaveLinks", []identitygraph.Link{
+		{DeviceID: 2, Identifier: identitygraph.UnitDeviceTokenIdentifier(100, "old"), Source: identitygraph.SourceDeviceHistory, FirstSeenAt: now, LastSeenAt: now},
+		{DeviceID: 2, Identifier: token, Source: identitygraph.SourceDeviceHistory, FirstSeenAt: now, LastSeenAt: now},
+	}).Return(nil).Once()
+
+	result, err := ts.useCase.BackfillHistories(10, 2)
+
+	ts.NoError(err)
+	ts.Equal(identitygraph.BackfillResult{LastHistoryID: 12, Histories: 2, Links: 3}, *result)
+}
+
+func link(id int64, deviceID int64, identifier identitygraph.Identifier, firstSeenAt time.Time) identitygraph.Link {
+	return identitygraph.Link{ID: id, DeviceID: deviceID, Identifier: identifier, Source: identitygraph.SourceDevice, FirstSeenAt: firstSeenAt, LastSeenAt: firstSeenAt}
+}
+
+func linkIDs(links []identitygraph.Link) []int64 {
+	ids := make([]int64, 0, len(links))
+	for _, link := range links {
+		ids = append(ids, link.ID)
+	}
+	return ids
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	useCase identitygraph.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.useCase = identitygraph.NewUseCase(ts.repo, policy)
+}
+
+func (ts *UseCaseTestSuite) TearDownTest() {
+	ts.repo.AssertExpectations(ts.T())
+}
+
+var _ identitygraph.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) SaveLinks(links []identitygraph.Link) error {
+	return r.Called(links).Error(0)
+}
+
+func (r *mockRepo) GetLinksByDevices(deviceIDs []int64) ([]identitygraph.Link, error) {
+	ret := r.Called(deviceIDs)
+	return ret.Get(0).([]identitygraph.Link), ret.Error(1)
+}
+
+func (r *mockRepo) GetLinksByIdentifiers(identifiers []identitygraph.Identifier) ([]identitygraph.Link, error) {
+	ret := r.Called(identifiers)
+	return ret.Get(0).([]identitygraph.Link), ret.Error(1)
+}
+
+func (r *mockRepo) GetDeviceHistories(afterID int64, size int) ([]identitygraph.DeviceHistory, error) {
+	ret := r.Called(afterID, size)
+	return ret.Get(0).([]identitygraph.DeviceHistory), ret.Error(1)
+}
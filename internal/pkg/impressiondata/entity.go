@@ -10,6 +10,7 @@ type ImpressionData struct {
 	Country         string  `json:"cou"`
 	Gender          *string `json:"sex,omitempty"`
 	YearOfBirth     *int    `json:"yob,omitempty"`
+	VariantID       int64   `json:"v,omitempty"` // creative variant of the content campaign
 }
 
 const (
@@ -0,0 +1,71 @@
+package landing
+
+import "strings"
+
+// Type is the way how a click lands
+type Type string
+
+// Type constants
+const (
+	TypeIntent        Type = "intent"
+	TypeUniversalLink Type = "universal_link"
+	TypeWeb           Type = "web"
+)
+
+// Target contains an ad's deep link and the fallback metadata
+type Target struct {
+	DeepLink         string
+	UniversalLink    string
+	TargetApp        string
+	WebURL           string
+	PreferredBrowser *string
+}
+
+// HasAppLink returns true if the target can land on an app
+func (t Target) HasAppLink() bool {
+	return t.DeepLink != "" || t.UniversalLink != ""
+}
+
+// Device contains the device information used to resolve landing
+type Device struct {
+	Platform          string
+	InstalledPackages []string
+}
+
+// NewDevice creates Device with comma separated installed packages of device profile
+func NewDevice(platform string, installedPackages *string) Device {
+	device := Device{Platform: platform}
+	if installedPackages == nil {
+		return device
+	}
+
+	for _, pkg := range strings.Split(*installedPackages, ",") {
+		if pkg = strings.TrimSpace(pkg); pkg != "" {
+			device.InstalledPackages = append(device.InstalledPackages, pkg)
+		}
+	}
+	return device
+}
+
+// HasPackage returns true if the package is installed on the device
+func (d Device) HasPackage(packageName string) bool {
+	for _, pkg := range d.InstalledPackages {
+		if pkg == packageName {
+			return true
+		}
+	}
+	return false
+}
+
+// Landing is the resolved landing of a click
+type Landing struct {
+	Type             Type    `json:"type"`
+	URL              string  `json:"url"`
+	FallbackURL      string  `json:"fallback_url,omitempty"`
+	PreferredBrowser *string `json:"preferred_browser,omitempty"`
+}
+
+// IsApp returns true if the landing opens an app
+func (l Landing) IsApp() bool {
+	return l.Type != TypeWeb
+}
@@ -0,0 +1,83 @@
+package landing
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
+)
+
+const intentScheme = "intent"
+
+// UseCase interface definition
+type UseCase interface {
+	ResolveLanding(target Target, device Device) Landing
+}
+
+type useCase struct {
+}
+
+// ResolveLanding chooses between android intent uri, ios universal link and web fallback
+func (u *useCase) ResolveLanding(target Target, device Device) Landing {
+	web := Landing{
+		Type:             TypeWeb,
+		URL:              target.WebURL,
+		PreferredBrowser: target.PreferredBrowser,
+	}
+
+	switch device.Platform {
+	case app.PlatformAndroid:
+		// 설치 여부를 알 수 없는 경우에는 웹으로 랜딩
+		if target.DeepLink == "" || target.TargetApp == "" || !device.HasPackage(target.TargetApp) {
+			return web
+		}
+		intentURI, err := buildIntentURI(target.DeepLink, target.TargetApp, target.WebURL)
+		if err != nil {
+			return web
+		}
+		return Landing{Type: TypeIntent, URL: intentURI, FallbackURL: target.WebURL}
+	case app.PlatformIOS:
+		// universal link는 앱이 설치되어 있지 않으면 OS가 웹으로 열어줌
+		if !isWebURL(target.UniversalLink) {
+			return web
+		}
+		return Landing{Type: TypeUniversalLink, URL: target.UniversalLink, FallbackURL: target.WebURL}
+	}
+	return web
+}
+
+// buildIntentURI converts a deep link (e.g. myapp://article/1) to an android intent uri which falls back to the web url
+func buildIntentURI(deepLink string, packageName string, fallbackURL string) (string, error) {
+	parsed, err := url.Parse(deepLink)
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.Scheme == intentScheme {
+		return deepLink, nil
+	}
+
+	prefix := parsed.Scheme + "://"
+	if parsed.Scheme == "" || !strings.HasPrefix(deepLink, prefix) {
+		return "", fmt.Errorf("invalid deep link %q", deepLink)
+	}
+
+	// intent uri에서 fragment는 intent 정보를 담으므로 deep link의 fragment는 제거
+	path := strings.SplitN(strings.TrimPrefix(deepLink, prefix), "#", 2)[0]
+	intentURI := fmt.Sprintf("intent://%s#Intent;scheme=%s;package=%s;", path, parsed.Scheme, packageName)
+	if isWebURL(fallbackURL) {
+		intentURI += "S.browser_fallback_url=" + url.QueryEscape(fallbackURL) + ";"
+	}
+	return intentURI + "end", nil
+}
+
+func isWebURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// NewUseCase returns UseCase interface
+func NewUseCase() UseCase {
+	return &useCase{}
+}
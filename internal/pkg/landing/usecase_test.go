@@ -0,0 +1,106 @@
+package landing_test
+
+import (
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/landing"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testDeepLink      = "myapp://article/1?ref=buzzvil"
+	testUniversalLink = "https://myapp.example.com/article/1"
+	testTargetApp     = "com.example.myapp"
+	testWebURL        = "https://www.example.com/article/1"
+)
+
+func (ts *UseCaseTestSuite) Test_ResolveLanding_AndroidInstalled() {
+	installedPackages := "com.other.app, com.example.myapp"
+	device := landing.NewDevice(app.PlatformAndroid, &installedPackages)
+
+	result := ts.useCase.ResolveLanding(ts.buildTarget(), device)
+
+	ts.Equal(landing.TypeIntent, result.Type)
+	ts.Equal("intent://article/1?ref=buzzvil#Intent;scheme=myapp;package=com.example.myapp;S.browser_fallback_url=https%3A%2F%2Fwww.example.com%2Farticle%2F1;end", result.URL)
+	ts.Equal(testWebURL, result.FallbackURL)
+	ts.Nil(result.PreferredBrowser)
+}
+
+func (ts *UseCaseTestSuite) Test_ResolveLanding_AndroidNotInstalled() {
+	installedPackages := "com.other.app"
+	for _, device := range []landing.Device{
+		landing.NewDevice(app.PlatformAndroid, &installedPackages),
+		landing.NewDevice(app.PlatformAndroid, nil),
+	} {
+		result := ts.useCase.ResolveLanding(ts.buildTarget(), device)
+
+		ts.Equal(landing.TypeWeb, result.Type)
+		ts.Equal(testWebURL, result.URL)
+		ts.Equal("chrome", *result.PreferredBrowser)
+	}
+}
+
+func (ts *UseCaseTestSuite) Test_ResolveLanding_AndroidInvalidDeepLink() {
+	installedPackages := testTargetApp
+	target := ts.buildTarget()
+	target.DeepLink = "myapp:article"
+
+	result := ts.useCase.ResolveLanding(target, landing.NewDevice(app.PlatformAndroid, &installedPackages))
+
+	ts.Equal(landing.TypeWeb, result.Type)
+	ts.Equal(testWebURL, result.URL)
+}
+
+func (ts *UseCaseTestSuite) Test_ResolveLanding_IOS() {
+	result := ts.useCase.ResolveLanding(ts.buildTarget(), landing.NewDevice(app.PlatformIOS, nil))
+
+	ts.Equal(landing.TypeUniversalLink, result.Type)
+	ts.Equal(testUniversalLink, result.URL)
+	ts.Equal(testWebURL, result.FallbackURL)
+}
+
+func (ts *UseCaseTestSuite) Test_ResolveLanding_IOSWithoutUniversalLink() {
+	target := ts.buildTarget()
+	target.UniversalLink = ""
+
+	result := ts.useCase.ResolveLanding(target, landing.NewDevice(app.PlatformIOS, nil))
+
+	ts.Equal(landing.TypeWeb, result.Type)
+	ts.Equal(testWebURL, result.URL)
+}
+
+func (ts *UseCaseTestSuite) Test_ResolveLanding_Web() {
+	result := ts.useCase.ResolveLanding(ts.buildTarget(), landing.NewDevice(app.PlatformWeb, nil))
+
+	ts.Equal(landing.TypeWeb, result.Type)
+	ts.Equal(testWebURL, result.URL)
+}
+
+func (ts *UseCaseTestSuite) buildTarget() landing.Target {
+	preferredBrowser := "chrome"
+	return landing.Target{
+		DeepLink:         testDeepLink,
+		UniversalLink:    testUniversalLink,
+		TargetApp:        testTargetApp,
+		WebURL:           testWebURL,
+		PreferredBrowser: &preferredBrowser,
+	}
+}
+
+var (
+	_ suite.SetupTestSuite = &UseCaseTestSuite{}
+)
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	useCase landing.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.useCase = landing.NewUseCase()
+}
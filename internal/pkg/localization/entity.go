@@ -0,0 +1,43 @@
+package localization
+
+// DefaultLanguage is the last fallback of every language chain
+const DefaultLanguage = "en"
+
+// ResourceType is the type of the resource having translated texts
+type ResourceType string
+
+// ResourceType constants
+const (
+	ResourceTypeChannel       ResourceType = "channel"
+	ResourceTypeNotification  ResourceType = "notification"
+	ResourceTypeCustomPreview ResourceType = "custom_preview"
+)
+
+// Translated fields of the resources
+const (
+	FieldName         = "name"
+	FieldTitle        = "title"
+	FieldDescription  = "description"
+	FieldInboxSummary = "inbox_summary"
+	FieldMessage      = "message"
+)
+
+// Translation is the text of a field of a resource in a language
+type Translation struct {
+	ResourceType ResourceType
+	ResourceID   int64
+	Field        string
+	Language     string
+	Text         string
+}
+
+// Texts are the localized texts of a resource keyed by field
+type Texts map[string]string
+
+// Get returns the localized text of the field. original is returned if the field isn't translated in any language of the chain
+func (t Texts) Get(field string, original string) string {
+	if text, ok := t[field]; ok && text != "" {
+		return text
+	}
+	return original
+}
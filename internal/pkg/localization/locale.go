@@ -0,0 +1,124 @@
+package localization
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Languages are the language tags a client accepts in order of preference. Each tag is followed by its parents. e.g. [zh-TW zh ko-KR ko]
+type Languages []string
+
+// Negotiate returns the languages of the locale of the request followed by the ones of the Accept-Language header.
+// The locale comes first since it's the language the user chose in the app
+func Negotiate(locale string, acceptLanguage string) Languages {
+	var languages Languages
+	if tag := normalizeTag(locale); tag != "" {
+		languages = languages.add(tag)
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		languages = languages.add(tag)
+	}
+	return languages
+}
+
+// WithFallback returns the chain of the languages followed by DefaultLanguage. e.g. zh-TW → zh → en
+func (l Languages) WithFallback() Languages {
+	return l.add(DefaultLanguage)
+}
+
+// Primary returns the first language or DefaultLanguage if there's none
+func (l Languages) Primary() string {
+	if len(l) == 0 {
+		return DefaultLanguage
+	}
+	return l[0]
+}
+
+// add returns the languages followed by the tag and its parents which aren't in the languages yet
+func (l Languages) add(tag string) Languages {
+	languages := append(Languages{}, l...)
+	for ; tag != ""; tag = parentTag(tag) {
+		if !languages.contains(tag) {
+			languages = append(languages, tag)
+		}
+	}
+	return languages
+}
+
+func (l Languages) contains(tag string) bool {
+	for _, language := range l {
+		if language == tag {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	tagSeparator = regexp.MustCompile("[-_]")
+	languageRe   = regexp.MustCompile("^[a-zA-Z]{2,3}$")
+	scriptRe     = regexp.MustCompile("^[a-zA-Z]{4}$")
+	regionRe     = regexp.MustCompile("^([a-zA-Z]{2}|[0-9]{3})$")
+)
+
+// normalizeTag returns the tag formatted as language[-Script][-REGION]. e.g. zh_hant_tw → zh-Hant-TW.
+// Variants and extensions are dropped and "" is returned for the invalid tag
+func normalizeTag(tag string) string {
+	subtags := tagSeparator.Split(strings.TrimSpace(tag), -1)
+	if !languageRe.MatchString(subtags[0]) {
+		return ""
+	}
+
+	normalized := []string{strings.ToLower(subtags[0])}
+	subtags = subtags[1:]
+	if len(subtags) > 0 && scriptRe.MatchString(subtags[0]) {
+		normalized = append(normalized, strings.ToUpper(subtags[0][:1])+strings.ToLower(subtags[0][1:]))
+		subtags = subtags[1:]
+	}
+	if len(subtags) > 0 && regionRe.MatchString(subtags[0]) {
+		normalized = append(normalized, strings.ToUpper(subtags[0]))
+	}
+	return strings.Join(normalized, "-")
+}
+
+// parentTag returns the tag without the last subtag. "" is returned for the language only tag
+func parentTag(tag string) string {
+	if i := strings.LastIndex(tag, "-"); i > 0 {
+		return tag[:i]
+	}
+	return ""
+}
+
+// parseAcceptLanguage returns the tags of the header in the order of quality. The tags of zero quality or invalid are dropped
+func parseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag     string
+		quality float64
+	}
+
+	weightedTags := make([]weightedTag, 0)
+	for _, item := range strings.Split(header, ",") {
+		params := strings.Split(item, ";")
+		wt := weightedTag{tag: normalizeTag(params[0]), quality: 1}
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					wt.quality = q
+				}
+			}
+		}
+		if wt.tag != "" && wt.quality > 0 {
+			weightedTags = append(weightedTags, wt)
+		}
+	}
+	sort.SliceStable(weightedTags, func(i, j int) bool { return weightedTags[i].quality > weightedTags[j].quality })
+
+	tags := make([]string, 0, len(weightedTags))
+	for _, wt := range weightedTags {
+		tags = append(tags, wt.tag)
+	}
+	return tags
+}
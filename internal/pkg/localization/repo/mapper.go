@@ -0,0 +1,16 @@
+package repo
+
+import "github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
+
+type entityMapper struct {
+}
+
+func (m *entityMapper) dbTranslationToTranslation(dbTranslation DBTranslation) localization.Translation {
+	return localization.Translation{
+		ResourceType: localization.ResourceType(dbTranslation.ResourceType),
+		ResourceID:   dbTranslation.ResourceID,
+		Field:        dbTranslation.Field,
+		Language:     dbTranslation.Language,
+		Text:         dbTranslation.Text,
+	}
+}
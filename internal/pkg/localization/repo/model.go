@@ -0,0 +1,21 @@
+package repo
+
+import "time"
+
+// DBTranslation struct definition
+type DBTranslation struct {
+	ID           int64 `gorm:"primary_key"`
+	ResourceType string
+	ResourceID   int64
+	Field        string
+	Language     string
+	Text         string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName func definition
+func (DBTranslation) TableName() string {
+	return "translations"
+}
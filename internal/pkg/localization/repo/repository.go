@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	translationsCacheKey = "CACHE_GO_TRANSLATIONS_%s_%s" // resource type, language
+	translationsCacheTTL = time.Hour
+)
+
+// Repository struct definition
+type Repository struct {
+	db         *gorm.DB
+	redisCache rediscache.RedisSource
+	mapper     *entityMapper
+}
+
+// GetTranslations returns the translations of the resources in any of the languages.
+// Translations of a resource type are cached by language for translationsCacheTTL
+func (r *Repository) GetTranslations(resourceType localization.ResourceType, resourceIDs []int64, languages []string) ([]localization.Translation, error) {
+	if len(resourceIDs) == 0 || len(languages) == 0 {
+		return []localization.Translation{}, nil
+	}
+
+	requested := make(map[int64]bool, len(resourceIDs))
+	for _, resourceID := range resourceIDs {
+		requested[resourceID] = true
+	}
+
+	translations := make([]localization.Translation, 0)
+	for _, language := range languages {
+		languageTranslations, err := r.getTranslationsIn(resourceType, language)
+		if err != nil {
+			return nil, err
+		}
+		for _, translation := range languageTranslations {
+			if requested[translation.ResourceID] {
+				translations = append(translations, translation)
+			}
+		}
+	}
+	return translations, nil
+}
+
+func (r *Repository) getTranslationsIn(resourceType localization.ResourceType, language string) ([]localization.Translation, error) {
+	cacheKey := fmt.Sprintf(translationsCacheKey, resourceType, language)
+	var translations []localization.Translation
+	if err := r.redisCache.GetCache(cacheKey, &translations); err == nil {
+		return translations, nil
+	}
+
+	var dbTranslations []DBTranslation
+	if err := r.db.Where("resource_type = ? AND language = ?", string(resourceType), language).Find(&dbTranslations).Error; err != nil {
+		return nil, err
+	}
+
+	translations = make([]localization.Translation, 0, len(dbTranslations))
+	for _, dbTranslation := range dbTranslations {
+		translations = append(translations, r.mapper.dbTranslationToTranslation(dbTranslation))
+	}
+	// 번역이 없는 언어도 캐시해서 fallback 언어마다 DB를 조회하지 않도록 한다
+	r.redisCache.SetCacheAsync(cacheKey, translations, translationsCacheTTL)
+	return translations, nil
+}
+
+// New returns localization repository
+func New(db *gorm.DB, redisCache rediscache.RedisSource) *Repository {
+	return &Repository{
+		db:         db,
+		redisCache: redisCache,
+		mapper:     &entityMapper{},
+	}
+}
+
+var _ localization.Repository = &Repository{}
@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
+	"github.com/go-redis/cache"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	mock  sqlmock.Sqlmock
+	db    *gorm.DB
+	cache *mockRedisCache
+	repo  localization.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	ts.NoError(err)
+	ts.mock = mock
+	ts.db, err = gorm.Open("mysql", db)
+	ts.NoError(err)
+	ts.cache = new(mockRedisCache)
+	ts.repo = New(ts.db, ts.cache)
+}
+
+func (ts *RepoTestSuite) AfterTest() {
+	_ = ts.db.Close()
+}
+
+func (ts *RepoTestSuite) Test_GetTranslations() {
+	req := "SELECT * FROM `translations` WHERE (resource_type = ? AND language = ?)"
+	ts.cache.On("GetCache", mock.Anything, mock.Anything).Return(cache.ErrCacheMiss).Twice()
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs("channel", "zh").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "resource_type", "resource_id", "field", "language", "text"}).
+			AddRow(10, "channel", 1, "name", "zh", "频道").
+			AddRow(11, "channel", 3, "name", "zh", "其他"))
+	ts.mock.ExpectQuery(ts.fixedFullRe(req)).WithArgs("channel", "en").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "resource_type", "resource_id", "field", "language", "text"}))
+	ts.cache.On("SetCacheAsync", "CACHE_GO_TRANSLATIONS_channel_zh", mock.Anything, time.Hour).Return().Once()
+	ts.cache.On("SetCacheAsync", "CACHE_GO_TRANSLATIONS_channel_en", []localization.Translation{}, time.Hour).Return().Once()
+
+	translations, err := ts.repo.GetTranslations(localization.ResourceTypeChannel, []int64{1, 2}, []string{"zh", "en"})
+
+	ts.NoError(err)
+	ts.Equal([]localization.Translation{
+		{ResourceType: localization.ResourceTypeChannel, ResourceID: 1, Field: localization.FieldName, Language: "zh", Text: "频道"},
+	}, translations)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) Test_GetTranslations_Cached() {
+	ts.cache.On("GetCache", "CACHE_GO_TRANSLATIONS_channel_en", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]localization.Translation) = []localization.Translation{
+			{ResourceType: localization.ResourceTypeChannel, ResourceID: 2, Field: localization.FieldName, Language: "en", Text: "Channel"},
+		}
+	}).Once()
+
+	translations, err := ts.repo.GetTranslations(localization.ResourceTypeChannel, []int64{1, 2}, []string{"en"})
+
+	ts.NoError(err)
+	ts.Equal([]localization.Translation{
+		{ResourceType: localization.ResourceTypeChannel, ResourceID: 2, Field: localization.FieldName, Language: "en", Text: "Channel"},
+	}, translations)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+	ts.cache.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) Test_GetTranslations_NoResource() {
+	translations, err := ts.repo.GetTranslations(localization.ResourceTypeChannel, nil, []string{"en"})
+
+	ts.NoError(err)
+	ts.Empty(translations)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) fixedFullRe(s string) string {
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(s))
+}
+
+var _ rediscache.RedisSource = &mockRedisCache{}
+
+type mockRedisCache struct {
+	mock.Mock
+}
+
+func (r *mockRedisCache) GetCache(key string, obj interface{}) error {
+	ret := r.Called(key, obj)
+	return ret.Error(0)
+}
+
+func (r *mockRedisCache) SetCacheAsync(key string, obj interface{}, expiration time.Duration) {
+	r.Called(key, obj, expiration)
+}
+
+func (r *mockRedisCache) SetCache(key string, obj interface{}, expiration time.Duration) error {
+	ret := r.Called(key, obj, expiration)
+	return ret.Error(0)
+}
+
+func (r *mockRedisCache) DeleteCache(key string) error {
+	ret := r.Called(key)
+	return ret.Error(0)
+}
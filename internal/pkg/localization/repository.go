@@ -0,0 +1,7 @@
+package localization
+
+// Repository interface definition
+type Repository interface {
+	// GetTranslations returns the translations of the resources in any of the languages
+	GetTranslations(resourceType ResourceType, resourceIDs []int64, languages []string) ([]Translation, error)
+}
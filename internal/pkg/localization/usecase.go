@@ -0,0 +1,58 @@
+package localization
+
+// UseCase interface definition
+type UseCase interface {
+	// Localize returns the texts of the resources keyed by resource id. Each field is taken from the first language of the fallback chain having the translation.
+	// Resources without any translation are omitted so that the callers keep their original texts
+	Localize(resourceType ResourceType, resourceIDs []int64, languages Languages) (map[int64]Texts, error)
+}
+
+type useCase struct {
+	repo Repository
+}
+
+// Localize func definition
+func (u *useCase) Localize(resourceType ResourceType, resourceIDs []int64, languages Languages) (map[int64]Texts, error) {
+	if len(resourceIDs) == 0 {
+		return map[int64]Texts{}, nil
+	}
+
+	chain := languages.WithFallback()
+	translations, err := u.repo.GetTranslations(resourceType, resourceIDs, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	priorities := make(map[string]int, len(chain))
+	for i, language := range chain {
+		priorities[language] = i
+	}
+
+	type fieldKey struct {
+		resourceID int64
+		field      string
+	}
+	chosen := make(map[fieldKey]int)
+	textsMap := make(map[int64]Texts)
+	for _, t := range translations {
+		priority, ok := priorities[t.Language]
+		if !ok || t.Text == "" {
+			continue
+		}
+		key := fieldKey{resourceID: t.ResourceID, field: t.Field}
+		if p, ok := chosen[key]; ok && p <= priority {
+			continue
+		}
+		chosen[key] = priority
+		if textsMap[t.ResourceID] == nil {
+			textsMap[t.ResourceID] = make(Texts)
+		}
+		textsMap[t.ResourceID][t.Field] = t.Text
+	}
+	return textsMap, nil
+}
+
+// NewUseCase returns localization use case
+func NewUseCase(repo Repository) UseCase {
+	return &useCase{repo: repo}
+}
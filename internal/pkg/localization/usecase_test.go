@@ -0,0 +1,79 @@
+package localization_test
+
+import (
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/localization"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func (ts *UseCaseTestSuite) Test_Negotiate() {
+	ts.Equal(localization.Languages{"zh-TW", "zh", "ko"}, localization.Negotiate("zh_tw", "ko;q=0.5, zh, *;q=0.1"))
+	ts.Equal(localization.Languages{"ja", "zh-Hant-TW", "zh-Hant", "zh", "en-US", "en"}, localization.Negotiate("ja", "en-US;q=0.8, zh-hant-tw, fr;q=0"))
+	ts.Equal(localization.Languages{"zh-TW", "zh", "en"}, localization.Negotiate("zh-TW", "").WithFallback())
+	ts.Empty(localization.Negotiate("", "invalid-tag-1"))
+}
+
+func (ts *UseCaseTestSuite) Test_Localize() {
+	languages := localization.Negotiate("zh-TW", "")
+	ts.repo.On("GetTranslations", localization.ResourceTypeNotification, []int64{1, 2, 3}, []string{"zh-TW", "zh", "en"}).Return([]localization.Translation{
+		{ResourceID: 1, Field: localization.FieldTitle, Language: "en", Text: "Title"},
+		{ResourceID: 1, Field: localization.FieldTitle, Language: "zh", Text: "标题"},
+		{ResourceID: 1, Field: localization.FieldDescription, Language: "en", Text: "Description"},
+		{ResourceID: 2, Field: localization.FieldTitle, Language: "zh-TW", Text: "標題"},
+		{ResourceID: 2, Field: localization.FieldTitle, Language: "zh", Text: "标题"},
+		{ResourceID: 2, Field: localization.FieldDescription, Language: "zh-TW", Text: ""},
+	}, nil).Once()
+
+	textsMap, err := ts.useCase.Localize(localization.ResourceTypeNotification, []int64{1, 2, 3}, languages)
+
+	ts.NoError(err)
+	ts.Equal(map[int64]localization.Texts{
+		1: {localization.FieldTitle: "标题", localization.FieldDescription: "Description"},
+		2: {localization.FieldTitle: "標題"},
+	}, textsMap)
+	ts.Equal("original", textsMap[2].Get(localization.FieldDescription, "original"))
+	ts.Equal("original", textsMap[3].Get(localization.FieldTitle, "original"))
+}
+
+func (ts *UseCaseTestSuite) Test_Localize_NoResource() {
+	textsMap, err := ts.useCase.Localize(localization.ResourceTypeChannel, nil, localization.Languages{"ko"})
+
+	ts.NoError(err)
+	ts.Empty(textsMap)
+	ts.repo.AssertNotCalled(ts.T(), "GetTranslations", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	useCase localization.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.useCase = localization.NewUseCase(ts.repo)
+}
+
+func (ts *UseCaseTestSuite) TearDownTest() {
+	ts.repo.AssertExpectations(ts.T())
+}
+
+var _ localization.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) GetTranslations(resourceType localization.ResourceType, resourceIDs []int64, languages []string) ([]localization.Translation, error) {
+	ret := r.Called(resourceType, resourceIDs, languages)
+	if translations := ret.Get(0); translations != nil {
+		return translations.([]localization.Translation), ret.Error(1)
+	}
+	return nil, ret.Error(1)
+}
@@ -15,7 +15,8 @@ type Payload struct {
 	Timezone    string  `json:"tz"`
 	YearOfBirth *int    `json:"yob,omitempty"`
 
-	UnitID *int64 `json:"unit_id,omitempty"`
+	UnitID    *int64 `json:"unit_id,omitempty"`
+	VariantID int64  `json:"vid,omitempty"` // creative variant of the content campaign
 }
 
 var acceptedUnitIDs = map[int64]struct{}{
@@ -0,0 +1,78 @@
+package privacy
+
+import "time"
+
+// RequestType type definition
+type RequestType string
+
+// RequestType constants
+const (
+	RequestTypeExport  RequestType = "export"
+	RequestTypeErasure RequestType = "erasure"
+)
+
+// Status is the progress of a request or a step of it
+type Status string
+
+// Status constants
+const (
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusCompleted  Status = "completed"
+	StatusFailed     Status = "failed"
+)
+
+// Subject identifies the person of a request in an app by the ifa or the publisher user id
+type Subject struct {
+	AppID     int64
+	IFA       string
+	PubUserID string
+}
+
+// IsValid func definition
+func (s Subject) IsValid() bool {
+	return s.AppID > 0 && (s.IFA != "" || s.PubUserID != "")
+}
+
+// Target is the devices of the subject a store runs on
+type Target struct {
+	AppID     int64
+	DeviceIDs []int64
+}
+
+// Record is an exported row or item of a store
+type Record map[string]interface{}
+
+// Export is the machine-readable data of the subject. Stores holds the records by store name
+type Export struct {
+	GeneratedAt time.Time
+	Stores      map[string][]Record
+}
+
+// Step is the audit of a store processing the request. Records is the number of records exported or erased
+type Step struct {
+	Store       string
+	Status      Status
+	Records     int
+	Error       string
+	CompletedAt *time.Time
+}
+
+// Request is the audit record of a data subject request. Steps are saved as each store completes
+type Request struct {
+	ID          int64
+	Type        RequestType
+	Subject     Subject
+	Status      Status
+	DeviceIDs   []int64
+	Steps       []Step
+	Export      *Export // nil for erasure requests
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// Target returns the target of the stores
+func (r *Request) Target() Target {
+	return Target{AppID: r.Subject.AppID, DeviceIDs: r.DeviceIDs}
+}
@@ -0,0 +1,29 @@
+package privacy
+
+import "fmt"
+
+var (
+	_ error = NotFoundError{}
+	_ error = NotRetryableError{}
+)
+
+// NotFoundError will be returned when the request doesn't exist
+type NotFoundError struct {
+	RequestID int64
+}
+
+// Error func definition
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("privacy request %d is not found", e.RequestID)
+}
+
+// NotRetryableError will be returned when retrying a request that hasn't failed
+type NotRetryableError struct {
+	RequestID int64
+	Status    Status
+}
+
+// Error func definition
+func (e NotRetryableError) Error() string {
+	return fmt.Sprintf("privacy request %d is %s. only failed requests can be retried", e.RequestID, e.Status)
+}
@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/guregu/dynamo"
+)
+
+const keyDeviceID = "did"
+
+// DynamoStore erases the items of the devices from a DynamoDB table whose hash key is the device id.
+// If anonymizedAttributes is set, the attributes are removed from the items instead of deleting them
+type DynamoStore struct {
+	name                 string
+	table                *dynamo.Table
+	rangeKey             string
+	anonymizedAttributes []string
+}
+
+// Name func definition
+func (s *DynamoStore) Name() string {
+	return s.name
+}
+
+// Export func definition
+func (s *DynamoStore) Export(target privacy.Target) ([]privacy.Record, error) {
+	records := make([]privacy.Record, 0)
+	for _, deviceID := range target.DeviceIDs {
+		items, err := s.getItems(deviceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			record := make(privacy.Record, len(item))
+			if err := dynamo.UnmarshalItem(item, &record); err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// Erase func definition
+func (s *DynamoStore) Erase(target privacy.Target) (int, error) {
+	erased := 0
+	for _, deviceID := range target.DeviceIDs {
+		items, err := s.getItems(deviceID)
+		if err != nil {
+			return erased, err
+		}
+		for _, item := range items {
+			if len(s.anonymizedAttributes) > 0 {
+				err = s.table.Update(keyDeviceID, deviceID).Range(s.rangeKey, item[s.rangeKey]).Remove(s.anonymizedAttributes...).Run()
+			} else {
+				err = s.table.Delete(keyDeviceID, deviceID).Range(s.rangeKey, item[s.rangeKey]).Run()
+			}
+			if err != nil {
+				return erased, err
+			}
+			erased++
+		}
+	}
+	return erased, nil
+}
+
+// getItems reads the items as attribute values so that the range keys are passed back without losing precision
+func (s *DynamoStore) getItems(deviceID int64) ([]map[string]*dynamodb.AttributeValue, error) {
+	var items []map[string]*dynamodb.AttributeValue
+	if err := s.table.Get(keyDeviceID, deviceID).All(&items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// NewDynamoStore func definition
+func NewDynamoStore(name string, table *dynamo.Table, rangeKey string, anonymizedAttributes ...string) *DynamoStore {
+	return &DynamoStore{name: name, table: table, rangeKey: rangeKey, anonymizedAttributes: anonymizedAttributes}
+}
+
+var _ privacy.Store = &DynamoStore{}
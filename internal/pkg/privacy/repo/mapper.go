@@ -0,0 +1,122 @@
+package repo
+
+import (
+	"encoding/json"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy"
+)
+
+type entityMapper struct {
+}
+
+func (m *entityMapper) requestToDBRequest(request privacy.Request) (*DBRequest, error) {
+	deviceIDs, err := json.Marshal(request.DeviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	dbSteps := make([]dbStep, 0, len(request.Steps))
+	for _, step := range request.Steps {
+		dbSteps = append(dbSteps, dbStep{
+			Store:       step.Store,
+			Status:      string(step.Status),
+			Records:     step.Records,
+			Error:       step.Error,
+			CompletedAt: step.CompletedAt,
+		})
+	}
+	steps, err := json.Marshal(dbSteps)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRequest := &DBRequest{
+		ID:          request.ID,
+		Type:        string(request.Type),
+		AppID:       request.Subject.AppID,
+		IFA:         request.Subject.IFA,
+		PubUserID:   request.Subject.PubUserID,
+		Status:      string(request.Status),
+		DeviceIDs:   string(deviceIDs),
+		Steps:       string(steps),
+		CreatedAt:   request.CreatedAt,
+		UpdatedAt:   request.UpdatedAt,
+		CompletedAt: request.CompletedAt,
+	}
+
+	if request.Export != nil {
+		export := dbExport{GeneratedAt: request.Export.GeneratedAt, Stores: make(map[string][]map[string]interface{}, len(request.Export.Stores))}
+		for store, records := range request.Export.Stores {
+			rows := make([]map[string]interface{}, 0, len(records))
+			for _, record := range records {
+				rows = append(rows, record)
+			}
+			export.Stores[store] = rows
+		}
+		exportJSON, err := json.Marshal(export)
+		if err != nil {
+			return nil, err
+		}
+		dbRequest.Export = string(exportJSON)
+	}
+	return dbRequest, nil
+}
+
+func (m *entityMapper) dbRequestToRequest(dbRequest DBRequest) (*privacy.Request, error) {
+	request := &privacy.Request{
+		ID:   dbRequest.ID,
+		Type: privacy.RequestType(dbRequest.Type),
+		Subject: privacy.Subject{
+			AppID:     dbRequest.AppID,
+			IFA:       dbRequest.IFA,
+			PubUserID: dbRequest.PubUserID,
+		},
+		Status:      privacy.Status(dbRequest.Status),
+		DeviceIDs:   []int64{},
+		Steps:       []privacy.Step{},
+		CreatedAt:   dbRequest.CreatedAt,
+		UpdatedAt:   dbRequest.UpdatedAt,
+		CompletedAt: dbRequest.CompletedAt,
+	}
+
+	if dbRequest.DeviceIDs != "" {
+		if err := json.Unmarshal([]byte(dbRequest.DeviceIDs), &request.DeviceIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if dbRequest.Steps != "" {
+		var dbSteps []dbStep
+		if err := json.Unmarshal([]byte(dbRequest.Steps), &dbSteps); err != nil {
+			return nil, err
+		}
+		for _, s := range dbSteps {
+			request.Steps = append(request.Steps, privacy.Step{
+				Store:       s.Store,
+				Status:      privacy.Status(s.Status),
+				Records:     s.Records,
+				Error:       s.Error,
+				CompletedAt: s.CompletedAt,
+			})
+		}
+	}
+
+	if request.Type == privacy.RequestTypeExport {
+		request.Export = &privacy.Export{Stores: make(map[string][]privacy.Record)}
+		if dbRequest.Export != "" {
+			var export dbExport
+			if err := json.Unmarshal([]byte(dbRequest.Export), &export); err != nil {
+				return nil, err
+			}
+			request.Export.GeneratedAt = export.GeneratedAt
+			for store, rows := range export.Stores {
+				records := make([]privacy.Record, 0, len(rows))
+				for _, row := range rows {
+					records = append(records, privacy.Record(row))
+				}
+				request.Export.Stores[store] = records
+			}
+		}
+	}
+	return request, nil
+}
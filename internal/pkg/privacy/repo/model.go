@@ -0,0 +1,37 @@
+package repo
+
+import "time"
+
+// DBRequest is the audit record of a privacy request
+type DBRequest struct {
+	ID          int64  `gorm:"primary_key"`
+	Type        string `gorm:"type:varchar(16)"`
+	AppID       int64
+	IFA         string `gorm:"type:varchar(45)"`
+	PubUserID   string `gorm:"type:varchar(255)"`
+	Status      string `gorm:"type:varchar(16)"`
+	DeviceIDs   string `gorm:"type:text"`       // JSON array
+	Steps       string `gorm:"type:text"`       // JSON of []dbStep
+	Export      string `gorm:"type:mediumtext"` // JSON of dbExport. empty for erasure requests
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// TableName func definition
+func (DBRequest) TableName() string {
+	return "privacy_requests"
+}
+
+type dbStep struct {
+	Store       string     `json:"store"`
+	Status      string     `json:"status"`
+	Records     int        `json:"records"`
+	Error       string     `json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+type dbExport struct {
+	GeneratedAt time.Time                           `json:"generated_at"`
+	Stores      map[string][]map[string]interface{} `json:"stores"`
+}
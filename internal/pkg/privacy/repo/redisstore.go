@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"fmt"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy"
+	"github.com/go-redis/redis"
+)
+
+const (
+	trackingURLKeyPattern = "CACHE_GO_TRACKINGURL-%d-*"
+	unitProfileStatKey    = "stat:device:profile:unit:%d"
+	scanCount             = 100
+)
+
+// RedisStore erases the tracking url caches of the devices and their bits in the profile stat of the app
+type RedisStore struct {
+	client *redis.Client
+}
+
+// Name func definition
+func (s *RedisStore) Name() string {
+	return "redis"
+}
+
+// Export func definition
+func (s *RedisStore) Export(target privacy.Target) ([]privacy.Record, error) {
+	records := make([]privacy.Record, 0)
+	statKey := fmt.Sprintf(unitProfileStatKey, target.AppID)
+	for _, deviceID := range target.DeviceIDs {
+		keys, err := s.scanKeys(fmt.Sprintf(trackingURLKeyPattern, deviceID))
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			records = append(records, privacy.Record{"device_id": deviceID, "key": key})
+		}
+
+		bit, err := s.client.GetBit(statKey, deviceID).Result()
+		if err != nil {
+			return nil, err
+		} else if bit == 1 {
+			records = append(records, privacy.Record{"device_id": deviceID, "key": statKey})
+		}
+	}
+	return records, nil
+}
+
+// Erase func definition
+func (s *RedisStore) Erase(target privacy.Target) (int, error) {
+	erased := 0
+	statKey := fmt.Sprintf(unitProfileStatKey, target.AppID)
+	for _, deviceID := range target.DeviceIDs {
+		keys, err := s.scanKeys(fmt.Sprintf(trackingURLKeyPattern, deviceID))
+		if err != nil {
+			return erased, err
+		}
+		if len(keys) > 0 {
+			deleted, err := s.client.Del(keys...).Result()
+			if err != nil {
+				return erased, err
+			}
+			erased += int(deleted)
+		}
+
+		bit, err := s.client.SetBit(statKey, deviceID, 0).Result()
+		if err != nil {
+			return erased, err
+		}
+		erased += int(bit)
+	}
+	return erased, nil
+}
+
+func (s *RedisStore) scanKeys(pattern string) ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		batch, next, err := s.client.Scan(cursor, pattern, scanCount).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, batch...)
+		if next == 0 {
+			return keys, nil
+		}
+		cursor = next
+	}
+}
+
+// NewRedisStore func definition
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+var _ privacy.Store = &RedisStore{}
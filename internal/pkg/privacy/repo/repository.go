@@ -0,0 +1,114 @@
+package repo
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy"
+	"github.com/jinzhu/gorm"
+)
+
+// Repository struct definition
+type Repository struct {
+	db     *gorm.DB
+	mapper *entityMapper
+}
+
+// CreateRequest func definition
+func (r *Repository) CreateRequest(request privacy.Request) (*privacy.Request, error) {
+	dbRequest, err := r.mapper.requestToDBRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Create(dbRequest).Error; err != nil {
+		return nil, err
+	}
+	return r.mapper.dbRequestToRequest(*dbRequest)
+}
+
+// UpdateRequest func definition
+func (r *Repository) UpdateRequest(request privacy.Request) error {
+	dbRequest, err := r.mapper.requestToDBRequest(request)
+	if err != nil {
+		return err
+	}
+	return r.db.Save(dbRequest).Error
+}
+
+// GetRequest returns nil if the request doesn't exist
+func (r *Repository) GetRequest(id int64) (*privacy.Request, error) {
+	var dbRequest DBRequest
+	if err := r.db.Where("id = ?", id).First(&dbRequest).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.mapper.dbRequestToRequest(dbRequest)
+}
+
+// FindDeviceIDs looks up device_update_history as well as device so that the devices that changed the ifa or the user id are included
+func (r *Repository) FindDeviceIDs(subject privacy.Subject) ([]int64, error) {
+	deviceConditions := make([]string, 0, 2)
+	historyConditions := make([]string, 0, 2)
+	deviceArgs := []interface{}{subject.AppID}
+	historyArgs := []interface{}{subject.AppID}
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"ifa", subject.IFA},
+		{"unit_device_token", subject.PubUserID},
+	} {
+		if field.value == "" {
+			continue
+		}
+		deviceConditions = append(deviceConditions, field.name+" = ?")
+		deviceArgs = append(deviceArgs, field.value)
+		historyConditions = append(historyConditions, "(device_update_history.updated_field = ? AND (device_update_history.from_value = ? OR device_update_history.to_value = ?))")
+		historyArgs = append(historyArgs, field.name, field.value, field.value)
+	}
+	if len(deviceConditions) == 0 {
+		return []int64{}, nil
+	}
+
+	var deviceIDs []int64
+	err := r.db.Table("device").
+		Where("app_id = ? AND ("+strings.Join(deviceConditions, " OR ")+")", deviceArgs...).
+		Pluck("id", &deviceIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var historyDeviceIDs []int64
+	err = r.db.Table("device_update_history").
+		Joins("JOIN device ON device.id = device_update_history.device_id").
+		Where("device.app_id = ? AND ("+strings.Join(historyConditions, " OR ")+")", historyArgs...).
+		Pluck("DISTINCT device_update_history.device_id", &historyDeviceIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return uniqueIDs(append(deviceIDs, historyDeviceIDs...)), nil
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	unique := make([]int64, 0, len(ids))
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// New returns privacy request repository
+func New(db *gorm.DB) *Repository {
+	return &Repository{
+		db:     db,
+		mapper: &entityMapper{},
+	}
+}
+
+var _ privacy.Repository = &Repository{}
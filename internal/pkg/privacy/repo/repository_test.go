@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	db   *gorm.DB
+	repo privacy.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	ts.NoError(err)
+	ts.mock = mock
+	ts.db, err = gorm.Open("mysql", db)
+	ts.NoError(err)
+	ts.repo = New(ts.db)
+}
+
+func (ts *RepoTestSuite) AfterTest() {
+	_ = ts.db.Close()
+}
+
+func (ts *RepoTestSuite) Test_FindDeviceIDs() {
+	deviceQuery := "SELECT id FROM `device` WHERE (app_id = ? AND (ifa = ? OR unit_device_token = ?))"
+	ts.mock.ExpectQuery(ts.fixedFullRe(deviceQuery)).WithArgs(100, "ifa-a", "user").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(1))
+	historyQuery := "SELECT DISTINCT device_update_history.device_id FROM `device_update_history` JOIN device ON device.id = device_update_history.device_id" +
+		" WHERE (device.app_id = ? AND ((device_update_history.updated_field = ? AND (device_update_history.from_value = ? OR device_update_history.to_value = ?))" +
+		" OR (device_update_history.updated_field = ? AND (device_update_history.from_value = ? OR device_update_history.to_value = ?))))"
+	ts.mock.ExpectQuery(ts.fixedFullRe(historyQuery)).WithArgs(100, "ifa", "ifa-a", "ifa-a", "unit_device_token", "user", "user").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}).AddRow(2).AddRow(3))
+
+	deviceIDs, err := ts.repo.FindDeviceIDs(privacy.Subject{AppID: 100, IFA: "ifa-a", PubUserID: "user"})
+
+	ts.NoError(err)
+	ts.Equal([]int64{1, 2, 3}, deviceIDs)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_FindDeviceIDs_IFAOnly() {
+	ts.mock.ExpectQuery(ts.fixedFullRe("SELECT id FROM `device` WHERE (app_id = ? AND (ifa = ?))")).WithArgs(100, "ifa-a").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	ts.mock.ExpectQuery("SELECT DISTINCT device_update_history.device_id").WithArgs(100, "ifa", "ifa-a", "ifa-a").
+		WillReturnRows(sqlmock.NewRows([]string{"device_id"}))
+
+	deviceIDs, err := ts.repo.FindDeviceIDs(privacy.Subject{AppID: 100, IFA: "ifa-a"})
+
+	ts.NoError(err)
+	ts.Equal([]int64{}, deviceIDs)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_GetRequest() {
+	createdAt := time.Date(2019, 5, 1, 10, 30, 0, 0, time.UTC)
+	query := "SELECT * FROM `privacy_requests` WHERE (id = ?) ORDER BY `privacy_requests`.`id` ASC LIMIT 1"
+	ts.mock.ExpectQuery(ts.fixedFullRe(query)).WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "app_id", "ifa", "pub_user_id", "status", "device_ids", "steps", "export", "created_at", "updated_at", "completed_at"}).
+			AddRow(7, "export", 100, "ifa-a", "", "completed", "[1]",
+				`[{"store":"device","status":"completed","records":1,"completed_at":"2019-05-01T10:30:00Z"}]`,
+				`{"generated_at":"2019-05-01T10:30:00Z","stores":{"device":[{"id":1,"ifa":"ifa-a"}]}}`,
+				createdAt, createdAt, createdAt))
+
+	request, err := ts.repo.GetRequest(7)
+
+	ts.NoError(err)
+	ts.Equal(&privacy.Request{
+		ID:          7,
+		Type:        privacy.RequestTypeExport,
+		Subject:     privacy.Subject{AppID: 100, IFA: "ifa-a"},
+		Status:      privacy.StatusCompleted,
+		DeviceIDs:   []int64{1},
+		Steps:       []privacy.Step{{Store: "device", Status: privacy.StatusCompleted, Records: 1, CompletedAt: &createdAt}},
+		Export:      &privacy.Export{GeneratedAt: createdAt, Stores: map[string][]privacy.Record{"device": {{"id": float64(1), "ifa": "ifa-a"}}}},
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+		CompletedAt: &createdAt,
+	}, request)
+}
+
+func (ts *RepoTestSuite) Test_GetRequest_NotFound() {
+	ts.mock.ExpectQuery("SELECT \\* FROM `privacy_requests`").WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	request, err := ts.repo.GetRequest(7)
+
+	ts.NoError(err)
+	ts.Nil(request)
+}
+
+func (ts *RepoTestSuite) Test_TableStore_Export() {
+	createdAt := time.Now()
+	ts.mock.ExpectQuery(ts.fixedFullRe("SELECT * FROM `content_reported` WHERE (`device_id` IN (?,?))")).WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "device_id", "ifa", "created_at"}).
+			AddRow(10, 1, []byte("ifa-a"), createdAt))
+
+	records, err := NewTableStore(ts.db, "content_reported", "device_id").Export(privacy.Target{AppID: 100, DeviceIDs: []int64{1, 2}})
+
+	ts.NoError(err)
+	ts.Equal([]privacy.Record{{"id": int64(10), "device_id": int64(1), "ifa": "ifa-a", "created_at": createdAt}}, records)
+}
+
+func (ts *RepoTestSuite) Test_TableStore_Erase() {
+	ts.mock.ExpectExec(ts.fixedFullRe("DELETE FROM `content_reported` WHERE `device_id` IN (?,?)")).WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	erased, err := NewTableStore(ts.db, "content_reported", "device_id").Erase(privacy.Target{AppID: 100, DeviceIDs: []int64{1, 2}})
+
+	ts.NoError(err)
+	ts.Equal(3, erased)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) Test_DeviceStore_Erase() {
+	query := "UPDATE `device` SET `ifa` = CONCAT('erased-', `id`), `unit_device_token` = CONCAT('erased-', `id`), " +
+		"`address` = NULL, `birthday` = NULL, `carrier` = NULL, `device_name` = '', `resolution` = '', `year_of_birth` = NULL, " +
+		"`sex` = NULL, `packages` = NULL, `serial_number` = NULL, `signup_ip` = 0 WHERE `id` IN (?,?)"
+	ts.mock.ExpectExec(ts.fixedFullRe(query)).WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	erased, err := NewDeviceStore(ts.db).Erase(privacy.Target{AppID: 100, DeviceIDs: []int64{1, 2}})
+
+	ts.NoError(err)
+	ts.Equal(2, erased)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+}
+
+func (ts *RepoTestSuite) fixedFullRe(s string) string {
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(s))
+}
@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"database/sql"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy"
+	"github.com/jinzhu/gorm"
+)
+
+// TableStore deletes the rows of the devices from a MySQL table
+type TableStore struct {
+	db     *gorm.DB
+	table  string
+	column string
+}
+
+// Name func definition
+func (s *TableStore) Name() string {
+	return s.table
+}
+
+// Export func definition
+func (s *TableStore) Export(target privacy.Target) ([]privacy.Record, error) {
+	return exportRows(s.db, s.table, s.column, target.DeviceIDs)
+}
+
+// Erase func definition
+func (s *TableStore) Erase(target privacy.Target) (int, error) {
+	result := s.db.Exec("DELETE FROM `"+s.table+"` WHERE `"+s.column+"` IN (?)", target.DeviceIDs)
+	return int(result.RowsAffected), result.Error
+}
+
+// NewTableStore returns the store of the table whose column is the device id
+func NewTableStore(db *gorm.DB, table string, column string) *TableStore {
+	return &TableStore{db: db, table: table, column: column}
+}
+
+var _ privacy.Store = &TableStore{}
+
+// DeviceStore anonymizes the rows of device instead of deleting them since the ids are referred by the rewards of the devices.
+// ifa and unit_device_token are replaced with values unique to the device to keep the unique indexes
+type DeviceStore struct {
+	db *gorm.DB
+}
+
+// Name func definition
+func (s *DeviceStore) Name() string {
+	return "device"
+}
+
+// Export func definition
+func (s *DeviceStore) Export(target privacy.Target) ([]privacy.Record, error) {
+	return exportRows(s.db, "device", "id", target.DeviceIDs)
+}
+
+// Erase func definition
+func (s *DeviceStore) Erase(target privacy.Target) (int, error) {
+	result := s.db.Exec("UPDATE `device` SET `ifa` = CONCAT('erased-', `id`), `unit_device_token` = CONCAT('erased-', `id`), "+
+		"`address` = NULL, `birthday` = NULL, `carrier` = NULL, `device_name` = '', `resolution` = '', `year_of_birth` = NULL, "+
+		"`sex` = NULL, `packages` = NULL, `serial_number` = NULL, `signup_ip` = 0 WHERE `id` IN (?)", target.DeviceIDs)
+	return int(result.RowsAffected), result.Error
+}
+
+// NewDeviceStore func definition
+func NewDeviceStore(db *gorm.DB) *DeviceStore {
+	return &DeviceStore{db: db}
+}
+
+var _ privacy.Store = &DeviceStore{}
+
+func exportRows(db *gorm.DB, table string, column string, deviceIDs []int64) ([]privacy.Record, error) {
+	rows, err := db.Table(table).Where("`"+column+"` IN (?)", deviceIDs).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRecords(rows)
+}
+
+// scanRecords reads the rows as records by column name. Text columns are read as []byte by the driver and converted to string
+func scanRecords(rows *sql.Rows) ([]privacy.Record, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]privacy.Record, 0)
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		record := make(privacy.Record, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				record[column] = string(b)
+			} else {
+				record[column] = values[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
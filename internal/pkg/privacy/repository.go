@@ -0,0 +1,18 @@
+package privacy
+
+// Repository keeps the audit records of the requests
+type Repository interface {
+	CreateRequest(request Request) (*Request, error)
+	UpdateRequest(request Request) error
+	GetRequest(id int64) (*Request, error)
+	// FindDeviceIDs returns the devices of the app that have or had the ifa or the publisher user id of the subject
+	FindDeviceIDs(subject Subject) ([]int64, error)
+}
+
+// Store is a data store holding personal data of devices
+type Store interface {
+	Name() string
+	Export(target Target) ([]Record, error)
+	// Erase deletes or anonymizes the records of the target and returns the number of them
+	Erase(target Target) (int, error)
+}
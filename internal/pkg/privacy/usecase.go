@@ -0,0 +1,153 @@
+package privacy
+
+import "time"
+
+// UseCase type definition
+type UseCase interface {
+	// CreateRequest records the request of the subject and runs it on every store
+	CreateRequest(requestType RequestType, subject Subject) (*Request, error)
+	GetRequest(id int64) (*Request, error)
+	// RetryRequest runs the failed request again on the stores that haven't completed
+	RetryRequest(id int64) (*Request, error)
+}
+
+type useCase struct {
+	repo   Repository
+	stores []Store
+}
+
+// CreateRequest func definition
+func (u *useCase) CreateRequest(requestType RequestType, subject Subject) (*Request, error) {
+	deviceIDs, err := u.repo.FindDeviceIDs(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	request := Request{
+		Type:      requestType,
+		Subject:   subject,
+		Status:    StatusPending,
+		DeviceIDs: deviceIDs,
+		Steps:     make([]Step, 0, len(u.stores)),
+	}
+	for _, store := range u.stores {
+		request.Steps = append(request.Steps, Step{Store: store.Name(), Status: StatusPending})
+	}
+	if requestType == RequestTypeExport {
+		request.Export = &Export{Stores: make(map[string][]Record)}
+	}
+	created, err := u.repo.CreateRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	return u.process(created)
+}
+
+// GetRequest func definition
+func (u *useCase) GetRequest(id int64) (*Request, error) {
+	request, err := u.repo.GetRequest(id)
+	if err != nil {
+		return nil, err
+	} else if request == nil {
+		return nil, NotFoundError{RequestID: id}
+	}
+	return request, nil
+}
+
+// RetryRequest func definition
+func (u *useCase) RetryRequest(id int64) (*Request, error) {
+	request, err := u.GetRequest(id)
+	if err != nil {
+		return nil, err
+	} else if request.Status != StatusFailed {
+		return nil, NotRetryableError{RequestID: id, Status: request.Status}
+	}
+	return u.process(request)
+}
+
+// process runs the steps that haven't completed and saves the request after each of them,
+// so that the progress is kept even if the process stops in the middle.
+// A failing store doesn't stop the others and the request fails once every step is run
+func (u *useCase) process(request *Request) (*Request, error) {
+	request.Status = StatusProcessing
+	if err := u.repo.UpdateRequest(*request); err != nil {
+		return nil, err
+	}
+
+	target := request.Target()
+	failed := false
+	for i := range request.Steps {
+		step := &request.Steps[i]
+		if step.Status == StatusCompleted {
+			continue
+		}
+
+		store := u.findStore(step.Store)
+		if store == nil {
+			step.Status, step.Error = StatusFailed, "store is not registered"
+			failed = true
+		} else if err := u.runStep(request, store, step, target); err != nil {
+			step.Status, step.Error = StatusFailed, err.Error()
+			failed = true
+		} else {
+			now := time.Now()
+			step.Status, step.Error, step.CompletedAt = StatusCompleted, "", &now
+		}
+
+		if err := u.repo.UpdateRequest(*request); err != nil {
+			return nil, err
+		}
+	}
+
+	if failed {
+		request.Status = StatusFailed
+	} else {
+		now := time.Now()
+		request.Status, request.CompletedAt = StatusCompleted, &now
+		if request.Export != nil {
+			request.Export.GeneratedAt = now
+		}
+	}
+	if err := u.repo.UpdateRequest(*request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (u *useCase) runStep(request *Request, store Store, step *Step, target Target) error {
+	if len(target.DeviceIDs) == 0 {
+		step.Records = 0
+		return nil
+	}
+
+	switch request.Type {
+	case RequestTypeExport:
+		records, err := store.Export(target)
+		if err != nil {
+			return err
+		}
+		request.Export.Stores[store.Name()] = records
+		step.Records = len(records)
+	case RequestTypeErasure:
+		erased, err := store.Erase(target)
+		if err != nil {
+			return err
+		}
+		step.Records = erased
+	}
+	return nil
+}
+
+func (u *useCase) findStore(name string) Store {
+	for _, store := range u.stores {
+		if store.Name() == name {
+			return store
+		}
+	}
+	return nil
+}
+
+// NewUseCase returns the use case running the requests on the stores in order
+func NewUseCase(repo Repository, stores []Store) UseCase {
+	return &useCase{repo: repo, stores: stores}
+}
@@ -0,0 +1,203 @@
+package privacy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/privacy"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	subject = privacy.Subject{AppID: 100, IFA: "ifa-a"}
+	target  = privacy.Target{AppID: 100, DeviceIDs: []int64{1, 2}}
+)
+
+func (ts *UseCaseTestSuite) Test_CreateRequest_Export() {
+	ts.repo.On("FindDeviceIDs", subject).Return([]int64{1, 2}, nil).Once()
+	ts.repo.On("CreateRequest", mock.MatchedBy(func(r privacy.Request) bool {
+		return r.Type == privacy.RequestTypeExport && r.Status == privacy.StatusPending && len(r.Steps) == 2 && r.Steps[0].Store == "device" && r.Export != nil
+	})).Return(&privacy.Request{
+		ID: 7, Type: privacy.RequestTypeExport, Subject: subject, DeviceIDs: []int64{1, 2},
+		Steps:  []privacy.Step{{Store: "device", Status: privacy.StatusPending}, {Store: "profile", Status: privacy.StatusPending}},
+		Export: &privacy.Export{Stores: map[string][]privacy.Record{}},
+	}, nil).Once()
+	ts.repo.On("UpdateRequest", mock.AnythingOfType("privacy.Request")).Return(nil).Times(4)
+	ts.device.On("Export", target).Return([]privacy.Record{{"id": 1}, {"id": 2}}, nil).Once()
+	ts.profile.On("Export", target).Return([]privacy.Record{{"did": 1, "profile": "rd"}}, nil).Once()
+
+	request, err := ts.useCase.CreateRequest(privacy.RequestTypeExport, subject)
+
+	ts.NoError(err)
+	ts.Equal(int64(7), request.ID)
+	ts.Equal(privacy.StatusCompleted, request.Status)
+	ts.NotNil(request.CompletedAt)
+	ts.Equal([]int64{1, 2}, request.DeviceIDs)
+	ts.Equal(2, request.Steps[0].Records)
+	ts.Equal(1, request.Steps[1].Records)
+	ts.Len(request.Export.Stores["device"], 2)
+	ts.Len(request.Export.Stores["profile"], 1)
+	ts.False(request.Export.GeneratedAt.IsZero())
+}
+
+func (ts *UseCaseTestSuite) Test_CreateRequest_Erasure() {
+	ts.repo.On("FindDeviceIDs", subject).Return([]int64{1, 2}, nil).Once()
+	ts.repo.On("CreateRequest", mock.AnythingOfType("privacy.Request")).Return(&privacy.Request{
+		ID: 7, Type: privacy.RequestTypeErasure, Subject: subject, DeviceIDs: []int64{1, 2},
+		Steps: []privacy.Step{{Store: "device", Status: privacy.StatusPending}, {Store: "profile", Status: privacy.StatusPending}},
+	}, nil).Once()
+	ts.repo.On("UpdateRequest", mock.AnythingOfType("privacy.Request")).Return(nil).Times(4)
+	ts.device.On("Erase", target).Return(2, nil).Once()
+	ts.profile.On("Erase", target).Return(5, nil).Once()
+
+	request, err := ts.useCase.CreateRequest(privacy.RequestTypeErasure, subject)
+
+	ts.NoError(err)
+	ts.Equal(privacy.StatusCompleted, request.Status)
+	ts.Nil(request.Export)
+	ts.Equal(2, request.Steps[0].Records)
+	ts.Equal(5, request.Steps[1].Records)
+}
+
+func (ts *UseCaseTestSuite) Test_CreateRequest_NoDevice() {
+	ts.repo.On("FindDeviceIDs", subject).Return([]int64{}, nil).Once()
+	ts.repo.On("CreateRequest", mock.AnythingOfType("privacy.Request")).Return(&privacy.Request{
+		ID: 7, Type: privacy.RequestTypeErasure, Subject: subject, DeviceIDs: []int64{},
+		Steps: []privacy.Step{{Store: "device", Status: privacy.StatusPending}, {Store: "profile", Status: privacy.StatusPending}},
+	}, nil).Once()
+	ts.repo.On("UpdateRequest", mock.AnythingOfType("privacy.Request")).Return(nil).Times(4)
+
+	request, err := ts.useCase.CreateRequest(privacy.RequestTypeErasure, subject)
+
+	ts.NoError(err)
+	ts.Equal(privacy.StatusCompleted, request.Status)
+	ts.device.AssertNotCalled(ts.T(), "Erase", mock.Anything)
+	ts.profile.AssertNotCalled(ts.T(), "Erase", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_CreateRequest_StoreFails() {
+	ts.repo.On("FindDeviceIDs", subject).Return([]int64{1, 2}, nil).Once()
+	ts.repo.On("CreateRequest", mock.AnythingOfType("privacy.Request")).Return(&privacy.Request{
+		ID: 7, Type: privacy.RequestTypeErasure, Subject: subject, DeviceIDs: []int64{1, 2},
+		Steps: []privacy.Step{{Store: "device", Status: privacy.StatusPending}, {Store: "profile", Status: privacy.StatusPending}},
+	}, nil).Once()
+	ts.repo.On("UpdateRequest", mock.AnythingOfType("privacy.Request")).Return(nil).Times(4)
+	ts.device.On("Erase", target).Return(0, errors.New("connection refused")).Once()
+	ts.profile.On("Erase", target).Return(5, nil).Once()
+
+	request, err := ts.useCase.CreateRequest(privacy.RequestTypeErasure, subject)
+
+	ts.NoError(err)
+	ts.Equal(privacy.StatusFailed, request.Status)
+	ts.Nil(request.CompletedAt)
+	ts.Equal(privacy.Step{Store: "device", Status: privacy.StatusFailed, Error: "connection refused"}, request.Steps[0])
+	ts.Equal(privacy.StatusCompleted, request.Steps[1].Status)
+}
+
+func (ts *UseCaseTestSuite) Test_GetRequest_NotFound() {
+	ts.repo.On("GetRequest", int64(7)).Return(nil, nil).Once()
+
+	_, err := ts.useCase.GetRequest(7)
+
+	ts.Equal(privacy.NotFoundError{RequestID: 7}, err)
+}
+
+func (ts *UseCaseTestSuite) Test_RetryRequest() {
+	ts.repo.On("GetRequest", int64(7)).Return(&privacy.Request{
+		ID: 7, Type: privacy.RequestTypeErasure, Subject: subject, Status: privacy.StatusFailed, DeviceIDs: []int64{1, 2},
+		Steps: []privacy.Step{{Store: "device", Status: privacy.StatusFailed, Error: "connection refused"}, {Store: "profile", Status: privacy.StatusCompleted, Records: 5}},
+	}, nil).Once()
+	ts.repo.On("UpdateRequest", mock.AnythingOfType("privacy.Request")).Return(nil).Times(3)
+	ts.device.On("Erase", target).Return(2, nil).Once()
+
+	request, err := ts.useCase.RetryRequest(7)
+
+	ts.NoError(err)
+	ts.Equal(privacy.StatusCompleted, request.Status)
+	ts.Equal(privacy.StatusCompleted, request.Steps[0].Status)
+	ts.Equal("", request.Steps[0].Error)
+	ts.Equal(5, request.Steps[1].Records)
+	ts.profile.AssertNotCalled(ts.T(), "Erase", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_RetryRequest_NotFailed() {
+	ts.repo.On("GetRequest", int64(7)).Return(&privacy.Request{ID: 7, Status: privacy.StatusCompleted}, nil).Once()
+
+	_, err := ts.useCase.RetryRequest(7)
+
+	ts.Equal(privacy.NotRetryableError{RequestID: 7, Status: privacy.StatusCompleted}, err)
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	device  *mockStore
+	profile *mockStore
+	useCase privacy.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.device = &mockStore{name: "device"}
+	ts.profile = &mockStore{name: "profile"}
+	ts.useCase = privacy.NewUseCase(ts.repo, []privacy.Store{ts.device, ts.profile})
+}
+
+func (ts *UseCaseTestSuite) TearDownTest() {
+	ts.repo.AssertExpectations(ts.T())
+	ts.device.AssertExpectations(ts.T())
+	ts.profile.AssertExpectations(ts.T())
+}
+
+var _ privacy.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) CreateRequest(request privacy.Request) (*privacy.Request, error) {
+	ret := r.Called(request)
+	created, _ := ret.Get(0).(*privacy.Request)
+	return created, ret.Error(1)
+}
+
+func (r *mockRepo) UpdateRequest(request privacy.Request) error {
+	return r.Called(request).Error(0)
+}
+
+func (r *mockRepo) GetRequest(id int64) (*privacy.Request, error) {
+	ret := r.Called(id)
+	request, _ := ret.Get(0).(*privacy.Request)
+	return request, ret.Error(1)
+}
+
+func (r *mockRepo) FindDeviceIDs(subject privacy.Subject) ([]int64, error) {
+	ret := r.Called(subject)
+	return ret.Get(0).([]int64), ret.Error(1)
+}
+
+var _ privacy.Store = &mockStore{}
+
+type mockStore struct {
+	mock.Mock
+	name string
+}
+
+func (s *mockStore) Name() string {
+	return s.name
+}
+
+func (s *mockStore) Export(target privacy.Target) ([]privacy.Record, error) {
+	ret := s.Called(target)
+	return ret.Get(0).([]privacy.Record), ret.Error(1)
+}
+
+func (s *mockStore) Erase(target privacy.Target) (int, error) {
+	ret := s.Called(target)
+	return ret.Int(0), ret.Error(1)
+}
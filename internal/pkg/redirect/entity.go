@@ -0,0 +1,75 @@
+package redirect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Target is the set of redirect urls bound to a click url at allocation time
+type Target struct {
+	CampaignID    int64
+	UnitID        int64
+	URL           string
+	CleanURL      string
+	DeepLink      string
+	UniversalLink string
+	TargetApp     string
+	// FailTrackers are fired by the server when the tracking url of the click can't be delivered
+	FailTrackers []string
+}
+
+// URLs returns non-empty redirect urls of the target
+func (t Target) URLs() []string {
+	urls := make([]string, 0, 3)
+	for _, u := range []string{t.URL, t.CleanURL, t.UniversalLink} {
+		if u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// signingMessage length-prefixes every field so that no two targets share a message
+// e.g. URL "a|b" with CleanURL "" and URL "a" with CleanURL "b"
+func (t Target) signingMessage() string {
+	fields := []string{
+		strconv.FormatInt(t.CampaignID, 10),
+		strconv.FormatInt(t.UnitID, 10),
+		t.URL,
+		t.CleanURL,
+		t.DeepLink,
+		t.UniversalLink,
+		t.TargetApp,
+	}
+	// 고정 필드 뒤에 붙기 때문에 fail tracker가 없는 target의 signature는 이전과 같다
+	fields = append(fields, t.FailTrackers...)
+
+	var message strings.Builder
+	for _, field := range fields {
+		fmt.Fprintf(&message, "%d:%s", len(field), field)
+	}
+	return message.String()
+}
+
+// AllowedDomains is the list of domains that redirect urls may point to
+type AllowedDomains []string
+
+// Contains returns true if the host is one of the domains or a subdomain of them
+func (ds AllowedDomains) Contains(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" {
+		return false
+	}
+
+	for _, domain := range ds {
+		domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+		if domain == "" {
+			continue
+		}
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
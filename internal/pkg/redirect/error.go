@@ -0,0 +1,18 @@
+package redirect
+
+import "fmt"
+
+var (
+	_ error = InvalidTargetError{}
+)
+
+// InvalidTargetError will be returned when the redirect url is neither signed nor allowed for the organization.
+type InvalidTargetError struct {
+	URL    string
+	Reason string
+}
+
+// Error func definition
+func (e InvalidTargetError) Error() string {
+	return fmt.Sprintf("invalid redirect target %q: %s", e.URL, e.Reason)
+}
@@ -0,0 +1,18 @@
+package repo
+
+import "time"
+
+// DBAllowedDomain struct definition
+type DBAllowedDomain struct {
+	ID             int64 `gorm:"primary_key"`
+	OrganizationID int64
+	Domain         string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName is name of DBAllowedDomain table
+func (DBAllowedDomain) TableName() string {
+	return "organization_redirect_domain"
+}
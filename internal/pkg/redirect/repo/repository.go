@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/redirect"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	allowedDomainsCacheKeyFormat = "CACHE_GO_REDIRECT_DOMAINS-%v"
+	cacheExpiration              = time.Hour * 24
+)
+
+// Repository struct definition
+type Repository struct {
+	db         *gorm.DB
+	redisCache rediscache.RedisSource
+}
+
+type allowedDomainsCache struct {
+	Domains   redirect.AllowedDomains
+	CreatedAt time.Time
+}
+
+func (c *allowedDomainsCache) needRefresh() bool {
+	return time.Now().After(c.CreatedAt.Add(time.Minute))
+}
+
+// GetAllowedDomains returns redirect domains allowed for the organization
+func (r *Repository) GetAllowedDomains(organizationID int64) (redirect.AllowedDomains, error) {
+	var domainsCache allowedDomainsCache
+
+	key := fmt.Sprintf(allowedDomainsCacheKeyFormat, organizationID)
+	if err := r.redisCache.GetCache(key, &domainsCache); err == nil && !domainsCache.needRefresh() {
+		return domainsCache.Domains, nil
+	}
+
+	var dbDomains []DBAllowedDomain
+	if err := r.db.Where(&DBAllowedDomain{OrganizationID: organizationID}).Find(&dbDomains).Error; err != nil {
+		return nil, err
+	}
+
+	domains := make(redirect.AllowedDomains, 0, len(dbDomains))
+	for _, dbDomain := range dbDomains {
+		domains = append(domains, dbDomain.Domain)
+	}
+
+	// 허용 도메인이 없는 경우에도 캐싱
+	r.redisCache.SetCacheAsync(key, allowedDomainsCache{Domains: domains, CreatedAt: time.Now()}, cacheExpiration)
+	return domains, nil
+}
+
+// New creates redirect repository
+func New(db *gorm.DB, redisCache rediscache.RedisSource) *Repository {
+	return &Repository{
+		db:         db,
+		redisCache: redisCache,
+	}
+}
+
+var _ redirect.Repository = &Repository{}
@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/redirect"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	mock       sqlmock.Sqlmock
+	db         *gorm.DB
+	redisCache *mockRedisCache
+	repo       redirect.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	ts.NoError(err)
+	ts.mock = mock
+	ts.db, err = gorm.Open("mysql", db)
+	ts.NoError(err)
+	ts.redisCache = new(mockRedisCache)
+	ts.repo = New(ts.db, ts.redisCache)
+}
+
+func (ts *RepoTestSuite) AfterTest(_, _ string) {
+	_ = ts.db.Close()
+}
+
+func (ts *RepoTestSuite) Test_GetAllowedDomains() {
+	organizationID := int64(3)
+	ts.redisCache.On("GetCache", mock.Anything, mock.Anything).Return(errors.New("cache: key is missing")).Once()
+	ts.redisCache.On("SetCacheAsync", fmt.Sprintf(allowedDomainsCacheKeyFormat, organizationID), mock.Anything, cacheExpiration).Return().Once()
+
+	query := "SELECT * FROM `organization_redirect_domain` WHERE (`organization_redirect_domain`.`organization_id` = ?)"
+	rows := sqlmock.NewRows([]string{"id", "organization_id", "domain"}).
+		AddRow(1, organizationID, "news.example.com").
+		AddRow(2, organizationID, "blog.example.com")
+	ts.mock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(query))).WithArgs(organizationID).WillReturnRows(rows)
+
+	domains, err := ts.repo.GetAllowedDomains(organizationID)
+
+	ts.NoError(err)
+	ts.Equal(redirect.AllowedDomains{"news.example.com", "blog.example.com"}, domains)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+	ts.redisCache.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) Test_GetAllowedDomains_Cached() {
+	organizationID := int64(3)
+	ts.redisCache.On("GetCache", fmt.Sprintf(allowedDomainsCacheKeyFormat, organizationID), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		cache := args.Get(1).(*allowedDomainsCache)
+		cache.Domains = redirect.AllowedDomains{"news.example.com"}
+		cache.CreatedAt = time.Now()
+	}).Once()
+
+	domains, err := ts.repo.GetAllowedDomains(organizationID)
+
+	ts.NoError(err)
+	ts.Equal(redirect.AllowedDomains{"news.example.com"}, domains)
+	ts.NoError(ts.mock.ExpectationsWereMet())
+	ts.redisCache.AssertExpectations(ts.T())
+}
+
+var _ rediscache.RedisSource = &mockRedisCache{}
+
+type mockRedisCache struct {
+	mock.Mock
+}
+
+func (r *mockRedisCache) GetCache(key string, obj interface{}) error {
+	ret := r.Called(key, obj)
+	return ret.Error(0)
+}
+
+func (r *mockRedisCache) SetCacheAsync(key string, obj interface{}, expiration time.Duration) {
+	r.Called(key, obj, expiration)
+}
+
+func (r *mockRedisCache) SetCache(key string, obj interface{}, expiration time.Duration) error {
+	ret := r.Called(key, obj, expiration)
+	return ret.Error(0)
+}
+
+func (r *mockRedisCache) DeleteCache(key string) error {
+	ret := r.Called(key)
+	return ret.Error(0)
+}
@@ -0,0 +1,6 @@
+package redirect
+
+// Repository interface definition
+type Repository interface {
+	GetAllowedDomains(organizationID int64) (AllowedDomains, error)
+}
@@ -0,0 +1,94 @@
+package redirect
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+)
+
+// UseCase interface definition
+type UseCase interface {
+	SignTarget(target Target) string
+	ValidateTarget(target Target, signature string, organizationID int64) error
+}
+
+type useCase struct {
+	repo           Repository
+	signatureKey   []byte
+	defaultDomains AllowedDomains
+}
+
+// SignTarget returns the signature binding redirect urls to the campaign and unit
+// It returns empty string if the signature key is not configured.
+func (u *useCase) SignTarget(target Target) string {
+	if len(u.signatureKey) == 0 {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, u.signatureKey)
+	mac.Write([]byte(target.signingMessage()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateTarget accepts the target if it is signed at allocation time.
+// Otherwise every redirect url should point to a domain allowed for the organization.
+// Deep links and fail trackers can't be checked against domains, so they are accepted only if signed.
+// Without the signature key no signature is accepted and every target goes through the allowlist.
+func (u *useCase) ValidateTarget(target Target, signature string, organizationID int64) error {
+	if expected := u.SignTarget(target); signature != "" && expected != "" && hmac.Equal([]byte(signature), []byte(expected)) {
+		return nil
+	}
+
+	if target.DeepLink != "" {
+		return InvalidTargetError{URL: target.DeepLink, Reason: "deep link is not signed"}
+	}
+
+	if len(target.FailTrackers) > 0 {
+		return InvalidTargetError{URL: target.FailTrackers[0], Reason: "fail tracker is not signed"}
+	}
+
+	urls := target.URLs()
+	if len(urls) == 0 {
+		return nil
+	}
+
+	domains, err := u.repo.GetAllowedDomains(organizationID)
+	if err != nil {
+		return err
+	}
+	domains = append(domains, u.defaultDomains...)
+
+	for _, rawURL := range urls {
+		if err := u.validateURL(rawURL, domains); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *useCase) validateURL(rawURL string, domains AllowedDomains) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return InvalidTargetError{URL: rawURL, Reason: err.Error()}
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return InvalidTargetError{URL: rawURL, Reason: "scheme is not allowed"}
+	}
+
+	if !domains.Contains(parsed.Hostname()) {
+		return InvalidTargetError{URL: rawURL, Reason: "domain is not allowed"}
+	}
+	return nil
+}
+
+// NewUseCase returns UseCase interface
+// defaultDomains are allowed for every organization. e.g. BuzzAd and BuzzScreen API domains
+func NewUseCase(repo Repository, signatureKey string, defaultDomains AllowedDomains) UseCase {
+	return &useCase{
+		repo:           repo,
+		signatureKey:   []byte(signatureKey),
+		defaultDomains: defaultDomains,
+	}
+}
@@ -0,0 +1,150 @@
+package redirect_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/redirect"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testSignatureKey   = "TEST_REDIRECT_SIGNATURE_KEY"
+	testOrganizationID = int64(7)
+)
+
+func (ts *UseCaseTestSuite) Test_ValidateTarget_Signed() {
+	target := redirect.Target{CampaignID: 1, UnitID: 2, URL: "https://evil.example.com", CleanURL: "myapp://article/1"}
+	signature := ts.useCase.SignTarget(target)
+
+	err := ts.useCase.ValidateTarget(target, signature, testOrganizationID)
+
+	ts.NoError(err)
+	ts.repo.AssertNotCalled(ts.T(), "GetAllowedDomains", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_ValidateTarget_TamperedSignature() {
+	target := redirect.Target{CampaignID: 1, UnitID: 2, URL: "https://news.example.com/article"}
+	signature := ts.useCase.SignTarget(target)
+	target.URL = "https://evil.example.com"
+	ts.repo.On("GetAllowedDomains", testOrganizationID).Return(redirect.AllowedDomains{"news.example.com"}, nil).Once()
+
+	err := ts.useCase.ValidateTarget(target, signature, testOrganizationID)
+
+	ts.IsType(redirect.InvalidTargetError{}, err)
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_ValidateTarget_AllowedDomain() {
+	target := redirect.Target{URL: "https://m.news.example.com/article", CleanURL: "https://ad.buzzvil.com/clean"}
+	ts.repo.On("GetAllowedDomains", testOrganizationID).Return(redirect.AllowedDomains{"news.example.com"}, nil).Once()
+
+	err := ts.useCase.ValidateTarget(target, "", testOrganizationID)
+
+	ts.NoError(err)
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_ValidateTarget_NotAllowed() {
+	for _, rawURL := range []string{
+		"https://evil.example.com",
+		"https://news.example.com.evil.com",
+		"javascript:alert(1)",
+		"//evil.example.com",
+	} {
+		ts.repo.On("GetAllowedDomains", testOrganizationID).Return(redirect.AllowedDomains{"news.example.com"}, nil).Once()
+
+		err := ts.useCase.ValidateTarget(redirect.Target{URL: rawURL}, "", testOrganizationID)
+
+		ts.IsType(redirect.InvalidTargetError{}, err, rawURL)
+	}
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_ValidateTarget_SignedDeepLink() {
+	target := redirect.Target{CampaignID: 1, UnitID: 2, URL: "https://news.example.com", DeepLink: "myapp://article/1", TargetApp: "com.example.myapp"}
+	signature := ts.useCase.SignTarget(target)
+
+	ts.NoError(ts.useCase.ValidateTarget(target, signature, testOrganizationID))
+
+	target.DeepLink = "evilapp://steal"
+	ts.IsType(redirect.InvalidTargetError{}, ts.useCase.ValidateTarget(target, signature, testOrganizationID))
+	ts.repo.AssertNotCalled(ts.T(), "GetAllowedDomains", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_ValidateTarget_SignedFailTrackers() {
+	target := redirect.Target{CampaignID: 1, UnitID: 2, URL: "https://news.example.com", FailTrackers: []string{"https://ad.buzzvil.com/api/fail_tracker"}}
+	signature := ts.useCase.SignTarget(target)
+
+	ts.NoError(ts.useCase.ValidateTarget(target, signature, testOrganizationID))
+	ts.NotEqual(signature, ts.useCase.SignTarget(redirect.Target{CampaignID: 1, UnitID: 2, URL: "https://news.example.com"}))
+
+	target.FailTrackers = []string{"http://169.254.169.254/latest/meta-data"}
+	ts.IsType(redirect.InvalidTargetError{}, ts.useCase.ValidateTarget(target, signature, testOrganizationID))
+	ts.repo.AssertNotCalled(ts.T(), "GetAllowedDomains", mock.Anything)
+}
+
+func (ts *UseCaseTestSuite) Test_SignTarget_FieldBoundaries() {
+	for _, pair := range [][2]redirect.Target{
+		{{URL: "https://a.example.com|b"}, {URL: "https://a.example.com", CleanURL: "b"}},
+		{{CampaignID: 1, UnitID: 23}, {CampaignID: 12, UnitID: 3}},
+		{{DeepLink: "myapp://a", UniversalLink: ""}, {DeepLink: "", UniversalLink: "myapp://a"}},
+	} {
+		ts.NotEqual(ts.useCase.SignTarget(pair[0]), ts.useCase.SignTarget(pair[1]), pair)
+	}
+}
+
+func (ts *UseCaseTestSuite) Test_ValidateTarget_NoSignatureKey() {
+	useCase := redirect.NewUseCase(ts.repo, "", redirect.AllowedDomains{"buzzvil.com"})
+	target := redirect.Target{CampaignID: 1, UnitID: 2, URL: "https://evil.example.com", DeepLink: "myapp://article/1"}
+	ts.Empty(useCase.SignTarget(target))
+	ts.IsType(redirect.InvalidTargetError{}, useCase.ValidateTarget(target, "", testOrganizationID))
+
+	target.DeepLink = ""
+	ts.repo.On("GetAllowedDomains", testOrganizationID).Return(redirect.AllowedDomains{"news.example.com"}, nil).Once()
+	ts.IsType(redirect.InvalidTargetError{}, useCase.ValidateTarget(target, "", testOrganizationID))
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_ValidateTarget_RepoError() {
+	ts.repo.On("GetAllowedDomains", testOrganizationID).Return(redirect.AllowedDomains(nil), errors.New("db error")).Once()
+
+	err := ts.useCase.ValidateTarget(redirect.Target{URL: "https://news.example.com"}, "", testOrganizationID)
+
+	ts.Error(err)
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_ValidateTarget_Empty() {
+	err := ts.useCase.ValidateTarget(redirect.Target{}, "", testOrganizationID)
+
+	ts.NoError(err)
+	ts.repo.AssertNotCalled(ts.T(), "GetAllowedDomains", mock.Anything)
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	useCase redirect.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.useCase = redirect.NewUseCase(ts.repo, testSignatureKey, redirect.AllowedDomains{"buzzvil.com"})
+}
+
+var _ redirect.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) GetAllowedDomains(organizationID int64) (redirect.AllowedDomains, error) {
+	ret := r.Called(organizationID)
+	return ret.Get(0).(redirect.AllowedDomains), ret.Error(1)
+}
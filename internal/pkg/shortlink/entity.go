@@ -0,0 +1,14 @@
+package shortlink
+
+import "time"
+
+const (
+	// MaxURLLength is the url length which old android (kitkat, api level 19 - 20) can handle
+	MaxURLLength = 2048
+
+	// Path is the path prefix of short links
+	Path = "/c/"
+
+	idLength = 16
+	linkTTL  = time.Hour * 24 * 7
+)
@@ -0,0 +1,17 @@
+package shortlink
+
+import "fmt"
+
+var (
+	_ error = NotFoundError{}
+)
+
+// NotFoundError will be returned when the short link doesn't exist or is expired
+type NotFoundError struct {
+	ID string
+}
+
+// Error func definition
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("short link %s is not found", e.ID)
+}
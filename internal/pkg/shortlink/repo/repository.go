@@ -0,0 +1,41 @@
+package repo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
+	"github.com/go-redis/cache"
+)
+
+const shortLinkCacheKeyFormat = "CACHE_GO_SHORTLINK-%s"
+
+// Repository struct definition
+type Repository struct {
+	redisCache rediscache.RedisSource
+}
+
+// Save stores the url under the id
+func (r *Repository) Save(id string, longURL string, expiration time.Duration) error {
+	return r.redisCache.SetCache(fmt.Sprintf(shortLinkCacheKeyFormat, id), longURL, expiration)
+}
+
+// Get returns the url stored under the id
+func (r *Repository) Get(id string) (string, error) {
+	var longURL string
+	err := r.redisCache.GetCache(fmt.Sprintf(shortLinkCacheKeyFormat, id), &longURL)
+	if err == cache.ErrCacheMiss {
+		return "", shortlink.NotFoundError{ID: id}
+	} else if err != nil {
+		return "", err
+	}
+	return longURL, nil
+}
+
+// New returns short link repository
+func New(redisCache rediscache.RedisSource) *Repository {
+	return &Repository{redisCache: redisCache}
+}
+
+var _ shortlink.Repository = &Repository{}
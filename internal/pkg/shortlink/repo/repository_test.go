@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/datasource/rediscache"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
+	"github.com/go-redis/cache"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	redisCache *mockRedisCache
+	repo       shortlink.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	ts.redisCache = new(mockRedisCache)
+	ts.repo = New(ts.redisCache)
+}
+
+func (ts *RepoTestSuite) AfterTest(_, _ string) {
+	ts.redisCache.AssertExpectations(ts.T())
+}
+
+func (ts *RepoTestSuite) Test_Save() {
+	ts.redisCache.On("SetCache", "CACHE_GO_SHORTLINK-abc", "https://long.url", time.Hour).Return(nil).Once()
+
+	ts.NoError(ts.repo.Save("abc", "https://long.url", time.Hour))
+}
+
+func (ts *RepoTestSuite) Test_Get() {
+	ts.redisCache.On("GetCache", "CACHE_GO_SHORTLINK-abc", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*(args.Get(1).(*string)) = "https://long.url"
+	}).Once()
+
+	longURL, err := ts.repo.Get("abc")
+
+	ts.NoError(err)
+	ts.Equal("https://long.url", longURL)
+}
+
+func (ts *RepoTestSuite) Test_Get_NotFound() {
+	ts.redisCache.On("GetCache", "CACHE_GO_SHORTLINK-abc", mock.Anything).Return(cache.ErrCacheMiss).Once()
+
+	_, err := ts.repo.Get("abc")
+
+	ts.Equal(shortlink.NotFoundError{ID: "abc"}, err)
+}
+
+var _ rediscache.RedisSource = &mockRedisCache{}
+
+type mockRedisCache struct {
+	mock.Mock
+}
+
+func (r *mockRedisCache) GetCache(key string, obj interface{}) error {
+	ret := r.Called(key, obj)
+	return ret.Error(0)
+}
+
+func (r *mockRedisCache) SetCacheAsync(key string, obj interface{}, expiration time.Duration) {
+	r.Called(key, obj, expiration)
+}
+
+func (r *mockRedisCache) SetCache(key string, obj interface{}, expiration time.Duration) error {
+	ret := r.Called(key, obj, expiration)
+	return ret.Error(0)
+}
+
+func (r *mockRedisCache) DeleteCache(key string) error {
+	ret := r.Called(key)
+	return ret.Error(0)
+}
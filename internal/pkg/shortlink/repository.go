@@ -0,0 +1,9 @@
+package shortlink
+
+import "time"
+
+// Repository interface definition
+type Repository interface {
+	Save(id string, longURL string, expiration time.Duration) error
+	Get(id string) (string, error)
+}
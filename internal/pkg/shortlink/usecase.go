@@ -0,0 +1,123 @@
+package shortlink
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// macroPattern matches query values replaced by the client. e.g. __reward__, __check__
+var macroPattern = regexp.MustCompile(`^__[a-z_]+__$`)
+
+// UseCase interface definition
+type UseCase interface {
+	Shorten(longURL string) (string, error)
+	ShortenIfLonger(longURL string, maxLength int) string
+	Resolve(id string, query url.Values) (string, error)
+}
+
+type useCase struct {
+	repo    Repository
+	baseURL string
+}
+
+// Shorten stores the url and returns the short link of it.
+// Query parameters holding macros are kept in the short link, so the client can still replace them.
+// The stored url keeps the macros as well, so that Resolve only lets the client replace them.
+func (u *useCase) Shorten(longURL string) (string, error) {
+	parsed, err := url.Parse(longURL)
+	if err != nil {
+		return "", err
+	}
+
+	query, macros := parsed.Query(), url.Values{}
+	for key, values := range query {
+		for _, value := range values {
+			if macroPattern.MatchString(value) {
+				macros.Add(key, value)
+			}
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	storedURL := parsed.String()
+
+	id := buildID(storedURL)
+	if err := u.repo.Save(id, storedURL, linkTTL); err != nil {
+		return "", err
+	}
+
+	shortURL := u.baseURL + Path + id
+	if len(macros) > 0 {
+		shortURL += "?" + macros.Encode()
+	}
+	return shortURL, nil
+}
+
+// ShortenIfLonger returns the short link if the url exceeds maxLength.
+// The url is returned as it is if maxLength is not set or shortening is failed.
+func (u *useCase) ShortenIfLonger(longURL string, maxLength int) string {
+	if maxLength <= 0 || len(longURL) <= maxLength {
+		return longURL
+	}
+
+	shortURL, err := u.Shorten(longURL)
+	if err != nil {
+		return longURL
+	}
+	return shortURL
+}
+
+// Resolve returns the url stored under the id with the macros replaced by the query of the short link.
+// Query parameters which are not macros in the stored url are ignored.
+func (u *useCase) Resolve(id string, query url.Values) (string, error) {
+	if len(id) != idLength {
+		return "", NotFoundError{ID: id}
+	}
+
+	storedURL, err := u.repo.Get(id)
+	if err != nil {
+		return "", err
+	}
+
+	parsed, err := url.Parse(storedURL)
+	if err != nil {
+		return "", err
+	}
+	merged := parsed.Query()
+	for key, values := range query {
+		if isMacro(merged[key]) {
+			merged[key] = values
+		}
+	}
+	parsed.RawQuery = merged.Encode()
+	return parsed.String(), nil
+}
+
+func isMacro(values []string) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for _, value := range values {
+		if !macroPattern.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// buildID returns the same id for the same url, so repeated allocations don't create new links
+func buildID(longURL string) string {
+	hash := sha256.Sum256([]byte(longURL))
+	return base64.RawURLEncoding.EncodeToString(hash[:])[:idLength]
+}
+
+// NewUseCase returns UseCase interface
+// baseURL is the scheme and host serving short links. e.g. https://screen.buzzvil.com
+func NewUseCase(repo Repository, baseURL string) UseCase {
+	return &useCase{
+		repo:    repo,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
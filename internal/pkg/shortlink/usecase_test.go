@@ -0,0 +1,115 @@
+package shortlink_test
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/shortlink"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testBaseURL = "https://screen.buzzvil.com/"
+
+func (ts *UseCaseTestSuite) Test_Shorten() {
+	longURL := "https://screen.buzzvil.com/api/click_redirect/?check=__check__&redirect_url=" + strings.Repeat("a", shortlink.MaxURLLength)
+	storedURL := longURL
+	var savedID string
+	ts.repo.On("Save", mock.AnythingOfType("string"), storedURL, mock.AnythingOfType("time.Duration")).Return(nil).Run(func(args mock.Arguments) {
+		savedID = args.String(0)
+	}).Twice()
+
+	shortURL, err := ts.useCase.Shorten(longURL)
+	ts.NoError(err)
+	ts.Equal("https://screen.buzzvil.com/c/"+savedID+"?check=__check__", shortURL)
+
+	sameURL, err := ts.useCase.Shorten(longURL)
+	ts.NoError(err)
+	ts.Equal(shortURL, sameURL)
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_ShortenIfLonger() {
+	shortEnough := "https://screen.buzzvil.com/api/click_redirect/?id=1"
+	ts.Equal(shortEnough, ts.useCase.ShortenIfLonger(shortEnough, shortlink.MaxURLLength))
+
+	longURL := shortEnough + strings.Repeat("a", shortlink.MaxURLLength)
+	ts.Equal(longURL, ts.useCase.ShortenIfLonger(longURL, 0))
+
+	ts.repo.On("Save", mock.AnythingOfType("string"), longURL, mock.AnythingOfType("time.Duration")).Return(nil).Once()
+	shortURL := ts.useCase.ShortenIfLonger(longURL, shortlink.MaxURLLength)
+	ts.True(strings.HasPrefix(shortURL, "https://screen.buzzvil.com/c/"))
+	ts.True(len(shortURL) <= shortlink.MaxURLLength)
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_ShortenIfLonger_SaveError() {
+	longURL := "https://screen.buzzvil.com/api/click_redirect/?id=" + strings.Repeat("a", shortlink.MaxURLLength)
+	ts.repo.On("Save", mock.AnythingOfType("string"), longURL, mock.AnythingOfType("time.Duration")).Return(errors.New("redis error")).Once()
+
+	ts.Equal(longURL, ts.useCase.ShortenIfLonger(longURL, shortlink.MaxURLLength))
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_Resolve() {
+	id := "abcdefghijklmnop"
+	ts.repo.On("Get", id).Return("https://screen.buzzvil.com/api/click_redirect/?check=__check__&id=1&reward=__reward__", nil).Once()
+
+	longURL, err := ts.useCase.Resolve(id, url.Values{"check": {"abc"}, "reward": {"3"}})
+
+	ts.NoError(err)
+	ts.Equal("https://screen.buzzvil.com/api/click_redirect/?check=abc&id=1&reward=3", longURL)
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_Resolve_IgnoresNonMacros() {
+	id := "abcdefghijklmnop"
+	ts.repo.On("Get", id).Return("https://screen.buzzvil.com/api/click_redirect/?id=1&redirect_url=https%3A%2F%2Fnews.example.com&reward=__reward__", nil).Once()
+
+	longURL, err := ts.useCase.Resolve(id, url.Values{"id": {"2"}, "redirect_url": {"https://evil.example.com"}, "tracking_url": {"http://10.0.0.1"}, "reward": {"3"}})
+
+	ts.NoError(err)
+	ts.Equal("https://screen.buzzvil.com/api/click_redirect/?id=1&redirect_url=https%3A%2F%2Fnews.example.com&reward=3", longURL)
+	ts.repo.AssertExpectations(ts.T())
+}
+
+func (ts *UseCaseTestSuite) Test_Resolve_InvalidID() {
+	_, err := ts.useCase.Resolve("short", nil)
+
+	ts.IsType(shortlink.NotFoundError{}, err)
+	ts.repo.AssertNotCalled(ts.T(), "Get", mock.Anything)
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo    *mockRepo
+	useCase shortlink.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.useCase = shortlink.NewUseCase(ts.repo, testBaseURL)
+}
+
+var _ shortlink.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) Save(id string, longURL string, expiration time.Duration) error {
+	ret := r.Called(id, longURL, expiration)
+	return ret.Error(0)
+}
+
+func (r *mockRepo) Get(id string) (string, error) {
+	ret := r.Called(id)
+	return ret.String(0), ret.Error(1)
+}
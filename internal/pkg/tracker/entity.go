@@ -0,0 +1,49 @@
+package tracker
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Request is a set of tracker urls fired for an action
+// FailURLs are fired once if any of URLs can't be delivered
+type Request struct {
+	URLs     []string
+	FailURLs []string
+	Macros   Macros
+}
+
+// Macros maps a macro in tracker urls (e.g. __ifa__) to its value
+type Macros map[string]string
+
+// Expand replaces macros in the url with query escaped values
+func (m Macros) Expand(rawURL string) string {
+	for macro, value := range m {
+		rawURL = strings.Replace(rawURL, macro, url.QueryEscape(value), -1)
+	}
+	return rawURL
+}
+
+// Config struct definition
+type Config struct {
+	// QueueSize is the maximum number of tracker urls waiting for delivery
+	QueueSize int
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// RetryInterval is doubled on every retry
+	RetryInterval time.Duration
+	// MaxConcurrencyPerHost is the maximum number of concurrent requests to an advertiser host
+	MaxConcurrencyPerHost int
+}
+
+// Result is the delivery result of a tracker url
+type Result string
+
+// Result constants
+const (
+	ResultDelivered Result = "delivered"
+	ResultFailed    Result = "failed"
+	ResultRetried   Result = "retried"
+	ResultDropped   Result = "dropped"
+)
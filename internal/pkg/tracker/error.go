@@ -0,0 +1,34 @@
+package tracker
+
+import "fmt"
+
+var (
+	_ error = QueueFullError{}
+	_ error = DeliveryError{}
+)
+
+// QueueFullError will be returned when the tracker url can't be queued
+type QueueFullError struct {
+	URL string
+}
+
+// Error func definition
+func (e QueueFullError) Error() string {
+	return fmt.Sprintf("tracker queue is full. url: %s", e.URL)
+}
+
+// DeliveryError will be returned when the tracker responds with unsuccessful status code
+type DeliveryError struct {
+	URL        string
+	StatusCode int
+}
+
+// Error func definition
+func (e DeliveryError) Error() string {
+	return fmt.Sprintf("tracker responds with status code %d. url: %s", e.StatusCode, e.URL)
+}
+
+// Retryable returns true if the tracker may succeed on retry
+func (e DeliveryError) Retryable() bool {
+	return e.StatusCode >= 500 || e.StatusCode == 429
+}
@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/network"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/tracker"
+	"github.com/pkg/errors"
+)
+
+// Repository struct definition
+type Repository struct {
+	timeout time.Duration
+}
+
+// Fire calls the tracker url
+func (r *Repository) Fire(trackerURL string) error {
+	res, err := (&network.Request{
+		URL:     trackerURL,
+		Method:  http.MethodGet,
+		Timeout: r.timeout,
+	}).MakeRequest()
+	if err != nil {
+		return errors.Wrapf(err, "tracker api call with url %s has error", trackerURL)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return tracker.DeliveryError{URL: trackerURL, StatusCode: res.StatusCode}
+	}
+	return nil
+}
+
+// New returns tracker repository
+func New(timeout time.Duration) *Repository {
+	return &Repository{timeout: timeout}
+}
+
+var _ tracker.Repository = &Repository{}
@@ -0,0 +1,57 @@
+package repo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/tracker"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoTestSuite))
+}
+
+type RepoTestSuite struct {
+	suite.Suite
+	server     *httptest.Server
+	statusCode int
+	repo       tracker.Repository
+}
+
+func (ts *RepoTestSuite) SetupTest() {
+	ts.statusCode = http.StatusOK
+	ts.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(time.Millisecond * 200)
+		}
+		w.WriteHeader(ts.statusCode)
+	}))
+	ts.repo = New(time.Millisecond * 100)
+}
+
+func (ts *RepoTestSuite) TearDownTest() {
+	ts.server.Close()
+}
+
+func (ts *RepoTestSuite) Test_Fire() {
+	ts.NoError(ts.repo.Fire(ts.server.URL + "/track"))
+}
+
+func (ts *RepoTestSuite) Test_Fire_BadStatus() {
+	ts.statusCode = http.StatusServiceUnavailable
+
+	err := ts.repo.Fire(ts.server.URL + "/track")
+
+	ts.Equal(tracker.DeliveryError{URL: ts.server.URL + "/track", StatusCode: http.StatusServiceUnavailable}, err)
+}
+
+func (ts *RepoTestSuite) Test_Fire_Timeout() {
+	err := ts.repo.Fire(ts.server.URL + "/slow")
+
+	ts.Error(err)
+	_, isDeliveryError := err.(tracker.DeliveryError)
+	ts.False(isDeliveryError)
+}
@@ -0,0 +1,6 @@
+package tracker
+
+// Repository interface definition
+type Repository interface {
+	Fire(trackerURL string) error
+}
@@ -0,0 +1,190 @@
+package tracker
+
+import (
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Buzzvil/buzzlib-go/core"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UseCase interface definition
+type UseCase interface {
+	Dispatch(req Request) error
+	Wait()
+}
+
+type useCase struct {
+	repo       Repository
+	config     Config
+	deliveries *prometheus.CounterVec
+
+	queue     chan struct{}
+	waitGroup sync.WaitGroup
+
+	hostMutex sync.Mutex
+	hostSlots map[string]*hostSlots
+}
+
+// hostSlots limits concurrent requests to a host. It is evicted when no tracker holds or waits for it
+type hostSlots struct {
+	slots chan struct{}
+	users int
+}
+
+// delivery is shared by tracker urls of a request to fire FailURLs at most once
+type delivery struct {
+	failURLs []string
+	macros   Macros
+	failOnce sync.Once
+}
+
+// Dispatch queues tracker urls with macros expanded and returns immediately.
+// Urls which can't be queued are dropped and QueueFullError is returned.
+// FailURLs are not fired for dropped urls since the queue is full anyway.
+func (u *useCase) Dispatch(req Request) error {
+	d := &delivery{failURLs: req.FailURLs, macros: req.Macros}
+
+	var err error
+	for _, trackerURL := range req.URLs {
+		if trackerURL == "" {
+			continue
+		}
+		if queueErr := u.enqueue(req.Macros.Expand(trackerURL), d); queueErr != nil {
+			err = queueErr
+		}
+	}
+	return err
+}
+
+// Wait blocks until every queued tracker url is delivered or failed
+func (u *useCase) Wait() {
+	u.waitGroup.Wait()
+}
+
+func (u *useCase) enqueue(trackerURL string, d *delivery) error {
+	select {
+	case u.queue <- struct{}{}:
+	default:
+		u.count(ResultDropped)
+		core.Logger.Warnf("Dispatch() - tracker is dropped. url: %s", trackerURL)
+		return QueueFullError{URL: trackerURL}
+	}
+
+	u.waitGroup.Add(1)
+	go func() {
+		defer func() {
+			<-u.queue
+			u.waitGroup.Done()
+		}()
+		if !u.deliver(trackerURL) && d != nil {
+			d.fail(u)
+		}
+	}()
+	return nil
+}
+
+// fail queues FailURLs of the delivery. FailURLs don't have FailURLs of their own
+func (d *delivery) fail(u *useCase) {
+	d.failOnce.Do(func() {
+		for _, failURL := range d.failURLs {
+			if failURL != "" {
+				u.enqueue(d.macros.Expand(failURL), nil)
+			}
+		}
+	})
+}
+
+func (u *useCase) deliver(trackerURL string) bool {
+	host := getHost(trackerURL)
+	retryInterval := u.config.RetryInterval
+
+	for attempt := 0; ; attempt++ {
+		err := u.fire(host, trackerURL)
+		if err == nil {
+			u.count(ResultDelivered)
+			return true
+		}
+
+		if attempt >= u.config.MaxRetries || !isRetryable(err) {
+			u.count(ResultFailed)
+			core.Logger.WithError(err).Warnf("deliver() - failed to fire tracker. url: %s, attempts: %d", trackerURL, attempt+1)
+			return false
+		}
+
+		u.count(ResultRetried)
+		time.Sleep(retryInterval)
+		retryInterval *= 2
+	}
+}
+
+// fire holds a slot of the host while the request is in flight. slots are not held during retry interval
+func (u *useCase) fire(host string, trackerURL string) error {
+	hs := u.acquireHostSlots(host)
+	hs.slots <- struct{}{}
+	defer func() {
+		<-hs.slots
+		u.releaseHostSlots(host, hs)
+	}()
+
+	return u.repo.Fire(trackerURL)
+}
+
+func (u *useCase) count(result Result) {
+	u.deliveries.WithLabelValues(string(result)).Inc()
+}
+
+func (u *useCase) acquireHostSlots(host string) *hostSlots {
+	u.hostMutex.Lock()
+	defer u.hostMutex.Unlock()
+
+	hs, ok := u.hostSlots[host]
+	if !ok {
+		hs = &hostSlots{slots: make(chan struct{}, u.config.MaxConcurrencyPerHost)}
+		u.hostSlots[host] = hs
+	}
+	hs.users++
+	return hs
+}
+
+func (u *useCase) releaseHostSlots(host string, hs *hostSlots) {
+	u.hostMutex.Lock()
+	defer u.hostMutex.Unlock()
+
+	hs.users--
+	if hs.users == 0 {
+		delete(u.hostSlots, host)
+	}
+}
+
+func isRetryable(err error) bool {
+	if deliveryErr, ok := err.(DeliveryError); ok {
+		return deliveryErr.Retryable()
+	}
+	// network error
+	return true
+}
+
+func getHost(trackerURL string) string {
+	parsed, err := url.Parse(trackerURL)
+	if err != nil || parsed.Host == "" {
+		return "unknown"
+	}
+	return parsed.Hostname()
+}
+
+// NewUseCase returns UseCase interface
+// deliveries should have "result" label
+func NewUseCase(repo Repository, config Config, deliveries *prometheus.CounterVec) UseCase {
+	if config.MaxConcurrencyPerHost <= 0 {
+		config.MaxConcurrencyPerHost = 1
+	}
+	return &useCase{
+		repo:       repo,
+		config:     config,
+		deliveries: deliveries,
+		queue:      make(chan struct{}, config.QueueSize),
+		hostSlots:  make(map[string]*hostSlots),
+	}
+}
@@ -0,0 +1,147 @@
+package tracker_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/tracker"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testTrackerURL = "https://tracker.example.com/click?ifa=__ifa__&position=__position__"
+	testFailURL    = "https://fail.example.com/fail?ifa=__ifa__"
+)
+
+var testMacros = tracker.Macros{"__ifa__": "test ifa", "__position__": "3"}
+
+func (ts *UseCaseTestSuite) Test_Dispatch() {
+	ts.repo.On("Fire", "https://tracker.example.com/click?ifa=test+ifa&position=3").Return(nil).Once()
+
+	err := ts.useCase.Dispatch(tracker.Request{URLs: []string{testTrackerURL}, FailURLs: []string{testFailURL}, Macros: testMacros})
+	ts.useCase.Wait()
+
+	ts.NoError(err)
+	ts.repo.AssertExpectations(ts.T())
+	ts.Equal(float64(1), ts.count(tracker.ResultDelivered))
+}
+
+func (ts *UseCaseTestSuite) Test_Dispatch_Retry() {
+	expanded := testMacros.Expand(testTrackerURL)
+	ts.repo.On("Fire", expanded).Return(tracker.DeliveryError{URL: expanded, StatusCode: 503}).Twice()
+	ts.repo.On("Fire", expanded).Return(nil).Once()
+
+	ts.NoError(ts.useCase.Dispatch(tracker.Request{URLs: []string{testTrackerURL}, FailURLs: []string{testFailURL}, Macros: testMacros}))
+	ts.useCase.Wait()
+
+	ts.repo.AssertExpectations(ts.T())
+	ts.Equal(float64(2), ts.count(tracker.ResultRetried))
+	ts.Equal(float64(1), ts.count(tracker.ResultDelivered))
+}
+
+func (ts *UseCaseTestSuite) Test_Dispatch_FailTrackers() {
+	expanded := testMacros.Expand(testTrackerURL)
+	ts.repo.On("Fire", expanded).Return(errors.New("connection refused")).Times(3)
+	ts.repo.On("Fire", "https://fail.example.com/fail?ifa=test+ifa").Return(nil).Once()
+
+	ts.NoError(ts.useCase.Dispatch(tracker.Request{URLs: []string{testTrackerURL}, FailURLs: []string{testFailURL}, Macros: testMacros}))
+	ts.useCase.Wait()
+
+	ts.repo.AssertExpectations(ts.T())
+	ts.Equal(float64(2), ts.count(tracker.ResultRetried))
+	ts.Equal(float64(1), ts.count(tracker.ResultFailed))
+	ts.Equal(float64(1), ts.count(tracker.ResultDelivered))
+}
+
+func (ts *UseCaseTestSuite) Test_Dispatch_NotRetryable() {
+	expanded := testMacros.Expand(testTrackerURL)
+	ts.repo.On("Fire", expanded).Return(tracker.DeliveryError{URL: expanded, StatusCode: 404}).Once()
+
+	ts.NoError(ts.useCase.Dispatch(tracker.Request{URLs: []string{testTrackerURL}, Macros: testMacros}))
+	ts.useCase.Wait()
+
+	ts.repo.AssertExpectations(ts.T())
+	ts.Equal(float64(1), ts.count(tracker.ResultFailed))
+}
+
+func (ts *UseCaseTestSuite) Test_Dispatch_QueueFull() {
+	blocked := make(chan struct{})
+	ts.repo.On("Fire", mock.Anything).Return(nil).Run(func(mock.Arguments) { <-blocked })
+	useCase := tracker.NewUseCase(ts.repo, tracker.Config{QueueSize: 1, MaxConcurrencyPerHost: 1}, ts.deliveries)
+
+	ts.NoError(useCase.Dispatch(tracker.Request{URLs: []string{"https://tracker.example.com/1"}}))
+	err := useCase.Dispatch(tracker.Request{URLs: []string{"https://tracker.example.com/2"}, FailURLs: []string{testFailURL}})
+	close(blocked)
+	useCase.Wait()
+
+	ts.IsType(tracker.QueueFullError{}, err)
+	ts.repo.AssertNumberOfCalls(ts.T(), "Fire", 1)
+	ts.Equal(float64(1), ts.count(tracker.ResultDelivered))
+	ts.Equal(float64(1), ts.count(tracker.ResultDropped))
+}
+
+func (ts *UseCaseTestSuite) Test_Dispatch_ConcurrencyPerHost() {
+	var inFlight, maxInFlight int32
+	ts.repo.On("Fire", mock.Anything).Return(nil).Run(func(mock.Arguments) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 10)
+		atomic.AddInt32(&inFlight, -1)
+	})
+
+	urls := []string{"https://tracker.example.com/1", "https://tracker.example.com/2", "https://tracker.example.com/3", "https://tracker.example.com/4"}
+	ts.NoError(ts.useCase.Dispatch(tracker.Request{URLs: urls}))
+	ts.useCase.Wait()
+
+	ts.Equal(float64(4), ts.count(tracker.ResultDelivered))
+	ts.Equal(int32(2), atomic.LoadInt32(&maxInFlight))
+}
+
+func TestUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(UseCaseTestSuite))
+}
+
+type UseCaseTestSuite struct {
+	suite.Suite
+	repo       *mockRepo
+	deliveries *prometheus.CounterVec
+	useCase    tracker.UseCase
+}
+
+func (ts *UseCaseTestSuite) SetupTest() {
+	ts.repo = new(mockRepo)
+	ts.deliveries = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "tracker_deliveries"}, []string{"result"})
+	ts.useCase = tracker.NewUseCase(ts.repo, tracker.Config{
+		QueueSize:             10,
+		MaxRetries:            2,
+		RetryInterval:         time.Millisecond,
+		MaxConcurrencyPerHost: 2,
+	}, ts.deliveries)
+}
+
+func (ts *UseCaseTestSuite) count(result tracker.Result) float64 {
+	var metric dto.Metric
+	ts.NoError(ts.deliveries.WithLabelValues(string(result)).Write(&metric))
+	return metric.GetCounter().GetValue()
+}
+
+var _ tracker.Repository = &mockRepo{}
+
+type mockRepo struct {
+	mock.Mock
+}
+
+func (r *mockRepo) Fire(trackerURL string) error {
+	ret := r.Called(trackerURL)
+	return ret.Error(0)
+}
@@ -2,7 +2,8 @@ package trackingdata
 
 // TrackingData struct definition
 type TrackingData struct {
-	ModelArtifact string `json:"ma"` // ML model 정보가 저장됨
+	ModelArtifact string `json:"ma"`           // ML model 정보가 저장됨
+	Exploration   string `json:"ex,omitempty"` // how the campaign was chosen for exploration. empty if it's ranked by the model
 }
 
 const (
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `organization_redirect_domain` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `organization_id` bigint(20) NOT NULL,
+  `domain` varchar(255) NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `organization_redirect_domain_organization_id_domain` (`organization_id`, `domain`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `translations` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `resource_type` varchar(32) NOT NULL,
+  `resource_id` bigint(20) NOT NULL,
+  `field` varchar(32) NOT NULL,
+  `language` varchar(16) NOT NULL,
+  `text` text NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `translations_resource_field_language` (`resource_type`, `resource_id`, `field`, `language`),
+  KEY `translations_resource_type_language` (`resource_type`, `language`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `content_score_sets`;
+DROP TABLE IF EXISTS `content_score_versions`;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS `content_score_versions` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `name` varchar(64) NOT NULL,
+  `status` varchar(16) NOT NULL,
+  `published_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `content_score_versions_name` (`name`),
+  KEY `content_score_versions_status_published_at` (`status`, `published_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `content_score_sets` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `version_id` bigint(20) NOT NULL,
+  `country` varchar(8) NOT NULL,
+  `gender` varchar(8) NOT NULL,
+  `age` int(11) NOT NULL,
+  `scores` mediumtext NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `content_score_sets_version_id_target` (`version_id`, `country`, `gender`, `age`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
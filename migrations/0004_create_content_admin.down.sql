@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `content_audit_logs`;
+DROP TABLE IF EXISTS `content_categories`;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS `content_categories` (
+  `id` varchar(64) NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `translation` varchar(255) NOT NULL DEFAULT '',
+  `icon_url` varchar(1024) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `content_audit_logs` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `actor` varchar(255) NOT NULL,
+  `action` varchar(32) NOT NULL,
+  `entity_type` varchar(32) NOT NULL,
+  `entity_id` varchar(64) NOT NULL,
+  `before` text,
+  `after` text,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `content_audit_logs_entity_type_entity_id` (`entity_type`, `entity_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
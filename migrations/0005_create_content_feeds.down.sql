@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `content_feed_items`;
+DROP TABLE IF EXISTS `content_feeds`;
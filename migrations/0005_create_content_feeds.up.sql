@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS `content_feeds` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `channel_id` bigint(20) NOT NULL,
+  `url` varchar(1024) NOT NULL,
+  `is_enabled` tinyint(1) NOT NULL DEFAULT '1',
+  `poll_interval_minutes` int(11) NOT NULL DEFAULT '0',
+  `last_polled_at` datetime DEFAULT NULL,
+  `categories` varchar(255) NOT NULL DEFAULT '',
+  `country` varchar(8) NOT NULL DEFAULT '',
+  `target_language` varchar(16) NOT NULL DEFAULT '',
+  `organization_id` bigint(20) NOT NULL DEFAULT '0',
+  `owner_id` bigint(20) NOT NULL DEFAULT '0',
+  `provider_id` bigint(20) DEFAULT NULL,
+  `landing_type` int(11) NOT NULL DEFAULT '0',
+  `timezone` varchar(64) NOT NULL DEFAULT '',
+  `campaign_days` int(11) NOT NULL DEFAULT '0',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `content_feeds_is_enabled` (`is_enabled`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `content_feed_items` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `feed_id` bigint(20) NOT NULL,
+  `guid` varchar(512) NOT NULL,
+  `link` varchar(1024) NOT NULL,
+  `campaign_id` bigint(20) NOT NULL DEFAULT '0',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `content_feed_items_guid` (`guid`(191)),
+  KEY `content_feed_items_link` (`link`(191))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `content_bookmarks` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `device_id` bigint(20) NOT NULL,
+  `campaign_id` bigint(20) NOT NULL,
+  `snapshot` text NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `content_bookmarks_device_id_campaign_id` (`device_id`,`campaign_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `device_content_preferences` (
+  `device_id` bigint(20) NOT NULL,
+  `followed_channel_ids` text NOT NULL,
+  `muted_channel_ids` text NOT NULL,
+  `languages` varchar(255) NOT NULL DEFAULT '',
+  `category_weights` text NOT NULL,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`device_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
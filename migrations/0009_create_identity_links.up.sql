@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `identity_links` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `device_id` bigint(20) NOT NULL,
+  `identifier_type` varchar(32) NOT NULL,
+  `identifier_app_id` bigint(20) NOT NULL DEFAULT '0',
+  `identifier_value` varchar(255) NOT NULL,
+  `source` varchar(32) NOT NULL DEFAULT '',
+  `first_seen_at` datetime NOT NULL,
+  `last_seen_at` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `index_device_identifier` (`device_id`,`identifier_type`,`identifier_app_id`,`identifier_value`),
+  KEY `index_identifier` (`identifier_type`,`identifier_app_id`,`identifier_value`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `privacy_requests` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `type` varchar(16) NOT NULL,
+  `app_id` bigint(20) NOT NULL,
+  `ifa` varchar(45) NOT NULL DEFAULT '',
+  `pub_user_id` varchar(255) NOT NULL DEFAULT '',
+  `status` varchar(16) NOT NULL,
+  `device_ids` text NOT NULL,
+  `steps` text NOT NULL,
+  `export` mediumtext NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `completed_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `privacy_requests_app_id` (`app_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -5,6 +5,9 @@ import (
 
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/app"
 	appRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/app/repo"
+	bookmarkRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentbookmark/repo"
+	"github.com/Buzzvil/buzzscreen-api/internal/pkg/contentcampaign/indexer"
+	preferenceRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/contentpreference/repo"
 	"github.com/Buzzvil/buzzscreen-api/internal/pkg/device"
 	deviceRepo "github.com/Buzzvil/buzzscreen-api/internal/pkg/device/repo"
 
@@ -25,137 +28,8 @@ import (
 
 //noinspection GoUnusedConst
 const (
-	testMapping = `
-{
-	"settings":{
-		"analysis": {
-			"analyzer": {
-				"comma_analyzer": {
-					"type": "custom",
-					"tokenizer": "comma_tokenizer"
-				},
-				"ngram_analyzer": {
-					"type": "custom",
-					"tokenizer": "ngram_tokenizer"
-				},
-				"i_ngram_analyzer": {
-					"type": "custom",
-					"tokenizer": "ngram_tokenizer",
-					"filter": [
-						"lowercase"
-					]
-				}
-			},
-			"tokenizer": {
-				"comma_tokenizer": {
-					"type": "pattern",
-					"pattern": ","
-				},
-				"ngram_tokenizer": {
-					"type": "nGram",
-					"min_gram": "1",
-					"max_gram": "50"
-				}
-			}
-		},
-		"index": {
-			"number_of_shards": 1,
-			"number_of_replicas": 1,
-			"auto_expand_replicas": "0-all",
-			"refresh_interval": "1s"
-		}
-	},
-
-	"mappings": {
-		"content_campaign": {
-			"_all": {
-				"enabled": "false"
-			},
-			"properties": {
-				"id": { "type": "integer" },
-				"name": { "type": "text", "index": false },
-				"title": { "type": "text", "analyzer": "i_ngram_analyzer" },
-				"description": { "type": "text", "index": false },
-				"categories": { "type": "text", "analyzer": "comma_analyzer" },
-				"tags": { "type": "text", "analyzer": "comma_analyzer" },
-				"image": { "type": "text", "index": false },
-				"click_url": { "type": "text", "index": false },
-				"media_type": { "type": "short" },
-				"landing_type": { "type": "short" },
-				"clean_mode": { "type": "short" },
-				"clean_link": { "type": "text", "index": false },
-				"status": { "type": "short" },
-				"score": { "type": "double" },
-				"json": { "type": "text", "index": false },
-
-				"start_date": { "type": "date", "format": "date_optional_time" },
-				"end_date": { "type": "date", "format": "date_optional_time" },
-				"week_slot": { "type": "text", "analyzer": "comma_analyzer" },
-				"display_type": { "type": "keyword", "index": true },
-				"display_weight": { "type": "integer" },
-				"ipu": { "type": "integer" },
-				"dipu": { "type": "integer" },
-				"tipu": { "type": "integer" },
-
-				"target_age_min": { "type": "short", "null_value": -32768 },
-				"target_age_max": { "type": "short", "null_value": 32767 },
-				"registered_days_min": { "type": "short", "null_value": -32768 },
-				"registered_days_max": { "type": "short", "null_value": 32767 },
-				"target_sdk_min": { "type": "integer", "null_value": -2147483648 },
-				"target_sdk_max": { "type": "integer", "null_value": 2147483647 },
-				"target_gender": { "type": "keyword", "index": true },
-				"target_language": { "type": "keyword", "index": true },
-				"target_carrier": { "type": "text", "analyzer": "comma_analyzer" },
-				"target_region": { "type": "text", "analyzer": "comma_analyzer" },
-				"custom_target_1": { "type": "text", "analyzer": "comma_analyzer" },
-				"custom_target_2": { "type": "text", "analyzer": "comma_analyzer" },
-				"custom_target_3": { "type": "text", "analyzer": "comma_analyzer" },
-
-				"target_app": { "type": "text", "analyzer": "comma_analyzer" },
-				"target_unit": { "type": "text", "analyzer": "comma_analyzer" },
-				"target_app_id": { "type": "text", "analyzer": "comma_analyzer" },
-				"target_org": { "type": "text", "analyzer": "comma_analyzer" },
-				"target_os_min": { "type": "integer", "null_value": 2147483647 },
-				"target_os_max": { "type": "integer", "null_value": 2147483647 },
-				"target_battery_optimization": { "type": "boolean" },
-
-				"detarget_app": { "type": "text", "analyzer": "comma_analyzer" },
-				"detarget_unit": { "type": "text", "analyzer": "comma_analyzer" },
-				"detarget_app_id": { "type": "text", "analyzer": "comma_analyzer" },
-				"detarget_org": { "type": "text", "analyzer": "comma_analyzer" },
-
-				"country": { "type": "keyword", "index": true },
-				"timezone": { "type": "text", "index": false },
-				"organization_id": { "type": "integer" },
-				"owner_id": { "type": "integer" },
-				"channel_id": { "type": "integer" },
-				"provider_id": { "type": "integer" },
-				"natural_id": { "type": "text", "index": false },
-				"template_id": { "type": "short" },
-				"is_enabled": { "type": "boolean" },
-				"is_ctr_filter_off": { "type": "boolean" },
-				"created_at": { "type": "date", "format": "date_optional_time" },
-				"updated_at": { "type": "date", "format": "date_optional_time" },
-				"published_at": { "type": "date", "format": "date_optional_time" },
-
-				"creative_types": { "type": "text", "analyzer": "comma_analyzer" },
-				"creative_links": { "type": "object", "enabled": "false" },
-            	"channel": { "type": "object", "enabled": "false" },
-            	"provider": {"type": "object", "enabled": "false"},
-				"related": { "type": "integer" },
-            	"related_count": {"type": "long"},
-            	"image_width": { "type": "integer" },
-            	"image_height": { "type": "integer" },
-				"image_ratio": {"type": "double"},
-				"impressions": { "type": "integer" },
-				"clicks": { "type": "integer" }
-			}
-   		}
-    }
-}
-`
 	//TestAppIDUnknown
-	TestAppID1 int64 = iota
+	TestAppID1 int64 = iota + 1
 	TestAppID2
 	TestAppID3
 	TestAppID4
@@ -198,7 +72,7 @@ func SetupElasticSearch() func() {
 	client := buzzscreen.Service.ES
 	client.DeleteIndex(env.Config.ElasticSearch.CampaignIndexName).Do(context.Background())
 
-	createIndex, err := client.CreateIndex(env.Config.ElasticSearch.CampaignIndexName).Body(testMapping).Do(context.Background())
+	createIndex, err := client.CreateIndex(env.Config.ElasticSearch.CampaignIndexName).Body(indexer.Mapping).Do(context.Background())
 	if err != nil {
 		core.Logger.Fatal(err)
 	}
@@ -227,7 +101,7 @@ func SetupDatabase() func() {
 	dropAndCreateTables(db, &dbapp.App{}, &dbapp.Unit{}, &dbdevice.Device{}, &model.DeviceUser{},
 		&dbdevice.DeviceUpdateHistory{}, &model.ContentCampaign{},
 		&model.ContentChannel{}, &model.ContentProvider{}, &model.WelcomeReward{}, &dbapp.WelcomeRewardConfig{},
-		&model.NotificationSchedule{})
+		&model.NotificationSchedule{}, &bookmarkRepo.DBBookmark{}, &preferenceRepo.DBPreference{})
 
 	core.Logger.Infof("SetupDatabase() - Insert rows...")
 